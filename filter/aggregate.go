@@ -0,0 +1,168 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// aggregateSQL maps each AggregateFunc to its SQL function
+var aggregateSQL = map[AggregateFunc]string{
+	AggregateSum:   "SUM",
+	AggregateAvg:   "AVG",
+	AggregateMin:   "MIN",
+	AggregateMax:   "MAX",
+	AggregateCount: "COUNT",
+}
+
+// Key returns the key of the aggregation in PaginationResult.Aggregates, such as "sum_amount"
+func (a Aggregation) Key() string {
+	return string(a.Func) + "_" + a.Field
+}
+
+// checkAggregations returns an error for the first aggregation of root with an unknown function,
+// or on a field that is unknown, not numeric or under a slice of T
+func (f *Handler[T]) checkAggregations(root Root) error {
+	for _, aggregation := range root.Aggregations {
+		if err := f.checkAggregation(aggregation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAggregation returns an error when aggregation cannot be computed, see checkAggregations
+func (f *Handler[T]) checkAggregation(aggregation Aggregation) error {
+	if _, ok := aggregateSQL[aggregation.Func]; !ok {
+		return fmt.Errorf("unknown aggregate function %q for field %s", aggregation.Func, aggregation.Field)
+	}
+	if f.isSliceField(aggregation.Field) {
+		return fmt.Errorf("cannot aggregate to-many field %s", aggregation.Field)
+	}
+	dataType, exists := f.fieldDataType(aggregation.Field)
+	if !exists {
+		return &UnknownFieldError{Field: aggregation.Field, reason: fmt.Sprintf("unknown aggregation field %s", aggregation.Field)}
+	}
+	if dataType != DataTypeNumber {
+		return fmt.Errorf("cannot aggregate %s field %s", dataType, aggregation.Field)
+	}
+	return nil
+}
+
+// fieldDataType returns the suggested data type of a field listed by Fields
+func (f *Handler[T]) fieldDataType(field string) (DataType, bool) {
+	descriptor, ok := f.fieldDescriptor(field)
+	return descriptor.DataType, ok
+}
+
+// fieldDescriptor returns the descriptor of a field listed by Fields
+func (f *Handler[T]) fieldDescriptor(field string) (FieldDescriptor, bool) {
+	id := f.fieldID(field)
+	for _, descriptor := range f.descriptors {
+		if f.fieldID(descriptor.Path) == id {
+			return descriptor, true
+		}
+	}
+	return FieldDescriptor{}, false
+}
+
+// aggregateItems computes aggregations over items in memory. Like SQL, nil values are skipped,
+// and avg, min and max are left out when no item has a value.
+func (f *Handler[T]) aggregateItems(items []*T, aggregations []Aggregation) (map[string]float64, error) {
+	if len(aggregations) == 0 {
+		return nil, nil
+	}
+	aggregates := make(map[string]float64, len(aggregations))
+	for _, aggregation := range aggregations {
+		getter, _ := f.getter(aggregation.Field)
+		count := 0
+		sum, minimum, maximum := 0.0, math.Inf(1), math.Inf(-1)
+		for _, item := range items {
+			value := sortKey(getter(item))
+			if isMissing(value) {
+				continue
+			}
+			num, err := parseNumber(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to aggregate field %s: %w", aggregation.Field, err)
+			}
+			count++
+			sum += num
+			minimum = math.Min(minimum, num)
+			maximum = math.Max(maximum, num)
+		}
+
+		key := aggregation.Key()
+		switch aggregation.Func {
+		case AggregateCount:
+			aggregates[key] = float64(count)
+		case AggregateSum:
+			aggregates[key] = sum
+		case AggregateAvg:
+			if count > 0 {
+				aggregates[key] = sum / float64(count)
+			}
+		case AggregateMin:
+			if count > 0 {
+				aggregates[key] = minimum
+			}
+		case AggregateMax:
+			if count > 0 {
+				aggregates[key] = maximum
+			}
+		}
+	}
+	return aggregates, nil
+}
+
+// aggregateGorm computes aggregations over the records matched by query in one SELECT, without
+// ORDER BY or LIMIT. When to-many joins repeat rows, the aggregates run over the distinct primary
+// keys matched by query instead, so each record is counted once.
+func (f *Handler[T]) aggregateGorm(db *gorm.DB, query *gorm.DB, filterRoot Root, toMany bool) (map[string]float64, error) {
+	d := dialectOf(db)
+	if len(filterRoot.Aggregations) == 0 {
+		return nil, nil
+	}
+	mainTableName := f.mainTableName(d)
+	aggregateQuery := query.Session(&gorm.Session{})
+	if toMany {
+		primaryKey := f.primaryKeyColumn(d, mainTableName)
+		// GORM adds the columns of joined belongs-to relations to any SELECT, so the key is read back
+		// from a derived table for the IN subquery to return one column
+		matched := query.Session(&gorm.Session{}).Select(primaryKey + " AS matched_key")
+		keys := db.Session(&gorm.Session{NewDB: true}).Table("(?) AS matched_keys", matched).Select("matched_key")
+		aggregateQuery = f.modelQuery(db, filterRoot).Where(fmt.Sprintf("%s IN (?)", primaryKey), keys)
+		mainTableName = ""
+	}
+
+	selects := make([]string, len(filterRoot.Aggregations))
+	for i, aggregation := range filterRoot.Aggregations {
+		column := f.columnExpr(d, aggregation.Field, mainTableName)
+		selects[i] = fmt.Sprintf("%s(%s) AS agg_%d", aggregateSQL[aggregation.Func], column, i)
+	}
+	row := make(map[string]any, len(selects))
+	if err := aggregateQuery.Select(strings.Join(selects, ", ")).Scan(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate records: %w", err)
+	}
+
+	aggregates := make(map[string]float64, len(filterRoot.Aggregations))
+	for i, aggregation := range filterRoot.Aggregations {
+		// Some drivers return DECIMAL results as text
+		value := scannedValue(row[fmt.Sprintf("agg_%d", i)])
+		if value == nil {
+			// SUM and COUNT over no rows are 0, as in memory; AVG, MIN and MAX are left out
+			if aggregation.Func == AggregateSum || aggregation.Func == AggregateCount {
+				aggregates[aggregation.Key()] = 0
+			}
+			continue
+		}
+		num, err := parseNumber(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate field %s: %w", aggregation.Field, err)
+		}
+		aggregates[aggregation.Key()] = num
+	}
+	return aggregates, nil
+}
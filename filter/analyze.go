@@ -0,0 +1,258 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// analysisDialect names the columns of an Analysis as GORM's default naming strategy does, unquoted
+var analysisDialect = sqlDialect{
+	name:    "sqlite",
+	quoteTo: func(writer clause.Writer, str string) { writer.WriteString(str) },
+	namer:   schema.NamingStrategy{},
+}
+
+// Analyze describes how the queries would apply filterRoot, without touching any data or database,
+// so a saved filter can be checked before it is stored. Each filter of filterRoot and its nested
+// groups, and each sort field, reports whether it resolves to a field the queries apply, the column
+// the GORM path reads (under GORM's default naming strategy), the value after transforms and relative
+// dates parsed as its DataType (a float64, time.Time, RangeNumber, RangeDate, []any and so on), and
+// warnings for what is ignored or may not do what it seems to. Unlike ValidateRoot it explains rather
+// than rejects: only an unknown TimeZone is an error.
+//
+// Example usage:
+//
+//	analysis, err := handler.Analyze(savedFilter)
+//	for _, filter := range analysis.Filters {
+//	    if !filter.Resolved {
+//	        log.Printf("%s: %s", filter.Field, strings.Join(filter.Warnings, "; "))
+//	    }
+//	}
+func (f *Handler[T]) Analyze(filterRoot Root) (Analysis, error) {
+	loc := f.location
+	if filterRoot.TimeZone != "" {
+		zone, err := time.LoadLocation(filterRoot.TimeZone)
+		if err != nil {
+			return Analysis{}, fmt.Errorf("unknown time zone %q: %w", filterRoot.TimeZone, err)
+		}
+		loc = zone
+	}
+	now := f.now().In(loc)
+
+	analysis := Analysis{Filters: []FilterAnalysis{}, Sorts: []SortAnalysis{}}
+	for _, filter := range flattenFieldFilters(filterRoot) {
+		analysis.Filters = append(analysis.Filters, f.analyzeFilter(filter, now))
+	}
+	sorted := make(map[string]bool, len(filterRoot.SortFields))
+	for _, sortField := range filterRoot.SortFields {
+		analysis.Sorts = append(analysis.Sorts, f.analyzeSort(sortField, filterRoot.Search, sorted))
+	}
+	return analysis, nil
+}
+
+// analyzeFilter describes filter as Analyze does, resolving relative dates against now
+func (f *Handler[T]) analyzeFilter(filter FieldFilter, now time.Time) FilterAnalysis {
+	analysis := FilterAnalysis{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType, ParsedValue: filter.Value}
+	warn := func(format string, args ...any) {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(format, args...))
+	}
+	if !f.checkAnalyzedField(filter.Field, warn) {
+		return analysis
+	}
+	if filter.CompareField != "" {
+		analysis.ParsedValue = nil
+		if !f.checkAnalyzedField(filter.CompareField, warn) {
+			return analysis
+		}
+		if err := f.checkCompareFilter(filter); err != nil {
+			warn("%v", err)
+			return analysis
+		}
+		analysis.Resolved = true
+		analysis.ResolvedColumn = f.analyzedColumn(filter.Field)
+		return analysis
+	}
+
+	if fn, exists := f.transforms[f.fieldID(filter.Field)]; exists && filter.Mode != ModeIsEmpty && filter.Mode != ModeIsNotEmpty && !isExistsMode(filter.Mode) {
+		value, err := transformValue(fn, filter)
+		if err != nil {
+			warn("%v", filterError(filter, err))
+			return analysis
+		}
+		filter.Value = value
+	}
+	if filter.DataType == DataTypeDate {
+		value, _, err := f.resolveDateValue(filter.Value, now)
+		if err != nil {
+			warn("%v", filterError(filter, err))
+			return analysis
+		}
+		filter.Value = value
+	}
+	if _, err := f.compileFilter(filter); err != nil {
+		warn("%v", err)
+		return analysis
+	}
+	if err := f.checkValue(filter); err != nil {
+		warn("%v", err)
+		return analysis
+	}
+
+	numeric, _ := f.numberFilter(filter)
+	parsed, err := parsedValue(numeric)
+	if err != nil {
+		warn("%v", filterError(filter, err))
+		return analysis
+	}
+	analysis.Resolved = true
+	analysis.ParsedValue = parsed
+	if !isExistsMode(filter.Mode) {
+		analysis.ResolvedColumn = f.analyzedColumn(filter.Field)
+	}
+	switch list := parsed.(type) {
+	case []any:
+		if len(list) == 0 && filter.Mode == ModeIn {
+			warn("an empty list matches no records")
+		} else if len(list) == 0 {
+			warn("an empty list matches every record")
+		}
+	case string:
+		if list == "" && (filter.Mode == ModeContains || filter.Mode == ModeStartsWith || filter.Mode == ModeEndsWith) {
+			warn("an empty value matches every record")
+		}
+	}
+	return analysis
+}
+
+// checkAnalyzedField reports through warn why the queries ignore field, and whether they apply it
+func (f *Handler[T]) checkAnalyzedField(field string, warn func(format string, args ...any)) bool {
+	switch {
+	case field == "":
+		warn("the field is empty")
+		return false
+	case !strings.Contains(field, ".") && !f.fieldExists(field):
+		warn("unknown field %s: ignored, or an error with RejectUnknownFields", field)
+		return false
+	case !f.isFieldAllowed(field):
+		warn("field %s is not allowed: ignored, or an error with RejectDisallowedFields", field)
+		return false
+	}
+	if !f.fieldExists(field) {
+		warn("field %s is nested deeper than MaxDepth: only the GORM path applies it", field)
+	}
+	if !f.hasSQL(field) {
+		warn("computed field %s has no SQL expression: only the in-memory path applies it", field)
+	}
+	return true
+}
+
+// analyzedColumn returns the column or SQL expression the GORM path reads field from, unquoted,
+// or "" for computed fields without SQL
+func (f *Handler[T]) analyzedColumn(field string) string {
+	if !f.hasSQL(field) {
+		return ""
+	}
+	return f.columnExpr(analysisDialect, field, "")
+}
+
+// analyzeSort describes sortField as Analyze does. sorted holds the fields sorted by earlier, which
+// a repeated sort field is ignored for.
+func (f *Handler[T]) analyzeSort(sortField SortField, search *SearchFilter, sorted map[string]bool) SortAnalysis {
+	analysis := SortAnalysis{Field: sortField.Field, Order: sortField.Order}
+	if analysis.Order == "" {
+		analysis.Order = SortOrderAsc
+	}
+	warn := func(format string, args ...any) {
+		analysis.Warnings = append(analysis.Warnings, fmt.Sprintf(format, args...))
+	}
+	if sortField.Field == RelevanceField {
+		if search == nil {
+			warn("sorting by relevance needs a search: ignored")
+			return analysis
+		}
+		analysis.Resolved = true
+		return analysis
+	}
+	if !f.checkAnalyzedField(sortField.Field, warn) {
+		return analysis
+	}
+	id := f.fieldID(sortField.Field)
+	if sorted[id] {
+		warn("field %s is already sorted by: ignored", sortField.Field)
+		return analysis
+	}
+	sorted[id] = true
+	if err := checkLanguage(sortField.Language); err != nil {
+		warn("unknown language '%s'", sortField.Language)
+	}
+	if f.isSliceField(sortField.Field) {
+		warn("records sort by their smallest %s ascending and largest descending", sortField.Field)
+	}
+	analysis.Resolved = true
+	analysis.ResolvedColumn = f.analyzedColumn(sortField.Field)
+	return analysis
+}
+
+// parsedValue returns the value of filter parsed as the queries compare it: nil for modes without a
+// value, a Range parsed into a RangeNumber, RangeText or RangeDate, and the items of a list parsed one by one
+func parsedValue(filter FieldFilter) (any, error) {
+	switch filter.Mode {
+	case ModeIsEmpty, ModeIsNotEmpty, ModeExists, ModeNotExists:
+		return nil, nil
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		parsed := make([]any, len(list))
+		for i, item := range list {
+			if parsed[i], err = parseScalar(filter.DataType, item); err != nil {
+				return nil, err
+			}
+		}
+		return parsed, nil
+	case ModeRange:
+		switch filter.DataType {
+		case DataTypeNumber:
+			return parseRangeNumber(filter.Value)
+		case DataTypeText:
+			return parseRangeText(filter.Value)
+		case DataTypeDate:
+			return parseRangeDateTime(filter.Value)
+		case DataTypeTime:
+			return parseRangeTime(filter.Value)
+		}
+		return filter.Value, nil
+	}
+	return parseScalar(filter.DataType, filter.Value)
+}
+
+// parseScalar parses a single value as dataType
+func parseScalar(dataType DataType, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch dataType {
+	case DataTypeNumber:
+		return parseNumber(value)
+	case DataTypeText:
+		return parseText(value)
+	case DataTypeBool:
+		return parseBool(value)
+	case DataTypeDate:
+		return parseDateTime(value)
+	case DataTypeTime:
+		return parseTime(value)
+	case DataTypeUUID:
+		id, err := parseUUID(value)
+		if err != nil {
+			return nil, err
+		}
+		return formatUUID(id), nil
+	}
+	return value, nil
+}
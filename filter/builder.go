@@ -0,0 +1,649 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RootBuilder builds a Root in Go code, pairing each mode with the data type it belongs to: a field
+// is named through the builder of its data type (Text, Number, Date, ...), which only offers the modes
+// that data type supports. Build returns exactly the Root the calls describe, or an error for misuse
+// such as an empty field name, a value of the wrong kind or an inverted range.
+//
+// Example usage:
+//
+//	root, err := filter.NewRoot().And().
+//		Text("name").Contains("john").
+//		Number("age").GTE(18).
+//		Date("created_at").Between("2025-01-01", "2025-12-31").
+//		SortDesc("created_at").
+//		Preload("Department").
+//		Build()
+type RootBuilder struct {
+	root   Root
+	groups []*RootBuilder
+	errs   []error
+}
+
+// NewRoot returns an empty RootBuilder, whose filters are combined with LogicAnd unless Or is called
+// (a Root written by hand without a Logic combines them with OR)
+func NewRoot() *RootBuilder {
+	return &RootBuilder{root: Root{Logic: LogicAnd}}
+}
+
+// And combines the filters and groups with LogicAnd
+func (b *RootBuilder) And() *RootBuilder {
+	b.root.Logic = LogicAnd
+	return b
+}
+
+// Or combines the filters and groups with LogicOr
+func (b *RootBuilder) Or() *RootBuilder {
+	b.root.Logic = LogicOr
+	return b
+}
+
+// Group adds the filters of group as a nested group, combined with the other filters using this
+// builder's logic. Only its filters, groups and logic are used; its errors are returned by Build.
+func (b *RootBuilder) Group(group *RootBuilder) *RootBuilder {
+	if group == nil {
+		b.errs = append(b.errs, errors.New("group cannot be nil"))
+		return b
+	}
+	b.groups = append(b.groups, group)
+	return b
+}
+
+// Search matches value against fields (every string field when none are given), see Root.Search
+func (b *RootBuilder) Search(value string, fields ...string) *RootBuilder {
+	for _, field := range fields {
+		if field == "" {
+			b.errs = append(b.errs, errors.New("search field cannot be empty"))
+		}
+	}
+	b.root.Search = &SearchFilter{Fields: fields, Value: value}
+	return b
+}
+
+// SortAsc sorts by field in ascending order, after the sort fields added before it
+func (b *RootBuilder) SortAsc(field string) *RootBuilder {
+	return b.sort(field, SortOrderAsc)
+}
+
+// SortDesc sorts by field in descending order, after the sort fields added before it
+func (b *RootBuilder) SortDesc(field string) *RootBuilder {
+	return b.sort(field, SortOrderDesc)
+}
+
+// SortRelevance sorts by how well records match the search, see RelevanceField
+func (b *RootBuilder) SortRelevance() *RootBuilder {
+	return b.sort(RelevanceField, SortOrderDesc)
+}
+
+func (b *RootBuilder) sort(field string, order SortOrder) *RootBuilder {
+	if field == "" {
+		b.errs = append(b.errs, errors.New("sort field cannot be empty"))
+		return b
+	}
+	b.root.SortFields = append(b.root.SortFields, SortField{Field: field, Order: order})
+	return b
+}
+
+// Preload loads relations with the records of the GORM paths, see Root.Preload
+func (b *RootBuilder) Preload(relations ...string) *RootBuilder {
+	for _, relation := range relations {
+		if relation == "" {
+			b.errs = append(b.errs, errors.New("preload relation cannot be empty"))
+			continue
+		}
+		b.root.Preload = append(b.root.Preload, relation)
+	}
+	return b
+}
+
+// PreloadWhere loads relation (e.g. "Orders.Items") with the records of the GORM paths, restricted by
+// GORM conditions such as "status = ?", "open", see Root.Preloads
+func (b *RootBuilder) PreloadWhere(relation string, conditions ...any) *RootBuilder {
+	if relation == "" {
+		b.errs = append(b.errs, errors.New("preload relation cannot be empty"))
+		return b
+	}
+	b.root.Preloads = append(b.root.Preloads, Preload{Relation: relation, Conditions: conditions})
+	return b
+}
+
+// Build returns the Root, or every misuse found while building it and its groups joined into one error
+func (b *RootBuilder) Build() (Root, error) {
+	root := b.root
+	errs := b.errs
+	root.FieldFilters = append([]FieldFilter(nil), b.root.FieldFilters...)
+	root.SortFields = append([]SortField(nil), b.root.SortFields...)
+	root.Preload = append([]string(nil), b.root.Preload...)
+	root.Preloads = append([]Preload(nil), b.root.Preloads...)
+	if b.root.Search != nil {
+		search := *b.root.Search
+		root.Search = &search
+	}
+	for _, group := range b.groups {
+		built, err := group.Build()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		root.Groups = append(root.Groups, Root{FieldFilters: built.FieldFilters, Logic: built.Logic, Groups: built.Groups})
+	}
+	if len(errs) > 0 {
+		return Root{}, errors.Join(errs...)
+	}
+	return root, nil
+}
+
+// add appends a filter on field, or records err (or an empty field name) for Build
+func (b *RootBuilder) add(field string, mode Mode, dataType DataType, value any, err error) *RootBuilder {
+	if field == "" {
+		b.errs = append(b.errs, fmt.Errorf("%s filter field cannot be empty", dataType))
+		return b
+	}
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("field %s: %w", field, err))
+		return b
+	}
+	b.root.FieldFilters = append(b.root.FieldFilters, FieldFilter{Field: field, Value: value, Mode: mode, DataType: dataType})
+	return b
+}
+
+// Text starts a DataTypeText filter on field
+func (b *RootBuilder) Text(field string) TextFilterBuilder {
+	return TextFilterBuilder{b: b, field: field}
+}
+
+// TextFilterBuilder adds a DataTypeText filter, see RootBuilder.Text
+type TextFilterBuilder struct {
+	b             *RootBuilder
+	field         string
+	caseSensitive *bool
+}
+
+// CaseSensitive makes the filter case-sensitive, see FieldFilter.CaseSensitive
+func (t TextFilterBuilder) CaseSensitive() TextFilterBuilder {
+	caseSensitive := true
+	t.caseSensitive = &caseSensitive
+	return t
+}
+
+// CaseInsensitive makes the filter case-insensitive even when GolangFilteringConfig.CaseSensitive is
+// set, see FieldFilter.CaseSensitive
+func (t TextFilterBuilder) CaseInsensitive() TextFilterBuilder {
+	caseSensitive := false
+	t.caseSensitive = &caseSensitive
+	return t
+}
+
+func (t TextFilterBuilder) add(mode Mode, value any, err error) *RootBuilder {
+	t.b.add(t.field, mode, DataTypeText, value, err)
+	if err == nil && t.field != "" && t.caseSensitive != nil {
+		t.b.root.FieldFilters[len(t.b.root.FieldFilters)-1].CaseSensitive = t.caseSensitive
+	}
+	return t.b
+}
+
+// Equal matches value
+func (t TextFilterBuilder) Equal(value string) *RootBuilder {
+	return t.add(ModeEqual, value, nil)
+}
+
+// NotEqual matches anything but value
+func (t TextFilterBuilder) NotEqual(value string) *RootBuilder {
+	return t.add(ModeNotEqual, value, nil)
+}
+
+// Contains matches text containing value
+func (t TextFilterBuilder) Contains(value string) *RootBuilder {
+	return t.add(ModeContains, value, nil)
+}
+
+// NotContains matches text not containing value
+func (t TextFilterBuilder) NotContains(value string) *RootBuilder {
+	return t.add(ModeNotContains, value, nil)
+}
+
+// StartsWith matches text starting with value
+func (t TextFilterBuilder) StartsWith(value string) *RootBuilder {
+	return t.add(ModeStartsWith, value, nil)
+}
+
+// EndsWith matches text ending with value
+func (t TextFilterBuilder) EndsWith(value string) *RootBuilder {
+	return t.add(ModeEndsWith, value, nil)
+}
+
+// In matches any of values
+func (t TextFilterBuilder) In(values ...string) *RootBuilder {
+	return t.add(ModeIn, values, nil)
+}
+
+// NotIn matches none of values
+func (t TextFilterBuilder) NotIn(values ...string) *RootBuilder {
+	return t.add(ModeNotIn, values, nil)
+}
+
+// Between matches text from from to to, both included
+func (t TextFilterBuilder) Between(from, to string) *RootBuilder {
+	var err error
+	if caseSensitive := t.caseSensitive != nil && *t.caseSensitive; caseSensitive && from > to ||
+		!caseSensitive && strings.ToLower(from) > strings.ToLower(to) {
+		err = &InvalidRangeError{Err: invertedRangeError{kind: "text"}}
+	}
+	return t.add(ModeRange, Range{From: from, To: to}, err)
+}
+
+// IsEmpty matches NULL and empty text
+func (t TextFilterBuilder) IsEmpty() *RootBuilder {
+	return t.add(ModeIsEmpty, nil, nil)
+}
+
+// IsNotEmpty matches text that is neither NULL nor empty
+func (t TextFilterBuilder) IsNotEmpty() *RootBuilder {
+	return t.add(ModeIsNotEmpty, nil, nil)
+}
+
+// Number starts a DataTypeNumber filter on field
+func (b *RootBuilder) Number(field string) NumberFilterBuilder {
+	return NumberFilterBuilder{b: b, field: field}
+}
+
+// NumberFilterBuilder adds a DataTypeNumber filter, see RootBuilder.Number
+type NumberFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (n NumberFilterBuilder) add(mode Mode, value any, err error) *RootBuilder {
+	return n.b.add(n.field, mode, DataTypeNumber, value, err)
+}
+
+// Equal matches value
+func (n NumberFilterBuilder) Equal(value float64) *RootBuilder {
+	return n.add(ModeEqual, value, nil)
+}
+
+// NotEqual matches anything but value
+func (n NumberFilterBuilder) NotEqual(value float64) *RootBuilder {
+	return n.add(ModeNotEqual, value, nil)
+}
+
+// GT matches numbers greater than value
+func (n NumberFilterBuilder) GT(value float64) *RootBuilder {
+	return n.add(ModeGT, value, nil)
+}
+
+// GTE matches numbers greater than or equal to value
+func (n NumberFilterBuilder) GTE(value float64) *RootBuilder {
+	return n.add(ModeGTE, value, nil)
+}
+
+// LT matches numbers less than value
+func (n NumberFilterBuilder) LT(value float64) *RootBuilder {
+	return n.add(ModeLT, value, nil)
+}
+
+// LTE matches numbers less than or equal to value
+func (n NumberFilterBuilder) LTE(value float64) *RootBuilder {
+	return n.add(ModeLTE, value, nil)
+}
+
+// In matches any of values
+func (n NumberFilterBuilder) In(values ...float64) *RootBuilder {
+	return n.add(ModeIn, values, nil)
+}
+
+// NotIn matches none of values
+func (n NumberFilterBuilder) NotIn(values ...float64) *RootBuilder {
+	return n.add(ModeNotIn, values, nil)
+}
+
+// Between matches numbers from from to to, both included
+func (n NumberFilterBuilder) Between(from, to float64) *RootBuilder {
+	var err error
+	if from > to {
+		err = &InvalidRangeError{Err: invertedRangeError{kind: "number"}}
+	}
+	return n.add(ModeRange, Range{From: from, To: to}, err)
+}
+
+// IsEmpty matches NULL
+func (n NumberFilterBuilder) IsEmpty() *RootBuilder {
+	return n.add(ModeIsEmpty, nil, nil)
+}
+
+// IsNotEmpty matches any number
+func (n NumberFilterBuilder) IsNotEmpty() *RootBuilder {
+	return n.add(ModeIsNotEmpty, nil, nil)
+}
+
+// Duration starts a DataTypeDuration filter on field, see Handler.DurationUnit
+func (b *RootBuilder) Duration(field string) DurationFilterBuilder {
+	return DurationFilterBuilder{b: b, field: field}
+}
+
+// DurationFilterBuilder adds a DataTypeDuration filter, see RootBuilder.Duration
+type DurationFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (d DurationFilterBuilder) add(mode Mode, value any, err error) *RootBuilder {
+	return d.b.add(d.field, mode, DataTypeDuration, value, err)
+}
+
+// Equal matches value
+func (d DurationFilterBuilder) Equal(value time.Duration) *RootBuilder {
+	return d.add(ModeEqual, value, nil)
+}
+
+// NotEqual matches anything but value
+func (d DurationFilterBuilder) NotEqual(value time.Duration) *RootBuilder {
+	return d.add(ModeNotEqual, value, nil)
+}
+
+// GT matches durations longer than value
+func (d DurationFilterBuilder) GT(value time.Duration) *RootBuilder {
+	return d.add(ModeGT, value, nil)
+}
+
+// GTE matches durations at least as long as value
+func (d DurationFilterBuilder) GTE(value time.Duration) *RootBuilder {
+	return d.add(ModeGTE, value, nil)
+}
+
+// LT matches durations shorter than value
+func (d DurationFilterBuilder) LT(value time.Duration) *RootBuilder {
+	return d.add(ModeLT, value, nil)
+}
+
+// LTE matches durations at most as long as value
+func (d DurationFilterBuilder) LTE(value time.Duration) *RootBuilder {
+	return d.add(ModeLTE, value, nil)
+}
+
+// In matches any of values
+func (d DurationFilterBuilder) In(values ...time.Duration) *RootBuilder {
+	return d.add(ModeIn, values, nil)
+}
+
+// NotIn matches none of values
+func (d DurationFilterBuilder) NotIn(values ...time.Duration) *RootBuilder {
+	return d.add(ModeNotIn, values, nil)
+}
+
+// Between matches durations from from to to, both included
+func (d DurationFilterBuilder) Between(from, to time.Duration) *RootBuilder {
+	var err error
+	if from > to {
+		err = &InvalidRangeError{Err: invertedRangeError{kind: "duration"}}
+	}
+	return d.add(ModeRange, Range{From: from, To: to}, err)
+}
+
+// IsEmpty matches NULL
+func (d DurationFilterBuilder) IsEmpty() *RootBuilder {
+	return d.add(ModeIsEmpty, nil, nil)
+}
+
+// IsNotEmpty matches any duration
+func (d DurationFilterBuilder) IsNotEmpty() *RootBuilder {
+	return d.add(ModeIsNotEmpty, nil, nil)
+}
+
+// Bool starts a DataTypeBool filter on field
+func (b *RootBuilder) Bool(field string) BoolFilterBuilder {
+	return BoolFilterBuilder{b: b, field: field}
+}
+
+// BoolFilterBuilder adds a DataTypeBool filter, see RootBuilder.Bool
+type BoolFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (o BoolFilterBuilder) add(mode Mode, value any) *RootBuilder {
+	return o.b.add(o.field, mode, DataTypeBool, value, nil)
+}
+
+// Equal matches value
+func (o BoolFilterBuilder) Equal(value bool) *RootBuilder {
+	return o.add(ModeEqual, value)
+}
+
+// NotEqual matches anything but value
+func (o BoolFilterBuilder) NotEqual(value bool) *RootBuilder {
+	return o.add(ModeNotEqual, value)
+}
+
+// IsEmpty matches NULL
+func (o BoolFilterBuilder) IsEmpty() *RootBuilder {
+	return o.add(ModeIsEmpty, nil)
+}
+
+// IsNotEmpty matches true and false
+func (o BoolFilterBuilder) IsNotEmpty() *RootBuilder {
+	return o.add(ModeIsNotEmpty, nil)
+}
+
+// UUID starts a DataTypeUUID filter on field. Values may be a uuid.UUID, a [16]byte or a canonical string.
+func (b *RootBuilder) UUID(field string) UUIDFilterBuilder {
+	return UUIDFilterBuilder{b: b, field: field}
+}
+
+// UUIDFilterBuilder adds a DataTypeUUID filter, see RootBuilder.UUID
+type UUIDFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (u UUIDFilterBuilder) add(mode Mode, value any, values ...any) *RootBuilder {
+	var err error
+	for _, item := range values {
+		if _, parseErr := parseUUID(item); parseErr != nil {
+			err = parseErr
+			break
+		}
+	}
+	return u.b.add(u.field, mode, DataTypeUUID, value, err)
+}
+
+// Equal matches value
+func (u UUIDFilterBuilder) Equal(value any) *RootBuilder {
+	return u.add(ModeEqual, value, value)
+}
+
+// NotEqual matches anything but value
+func (u UUIDFilterBuilder) NotEqual(value any) *RootBuilder {
+	return u.add(ModeNotEqual, value, value)
+}
+
+// In matches any of values
+func (u UUIDFilterBuilder) In(values ...any) *RootBuilder {
+	return u.add(ModeIn, values, values...)
+}
+
+// NotIn matches none of values
+func (u UUIDFilterBuilder) NotIn(values ...any) *RootBuilder {
+	return u.add(ModeNotIn, values, values...)
+}
+
+// IsEmpty matches NULL
+func (u UUIDFilterBuilder) IsEmpty() *RootBuilder {
+	return u.add(ModeIsEmpty, nil)
+}
+
+// IsNotEmpty matches any UUID
+func (u UUIDFilterBuilder) IsNotEmpty() *RootBuilder {
+	return u.add(ModeIsNotEmpty, nil)
+}
+
+// Date starts a DataTypeDate filter on field. Values may be a time.Time or a string: a date, a
+// timestamp or a relative value such as "today" or "now-7d".
+func (b *RootBuilder) Date(field string) DateFilterBuilder {
+	return DateFilterBuilder{b: b, field: field}
+}
+
+// DateFilterBuilder adds a DataTypeDate filter, see RootBuilder.Date
+type DateFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (d DateFilterBuilder) add(mode Mode, value any, values ...any) *RootBuilder {
+	return d.b.add(d.field, mode, DataTypeDate, value, checkTimeValues(values))
+}
+
+// Equal matches value, the whole day for a date without a time
+func (d DateFilterBuilder) Equal(value any) *RootBuilder {
+	return d.add(ModeEqual, value, value)
+}
+
+// NotEqual matches anything but value
+func (d DateFilterBuilder) NotEqual(value any) *RootBuilder {
+	return d.add(ModeNotEqual, value, value)
+}
+
+// Before matches dates before value
+func (d DateFilterBuilder) Before(value any) *RootBuilder {
+	return d.add(ModeBefore, value, value)
+}
+
+// After matches dates after value
+func (d DateFilterBuilder) After(value any) *RootBuilder {
+	return d.add(ModeAfter, value, value)
+}
+
+// GTE matches value and the dates after it
+func (d DateFilterBuilder) GTE(value any) *RootBuilder {
+	return d.add(ModeGTE, value, value)
+}
+
+// LTE matches value and the dates before it
+func (d DateFilterBuilder) LTE(value any) *RootBuilder {
+	return d.add(ModeLTE, value, value)
+}
+
+// In matches any of values
+func (d DateFilterBuilder) In(values ...any) *RootBuilder {
+	return d.add(ModeIn, values, values...)
+}
+
+// NotIn matches none of values
+func (d DateFilterBuilder) NotIn(values ...any) *RootBuilder {
+	return d.add(ModeNotIn, values, values...)
+}
+
+// Between matches dates from from to to, both included
+func (d DateFilterBuilder) Between(from, to any) *RootBuilder {
+	err := checkTimeValues([]any{from, to})
+	if err == nil {
+		// Relative values depend on the time of the query and are checked by the Handler
+		fromTime, fromErr := parseDateTime(from)
+		toTime, toErr := parseDateTime(to)
+		if fromErr == nil && toErr == nil && fromTime.After(toTime) {
+			err = &InvalidRangeError{Err: invertedRangeError{kind: "date"}}
+		}
+	}
+	return d.b.add(d.field, ModeRange, DataTypeDate, Range{From: from, To: to}, err)
+}
+
+// IsEmpty matches NULL and zero dates
+func (d DateFilterBuilder) IsEmpty() *RootBuilder {
+	return d.add(ModeIsEmpty, nil)
+}
+
+// IsNotEmpty matches any date
+func (d DateFilterBuilder) IsNotEmpty() *RootBuilder {
+	return d.add(ModeIsNotEmpty, nil)
+}
+
+// Time starts a DataTypeTime filter on field, comparing the time of day. Values may be a time.Time
+// or a string such as "08:00" or "17:30:00".
+func (b *RootBuilder) Time(field string) TimeFilterBuilder {
+	return TimeFilterBuilder{b: b, field: field}
+}
+
+// TimeFilterBuilder adds a DataTypeTime filter, see RootBuilder.Time
+type TimeFilterBuilder struct {
+	b     *RootBuilder
+	field string
+}
+
+func (t TimeFilterBuilder) add(mode Mode, value any) *RootBuilder {
+	err := checkTimeValues([]any{value})
+	if err == nil {
+		_, err = parseTime(value)
+	}
+	return t.b.add(t.field, mode, DataTypeTime, value, err)
+}
+
+// Equal matches value
+func (t TimeFilterBuilder) Equal(value any) *RootBuilder {
+	return t.add(ModeEqual, value)
+}
+
+// NotEqual matches anything but value
+func (t TimeFilterBuilder) NotEqual(value any) *RootBuilder {
+	return t.add(ModeNotEqual, value)
+}
+
+// GT matches times later than value
+func (t TimeFilterBuilder) GT(value any) *RootBuilder {
+	return t.add(ModeGT, value)
+}
+
+// GTE matches value and the times after it
+func (t TimeFilterBuilder) GTE(value any) *RootBuilder {
+	return t.add(ModeGTE, value)
+}
+
+// LT matches times earlier than value
+func (t TimeFilterBuilder) LT(value any) *RootBuilder {
+	return t.add(ModeLT, value)
+}
+
+// LTE matches value and the times before it
+func (t TimeFilterBuilder) LTE(value any) *RootBuilder {
+	return t.add(ModeLTE, value)
+}
+
+// Before matches times earlier than value
+func (t TimeFilterBuilder) Before(value any) *RootBuilder {
+	return t.add(ModeBefore, value)
+}
+
+// After matches value and the times after it, as ModeAfter does for time filters
+func (t TimeFilterBuilder) After(value any) *RootBuilder {
+	return t.add(ModeAfter, value)
+}
+
+// Between matches times from from to to, both included
+func (t TimeFilterBuilder) Between(from, to any) *RootBuilder {
+	err := checkTimeValues([]any{from, to})
+	if err == nil {
+		_, err = parseRangeTime(Range{From: from, To: to})
+	}
+	return t.b.add(t.field, ModeRange, DataTypeTime, Range{From: from, To: to}, err)
+}
+
+// checkTimeValues returns an error for the first value that is neither a time.Time nor a string
+func checkTimeValues(values []any) error {
+	for _, value := range values {
+		switch v := value.(type) {
+		case time.Time, string:
+		case *time.Time:
+			if v == nil {
+				return errors.New("time value cannot be nil")
+			}
+		default:
+			return fmt.Errorf("invalid time value %v (type: %T)", value, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package filter
+
+import (
+	"container/list"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compileCache keeps the work done for the most recently used Roots (see
+// GolangFilteringConfig.CompileCacheSize), evicting the least recently used once full.
+// A nil *compileCache caches nothing.
+type compileCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Most recently used first
+	entries map[string]*list.Element
+}
+
+// cacheEntry is an element of compileCache.order
+type cacheEntry struct {
+	key   string
+	value any
+}
+
+// newCompileCache returns a cache of size entries, or nil when size <= 0
+func newCompileCache(size int) *compileCache {
+	if size <= 0 {
+		return nil
+	}
+	return &compileCache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the value cached for key
+func (c *compileCache) get(key string) (any, bool) {
+	if c == nil || key == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).value, true
+}
+
+// put caches value for key, evicting the least recently used entry when the cache is full
+func (c *compileCache) put(key string, value any) {
+	if c == nil || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, exists := c.entries[key]; exists {
+		element.Value.(*cacheEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// rootKey returns the key the compiled filters of filterRoot are cached under, and false when the
+// cache is disabled or filterRoot cannot be cached: it holds relative date values, or a value that
+// cannot be compared by content, such as a func
+func (f *Handler[T]) rootKey(filterRoot Root) (string, bool) {
+	if f.compiled == nil || f.hasRelativeDates(filterRoot) {
+		return "", false
+	}
+	filterRoot.compileKey = ""
+	var b strings.Builder
+	if !writeKey(&b, reflect.ValueOf(filterRoot)) {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// hasRelativeDates reports whether a date filter of root, its nested groups or its ChildCounts holds a
+// relative value such as "today", which resolves to a different time on every query
+func (f *Handler[T]) hasRelativeDates(root Root) bool {
+	for _, filter := range root.FieldFilters {
+		if filter.DataType == DataTypeDate && f.isRelativeDate(filter.Value) {
+			return true
+		}
+	}
+	for _, group := range root.Groups {
+		if f.hasRelativeDates(group) {
+			return true
+		}
+	}
+	for _, count := range root.ChildCounts {
+		if f.hasRelativeDates(count.Where) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRelativeDate reports whether value, the bounds of a Range or an item of a list is a relative date string
+func (f *Handler[T]) isRelativeDate(value any) bool {
+	switch v := value.(type) {
+	case string:
+		_, ok, _ := f.parseRelativeDate(v, time.Now())
+		return ok
+	case Range:
+		return f.isRelativeDate(v.From) || f.isRelativeDate(v.To)
+	case map[string]any:
+		return f.isRelativeDate(v["from"]) || f.isRelativeDate(v["to"])
+	case []any:
+		return slices.ContainsFunc(v, f.isRelativeDate)
+	case []string:
+		return slices.ContainsFunc(v, func(item string) bool { return f.isRelativeDate(item) })
+	}
+	return false
+}
+
+// writeKey writes a canonical encoding of v to b: equal for values with the same content, whatever
+// pointers or map orders they hold, and tagged with the dynamic type of interface values. It reports
+// false for funcs, channels and unsafe pointers.
+func writeKey(b *strings.Builder, v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		b.WriteString("nil")
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return true
+		}
+		if v.Kind() == reflect.Interface {
+			b.WriteString(v.Elem().Type().String())
+		}
+		b.WriteByte('&')
+		return writeKey(b, v.Elem())
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[time.Time]() && v.CanInterface() {
+			t := v.Interface().(time.Time)
+			b.WriteString(t.Format(time.RFC3339Nano) + " " + t.Location().String())
+			return true
+		}
+		b.WriteByte('{')
+		for i := range v.NumField() {
+			if !writeKey(b, v.Field(i)) {
+				return false
+			}
+			b.WriteByte(',')
+		}
+		b.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("nil")
+			return true
+		}
+		b.WriteByte('[')
+		for i := range v.Len() {
+			if !writeKey(b, v.Index(i)) {
+				return false
+			}
+			b.WriteByte(',')
+		}
+		b.WriteByte(']')
+	case reflect.Map:
+		pairs := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var pair strings.Builder
+			if !writeKey(&pair, iter.Key()) {
+				return false
+			}
+			pair.WriteByte(':')
+			if !writeKey(&pair, iter.Value()) {
+				return false
+			}
+			pairs = append(pairs, pair.String())
+		}
+		slices.Sort(pairs)
+		b.WriteString("map[" + strings.Join(pairs, ",") + "]")
+	case reflect.String:
+		b.WriteString(strconv.Quote(v.String()))
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		b.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,359 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// childCountModes are the modes a ChildCountFilter compares its count with
+var childCountModes = map[Mode]bool{
+	ModeEqual:    true,
+	ModeNotEqual: true,
+	ModeGT:       true,
+	ModeGTE:      true,
+	ModeLT:       true,
+	ModeLTE:      true,
+	ModeRange:    true,
+}
+
+// childCount is a ChildCountFilter compiled for the in-memory queries
+type childCount[T any] struct {
+	elements func(*T) any    // Getter of the relation's slice
+	getters  []func(*T) any  // Getters of the Where filters, each returning a manyValues
+	where    childCountGroup // Where, its filters reading the values of getters
+	match    predicate       // Compares the count
+}
+
+// childCountGroup is a group of Where, with filters referring to childCount.getters by index
+type childCountGroup struct {
+	logic   Logic
+	filters []childCountFilter
+	groups  []childCountGroup
+}
+
+type childCountFilter struct {
+	getter int
+	match  predicate
+}
+
+// relationName returns the Go field name of relation when it is a slice of structs of T
+func (f *Handler[T]) relationName(relation string) (string, bool) {
+	path, exists := f.fieldPaths[relation]
+	if !exists {
+		path, exists = f.fieldPaths[strings.ToLower(relation)]
+	}
+	if !exists || strings.Contains(path, ".") {
+		return "", false
+	}
+	field, _ := reflect.TypeOf(new(T)).Elem().FieldByName(path)
+	_, isSlice := sliceElemStruct(field.Type)
+	return path, isSlice
+}
+
+// childWhere returns the Where of count with its fields prefixed by the relation ("status" -> "orders.status"),
+// its filters grouped, values transformed and dates resolved in timeZone, as restrictRoot does for the Root
+func (f *Handler[T]) childWhere(count ChildCountFilter, timeZone string) (Root, error) {
+	where := prefixFields(Root{FieldFilters: count.Where.FieldFilters, Logic: count.Where.Logic, Groups: count.Where.Groups}, count.Relation)
+	where.TimeZone = timeZone
+	where = groupFilters(where)
+	where, err := f.transformValues(where)
+	if err != nil {
+		return Root{}, err
+	}
+	return f.resolveDates(where)
+}
+
+// prefixFields returns a copy of group with relation and a dot prepended to its filter fields, including nested groups
+func prefixFields(group Root, relation string) Root {
+	prefixed := group
+	prefixed.FieldFilters = make([]FieldFilter, len(group.FieldFilters))
+	for i, filter := range group.FieldFilters {
+		filter.Field = relation + "." + filter.Field
+		prefixed.FieldFilters[i] = filter
+	}
+	prefixed.Groups = make([]Root, len(group.Groups))
+	for i, child := range group.Groups {
+		prefixed.Groups[i] = prefixFields(child, relation)
+	}
+	return prefixed
+}
+
+// checkChildCounts returns an error for the first ChildCountFilter of filterRoot the queries cannot run:
+// an unknown or disallowed relation or Where field, an unsupported mode, or a value that cannot be parsed
+func (f *Handler[T]) checkChildCounts(filterRoot Root) error {
+	_, err := f.buildChildCounts(filterRoot)
+	return err
+}
+
+// buildChildCounts compiles the ChildCounts of filterRoot
+func (f *Handler[T]) buildChildCounts(filterRoot Root) ([]childCount[T], error) {
+	if len(filterRoot.ChildCounts) == 0 {
+		return nil, nil
+	}
+	counts := make([]childCount[T], len(filterRoot.ChildCounts))
+	for i, count := range filterRoot.ChildCounts {
+		compiled, err := f.buildChildCount(count, filterRoot.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		counts[i] = compiled
+	}
+	return counts, nil
+}
+
+// buildChildCount compiles count
+func (f *Handler[T]) buildChildCount(count ChildCountFilter, timeZone string) (childCount[T], error) {
+	if _, ok := f.relationName(count.Relation); !ok {
+		return childCount[T]{}, &UnknownFieldError{Field: count.Relation, reason: fmt.Sprintf("unknown relation %s", count.Relation)}
+	}
+	if !f.isFieldAllowed(count.Relation) {
+		return childCount[T]{}, fmt.Errorf("relation %s is not allowed", count.Relation)
+	}
+	if !childCountModes[count.Mode] {
+		return childCount[T]{}, &UnsupportedModeError{Field: count.Relation, Mode: count.Mode, DataType: DataTypeNumber,
+			reason: fmt.Sprintf("child count mode %s not supported on relation %s", count.Mode, count.Relation)}
+	}
+	match, err := compileNumber(FieldFilter{Field: count.Relation, Value: count.Value, Mode: count.Mode, DataType: DataTypeNumber})
+	if err != nil {
+		return childCount[T]{}, err
+	}
+	where, err := f.childWhere(count, timeZone)
+	if err != nil {
+		return childCount[T]{}, err
+	}
+	elements, _ := f.getter(count.Relation)
+	compiled := childCount[T]{elements: elements, match: match}
+	if compiled.where, err = f.buildChildCountGroup(where, &compiled.getters); err != nil {
+		return childCount[T]{}, err
+	}
+	return compiled, nil
+}
+
+// buildChildCountGroup compiles a group of a prefixed Where, appending the getters of its filters to getters
+func (f *Handler[T]) buildChildCountGroup(group Root, getters *[]func(*T) any) (childCountGroup, error) {
+	compiled := childCountGroup{logic: group.Logic}
+	for _, filter := range group.FieldFilters {
+		getter, exists := f.getter(filter.Field)
+		if !exists || strings.Count(filter.Field, ".") != 1 {
+			return childCountGroup{}, &UnknownFieldError{Field: filter.Field}
+		}
+		if !f.isFieldAllowed(filter.Field) {
+			return childCountGroup{}, fmt.Errorf("field %s is not allowed", filter.Field)
+		}
+		if filter.CompareField != "" {
+			return childCountGroup{}, fmt.Errorf("compare field not supported in the child count of field %s", filter.Field)
+		}
+		match, err := f.compileFilter(filter)
+		if err != nil {
+			return childCountGroup{}, err
+		}
+		if err := f.checkValue(filter); err != nil {
+			return childCountGroup{}, err
+		}
+		compiled.filters = append(compiled.filters, childCountFilter{getter: len(*getters), match: match})
+		*getters = append(*getters, getter)
+	}
+	for _, child := range group.Groups {
+		childGroup, err := f.buildChildCountGroup(child, getters)
+		if err != nil {
+			return childCountGroup{}, err
+		}
+		if len(childGroup.filters) > 0 || len(childGroup.groups) > 0 {
+			compiled.groups = append(compiled.groups, childGroup)
+		}
+	}
+	return compiled, nil
+}
+
+// matchChildCounts reports whether item matches every count
+func matchChildCounts[T any](item *T, counts []childCount[T]) (bool, error) {
+	for _, count := range counts {
+		n, err := count.count(item)
+		if err != nil {
+			return false, err
+		}
+		if matched, err := count.match(n); err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// count returns the number of non-nil elements of the relation of item matching Where
+func (c childCount[T]) count(item *T) (int, error) {
+	slice := reflect.ValueOf(derefValue(c.elements(item)))
+	if slice.Kind() != reflect.Slice {
+		return 0, nil
+	}
+	// The getters return the values of the non-nil elements in order, so values[k][i] belong together
+	values := make([]manyValues, len(c.getters))
+	for k, getter := range c.getters {
+		values[k], _ = getter(item).(manyValues)
+	}
+	n, index := 0, 0
+	for i := 0; i < slice.Len(); i++ {
+		if elem := slice.Index(i); elem.Kind() == reflect.Pointer && elem.IsNil() {
+			continue
+		}
+		matched, err := c.where.match(values, index)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			n++
+		}
+		index++
+	}
+	return n, nil
+}
+
+// match evaluates the group against the element at index of values, like matchGroup does against an item.
+// An empty group matches every element.
+func (g childCountGroup) match(values []manyValues, index int) (bool, error) {
+	if len(g.filters) == 0 && len(g.groups) == 0 {
+		return true, nil
+	}
+	isAnd := g.logic == LogicAnd
+	for _, filter := range g.filters {
+		var value any
+		if index < len(values[filter.getter]) {
+			value = values[filter.getter][index]
+		}
+		matched, err := filter.match(value)
+		if err != nil {
+			return false, err
+		}
+		if matched != isAnd {
+			return matched, nil
+		}
+	}
+	for _, child := range g.groups {
+		matched, err := child.match(values, index)
+		if err != nil {
+			return false, err
+		}
+		if matched != isAnd {
+			return matched, nil
+		}
+	}
+	return isAnd, nil
+}
+
+// childCountConditions returns a WHERE condition comparing a correlated COUNT subquery for each ChildCountFilter
+// of filterRoot, such as "(SELECT COUNT(*) FROM orders Orders WHERE customers.id = Orders.customer_id AND
+// (Orders.status = ?)) >= ?". The related rows are aliased by the relation's field name, so the Where
+// conditions are built like those of nested filters.
+func (f *Handler[T]) childCountConditions(d sqlDialect, filterRoot Root) ([]whereCondition, error) {
+	conditions := make([]whereCondition, 0, len(filterRoot.ChildCounts))
+	for _, count := range filterRoot.ChildCounts {
+		condition, values, err := f.buildChildCountCondition(d, count, filterRoot.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, whereCondition{sql: condition, values: values})
+	}
+	return conditions, nil
+}
+
+// buildChildCountCondition builds the condition of childCountConditions for count
+func (f *Handler[T]) buildChildCountCondition(d sqlDialect, count ChildCountFilter, timeZone string) (string, []any, error) {
+	name, ok := f.relationName(count.Relation)
+	if !ok {
+		return "", nil, &UnknownFieldError{Field: count.Relation, reason: fmt.Sprintf("unknown relation %s", count.Relation)}
+	}
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return "", nil, err
+	}
+	rel := modelSchema.Relationships.Relations[name]
+	if rel == nil || (rel.Type != schema.HasMany && rel.Type != schema.Many2Many) {
+		return "", nil, fmt.Errorf("relation %s is not a has-many or many2many relation", count.Relation)
+	}
+
+	subquery, args := relatedRowsSubquery(d, rel, name, "COUNT(*)")
+	where, err := f.childWhere(count, timeZone)
+	if err != nil {
+		return "", nil, err
+	}
+	condition, values, err := f.buildGroupCondition(d, where, f.mainTableName(d), true, true)
+	if err != nil {
+		return "", nil, err
+	}
+	if condition != "" {
+		subquery += " AND " + condition
+		args = append(args, values...)
+	}
+	expr := "(" + subquery + ")"
+
+	// The subquery's arguments are repeated for every comparison it appears in
+	var comparisons []string
+	var comparisonArgs []any
+	compare := func(operator string, num float64) {
+		comparisons = append(comparisons, fmt.Sprintf("%s %s ?", expr, operator))
+		comparisonArgs = append(append(comparisonArgs, args...), num)
+	}
+	if count.Mode == ModeRange {
+		rng, err := parseRangeNumber(count.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		fromOp, toOp := rangeOperators(rng.FromExclusive, rng.ToExclusive)
+		compare(fromOp, rng.From)
+		compare(toOp, rng.To)
+	} else {
+		num, err := parseNumber(count.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		compare(compareOperators[count.Mode], num)
+	}
+	return strings.Join(comparisons, " AND "), comparisonArgs, nil
+}
+
+// relatedRowsSubquery returns "SELECT <selection> FROM ... WHERE ..." over the rows of rel, aliased alias,
+// that belong to the current row of T's table, leaving out soft-deleted related rows as the joins do.
+// selection is COUNT(*) for child counts and 1 for exists filters.
+func relatedRowsSubquery(d sqlDialect, rel *schema.Relationship, alias string, selection string) (string, []any) {
+	quotedAlias := quoteIdentifier(d, alias)
+	mainTable := quoteIdentifier(d, rel.Schema.Table)
+	var conditions []string
+	var args []any
+	from := fmt.Sprintf("%s %s", quoteIdentifier(d, rel.FieldSchema.Table), quotedAlias)
+
+	if rel.Type == schema.Many2Many {
+		// Count the join table's rows for the parent that link to a related row
+		joinAlias := quoteIdentifier(d, alias+"__"+rel.JoinTable.Table)
+		var relatedConditions []string
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey {
+				conditions = append(conditions, fmt.Sprintf("%s.%s = %s.%s",
+					mainTable, quoteIdentifier(d, ref.PrimaryKey.DBName),
+					joinAlias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+			} else {
+				relatedConditions = append(relatedConditions, fmt.Sprintf("%s.%s = %s.%s",
+					joinAlias, quoteIdentifier(d, ref.ForeignKey.DBName),
+					quotedAlias, quoteIdentifier(d, ref.PrimaryKey.DBName)))
+			}
+		}
+		from = fmt.Sprintf("%s %s JOIN %s ON %s", quoteIdentifier(d, rel.JoinTable.Table), joinAlias,
+			from, strings.Join(relatedConditions, " AND "))
+	} else {
+		for _, ref := range rel.References {
+			switch {
+			case ref.OwnPrimaryKey:
+				conditions = append(conditions, fmt.Sprintf("%s.%s = %s.%s",
+					mainTable, quoteIdentifier(d, ref.PrimaryKey.DBName),
+					quotedAlias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+			case ref.PrimaryValue != "":
+				// Polymorphic relations also match the owner type
+				conditions = append(conditions, fmt.Sprintf("%s.%s = ?", quotedAlias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+				args = append(args, ref.PrimaryValue)
+			}
+		}
+	}
+	conditions = append(conditions, softDeleteConditions(d, rel.FieldSchema, quotedAlias)...)
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s", selection, from, strings.Join(conditions, " AND ")), args
+}
@@ -0,0 +1,63 @@
+package filter
+
+import (
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// textCompare compares two strings like strings.Compare
+type textCompare func(a, b string) int
+
+// textComparers returns the string comparison of each of sortFields, see textComparer
+func (f *Handler[T]) textComparers(sortFields []SortField) []textCompare {
+	compares := make([]textCompare, len(sortFields))
+	for i, sortField := range sortFields {
+		compares[i] = f.textComparer(sortField)
+	}
+	return compares
+}
+
+// textComparer returns how sortField compares strings in memory: by a collator for its Language,
+// else by GolangFilteringConfig.Collator, else case-insensitively unless CaseSensitive is set,
+// as orderExpr sorts in SQL (see foldsCase). The primary key tiebreaker compares byte-wise.
+func (f *Handler[T]) textComparer(sortField SortField) textCompare {
+	if sortField.tiebreaker {
+		return strings.Compare
+	}
+	if sortField.Language != "" {
+		if tag, err := language.Parse(sortField.Language); err == nil {
+			// Only used by the goroutine sorting this query
+			return collate.New(tag).CompareString
+		}
+	}
+	if f.collator != nil {
+		return func(a, b string) int {
+			f.collatorMu.Lock()
+			defer f.collatorMu.Unlock()
+			return f.collator.CompareString(a, b)
+		}
+	}
+	if !f.caseSensitive {
+		return compareFold
+	}
+	return strings.Compare
+}
+
+// compareFold compares a and b case-insensitively, then byte-wise so "Apple" and "apple" keep a fixed order
+func compareFold(a, b string) int {
+	if cmp := strings.Compare(strings.ToLower(a), strings.ToLower(b)); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(a, b)
+}
+
+// checkLanguage returns an error when tag, a SortField.Language, is not a BCP 47 language tag
+func checkLanguage(tag string) error {
+	if tag == "" {
+		return nil
+	}
+	_, err := language.Parse(tag)
+	return err
+}
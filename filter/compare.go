@@ -0,0 +1,134 @@
+package filter
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// compareOperators maps the modes a CompareField filter supports to their SQL operator.
+// ModeBefore and ModeAfter are strict, like ModeLT and ModeGT.
+var compareOperators = map[Mode]string{
+	ModeEqual:    "=",
+	ModeNotEqual: "!=",
+	ModeGT:       ">",
+	ModeGTE:      ">=",
+	ModeLT:       "<",
+	ModeLTE:      "<=",
+	ModeBefore:   "<",
+	ModeAfter:    ">",
+}
+
+// checkCompareFilter returns an error when filter cannot compare its field to filter.CompareField:
+// an unsupported mode or data type, or a CompareField that is not a field of T
+func (f *Handler[T]) checkCompareFilter(filter FieldFilter) error {
+	if _, ok := compareOperators[filter.Mode]; !ok {
+		return &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("filter mode %s not supported with compare field %s on field %s", filter.Mode, filter.CompareField, filter.Field)}
+	}
+	switch filter.DataType {
+	case DataTypeNumber, DataTypeDuration, DataTypeDate, DataTypeTime:
+	default:
+		return &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("unsupported data type %s with compare field %s on field %s", filter.DataType, filter.CompareField, filter.Field)}
+	}
+	if strings.Contains(filter.CompareField, ".") || !f.fieldExists(filter.CompareField) {
+		return &UnknownFieldError{Field: filter.CompareField,
+			reason: fmt.Sprintf("unknown compare field %s for field %s", filter.CompareField, filter.Field)}
+	}
+	return nil
+}
+
+// compileCompare returns a predicate comparing the field value of a row (a) to its CompareField value (b).
+// A nil on either side only matches ModeNotEqual, as "a IS NULL OR b IS NULL OR a != b" does in SQL,
+// and a field under a slice matches when any element does.
+func compileCompare(filter FieldFilter) func(a, b any) (bool, error) {
+	var parse func(value any) (any, error)
+	switch filter.DataType {
+	case DataTypeNumber, DataTypeDuration:
+		parse = func(value any) (any, error) { return parseNumber(value) }
+	case DataTypeDate:
+		parse = func(value any) (any, error) { return parseDateTime(value) }
+	default:
+		parse = func(value any) (any, error) { return parseTime(value) }
+	}
+	test := compareResult(filter.Mode)
+	matchesNil := filter.Mode == ModeNotEqual
+
+	matchValue := func(a, b any) (bool, error) {
+		a, b = sortKey(a), sortKey(b)
+		if isMissing(a) || isMissing(b) {
+			return matchesNil, nil
+		}
+		parsedA, err := parse(a)
+		if err != nil {
+			return false, err
+		}
+		parsedB, err := parse(b)
+		if err != nil {
+			return false, err
+		}
+		return test(comparePair(parsedA, parsedB)), nil
+	}
+	return func(a, b any) (bool, error) {
+		if values, ok := a.(manyValues); ok {
+			if len(values) == 0 {
+				return matchesNil, nil
+			}
+			for _, v := range values {
+				matched, err := matchValue(v, b)
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+		return matchValue(a, b)
+	}
+}
+
+// compareResult turns the result of comparing a to b into the match of mode
+func compareResult(mode Mode) func(c int) bool {
+	switch mode {
+	case ModeEqual:
+		return func(c int) bool { return c == 0 }
+	case ModeNotEqual:
+		return func(c int) bool { return c != 0 }
+	case ModeGT, ModeAfter:
+		return func(c int) bool { return c > 0 }
+	case ModeGTE:
+		return func(c int) bool { return c >= 0 }
+	case ModeLT, ModeBefore:
+		return func(c int) bool { return c < 0 }
+	default:
+		return func(c int) bool { return c <= 0 }
+	}
+}
+
+// comparePair compares two values parsed by compileCompare, both float64 or both time.Time
+func comparePair(a, b any) int {
+	if numA, ok := a.(float64); ok {
+		return cmp.Compare(numA, b.(float64))
+	}
+	return a.(time.Time).Compare(b.(time.Time))
+}
+
+// buildCompareCondition builds the SQL condition comparing the column of filter.Field to the column
+// of filter.CompareField, such as "check_out < check_in"
+func (f *Handler[T]) buildCompareCondition(d sqlDialect, filter FieldFilter, mainTableName string) (string, error) {
+	if err := f.checkCompareFilter(filter); err != nil {
+		return "", err
+	}
+	field := f.columnExpr(d, filter.Field, mainTableName)
+	compareField := f.columnExpr(d, filter.CompareField, mainTableName)
+	left, right := field, compareField
+	if filter.DataType == DataTypeTime {
+		left, right = timeOfDayExpr(d, field), timeOfDayExpr(d, compareField)
+	}
+	condition := fmt.Sprintf("%s %s %s", left, compareOperators[filter.Mode], right)
+	if filter.Mode == ModeNotEqual {
+		condition = fmt.Sprintf("(%s IS NULL OR %s IS NULL OR %s)", field, compareField, condition)
+	}
+	return condition, nil
+}
@@ -0,0 +1,104 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Getter returns the function reading field (any filter or sort key, including fields added with
+// RegisterField) from a T, and false for an unknown field. Nil parents of nested fields read as nil,
+// and fields under a slice as a []any of the element values.
+//
+// Example usage:
+//
+//	if get, ok := handler.Getter("department.name"); ok {
+//		fmt.Println(get(employee))
+//	}
+func (f *Handler[T]) Getter(field string) (func(*T) any, bool) {
+	getter, exists := f.fieldGetter(field)
+	if !exists {
+		return nil, false
+	}
+	return func(item *T) any {
+		switch value := getter(item).(type) {
+		case missingValue:
+			return nil
+		case manyValues:
+			return []any(value)
+		default:
+			return value
+		}
+	}, true
+}
+
+// RegisterField adds a computed field, such as "full_name" from FirstName and LastName, that can be
+// filtered with dataType, sorted on and exported like the fields of T. It is available in memory
+// (DataQuery, the memory strategy of Hybrid) and in every export, which read rows through getter; the
+// GORM path filters and sorts on it only once RegisterFieldSQL gives it an SQL expression, and skips it
+// otherwise (strict queries fail instead). Registered fields are allowed even with AllowedFields.
+// It panics when name is empty, contains a dot or is already a field, and must be called before
+// the Handler is shared between goroutines.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[User](filter.GolangFilteringConfig{}).
+//		RegisterField("full_name", func(u *User) any { return u.FirstName + " " + u.LastName }, filter.DataTypeText).
+//		RegisterFieldSQL("full_name", "first_name || ' ' || last_name")
+func (f *Handler[T]) RegisterField(name string, getter func(*T) any, dataType DataType) *Handler[T] {
+	if name == "" || strings.Contains(name, ".") || strings.Contains(name, jsonPathSeparator) {
+		panic(fmt.Sprintf("filter: RegisterField with invalid name %q", name))
+	}
+	if f.fieldExists(name) {
+		panic(fmt.Sprintf("filter: RegisterField on existing field %q", name))
+	}
+	if _, ok := lookupDataType(dataType); !ok {
+		panic(fmt.Sprintf("filter: RegisterField %q with unknown data type %q", name, dataType))
+	}
+	f.getters[name] = getter
+	if f.computed == nil {
+		f.computed = make(map[string]string)
+	}
+	f.computed[name] = ""
+	f.descriptors = append(f.descriptors, FieldDescriptor{
+		Name:     name,
+		Path:     name,
+		DataType: dataType,
+		Nullable: true,
+		Computed: true,
+	})
+	if f.allowedFields != nil {
+		f.allowedFields[f.fieldID(name)] = true
+	}
+	return f
+}
+
+// RegisterFieldSQL sets the SQL expression the GORM path uses for a field added with RegisterField in
+// WHERE and ORDER BY clauses. The expression is inserted into queries as is, so it must never contain
+// user input, and columns should be qualified with the table name when relations are joined.
+// It panics when name was not added with RegisterField.
+func (f *Handler[T]) RegisterFieldSQL(name string, expression string) *Handler[T] {
+	if _, exists := f.computed[name]; !exists {
+		panic(fmt.Sprintf("filter: RegisterFieldSQL on field %q not added with RegisterField", name))
+	}
+	f.computed[name] = strings.TrimSpace(expression)
+	return f
+}
+
+// computedField reports whether field was added with RegisterField, and its SQL expression ("" when none)
+func (f *Handler[T]) computedField(field string) (string, bool) {
+	if expression, exists := f.computed[field]; exists {
+		return expression, true
+	}
+	expression, exists := f.computed[strings.ToLower(field)]
+	return expression, exists
+}
+
+// hasSQL reports whether the GORM path can filter and sort on field: every field except
+// those added with RegisterField without an SQL expression, and JSON paths into them
+func (f *Handler[T]) hasSQL(field string) bool {
+	if column, _, ok := splitJSONPath(field); ok {
+		field = column
+	}
+	expression, computed := f.computedField(field)
+	return !computed || expression != ""
+}
@@ -0,0 +1,103 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// CountGorm returns the number of records matching filterRoot without fetching any rows.
+// It applies the same auto-joins and WHERE conditions as DataGorm, but skips sorting,
+// preloads, and pagination, so the result always equals DataGorm's TotalSize.
+//
+// Example usage:
+//
+//	overdue, err := handler.CountGorm(db.Where("organization_id = ?", orgID), filterRoot)
+func (f *Handler[T]) CountGorm(
+	db *gorm.DB,
+	filterRoot Root,
+) (int64, error) {
+	return f.CountGormCtx(context.Background(), db, filterRoot)
+}
+
+// CountGormCtx is CountGorm with cancellation support.
+// The context is passed to GORM via db.WithContext so running SQL is cancelled with ctx.
+func (f *Handler[T]) CountGormCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+) (int64, error) {
+	return observe(f, ctx, "CountGorm", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (int64, error) {
+		count, err := f.countGorm(ctx, db, filterRoot)
+		report.TotalSize = int(count)
+		return count, err
+	})
+}
+
+// countGorm implements CountGormCtx
+func (f *Handler[T]) countGorm(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+) (int64, error) {
+	// WithContext starts a new session, so the caller's handle is never mutated
+	db = db.WithContext(ctx)
+
+	filterRoot, err := f.restrictRoot(filterRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	// Build the query - db may already have WHERE conditions, they will be preserved
+	query := f.modelQuery(db, filterRoot)
+
+	// Collect filters from the root and all nested groups
+	fieldFilters := flattenFieldFilters(filterRoot)
+
+	// Auto-join related tables needed by nested field filters (sort fields are not needed)
+	query = f.autoJoinRelatedTables(query, fieldFilters, nil)
+
+	// Apply filters
+	query, err = f.applysGorm(query, filterRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	// Filters on to-many relations are EXISTS subqueries, so no join repeats a record
+	totalCount, err := f.countDistinct(db, query, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count records: %w", err)
+	}
+	return totalCount, nil
+}
+
+// CountQuery returns the number of items in data matching filterRoot.
+// It uses the same parallel matching as DataQuery but skips sorting and pagination.
+func (f *Handler[T]) CountQuery(
+	data []*T,
+	filterRoot Root,
+) (int, error) {
+	return f.CountQueryCtx(context.Background(), data, filterRoot)
+}
+
+// CountQueryCtx is CountQuery with cancellation support.
+func (f *Handler[T]) CountQueryCtx(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+) (int, error) {
+	return observe(f, ctx, "CountQuery", filterRoot, StrategyMemory, func(report *QueryResultInfo) (int, error) {
+		// Sorting does not affect the count
+		unsorted := filterRoot
+		unsorted.SortFields = nil
+		unsorted.Limit = 0
+
+		filteredData, err := f.filterUnsorted(ctx, data, unsorted)
+		if err != nil {
+			return 0, err
+		}
+		report.TotalSize, report.Scanned = len(filteredData), len(data)
+		return len(filteredData), nil
+	})
+}
@@ -0,0 +1,454 @@
+package filter
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultCSVOptions returns the options used by the CSV methods without options:
+// comma-delimited, with headers, all columns sorted alphabetically, nil values written as "<nil>",
+// times as RFC 3339 and formulas escaped
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		Delimiter:      ',',
+		NullAs:         "<nil>",
+		TimeFormat:     time.RFC3339,
+		EscapeFormulas: true,
+	}
+}
+
+// GormCSVStream performs database-level filtering like GormNoPaginationCSV but writes the CSV
+// directly to w instead of building it in memory. Rows are fetched in batches of opts.BatchSize
+// and the writer is flushed after every batch, so memory use stays bounded for large exports.
+// With DefaultCSVOptions the output is identical to GormNoPaginationCSV.
+//
+// Batches are fetched as described on findInBatches.
+// If an error occurs mid-stream, the rows already written are flushed before it is returned.
+//
+// Example usage:
+//
+//	w.Header().Set("Content-Type", "text/csv")
+//	opts := filter.DefaultCSVOptions()
+//	opts.BatchSize = 500
+//	err := handler.GormCSVStream(db, filterRoot, w, opts)
+func (f *Handler[T]) GormCSVStream(
+	db *gorm.DB,
+	filterRoot Root,
+	w io.Writer,
+	opts CSVOptions,
+) error {
+	_, err := observe(f, dbContext(db), "GormCSVStream", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (struct{}, error) {
+		return struct{}{}, f.gormCSVStream(db, filterRoot, w, opts, report)
+	})
+	return err
+}
+
+// gormCSVStream implements GormCSVStream, counting the rows written in report
+func (f *Handler[T]) gormCSVStream(db *gorm.DB, filterRoot Root, w io.Writer, opts CSVOptions, report *QueryResultInfo) error {
+	columns, err := f.csvColumns(opts)
+	if err != nil {
+		return err
+	}
+
+	query, order, err := f.gormNoPageQuery(db, f.exportRoot(f.withColumnPreloads(db, filterRoot, columns)))
+	if err != nil {
+		return fmt.Errorf("failed to filter data: %w", err)
+	}
+	if err := f.checkExportLimit(db, query, filterRoot); err != nil {
+		return err
+	}
+
+	csvWriter := opts.newWriter(w)
+	if err := opts.writeHeaders(csvWriter, columns); err != nil {
+		return err
+	}
+
+	err = f.findInBatches(db, query, order, opts.BatchSize, func(batch []*T) error {
+		batch, err := f.limitExportBatch(batch, report.TotalSize, filterRoot, report)
+		if err != nil {
+			return err
+		}
+		if err := f.writeCSVRows(csvWriter, columns, batch, opts); err != nil {
+			return err
+		}
+		report.TotalSize += len(batch)
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return fmt.Errorf("CSV writer error: %w", err)
+		}
+		return nil
+	})
+	return flushCSV(csvWriter, err)
+}
+
+// DataQueryCSVStream performs in-memory filtering like DataQueryNoPageCSV but writes the CSV
+// directly to w, flushing after every opts.BatchSize rows.
+// With DefaultCSVOptions the output is identical to DataQueryNoPageCSV.
+// If an error occurs mid-stream, the rows already written are flushed before it is returned.
+func (f *Handler[T]) DataQueryCSVStream(
+	data []*T,
+	filterRoot Root,
+	w io.Writer,
+	opts CSVOptions,
+) error {
+	_, err := observe(f, context.Background(), "DataQueryCSVStream", filterRoot, StrategyMemory, func(report *QueryResultInfo) (struct{}, error) {
+		return struct{}{}, f.dataQueryCSVStream(data, filterRoot, w, opts, report)
+	})
+	return err
+}
+
+// dataQueryCSVStream implements DataQueryCSVStream, counting the rows written in report
+func (f *Handler[T]) dataQueryCSVStream(data []*T, filterRoot Root, w io.Writer, opts CSVOptions, report *QueryResultInfo) error {
+	columns, err := f.csvColumns(opts)
+	if err != nil {
+		return err
+	}
+
+	filteredData, err := f.dataQueryNoPage(context.Background(), data, filterRoot, report)
+	if err != nil {
+		return fmt.Errorf("failed to filter data: %w", err)
+	}
+	report.Scanned = len(data)
+
+	csvWriter := opts.newWriter(w)
+	if err := opts.writeHeaders(csvWriter, columns); err != nil {
+		return err
+	}
+
+	batchSize := streamBatchSize(opts.BatchSize)
+	for start := 0; start < len(filteredData); start += batchSize {
+		end := min(start+batchSize, len(filteredData))
+		if err := f.writeCSVRows(csvWriter, columns, filteredData[start:end], opts); err != nil {
+			return flushCSV(csvWriter, err)
+		}
+		report.TotalSize += end - start
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return fmt.Errorf("CSV writer error: %w", err)
+		}
+	}
+
+	return flushCSV(csvWriter, nil)
+}
+
+// csvBytes writes items as CSV using the getters and returns the result
+func (f *Handler[T]) csvBytes(items []*T, opts CSVOptions) ([]byte, error) {
+	columns, err := f.csvColumns(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	csvWriter := opts.newWriter(&buf)
+	if err := opts.writeHeaders(csvWriter, columns); err != nil {
+		return nil, err
+	}
+	if err := f.writeCSVRows(csvWriter, columns, items, opts); err != nil {
+		return nil, err
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return nil, fmt.Errorf("CSV writer error: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csvBytesCustom writes items as CSV using customGetter and returns the result.
+// Columns default to the keys returned for the first item; with no items the output is empty
+// unless opts.Columns names the headers.
+func csvBytesCustom[T any](items []*T, customGetter func(*T) map[string]any, opts CSVOptions) ([]byte, error) {
+	var columns []string
+	if len(items) > 0 {
+		firstItemFields := customGetter(items[0])
+		available := make([]string, 0, len(firstItemFields))
+		for fieldName := range firstItemFields {
+			available = append(available, fieldName)
+		}
+		var err error
+		if columns, err = opts.columns(available); err != nil {
+			return nil, err
+		}
+		columns = opts.withoutExcluded(columns)
+	} else if len(opts.Columns) > 0 {
+		columns = opts.withoutExcluded(opts.Columns)
+	} else {
+		// If no data, we can't determine headers, return empty CSV with no headers
+		return []byte(""), nil
+	}
+
+	var buf bytes.Buffer
+	csvWriter := opts.newWriter(&buf)
+	if err := opts.writeHeaders(csvWriter, columns); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		itemFields := customGetter(item)
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			// Keys missing from this item's result are written as empty strings
+			if value, exists := itemFields[column]; exists {
+				record[i] = opts.formatValue(value)
+			}
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return nil, fmt.Errorf("CSV writer error: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newWriter returns a csv.Writer for w using opts.Delimiter
+func (opts CSVOptions) newWriter(w io.Writer) *csv.Writer {
+	csvWriter := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		csvWriter.Comma = opts.Delimiter
+	}
+	return csvWriter
+}
+
+// columns returns opts.Columns after checking each one is available,
+// or all available columns sorted alphabetically when opts.Columns is empty
+func (opts CSVOptions) columns(available []string) ([]string, error) {
+	if len(opts.Columns) == 0 {
+		columns := append([]string(nil), available...)
+		sort.Strings(columns)
+		return columns, nil
+	}
+	known := make(map[string]bool, len(available))
+	for _, column := range available {
+		known[column] = true
+	}
+	for _, column := range opts.Columns {
+		if !known[column] {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+	}
+	return opts.Columns, nil
+}
+
+// withoutExcluded returns columns without those opts.ExcludeFields names or nests under, compared
+// as written (customGetter keys have no aliases)
+func (opts CSVOptions) withoutExcluded(columns []string) []string {
+	if len(opts.ExcludeFields) == 0 {
+		return columns
+	}
+	kept := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if !isExcluded(column, opts.ExcludeFields) {
+			kept = append(kept, column)
+		}
+	}
+	return kept
+}
+
+// isExcluded reports whether field is one of excluded or nested under one of them
+func isExcluded(field string, excluded []string) bool {
+	return slices.ContainsFunc(excluded, func(name string) bool {
+		return field == name || strings.HasPrefix(field, name+".")
+	})
+}
+
+// writeHeaders writes the header row unless opts.OmitHeaders is set
+func (opts CSVOptions) writeHeaders(csvWriter *csv.Writer, columns []string) error {
+	if opts.OmitHeaders {
+		return nil
+	}
+	if err := csvWriter.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV headers: %w", err)
+	}
+	return nil
+}
+
+// formatValue formats value with %v, dereferencing pointers and writing nil values, including
+// driver.Valuers producing NULL (sql.NullTime, gorm.DeletedAt), as opts.NullAs. Nested values
+// under a nil relation (see missingValue) are written as "".
+// Relation structs, which only appear in explicitly chosen columns, are written as JSON.
+// Times are formatted with opts.TimeFormat in opts.Location, and zero times written as "".
+func (opts CSVOptions) formatValue(value any) string {
+	if isMissing(value) {
+		return ""
+	}
+	if isNilValue(value) {
+		return opts.NullAs
+	}
+	value = derefValue(value)
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil && v == nil {
+			return opts.NullAs
+		}
+	}
+	if t, ok := csvTime(value); ok {
+		if t.IsZero() {
+			return ""
+		}
+		if opts.Location != nil {
+			t = t.In(opts.Location)
+		}
+		layout := opts.TimeFormat
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return t.Format(layout)
+	}
+	if isRelationType(reflect.TypeOf(value)) {
+		// A struct dump ("{3 Morning ...}") or pointer addresses are never useful in a cell
+		if encoded, err := json.Marshal(value); err == nil {
+			return opts.escapeFormula(string(encoded))
+		}
+	}
+	return opts.escapeFormula(fmt.Sprintf("%v", value))
+}
+
+// escapeFormula prefixes a formatted cell a spreadsheet would run as a formula with a single quote,
+// as OWASP recommends against CSV injection, when opts.EscapeFormulas is set
+func (opts CSVOptions) escapeFormula(cell string) string {
+	if !opts.EscapeFormulas || cell == "" {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+	default:
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// csvTime returns the time held by value: a time.Time, a named type converting to time.Time
+// (type Date time.Time), a struct embedding time.Time, or a driver.Valuer producing a time.Time
+func csvTime(value any) (time.Time, bool) {
+	if t, ok := value.(time.Time); ok {
+		return t, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Struct {
+		return time.Time{}, false
+	}
+	if rv.Type().ConvertibleTo(timeType) {
+		return rv.Convert(timeType).Interface().(time.Time), true
+	}
+	if field, ok := rv.Type().FieldByName("Time"); ok && field.Anonymous && field.Type == timeType {
+		return rv.FieldByIndex(field.Index).Interface().(time.Time), true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			if t, ok := v.(time.Time); ok {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// csvFieldNames returns the getter keys sorted for deterministic column ordering
+func (f *Handler[T]) csvFieldNames() []string {
+	fieldNames := f.getterKeys()
+	sort.Strings(fieldNames)
+	return fieldNames
+}
+
+// defaultColumns returns the getter keys exported when no columns are chosen, sorted: every field
+// except relations, whose values are exported through their nested columns ("work_shift.name")
+// when MaxDepth reaches them
+func (f *Handler[T]) defaultColumns() []string {
+	fieldNames := f.csvFieldNames()
+	columns := fieldNames[:0]
+	for _, fieldName := range fieldNames {
+		if !f.relations[fieldName] {
+			columns = append(columns, fieldName)
+		}
+	}
+	return columns
+}
+
+// csvColumns returns the columns the getter-based CSV exports write: opts.Columns after checking each
+// one is a field (relations included) or a key of a map field, or defaultColumns, without opts.ExcludeFields
+func (f *Handler[T]) csvColumns(opts CSVOptions) ([]string, error) {
+	columns := f.defaultColumns()
+	if len(opts.Columns) > 0 {
+		available := f.csvFieldNames()
+		for _, column := range opts.Columns {
+			if f.isMapKey(column) {
+				available = append(available, column)
+			}
+		}
+		var err error
+		if columns, err = opts.columns(available); err != nil {
+			return nil, err
+		}
+	}
+	return f.excludeFields(columns, opts.ExcludeFields), nil
+}
+
+// excludeFields returns columns without the fields of excluded and the fields nested under them,
+// matching every alias of a field ("tax_id" also drops "taxid")
+func (f *Handler[T]) excludeFields(columns []string, excluded []string) []string {
+	if len(excluded) == 0 {
+		return columns
+	}
+	ids := make([]string, len(excluded))
+	for i, field := range excluded {
+		ids[i] = f.fieldID(field)
+	}
+	kept := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if !isExcluded(f.fieldID(column), ids) {
+			kept = append(kept, column)
+		}
+	}
+	return kept
+}
+
+// writeCSVRows writes one record per item with the getter values of columns
+func (f *Handler[T]) writeCSVRows(csvWriter *csv.Writer, columns []string, items []*T, opts CSVOptions) error {
+	getters := f.columnGetters(columns)
+	for _, item := range items {
+		record := make([]string, len(columns))
+		for i, getter := range getters {
+			record[i] = opts.formatValue(getter(item))
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+	return nil
+}
+
+// flushCSV flushes what has been written so far and returns err,
+// or the writer's own error when err is nil
+func flushCSV(csvWriter *csv.Writer, err error) error {
+	csvWriter.Flush()
+	if err != nil {
+		return err
+	}
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("CSV writer error: %w", err)
+	}
+	return nil
+}
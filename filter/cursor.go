@@ -0,0 +1,395 @@
+package filter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cursorValue is a single typed sort key value stored in a cursor.
+// The kind is kept so values decode back to the type the database driver expects.
+type cursorValue struct {
+	Kind  string `json:"k"`
+	Value string `json:"v"`
+}
+
+// DataGormCursor performs database-level filtering with keyset (cursor) pagination.
+// Instead of OFFSET it encodes the last row's sort-field values plus its primary key into an opaque cursor,
+// and the next page is fetched with WHERE conditions like (sort_col, id) > (?, ?), respecting
+// each SortField's direction. T's primary key is always appended as the final ascending tie-breaker,
+// even with DisableSortTiebreaker.
+// Pass an empty cursor to fetch the first page.
+//
+// Sort fields must be non-NULL for every row; NULL sort values cannot be encoded into a cursor.
+//
+// Example usage:
+//
+//	page, err := handler.DataGormCursor(db, filterRoot, "", 50)
+//	next, err := handler.DataGormCursor(db, filterRoot, page.NextCursor, 50)
+func (f *Handler[T]) DataGormCursor(
+	db *gorm.DB,
+	filterRoot Root,
+	cursor string,
+	pageSize int,
+) (*PaginationCursorResult[T], error) {
+	return observe(f, dbContext(db), "DataGormCursor", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (*PaginationCursorResult[T], error) {
+		result, err := f.dataGormCursor(db, filterRoot, cursor, pageSize)
+		if err == nil {
+			report.TotalSize = len(result.Data)
+		}
+		return result, err
+	})
+}
+
+// dataGormCursor implements DataGormCursor
+func (f *Handler[T]) dataGormCursor(
+	db *gorm.DB,
+	filterRoot Root,
+	cursor string,
+	pageSize int,
+) (*PaginationCursorResult[T], error) {
+	d := dialectOf(db)
+	_, pageSize = normalizePage(0, pageSize)
+	pageSize, clamped := f.clampPageSize(pageSize)
+
+	filterRoot, err := f.restrictRoot(filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFields, err := f.cursorSortFields(filterRoot.SortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the query - db may already have WHERE conditions, they will be preserved
+	db = newSession(db)
+	query := f.modelQuery(db, filterRoot)
+
+	// Collect filters from the root and all nested groups
+	fieldFilters := flattenFieldFilters(filterRoot)
+
+	// Auto-join related tables based on field filters and sort fields
+	query = f.autoJoinRelatedTables(query, fieldFilters, filterRoot.SortFields)
+
+	// Apply preloads (GORM only feature)
+	query, err = f.applyPreloads(db, query, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filters
+	query, err = f.applysGorm(query, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if any filters or sorts use nested fields (for table name disambiguation)
+	hasNestedFields := false
+	for _, filter := range fieldFilters {
+		if strings.Contains(filter.Field, ".") {
+			hasNestedFields = true
+			break
+		}
+	}
+	for _, keyField := range keyFields {
+		if strings.Contains(keyField.Field, ".") {
+			hasNestedFields = true
+			break
+		}
+	}
+
+	// Get the main table name for disambiguation
+	var mainTableName string
+	if hasNestedFields {
+		mainTableName = f.mainTableName(d)
+	}
+
+	// Continue after the cursor position
+	var values []any
+	if cursor != "" {
+		values, err = decodeCursor(cursor, len(keyFields))
+		if err != nil {
+			return nil, err
+		}
+	}
+	columns, orderFields, args := f.keysetColumns(d, keyFields, values, mainTableName)
+	if cursor != "" {
+		condition, args := buildKeysetCondition(columns, orderFields, args)
+		query = query.Where(condition, args...)
+	}
+
+	for i, keyField := range orderFields {
+		order := "ASC"
+		if keyField.Order == SortOrderDesc {
+			order = "DESC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s", columns[i], order))
+	}
+
+	// Fetch one extra row to determine whether another page exists
+	var data []*T
+	if err := query.Limit(pageSize + 1).Find(&data).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
+	}
+
+	return f.cursorResult(data, keyFields, pageSize, clamped)
+}
+
+// DataQueryCursor performs in-memory filtering with keyset (cursor) pagination.
+// It mirrors DataGormCursor so cursors behave the same on both paths.
+//
+// Example usage:
+//
+//	page, err := handler.DataQueryCursor(data, filterRoot, "", 50)
+//	next, err := handler.DataQueryCursor(data, filterRoot, page.NextCursor, 50)
+func (f *Handler[T]) DataQueryCursor(
+	data []*T,
+	filterRoot Root,
+	cursor string,
+	pageSize int,
+) (*PaginationCursorResult[T], error) {
+	return observe(f, context.Background(), "DataQueryCursor", filterRoot, StrategyMemory, func(report *QueryResultInfo) (*PaginationCursorResult[T], error) {
+		result, err := f.dataQueryCursor(data, filterRoot, cursor, pageSize)
+		if err == nil {
+			report.TotalSize, report.Scanned = len(result.Data), len(data)
+		}
+		return result, err
+	})
+}
+
+// dataQueryCursor implements DataQueryCursor
+func (f *Handler[T]) dataQueryCursor(
+	data []*T,
+	filterRoot Root,
+	cursor string,
+	pageSize int,
+) (*PaginationCursorResult[T], error) {
+	_, pageSize = normalizePage(0, pageSize)
+	pageSize, clamped := f.clampPageSize(pageSize)
+
+	filterRoot, err := f.restrictMemoryRoot(filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFields, err := f.cursorSortFields(filterRoot.SortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter without sorting, then sort once by the full key (sort fields + primary key)
+	unsorted := filterRoot
+	unsorted.SortFields = nil
+	unsorted.Limit = 0
+	filteredData, err := f.filterUnsorted(context.Background(), data, unsorted)
+	if err != nil {
+		return nil, err
+	}
+	texts := f.textComparers(keyFields)
+	sort.SliceStable(filteredData, func(i, j int) bool {
+		return f.compareItems(filteredData[i], filteredData[j], keyFields, texts) < 0
+	})
+
+	// Skip everything up to and including the cursor position
+	start := 0
+	if cursor != "" {
+		values, err := decodeCursor(cursor, len(keyFields))
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(filteredData), func(i int) bool {
+			return f.compareToCursor(filteredData[i], keyFields, texts, values) > 0
+		})
+	}
+
+	end := min(start+pageSize+1, len(filteredData))
+	return f.cursorResult(filteredData[start:end], keyFields, pageSize, clamped)
+}
+
+// cursorResult trims the extra look-ahead row and encodes the next cursor
+func (f *Handler[T]) cursorResult(data []*T, keyFields []SortField, pageSize int, clamped bool) (*PaginationCursorResult[T], error) {
+	result := PaginationCursorResult[T]{
+		PageSize:        pageSize,
+		PageSizeClamped: clamped,
+		Data:            data,
+	}
+	if len(data) > pageSize {
+		result.Data = data[:pageSize]
+		result.HasMore = true
+		nextCursor, err := f.encodeCursor(result.Data[pageSize-1], keyFields)
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = nextCursor
+	}
+	// Hooks run once the cursor is encoded, so they may redact the sort key
+	result.Data = f.afterFetch(result.Data)
+	if result.Data == nil {
+		result.Data = make([]*T, 0)
+	}
+	return &result, nil
+}
+
+// cursorSortFields returns the valid sort fields with the primary key appended as a tie-breaker
+func (f *Handler[T]) cursorSortFields(sortFields []SortField) ([]SortField, error) {
+	if f.primaryKey == "" {
+		return nil, fmt.Errorf("cursor pagination requires a primary key field")
+	}
+	keyFields := make([]SortField, 0, len(sortFields)+1)
+	for _, sortField := range sortFields {
+		if _, exists := f.getter(sortField.Field); !exists {
+			if !strings.Contains(sortField.Field, ".") {
+				// Silently ignore non-existent simple sort fields, consistent with DataGorm
+				continue
+			}
+			return nil, fmt.Errorf("cursor pagination requires a getter for sort field %s (increase MaxDepth for nested fields)", sortField.Field)
+		}
+		if f.isSliceField(sortField.Field) {
+			// A to-many field has no single value per record to continue from
+			return nil, fmt.Errorf("cursor pagination cannot sort by to-many field %s", sortField.Field)
+		}
+		if sortField.Nulls != NullsDefault {
+			// The keyset condition compares values with < and >, which never continue across NULLs
+			return nil, fmt.Errorf("cursor pagination does not support nulls order on sort field %s", sortField.Field)
+		}
+		keyFields = append(keyFields, sortField)
+	}
+	return f.withPrimaryKey(keyFields), nil
+}
+
+// compareToCursor compares an item's sort key against decoded cursor values, honoring sort direction
+func (f *Handler[T]) compareToCursor(item *T, keyFields []SortField, texts []textCompare, values []any) int {
+	for i, keyField := range keyFields {
+		getter, _ := f.getter(keyField.Field)
+		cmp := compareValues(getter(item), values[i], texts[i])
+		if keyField.Order == SortOrderDesc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// encodeCursor encodes the sort key of item into an opaque base64 cursor
+func (f *Handler[T]) encodeCursor(item *T, keyFields []SortField) (string, error) {
+	values := make([]cursorValue, len(keyFields))
+	for i, keyField := range keyFields {
+		getter, _ := f.getter(keyField.Field)
+		value, err := toCursorValue(getter(item))
+		if err != nil {
+			return "", fmt.Errorf("cannot encode cursor for sort field %s: %w", keyField.Field, err)
+		}
+		values[i] = value
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// toCursorValue converts a field value into a typed cursor value
+func toCursorValue(value any) (cursorValue, error) {
+	if isNilValue(value) || isMissing(value) {
+		return cursorValue{}, fmt.Errorf("NULL sort values are not supported")
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+		value = rv.Interface()
+	}
+	if t, ok := value.(time.Time); ok {
+		return cursorValue{Kind: "time", Value: t.Format(time.RFC3339Nano)}, nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{Kind: "int", Value: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{Kind: "int", Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{Kind: "float", Value: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return cursorValue{Kind: "string", Value: rv.String()}, nil
+	case reflect.Bool:
+		return cursorValue{Kind: "bool", Value: strconv.FormatBool(rv.Bool())}, nil
+	}
+	return cursorValue{}, fmt.Errorf("unsupported sort value type %T", value)
+}
+
+// decodeCursor decodes an opaque cursor into typed values for expectedLen sort fields
+func decodeCursor(cursor string, expectedLen int) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, &InvalidCursorError{Err: err}
+	}
+	var raw []cursorValue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, &InvalidCursorError{Err: err}
+	}
+	if len(raw) != expectedLen {
+		return nil, &InvalidCursorError{Err: fmt.Errorf("expected %d sort values, got %d (sort fields changed?)", expectedLen, len(raw))}
+	}
+
+	values := make([]any, len(raw))
+	for i, value := range raw {
+		var parsed any
+		switch value.Kind {
+		case "time":
+			parsed, err = time.Parse(time.RFC3339Nano, value.Value)
+		case "int":
+			parsed, err = strconv.ParseInt(value.Value, 10, 64)
+			if err != nil {
+				// Fall back to uint64 for values above math.MaxInt64
+				parsed, err = strconv.ParseUint(value.Value, 10, 64)
+			}
+		case "float":
+			parsed, err = strconv.ParseFloat(value.Value, 64)
+		case "string":
+			parsed = value.Value
+		case "bool":
+			parsed, err = strconv.ParseBool(value.Value)
+		default:
+			err = fmt.Errorf("unknown value kind %q", value.Kind)
+		}
+		if err != nil {
+			return nil, &InvalidCursorError{Err: err}
+		}
+		values[i] = parsed
+	}
+	return values, nil
+}
+
+// buildKeysetCondition builds the WHERE condition selecting rows after the cursor values.
+// For sort keys (a ASC, b DESC, id ASC) it produces:
+//
+//	(a > ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND id > ?)
+func buildKeysetCondition(columns []string, keyFields []SortField, values []any) (string, []any) {
+	orConditions := make([]string, 0, len(keyFields))
+	args := make([]any, 0, len(keyFields)*(len(keyFields)+1)/2)
+	for i, keyField := range keyFields {
+		andConditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			andConditions = append(andConditions, fmt.Sprintf("%s = ?", columns[j]))
+			args = append(args, values[j])
+		}
+		op := ">"
+		if keyField.Order == SortOrderDesc {
+			op = "<"
+		}
+		andConditions = append(andConditions, fmt.Sprintf("%s %s ?", columns[i], op))
+		args = append(args, values[i])
+		orConditions = append(orConditions, "("+strings.Join(andConditions, " AND ")+")")
+	}
+	return "(" + strings.Join(orConditions, " OR ") + ")", args
+}
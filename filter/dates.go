@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeOffsetPattern matches one offset of a relative date, e.g. "-7d" or "+1h"
+var relativeOffsetPattern = regexp.MustCompile(`^([+-])(\d+)([smhdwMy])`)
+
+// resolveDates returns a copy of root where string values of date filters are replaced by the time.Time
+// they denote in the query's zone (root.TimeZone, else the handler's Location), including Range bounds,
+// list items and nested groups. Relative values ("now-7d", "today", "start_of_month", ...) are all evaluated
+// against the same current time, and values without an explicit offset (e.g. "2025-11-03") are taken
+// as local to the zone, so date-only values cover the zone's day in DataQuery and DataGorm alike.
+func (f *Handler[T]) resolveDates(root Root) (Root, error) {
+	loc := f.location
+	if root.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(root.TimeZone); err != nil {
+			return Root{}, &InvalidFilterError{Err: fmt.Errorf("unknown time zone %q: %w", root.TimeZone, err)}
+		}
+	}
+	return f.resolveGroupDates(root, f.now().In(loc))
+}
+
+// resolveGroupDates is the recursive step of resolveDates
+func (f *Handler[T]) resolveGroupDates(root Root, now time.Time) (Root, error) {
+	// Copy the slices before replacing values so the caller's Root is never modified
+	resolved := root
+	copied := false
+	for i, filter := range root.FieldFilters {
+		if filter.DataType != DataTypeDate {
+			continue
+		}
+		value, changed, err := f.resolveDateValue(filter.Value, now)
+		if err != nil {
+			return Root{}, filterError(filter, err)
+		}
+		if !changed {
+			continue
+		}
+		if !copied {
+			resolved.FieldFilters = append([]FieldFilter{}, root.FieldFilters...)
+			copied = true
+		}
+		resolved.FieldFilters[i].Value = value
+	}
+	if len(root.Groups) > 0 {
+		resolved.Groups = make([]Root, len(root.Groups))
+		for i, group := range root.Groups {
+			resolvedGroup, err := f.resolveGroupDates(group, now)
+			if err != nil {
+				return Root{}, err
+			}
+			resolved.Groups[i] = resolvedGroup
+		}
+	}
+	return resolved, nil
+}
+
+// resolveDateValue resolves a relative date string, or the relative bounds of a Range
+// or items of a list, reporting whether anything was replaced
+func (f *Handler[T]) resolveDateValue(value any, now time.Time) (any, bool, error) {
+	switch v := value.(type) {
+	case string:
+		t, ok, err := f.parseRelativeDate(v, now)
+		if err != nil {
+			return value, false, err
+		}
+		if ok {
+			return t, true, nil
+		}
+		if now.Location() == time.UTC {
+			// Absolute values are parsed in UTC later on
+			return value, false, nil
+		}
+		// Leave unparseable values for the date filter to report
+		if t, err := parseDateTimeIn(v, now.Location()); err == nil {
+			return t, true, nil
+		}
+		return value, false, nil
+	case Range:
+		from, fromChanged, err := f.resolveDateValue(v.From, now)
+		if err != nil {
+			return value, false, err
+		}
+		to, toChanged, err := f.resolveDateValue(v.To, now)
+		if err != nil {
+			return value, false, err
+		}
+		return Range{From: from, To: to, FromExclusive: v.FromExclusive, ToExclusive: v.ToExclusive}, fromChanged || toChanged, nil
+	case map[string]any:
+		rng, err := toRange(v)
+		if err != nil {
+			// Leave malformed ranges for the date filter to report
+			return value, false, nil
+		}
+		return f.resolveDateValue(rng, now)
+	case []any:
+		var items []any
+		for i, item := range v {
+			resolvedItem, changed, err := f.resolveDateValue(item, now)
+			if err != nil {
+				return value, false, err
+			}
+			if changed && items == nil {
+				items = append([]any{}, v...)
+			}
+			if items != nil {
+				items[i] = resolvedItem
+			}
+		}
+		if items == nil {
+			return value, false, nil
+		}
+		return items, true, nil
+	case []string:
+		items := make([]any, len(v))
+		changed := false
+		for i, item := range v {
+			resolvedItem, itemChanged, err := f.resolveDateValue(item, now)
+			if err != nil {
+				return value, false, err
+			}
+			items[i] = resolvedItem
+			changed = changed || itemChanged
+		}
+		if !changed {
+			return value, false, nil
+		}
+		return items, true, nil
+	}
+	return value, false, nil
+}
+
+// parseRelativeDate parses an anchor followed by any number of offsets, e.g. "now-7d" or "today+1d-1h".
+// Anchors are "now" (the current instant) and the dates "today", "yesterday", "tomorrow",
+// "start_of_month" and "end_of_month", which compare by whole day like date-only values.
+// Offsets are a sign, a count and a unit: s, m, h (exact durations), d, w (calendar days, so
+// "now-1d" keeps the wall clock across DST changes), M or y (calendar months or years, clamped
+// to the last day of a shorter month). ok is false when s does not start with an anchor.
+func (f *Handler[T]) parseRelativeDate(s string, now time.Time) (t time.Time, ok bool, err error) {
+	text := strings.TrimSpace(s)
+	anchorEnd := strings.IndexAny(text, "+-")
+	if anchorEnd < 0 {
+		anchorEnd = len(text)
+	}
+
+	year, month, day := now.Date()
+	switch strings.ToLower(text[:anchorEnd]) {
+	case "now":
+		t = now
+	case "today":
+		t = time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	case "yesterday":
+		t = time.Date(year, month, day-1, 0, 0, 0, 0, now.Location())
+	case "tomorrow":
+		t = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+	case "start_of_month":
+		t = time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+	case "end_of_month":
+		t = time.Date(year, month+1, 0, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}, false, nil
+	}
+
+	for rest := text[anchorEnd:]; rest != ""; {
+		match := relativeOffsetPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return time.Time{}, false, fmt.Errorf("invalid relative date %q", s)
+		}
+		rest = rest[len(match[0]):]
+		n, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid relative date %q", s)
+		}
+		if match[1] == "-" {
+			n = -n
+		}
+		switch match[3] {
+		case "s":
+			t = t.Add(time.Duration(n) * time.Second)
+		case "m":
+			t = t.Add(time.Duration(n) * time.Minute)
+		case "h":
+			t = t.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "M":
+			t = addMonths(t, n)
+		case "y":
+			t = addMonths(t, 12*n)
+		}
+	}
+	return t, true, nil
+}
+
+// addMonths adds n calendar months to t, clamping the day to the last day of the resulting month
+// (Mar 31 - 1 month is Feb 28, not Mar 3)
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year, month+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	hour, minute, sec := t.Clock()
+	return time.Date(year, month+time.Month(n), day, hour, minute, sec, t.Nanosecond(), t.Location())
+}
@@ -1,13 +1,132 @@
 // Package filter provides utilities for filtering, sorting, and paginating data sets.
 package filter
 
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/text/collate"
+)
+
 // Handler is the main struct that handles filtering operations for a specific data type T.
 type Handler[T any] struct {
-	getters map[string]func(*T) any
+	getters          map[string]func(*T) any        // Getter key -> getter of a top-level field of T or a field added with RegisterField
+	nestedFields     map[string]reflect.StructField // Getter key -> top-level field of T whose nested getters are generated on first use
+	nestedGetters    map[string]func(*T) any        // Getter key -> getter of a nested field, see nestedGetter
+	nestedBuilt      map[string]bool                // Keys of nestedFields whose getters are in nestedGetters
+	nestedMu         sync.RWMutex                   // Guards nestedGetters and nestedBuilt
+	mapFields        map[string]bool                // Getter keys of the map fields of T, whose keys are read as "attributes.color"
+	maxDepth         int                            // GolangFilteringConfig.MaxDepth (1 when nil)
+	columns          map[string]string              // Filter field name -> database column name, used by the GORM path only
+	fieldPaths       map[string]string              // Getter key -> Go field path, used to match field aliases
+	textFields       []string                       // Getter keys of the string fields of T, searched when SearchFilter.Fields is empty
+	relations        map[string]bool                // Getter keys of relation fields, left out of the default export columns
+	descriptors      []FieldDescriptor              // Filterable fields of T in declaration order, see Fields
+	allowedFields    map[string]bool                // nil means every field is allowed
+	deniedFields     map[string]bool
+	allowedValues    map[string]map[string]bool        // Field identifier -> values set by RestrictValues
+	transforms       map[string]func(any) (any, error) // Field identifier -> function set by TransformValue
+	durationUnits    map[string]time.Duration          // Field identifier -> storage unit set by DurationUnit
+	computed         map[string]string                 // Field added by RegisterField -> SQL expression set by RegisterFieldSQL ("" for none)
+	rejectDisallowed bool
+	rejectUnknown    bool
+	caseSensitive    bool
+	collator         *collate.Collator // Compares strings when sorting in memory (nil for byte or case-insensitive order)
+	collatorMu       sync.Mutex        // Guards collator, which is not safe for concurrent use
+	strictValidation bool
+	maxWorkers       int               // Goroutines filtering large slices in memory (runtime.NumCPU() when <= 0)
+	maxPageSize      int               // Largest page size any paged query returns (no cap when <= 0)
+	maxExportRows    int               // Most records a no-page query or export returns (no cap when <= 0)
+	datePrecision    time.Duration     // Unit date values with a time component stand for (exact when <= 0)
+	textMatch        TextMatchStrategy // How case-insensitive pattern filters are written in SQL
+	buffers          *slicePool[T]     // Recycled match buffers of in-memory queries (nil unless PoolBuffers)
+	compiled         *compileCache     // Compiled filters of recent Roots (nil unless CompileCacheSize > 0)
+	isDeleted        func(*T) bool     // Reports soft-deleted items from T's DeletedAt field (nil without one)
+	primaryKey       string            // Getter key of T's primary key ("" when T has none)
+	sortTiebreaker   bool              // Append primaryKey to sorts, see withTiebreaker
+	location         *time.Location    // Zone relative date values are evaluated in
+	now              func() time.Time  // Clock for relative date values
+	schemas          sync.Map          // schema.Namer -> parsedSchema of T, see modelSchema
+	observer         Observer          // Notified around every query and export (nil for none)
+	gormHooks        []GormHook        // Added with Use, run in order on every GORM query
+	queryHooks       []QueryHook[T]    // Added with Use, run in order on the data of every in-memory query
+	fetchHooks       []FetchHook[T]    // Added with Use, run in order on the records every query returns
 }
 
 type GolangFilteringConfig struct {
 	MaxDepth *int
+	// StrictValidation makes DataGorm and friends return an error when a filter value
+	// cannot be parsed or a mode is unsupported, instead of silently skipping the filter
+	StrictValidation bool
+	// ColumnMappings maps filter field names to database column names when they differ
+	// (e.g. "reference_no" -> "ref_number"). Equivalent to a `filter:"column:ref_number"` struct tag.
+	// NewFilter panics if a key is not a known field or a column name is not a plain identifier.
+	ColumnMappings map[string]string
+	// AllowedFields restricts filtering and sorting to these fields (nil allows every field).
+	// DeniedFields blocks filtering and sorting on these fields. Both accept any getter key
+	// (json tag or lowercase name) and NewFilter panics on unknown fields.
+	AllowedFields []string
+	DeniedFields  []string
+	// CaseSensitive makes every text filter case-sensitive by default (see FieldFilter.CaseSensitive),
+	// and sorting compare strings byte-wise ("Zebra" before "apple") instead of ignoring case, which
+	// sorts text columns by LOWER(column) in SQL
+	CaseSensitive bool
+	// Collator orders strings when sorting in memory, e.g. collate.New(language.German) to sort "Ärger"
+	// with the A's as an ICU collation in the database does (case-insensitive or byte order when nil).
+	// SortField.Language overrides it per field. Handlers serialize their use of it, as a Collator is not
+	// safe for concurrent use.
+	Collator *collate.Collator
+	// RejectDisallowedFields returns an error listing disallowed fields instead of silently ignoring them
+	RejectDisallowedFields bool
+	// RejectUnknownFields returns an error listing filter, search and sort fields that do not exist on T,
+	// nested ones included, so a typo such as "crated_at" or "dept.nmae" fails instead of returning
+	// unfiltered results. Without it those
+	// fields are skipped and reported in PaginationResult.IgnoredFields. The in-memory paths only know the
+	// fields with a getter, so nested fields past MaxDepth are unknown there while DataGorm reads them.
+	RejectUnknownFields bool
+	// Location is the time zone relative date values such as "today" and "start_of_month" are evaluated in (UTC when nil)
+	Location *time.Location
+	// Now returns the current time for relative date values (time.Now when nil)
+	Now func() time.Time
+	// DisableSortTiebreaker stops DataGorm, DataQuery, Hybrid and their NoPage variants from appending the
+	// primary key (ascending) to the sort fields, which keeps page boundaries stable when sort values tie
+	DisableSortTiebreaker bool
+	// MaxWorkers caps the goroutines DataQuery and the other in-memory paths split a slice across
+	// (runtime.NumCPU() when <= 0); 1 filters on the calling goroutine only, as slices under 1000 items
+	// always are. The matches keep their order in the slice whatever the number of workers.
+	MaxWorkers int
+	// Observer is notified when each query or export starts and finishes, with its duration,
+	// strategy, total size and error, e.g. for logging or tracing (nothing is reported when nil)
+	Observer Observer
+	// MaxPageSize caps the page size of DataGorm, DataQuery, Hybrid and the cursor queries (no cap when <= 0).
+	// Larger requests return MaxPageSize records, reported by PageSizeClamped on the result.
+	MaxPageSize int
+	// MaxExportRows caps the records of DataGormNoPage, DataQueryNoPage, Hybrid's NoPage paths and the CSV,
+	// JSON and XLSX exports, which fail with an *ExportLimitError when more records match, or return the
+	// first MaxExportRows with Root.TruncateExport (DefaultMaxExportRows when 0, no cap when < 0).
+	// The ForEach methods are never capped.
+	MaxExportRows int
+	// DatePrecision compares date values with a time component as the whole unit they fall in, so with
+	// time.Second "2025-11-05T14:30:00Z" equals a stored 14:30:00.000123: ModeEqual matches [t, t+1s),
+	// ModeNotEqual everything outside it, and the other modes and Range bounds move to its edges.
+	// Exact timestamps are compared when <= 0.
+	DatePrecision time.Duration
+	// TextMatchStrategy chooses how case-insensitive pattern filters are written in SQL. By default
+	// PostgreSQL gets col ILIKE ?, which the planner can serve from a pg_trgm GIN index (gin_trgm_ops)
+	// on the column itself, and other databases LOWER(col) LIKE LOWER(?). DataQuery is unaffected.
+	TextMatchStrategy TextMatchStrategy
+	// PoolBuffers makes the in-memory paths reuse the slices they collect matches in across calls,
+	// which cuts allocations and GC work under sustained DataQuery load at the cost of keeping those
+	// buffers alive between calls. Results never share pooled memory.
+	PoolBuffers bool
+	// CompileCacheSize keeps the validated filters, compiled in-memory predicates and GORM WHERE
+	// conditions of the last CompileCacheSize distinct Roots, so a repeated query skips straight to
+	// matching. Roots are compared by content, pagination aside. Roots with relative date values such
+	// as "today" are never cached, and TransformValue functions must return the same result for the
+	// same value. Nothing is cached when <= 0.
+	CompileCacheSize int
 }
 
 // New creates a new filter handler that automatically generates getters using reflection
@@ -16,8 +135,61 @@ func NewFilter[T any](config GolangFilteringConfig) *Handler[T] {
 	if config.MaxDepth != nil {
 		depth = *config.MaxDepth
 	}
-	getters := generateGetters[T](depth)
-	return &Handler[T]{
-		getters: getters,
+	getters, nestedFields := generateGetters[T](depth)
+	handler := &Handler[T]{
+		getters:          getters,
+		nestedFields:     nestedFields,
+		nestedGetters:    make(map[string]func(*T) any),
+		nestedBuilt:      make(map[string]bool),
+		mapFields:        generateMapFields[T](),
+		maxDepth:         depth,
+		columns:          generateColumnMappings[T](depth),
+		fieldPaths:       generateFieldPaths[T](depth),
+		textFields:       generateTextFields[T](),
+		relations:        generateRelationFields[T](depth),
+		descriptors:      generateDescriptors[T](depth),
+		rejectDisallowed: config.RejectDisallowedFields,
+		rejectUnknown:    config.RejectUnknownFields,
+		caseSensitive:    config.CaseSensitive,
+		collator:         config.Collator,
+		strictValidation: config.StrictValidation,
+		maxWorkers:       config.MaxWorkers,
+		maxPageSize:      config.MaxPageSize,
+		maxExportRows:    config.MaxExportRows,
+		datePrecision:    config.DatePrecision,
+		textMatch:        config.TextMatchStrategy,
+		compiled:         newCompileCache(config.CompileCacheSize),
+		observer:         config.Observer,
+		isDeleted:        softDeleteChecker[T](),
+		location:         time.UTC,
+		now:              time.Now,
+		primaryKey:       primaryKeyField(getters),
+		sortTiebreaker:   !config.DisableSortTiebreaker,
+	}
+	if config.MaxExportRows == 0 {
+		handler.maxExportRows = DefaultMaxExportRows
+	}
+	if config.Location != nil {
+		handler.location = config.Location
+	}
+	if config.Now != nil {
+		handler.now = config.Now
+	}
+	if config.PoolBuffers {
+		handler.buffers = &slicePool[T]{}
+	}
+	for field, column := range config.ColumnMappings {
+		if _, exists := handler.keyGetter(field); !exists {
+			panic(fmt.Sprintf("filter: column mapping for unknown field %q", field))
+		}
+		handler.columns[field] = column
+	}
+	for field, column := range handler.columns {
+		if !isValidColumnName(column) {
+			panic(fmt.Sprintf("filter: invalid column name %q for field %q", column, field))
+		}
 	}
+	handler.allowedFields = handler.fieldSet(config.AllowedFields, "AllowedFields")
+	handler.deniedFields = handler.fieldSet(config.DeniedFields, "DeniedFields")
+	return handler
 }
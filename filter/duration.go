@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DurationUnit sets the unit field stores durations in (e.g. time.Minute for an integer column of
+// minutes), so DataTypeDuration filters on it can be written as "90m" or "1h30m". Fields without a
+// unit store nanoseconds, as time.Duration does. It panics on an unknown field or a unit that is not
+// positive, and must be called before the Handler is shared between goroutines.
+//
+// Example usage:
+//
+//	handler.DurationUnit("duration", time.Minute)
+//	// {"field": "duration", "value": {"from": "1h", "to": "2h30m"}, "mode": "range", "dataType": "duration"}
+func (f *Handler[T]) DurationUnit(field string, unit time.Duration) *Handler[T] {
+	if !strings.Contains(field, ".") && !f.fieldExists(field) {
+		panic(fmt.Sprintf("filter: DurationUnit on unknown field %q", field))
+	}
+	if unit <= 0 {
+		panic(fmt.Sprintf("filter: DurationUnit on field %q with unit %v", field, unit))
+	}
+	if f.durationUnits == nil {
+		f.durationUnits = make(map[string]time.Duration)
+	}
+	f.durationUnits[f.fieldID(field)] = unit
+	return f
+}
+
+// durationUnit returns the unit field stores durations in
+func (f *Handler[T]) durationUnit(field string) time.Duration {
+	if unit, exists := f.durationUnits[f.fieldID(field)]; exists {
+		return unit
+	}
+	return time.Nanosecond
+}
+
+// numberFilter returns the DataTypeNumber filter a DataTypeDuration filter stands for: every value,
+// including Range bounds and list items, becomes a number of the field's unit. Other filters are
+// returned as is.
+func (f *Handler[T]) numberFilter(filter FieldFilter) (FieldFilter, error) {
+	if filter.DataType != DataTypeDuration {
+		return filter, nil
+	}
+	filter.DataType = DataTypeNumber
+	if filter.Mode == ModeIsEmpty || filter.Mode == ModeIsNotEmpty {
+		return filter, nil
+	}
+	value, err := durationValue(filter.Value, f.durationUnit(filter.Field))
+	if err != nil {
+		return filter, &InvalidValueError{Field: filter.Field, Value: filter.Value, DataType: DataTypeDuration, Err: err}
+	}
+	filter.Value = value
+	return filter, nil
+}
+
+// durationValue converts a filter value to numbers of unit: scalars, Range bounds (as a Range or a
+// decoded JSON object) and the items of a list. nil values are left alone.
+func durationValue(value any, unit time.Duration) (any, error) {
+	value = derefValue(value)
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case Range:
+		from, err := durationValue(v.From, unit)
+		if err != nil {
+			return nil, err
+		}
+		to, err := durationValue(v.To, unit)
+		if err != nil {
+			return nil, err
+		}
+		v.From, v.To = from, to
+		return v, nil
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			if key == "from" || key == "to" {
+				num, err := durationValue(item, unit)
+				if err != nil {
+					return nil, err
+				}
+				item = num
+			}
+			converted[key] = item
+		}
+		return converted, nil
+	case string, time.Duration:
+		return durationNumber(v, unit)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items := make([]any, rv.Len())
+		for i := range items {
+			num, err := durationValue(rv.Index(i).Interface(), unit)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = num
+		}
+		return items, nil
+	}
+	return durationNumber(value, unit)
+}
+
+// durationNumber converts one duration to a number of unit: a time.Duration, a Go duration string
+// ("90m", "1h30m") or a number, which is already in unit
+func durationNumber(value any, unit time.Duration) (float64, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return float64(v) / float64(unit), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if d, err := time.ParseDuration(s); err == nil {
+			return float64(d) / float64(unit), nil
+		}
+		if num, err := parseNumericString(s); err == nil {
+			return num, nil
+		}
+		return 0, fmt.Errorf("%q is not a duration or a number", v)
+	}
+	return parseNumber(value)
+}
@@ -0,0 +1,217 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors for the categories of invalid filters. The error types below match them with
+// errors.Is, so an API can map a whole category to a status code without inspecting messages:
+//
+//	switch {
+//	case errors.Is(err, filter.ErrUnknownField), errors.Is(err, filter.ErrInvalidValue),
+//	    errors.Is(err, filter.ErrUnsupportedMode), errors.Is(err, filter.ErrInvalidRange),
+//	    errors.Is(err, filter.ErrInvalidFilter), errors.Is(err, filter.ErrInvalidCursor):
+//	    return http.StatusBadRequest
+//	case errors.Is(err, filter.ErrFieldNotAllowed):
+//	    return http.StatusForbidden
+//	}
+//	return http.StatusInternalServerError
+var (
+	ErrUnknownField    = errors.New("unknown field")
+	ErrInvalidValue    = errors.New("invalid value")
+	ErrUnsupportedMode = errors.New("unsupported filter mode")
+	ErrInvalidRange    = errors.New("invalid range")
+	ErrFieldNotAllowed = errors.New("field not allowed")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+)
+
+// UnknownFieldError reports a filter, search, sort, compare, facet or aggregation field, or a child count
+// relation, that does not exist on T
+type UnknownFieldError struct {
+	Field  string   // The unknown field, the first one when Fields lists several
+	Fields []string // Every unknown field of a Root rejected by RejectUnknownFields (nil otherwise)
+
+	reason string // Message when it says more than the default one
+}
+
+func (e *UnknownFieldError) Error() string {
+	if e.reason != "" {
+		return e.reason
+	}
+	if len(e.Fields) > 0 {
+		return "unknown fields: " + strings.Join(e.Fields, ", ")
+	}
+	return "unknown field " + e.Field
+}
+
+// Is reports whether target is ErrUnknownField
+func (e *UnknownFieldError) Is(target error) bool {
+	return target == ErrUnknownField
+}
+
+// InvalidValueError reports a filter value that cannot be parsed as its DataType, or that RestrictValues
+// or a TransformValue function rejects
+type InvalidValueError struct {
+	Field    string
+	Value    any // The invalid value: the filter value, or the item of a list or bound of a range
+	DataType DataType
+	Err      error // Why the value is invalid
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid value %v for field %s: %v", e.Value, e.Field, e.Err)
+}
+
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidValue
+func (e *InvalidValueError) Is(target error) bool {
+	return target == ErrInvalidValue
+}
+
+// UnsupportedModeError reports a filter whose Mode its DataType does not support (e.g. ModeRange on a
+// bool), or whose DataType is unknown or cannot be used with a CompareField
+type UnsupportedModeError struct {
+	Field    string
+	Mode     Mode
+	DataType DataType
+
+	reason string // Message when it says more than the default one
+}
+
+func (e *UnsupportedModeError) Error() string {
+	if e.reason != "" {
+		return e.reason
+	}
+	return fmt.Sprintf("filter mode %s not supported for %s field %s", e.Mode, e.DataType, e.Field)
+}
+
+// Is reports whether target is ErrUnsupportedMode
+func (e *UnsupportedModeError) Is(target error) bool {
+	return target == ErrUnsupportedMode
+}
+
+// InvalidRangeError reports a ModeRange value that is not a range with both bounds, or whose From is
+// after its To
+type InvalidRangeError struct {
+	Field string // "" when the range was checked outside a filter
+	Err   error  // What is wrong with the range
+
+	reason string // Message when it says more than the default one
+}
+
+func (e *InvalidRangeError) Error() string {
+	if e.reason != "" {
+		return e.reason
+	}
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("invalid range for field %s: %v", e.Field, e.Err)
+}
+
+func (e *InvalidRangeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidRange
+func (e *InvalidRangeError) Is(target error) bool {
+	return target == ErrInvalidRange
+}
+
+// FieldNotAllowedError reports fields rejected by RejectDisallowedFields, or nested deeper than Root.MaxDepth
+type FieldNotAllowedError struct {
+	Field  string   // The disallowed field, the first one when Fields lists several
+	Fields []string // Every disallowed field of a Root rejected by a query (nil otherwise)
+
+	reason string // Message when it says more than the default one
+}
+
+func (e *FieldNotAllowedError) Error() string {
+	if e.reason != "" {
+		return e.reason
+	}
+	if len(e.Fields) > 0 {
+		return "fields not allowed: " + strings.Join(e.Fields, ", ")
+	}
+	return "field is not allowed"
+}
+
+// Is reports whether target is ErrFieldNotAllowed
+func (e *FieldNotAllowedError) Is(target error) bool {
+	return target == ErrFieldNotAllowed
+}
+
+// InvalidFilterError reports a filter payload that is not valid JSON or base64, or a Root with an unknown
+// Logic, search mode, sort order, nulls order, language, aggregate function or time zone, or an empty field
+type InvalidFilterError struct {
+	Field string // The sort or aggregation field with the unknown setting ("" for the Root itself)
+	Err   error  // What is invalid
+}
+
+func (e *InvalidFilterError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v on field '%s'", e.Err, e.Field)
+}
+
+func (e *InvalidFilterError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidFilter
+func (e *InvalidFilterError) Is(target error) bool {
+	return target == ErrInvalidFilter
+}
+
+// InvalidCursorError reports a cursor that was not returned by the cursor methods for the same sort fields
+type InvalidCursorError struct {
+	Err error // Why the cursor cannot be decoded
+}
+
+func (e *InvalidCursorError) Error() string {
+	return "invalid cursor: " + e.Err.Error()
+}
+
+func (e *InvalidCursorError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidCursor
+func (e *InvalidCursorError) Is(target error) bool {
+	return target == ErrInvalidCursor
+}
+
+// rangeError returns an *InvalidRangeError outside a filter, see filterError
+func rangeError(format string, args ...any) error {
+	return &InvalidRangeError{Err: fmt.Errorf(format, args...)}
+}
+
+// filterError returns err, from compiling or building the condition of filter, as the error type of
+// its category: errors already of one are kept, with the field filled in for an *InvalidRangeError,
+// and any other error is the cause of an *InvalidValueError
+func filterError(filter FieldFilter, err error) error {
+	if err == nil {
+		return nil
+	}
+	var unknown *UnknownFieldError
+	var invalidValue *InvalidValueError
+	var unsupported *UnsupportedModeError
+	if errors.As(err, &unknown) || errors.As(err, &invalidValue) || errors.As(err, &unsupported) {
+		return err
+	}
+	var invalidRange *InvalidRangeError
+	if errors.As(err, &invalidRange) {
+		if invalidRange.Field != "" {
+			return err
+		}
+		return &InvalidRangeError{Field: filter.Field, Err: invalidRange.Err, reason: invalidRange.reason}
+	}
+	return &InvalidValueError{Field: filter.Field, Value: filter.Value, DataType: filter.DataType, Err: err}
+}
@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// isExistsMode reports whether mode checks for related records rather than comparing a value
+func isExistsMode(mode Mode) bool {
+	return mode == ModeExists || mode == ModeNotExists
+}
+
+// existsRelation returns the Go field name of relation when it is a slice of structs or a pointer to a struct of T
+func (f *Handler[T]) existsRelation(relation string) (string, bool) {
+	path, exists := f.fieldPaths[relation]
+	if !exists {
+		path, exists = f.fieldPaths[strings.ToLower(relation)]
+	}
+	if !exists || strings.Contains(path, ".") {
+		return "", false
+	}
+	field, _ := reflect.TypeOf(new(T)).Elem().FieldByName(path)
+	if _, isSlice := sliceElemStruct(field.Type); isSlice {
+		return path, true
+	}
+	isStruct := field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct &&
+		field.Type.Elem() != reflect.TypeOf(time.Time{})
+	return path, isStruct
+}
+
+// unloadedRelated is the value existsGetter reads from a belongs-to relation that was not loaded
+// but whose foreign key is set, so it has a related record
+type unloadedRelated struct{}
+
+// existsGetter returns the getter of a ModeExists or ModeNotExists filter on relation: its value, except
+// that a nil belongs-to pointer reads as unloadedRelated when T's foreign key is set, as it is when the
+// relation was not preloaded. It returns getter unchanged when T is not a GORM model.
+func (f *Handler[T]) existsGetter(relation string, getter func(*T) any) func(*T) any {
+	name, ok := f.existsRelation(relation)
+	if !ok {
+		return getter
+	}
+	modelSchema, err := f.modelSchema(sqlDialect{})
+	if err != nil {
+		return getter
+	}
+	rel := modelSchema.Relationships.Relations[name]
+	if rel == nil || rel.Type != schema.BelongsTo {
+		return getter
+	}
+	var foreignKeys []*schema.Field
+	for _, ref := range rel.References {
+		if !ref.OwnPrimaryKey {
+			foreignKeys = append(foreignKeys, ref.ForeignKey)
+		}
+	}
+	return func(item *T) any {
+		value := getter(item)
+		if !isNilValue(value) || len(foreignKeys) == 0 {
+			return value
+		}
+		itemValue := reflect.ValueOf(item).Elem()
+		for _, foreignKey := range foreignKeys {
+			if _, zero := foreignKey.ValueOf(context.Background(), itemValue); zero {
+				return value
+			}
+		}
+		return unloadedRelated{}
+	}
+}
+
+// compileExists compiles a ModeExists or ModeNotExists filter: a slice relation exists when it holds a
+// non-nil element, a pointer relation when it is not nil or its foreign key is set (see existsGetter).
+// A nil slice is a relation that was not loaded, which would silently have no records, so it is an error:
+// GORM preloads empty relations as empty slices.
+func (f *Handler[T]) compileExists(filter FieldFilter) (predicate, error) {
+	if _, ok := f.existsRelation(filter.Field); !ok {
+		return nil, &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("mode %s needs a relation, but %s is not a slice of structs or a pointer to a struct", filter.Mode, filter.Field)}
+	}
+	exists := filter.Mode == ModeExists
+	return func(value any) (bool, error) {
+		if _, ok := value.(unloadedRelated); ok {
+			return exists, nil
+		}
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return false, fmt.Errorf("relation %s is not loaded: preload it (Root.Preload) to filter with mode %s", filter.Field, filter.Mode)
+		}
+		if isMissing(value) || isNilValue(value) {
+			return !exists, nil
+		}
+		rv = reflect.ValueOf(derefValue(value))
+		if rv.Kind() != reflect.Slice {
+			return exists, nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if elem := rv.Index(i); elem.Kind() != reflect.Pointer || !elem.IsNil() {
+				return exists, nil
+			}
+		}
+		return !exists, nil
+	}, nil
+}
+
+// buildExistsCondition returns the WHERE condition of a ModeExists or ModeNotExists filter. A belongs-to
+// relation checks its foreign key on T's table ("orders"."customer_id" IS NOT NULL); other relations check
+// for a related row with a correlated subquery (EXISTS (SELECT 1 FROM "items" "Items" WHERE ...)).
+func (f *Handler[T]) buildExistsCondition(d sqlDialect, filter FieldFilter, mainTableName string) (string, []any, error) {
+	name, ok := f.existsRelation(filter.Field)
+	if !ok {
+		return "", nil, &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("mode %s needs a relation, but %s is not a slice of structs or a pointer to a struct", filter.Mode, filter.Field)}
+	}
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return "", nil, err
+	}
+	rel := modelSchema.Relationships.Relations[name]
+	if rel == nil {
+		return "", nil, fmt.Errorf("field %s is not a relation of the GORM model", filter.Field)
+	}
+
+	if rel.Type == schema.BelongsTo {
+		operator, joiner := "IS NOT NULL", " AND "
+		if filter.Mode == ModeNotExists {
+			operator, joiner = "IS NULL", " OR "
+		}
+		var conditions []string
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey {
+				continue
+			}
+			column := quoteIdentifier(d, ref.ForeignKey.DBName)
+			if mainTableName != "" {
+				column = quoteIdentifier(d, mainTableName) + "." + column
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s", column, operator))
+		}
+		return strings.Join(conditions, joiner), nil, nil
+	}
+
+	subquery, args := relatedRowsSubquery(d, rel, name, "1")
+	if filter.Mode == ModeNotExists {
+		return fmt.Sprintf("NOT EXISTS (%s)", subquery), args, nil
+	}
+	return fmt.Sprintf("EXISTS (%s)", subquery), args, nil
+}
@@ -0,0 +1,150 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ExplainGorm returns the SQL and arguments of the page query DataGorm would run for filterRoot,
+// without executing it: the same joins, WHERE conditions, ORDER BY and LIMIT/OFFSET, built on a
+// DryRun session. Preloads and the separate COUNT and aggregate queries are not included.
+// Pass the result to db.Dialector.Explain for a single string with the arguments inlined.
+//
+// Example usage:
+//
+//	sql, args, err := handler.ExplainGorm(db, filterRoot, 0, 20)
+//	log.Println(db.Dialector.Explain(sql, args...))
+func (f *Handler[T]) ExplainGorm(
+	db *gorm.DB,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (string, []any, error) {
+	// A DryRun session builds statements without executing them
+	db = db.Session(&gorm.Session{DryRun: true})
+
+	filterRoot, err := f.restrictRoot(filterRoot)
+	if err != nil {
+		return "", nil, err
+	}
+	pageIndex, pageSize = normalizePage(pageIndex, pageSize)
+	pageSize, _ = f.clampPageSize(pageSize)
+
+	query, toMany, err := f.filteredQuery(db, filterRoot)
+	if err != nil {
+		return "", nil, err
+	}
+	var data []*T
+	stmt := f.pageQuery(db, query, filterRoot, toMany, pageIndex, pageSize).Find(&data)
+	if stmt.Error != nil {
+		return "", nil, fmt.Errorf("failed to build query: %w", stmt.Error)
+	}
+	return stmt.Statement.SQL.String(), stmt.Statement.Vars, nil
+}
+
+// ExplainQuery describes how DataQuery evaluates filterRoot, one line per filter, group and sort:
+// the filters after search expansion, transforms, relative dates and field restrictions, with those
+// on fields it cannot read marked as ignored. It returns the error DataQuery would for invalid filters.
+//
+// Example output:
+//
+//	and
+//	  age gte 18 (number)
+//	  or
+//	    role equal "admin" (text)
+//	    name contains "jo" (text)
+//	sort: age desc, id asc
+func (f *Handler[T]) ExplainQuery(filterRoot Root) (string, error) {
+	filterRoot, err := f.restrictRoot(filterRoot)
+	if err != nil {
+		return "", err
+	}
+	// Compile the filters as DataQuery does, so invalid values are reported the same way
+	if _, err := f.buildFilterGroup(filterRoot); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if len(flattenFieldFilters(filterRoot)) == 0 {
+		b.WriteString("all records\n")
+	} else {
+		f.explainGroup(&b, filterRoot, 0)
+	}
+	if f.isDeleted != nil && !filterRoot.IncludeDeleted {
+		b.WriteString("skip soft-deleted\n")
+	}
+	sortFields := f.withTiebreaker(filterRoot.SortFields)
+	if len(sortFields) > 0 {
+		parts := make([]string, len(sortFields))
+		for i, sortField := range sortFields {
+			parts[i] = sortField.Field + " " + string(sortField.Order)
+			if sortField.Field == RelevanceField {
+				parts[i] = RelevanceField + " " + string(SortOrderDesc)
+				continue
+			}
+			if sortField.Nulls != NullsDefault {
+				parts[i] += " nulls " + string(sortField.Nulls)
+			}
+		}
+		fmt.Fprintf(&b, "sort: %s\n", strings.Join(parts, ", "))
+	}
+	return b.String(), nil
+}
+
+// explainGroup writes the logic of group and its filters and non-empty child groups, indented by depth
+func (f *Handler[T]) explainGroup(b *strings.Builder, group Root, depth int) {
+	indent := strings.Repeat("  ", depth)
+	logic := group.Logic
+	if logic == "" {
+		logic = LogicOr
+	}
+	fmt.Fprintf(b, "%s%s\n", indent, logic)
+	for _, filter := range group.FieldFilters {
+		fmt.Fprintf(b, "%s  %s\n", indent, f.explainFilter(filter))
+	}
+	for _, child := range group.Groups {
+		if len(flattenFieldFilters(child)) > 0 {
+			f.explainGroup(b, child, depth+1)
+		}
+	}
+}
+
+// explainFilter describes a single filter, such as `name contains "jo" (text)`
+func (f *Handler[T]) explainFilter(filter FieldFilter) string {
+	var description string
+	switch {
+	case filter.CompareField != "":
+		description = fmt.Sprintf("%s %s field %s (%s)", filter.Field, filter.Mode, filter.CompareField, filter.DataType)
+	case isExistsMode(filter.Mode):
+		description = fmt.Sprintf("%s %s", filter.Field, filter.Mode)
+	case filter.Mode == ModeIsEmpty || filter.Mode == ModeIsNotEmpty:
+		description = fmt.Sprintf("%s %s (%s)", filter.Field, filter.Mode, filter.DataType)
+	default:
+		description = fmt.Sprintf("%s %s %s (%s)", filter.Field, filter.Mode, explainValue(filter.Value), filter.DataType)
+	}
+	if _, exists := f.getter(filter.Field); !exists {
+		// buildFilterGroup skips filters without a getter
+		description += " ignored: unknown field"
+	}
+	return description
+}
+
+// explainValue formats a filter value: strings quoted, ranges in interval notation
+func explainValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case Range:
+		open, closing := "[", "]"
+		if v.FromExclusive {
+			open = "("
+		}
+		if v.ToExclusive {
+			closing = ")"
+		}
+		return fmt.Sprintf("%s%s, %s%s", open, explainValue(v.From), explainValue(v.To), closing)
+	}
+	return fmt.Sprintf("%v", value)
+}
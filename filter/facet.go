@@ -0,0 +1,216 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FacetGorm counts the records matching filterRoot per value of facetField, such as
+// {"active": 12, "pending": 3} for "status". Filters on facetField itself are left out, so a
+// sidebar can list every option next to the one currently selected. Values are keyed as text:
+// numbers without trailing zeros, booleans as "true" or "false", and records without a value as "".
+//
+// Example usage:
+//
+//	counts, err := handler.FacetGorm(db.Where("organization_id = ?", orgID), filterRoot, "status")
+func (f *Handler[T]) FacetGorm(
+	db *gorm.DB,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	return f.FacetGormCtx(context.Background(), db, filterRoot, facetField)
+}
+
+// FacetGormCtx is FacetGorm with cancellation support.
+// The context is passed to GORM via db.WithContext so running SQL is cancelled with ctx.
+func (f *Handler[T]) FacetGormCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	return observe(f, ctx, "FacetGorm", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (map[string]int64, error) {
+		counts, err := f.facetGorm(ctx, db, filterRoot, facetField)
+		report.TotalSize = facetTotal(counts)
+		return counts, err
+	})
+}
+
+// facetGorm implements FacetGormCtx
+func (f *Handler[T]) facetGorm(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	d := dialectOf(db)
+	// WithContext starts a new session, so the caller's handle is never mutated
+	db = db.WithContext(ctx)
+
+	if err := f.checkFacetField(facetField); err != nil {
+		return nil, err
+	}
+	filterRoot, err := f.restrictRoot(f.facetRoot(filterRoot, facetField))
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the query with the same joins and conditions as CountGorm, plus the facet field's relation
+	query := f.modelQuery(db, filterRoot)
+	fieldFilters := flattenFieldFilters(filterRoot)
+	query = f.autoJoinRelatedTables(query, fieldFilters, nil, facetField)
+	query, err = f.applysGorm(query, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filters on to-many relations are EXISTS subqueries, so each record is counted once per value
+	column := f.columnExpr(d, facetField, f.mainTableName(d))
+	var rows []map[string]any
+	err = query.Select(fmt.Sprintf("%s AS facet_value, COUNT(*) AS facet_count", column)).
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count facet values: %w", err)
+	}
+
+	dataType, _ := f.fieldDataType(facetField)
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		value, err := parseNumber(scannedValue(row["facet_count"]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to count facet values: %w", err)
+		}
+		// Values that only differ in their SQL representation share a key
+		counts[facetKey(scannedValue(row["facet_value"]), dataType)] += int64(value)
+	}
+	return counts, nil
+}
+
+// FacetQuery counts the items in data matching filterRoot per value of facetField, leaving out
+// filters on facetField like FacetGorm and keying values the same way.
+func (f *Handler[T]) FacetQuery(
+	data []*T,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	return f.FacetQueryCtx(context.Background(), data, filterRoot, facetField)
+}
+
+// FacetQueryCtx is FacetQuery with cancellation support.
+func (f *Handler[T]) FacetQueryCtx(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	return observe(f, ctx, "FacetQuery", filterRoot, StrategyMemory, func(report *QueryResultInfo) (map[string]int64, error) {
+		counts, err := f.facetQuery(ctx, data, filterRoot, facetField)
+		if err == nil {
+			report.TotalSize, report.Scanned = facetTotal(counts), len(data)
+		}
+		return counts, err
+	})
+}
+
+// facetQuery implements FacetQueryCtx
+func (f *Handler[T]) facetQuery(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	facetField string,
+) (map[string]int64, error) {
+	if err := f.checkFacetField(facetField); err != nil {
+		return nil, err
+	}
+	getter, exists := f.getter(facetField)
+	if !exists {
+		return nil, &UnknownFieldError{Field: facetField, reason: fmt.Sprintf("unknown facet field %s", facetField)}
+	}
+
+	filteredData, err := f.filterUnsorted(ctx, data, f.facetRoot(filterRoot, facetField))
+	if err != nil {
+		return nil, err
+	}
+	dataType, _ := f.fieldDataType(facetField)
+	counts := make(map[string]int64)
+	for _, item := range filteredData {
+		counts[facetKey(getter(item), dataType)]++
+	}
+	return counts, nil
+}
+
+// facetTotal returns the sum of the counts, reported as the TotalSize of the facet methods
+func facetTotal(counts map[string]int64) int {
+	total := 0
+	for _, count := range counts {
+		total += int(count)
+	}
+	return total
+}
+
+// checkFacetField returns an error when facetField is not allowed, is under a slice of T,
+// or is a simple field that does not exist on T
+func (f *Handler[T]) checkFacetField(facetField string) error {
+	if !f.isFieldAllowed(facetField) {
+		return fmt.Errorf("facet field not allowed: %s", facetField)
+	}
+	if f.isSliceField(facetField) {
+		return fmt.Errorf("cannot facet to-many field %s", facetField)
+	}
+	if !strings.Contains(facetField, ".") && !f.fieldExists(facetField) {
+		return &UnknownFieldError{Field: facetField, reason: fmt.Sprintf("unknown facet field %s", facetField)}
+	}
+	return nil
+}
+
+// facetRoot returns a copy of filterRoot without the filters on facetField, sort fields or aggregations
+func (f *Handler[T]) facetRoot(filterRoot Root, facetField string) Root {
+	id := f.fieldID(facetField)
+	root := pruneGroup(filterRoot, func(field string) bool {
+		return f.fieldID(field) != id
+	})
+	root.SortFields = nil
+	root.Aggregations = nil
+	root.Limit = 0
+	return root
+}
+
+// scannedValue unwraps a value GORM scanned into a map, which some drivers return as *any or []byte
+func scannedValue(value any) any {
+	if scanned, ok := value.(*any); ok {
+		value = *scanned
+	}
+	if raw, ok := value.([]byte); ok {
+		value = string(raw)
+	}
+	return value
+}
+
+// facetKey formats a facet value as text, so a value read from a struct field and the same value
+// scanned from SQL (e.g. int vs int64, or a SQLite boolean stored as 1) share a key
+func facetKey(value any, dataType DataType) string {
+	value = sortKey(value)
+	if isMissing(value) {
+		return ""
+	}
+	switch dataType {
+	case DataTypeBool:
+		if b, err := parseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case DataTypeNumber, DataTypeDuration:
+		if num, err := parseNumber(value); err == nil {
+			return strconv.FormatFloat(num, 'f', -1, 64)
+		}
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,526 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fieldID resolves a filter or sort field name to a stable identifier so aliases
+// ("tax_id", "taxid", "TaxID") of the same struct field compare equal
+func (f *Handler[T]) fieldID(field string) string {
+	if column, keys, ok := splitJSONPath(field); ok {
+		return f.fieldID(column) + jsonPathSeparator + strings.Join(keys, jsonPathSeparator)
+	}
+	if path, exists := f.fieldPaths[field]; exists {
+		return path
+	}
+	if path, exists := f.fieldPaths[strings.ToLower(field)]; exists {
+		return path
+	}
+	// Nested fields beyond MaxDepth are only known to GORM; compare them by name
+	return strings.ToLower(field)
+}
+
+// fieldSet resolves configured field names to identifiers, panicking on unknown simple fields
+// so typos in an allow or deny list are caught at NewFilter time
+func (f *Handler[T]) fieldSet(fields []string, option string) map[string]bool {
+	if fields == nil {
+		return nil
+	}
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !strings.Contains(field, ".") && !f.fieldExists(field) {
+			panic(fmt.Sprintf("filter: %s contains unknown field %q", option, field))
+		}
+		set[f.fieldID(field)] = true
+	}
+	return set
+}
+
+// isFieldAllowed reports whether field may be used for filtering and sorting.
+// JSON paths are allowed as their column is.
+func (f *Handler[T]) isFieldAllowed(field string) bool {
+	if column, _, ok := splitJSONPath(field); ok {
+		field = column
+	}
+	if column, _, ok := f.mapKey(field); ok {
+		field = column
+	}
+	id := f.fieldID(field)
+	if f.allowedFields != nil && !f.allowedFields[id] {
+		return false
+	}
+	return !f.deniedFields[id]
+}
+
+// restrictRoot moves filters sharing a FieldFilter.Group into nested groups, expands filterRoot.Search
+// into a filter group, applies TransformValue functions and resolves date values in the query's zone,
+// then removes filters and sort fields on disallowed fields from filterRoot, including nested groups,
+// and sort fields on unknown fields or repeating an earlier sort field.
+// With RejectUnknownFields it first returns an error listing the filter, search and sort fields
+// that do not exist on T, then one listing the preload relations that are not relations of T, and
+// with RejectDisallowedFields an error listing the disallowed fields.
+// Filters with a value outside the set given to RestrictValues are always an error, and so are fields
+// nested deeper than filterRoot.MaxDepth when it is set.
+// With StrictValidation, every problem ValidateRoot finds is returned first, joined into one error.
+// The caller's slices are never modified.
+func (f *Handler[T]) restrictRoot(filterRoot Root) (Root, error) {
+	return f.restrict(filterRoot, false)
+}
+
+// restrictMemoryRoot is restrictRoot for the memory strategy, where only fields with a getter are
+// known (see knownField)
+func (f *Handler[T]) restrictMemoryRoot(filterRoot Root) (Root, error) {
+	return f.restrict(filterRoot, true)
+}
+
+// restrict implements restrictRoot and restrictMemoryRoot
+func (f *Handler[T]) restrict(filterRoot Root, memory bool) (Root, error) {
+	key, cacheable := f.rootKey(filterRoot)
+	prefix := "root:"
+	if memory {
+		prefix = "memory root:"
+	}
+	if cached, ok := f.compiled.get(prefix + key); ok && cacheable {
+		return cached.(Root), nil
+	}
+	if f.isStrict(filterRoot) {
+		if err := f.validateRoot(filterRoot, false, memory); err != nil {
+			return Root{}, err
+		}
+	}
+	if f.rejectUnknown {
+		if unknown := f.unknownFields(filterRoot, memory); len(unknown) > 0 {
+			return Root{}, &UnknownFieldError{Field: unknown[0], Fields: unknown}
+		}
+		if _, unknown, err := f.preloads(sqlDialect{}, filterRoot); err == nil && len(unknown) > 0 {
+			return Root{}, unknownPreloadError(unknown)
+		}
+	}
+	if filterRoot.MaxDepth > 0 {
+		if deep := tooDeepFields(filterRoot); len(deep) > 0 {
+			return Root{}, &FieldNotAllowedError{Field: deep[0], Fields: deep,
+				reason: fmt.Sprintf("fields nested deeper than MaxDepth %d: %s", filterRoot.MaxDepth, strings.Join(deep, ", "))}
+		}
+	}
+	filterRoot = groupFilters(filterRoot)
+	filterRoot = f.expandSearch(filterRoot)
+	filterRoot, err := f.transformValues(filterRoot)
+	if err != nil {
+		return Root{}, err
+	}
+	filterRoot, err = f.resolveDates(filterRoot)
+	if err != nil {
+		return Root{}, err
+	}
+	filterRoot, err = f.restrictFields(filterRoot)
+	if err != nil {
+		return Root{}, err
+	}
+	filterRoot.SortFields = f.uniqueSortFields(filterRoot.SortFields, memory)
+	if err := f.checkValues(filterRoot); err != nil {
+		return Root{}, err
+	}
+	if err := f.checkAggregations(filterRoot); err != nil {
+		return Root{}, err
+	}
+	if err := f.checkChildCounts(filterRoot); err != nil {
+		return Root{}, err
+	}
+	filterRoot.compileKey = ""
+	if cacheable && !f.hasRelativeDates(filterRoot) {
+		// Transformed values can be relative dates too, checked once resolved
+		filterRoot.compileKey = key
+		f.compiled.put(prefix+key, filterRoot)
+	}
+	return filterRoot, nil
+}
+
+// unknownFields lists the filter, search and sort fields of filterRoot, including nested groups, that
+// are not fields of T (see knownField).
+func (f *Handler[T]) unknownFields(filterRoot Root, memory bool) []string {
+	var unknown []string
+	seen := make(map[string]bool)
+	check := func(field string) bool {
+		if !f.knownField(field, memory) && !seen[field] {
+			seen[field] = true
+			unknown = append(unknown, field)
+		}
+		return true
+	}
+
+	pruneGroup(filterRoot, check)
+	if filterRoot.Search != nil {
+		for _, field := range filterRoot.Search.Fields {
+			check(field)
+		}
+	}
+	for _, sortField := range filterRoot.SortFields {
+		if sortField.Field != RelevanceField {
+			check(sortField.Field)
+		}
+	}
+	return unknown
+}
+
+// knownField reports whether field is a field of T: one with a getter (nested fields down to MaxDepth,
+// map keys and JSON paths included), or, unless memory is set, a nested field that resolves to a column
+// through T's GORM relations without a getter (deeper than MaxDepth, or named after the column rather
+// than the json tag), which only the database strategy reads
+func (f *Handler[T]) knownField(field string, memory bool) bool {
+	if f.fieldExists(field) {
+		return true
+	}
+	if memory || !strings.Contains(field, ".") {
+		return false
+	}
+	_, exists := f.schemaColumn(sqlDialect{}, field)
+	return exists
+}
+
+// tooDeepFields lists the filter, search, sort, select and aggregation fields of filterRoot, including
+// nested groups and ChildCounts, with more dotted segments than filterRoot.MaxDepth ("a.b.c" has 3).
+// JSON paths count the segments of their column.
+func tooDeepFields(filterRoot Root) []string {
+	var deep []string
+	seen := make(map[string]bool)
+	check := func(field string) bool {
+		column := field
+		if jsonColumn, _, ok := splitJSONPath(field); ok {
+			column = jsonColumn
+		}
+		if strings.Count(column, ".")+1 > filterRoot.MaxDepth && !seen[field] {
+			seen[field] = true
+			deep = append(deep, field)
+		}
+		return true
+	}
+
+	pruneGroup(filterRoot, check)
+	if filterRoot.Search != nil {
+		for _, field := range filterRoot.Search.Fields {
+			check(field)
+		}
+	}
+	for _, sortField := range filterRoot.SortFields {
+		if sortField.Field != RelevanceField {
+			check(sortField.Field)
+		}
+	}
+	for _, field := range filterRoot.SelectFields {
+		check(field)
+	}
+	for _, aggregation := range filterRoot.Aggregations {
+		check(aggregation.Field)
+	}
+	for _, count := range filterRoot.ChildCounts {
+		check(count.Relation)
+		pruneGroup(prefixFields(count.Where, count.Relation), check)
+	}
+	return deep
+}
+
+// restrictFields applies AllowedFields and DeniedFields to the filters, sort fields and aggregations of filterRoot
+func (f *Handler[T]) restrictFields(filterRoot Root) (Root, error) {
+	if f.allowedFields == nil && f.deniedFields == nil {
+		return filterRoot, nil
+	}
+
+	var disallowed []string
+	seen := make(map[string]bool)
+	reject := func(field string) {
+		if !seen[field] {
+			seen[field] = true
+			disallowed = append(disallowed, field)
+		}
+	}
+
+	restricted := f.restrictGroup(filterRoot, reject)
+	restricted.SortFields = make([]SortField, 0, len(filterRoot.SortFields))
+	for _, sortField := range filterRoot.SortFields {
+		if sortField.Field != RelevanceField && !f.isFieldAllowed(sortField.Field) {
+			reject(sortField.Field)
+			continue
+		}
+		restricted.SortFields = append(restricted.SortFields, sortField)
+	}
+	if filterRoot.Aggregations != nil {
+		restricted.Aggregations = make([]Aggregation, 0, len(filterRoot.Aggregations))
+		for _, aggregation := range filterRoot.Aggregations {
+			if !f.isFieldAllowed(aggregation.Field) {
+				reject(aggregation.Field)
+				continue
+			}
+			restricted.Aggregations = append(restricted.Aggregations, aggregation)
+		}
+	}
+
+	if len(disallowed) > 0 && f.rejectDisallowed {
+		return Root{}, &FieldNotAllowedError{Field: disallowed[0], Fields: disallowed}
+	}
+	return restricted, nil
+}
+
+// restrictGroup returns a copy of root without filters on disallowed fields, recursing into groups
+func (f *Handler[T]) restrictGroup(root Root, reject func(field string)) Root {
+	return pruneGroup(root, func(field string) bool {
+		if f.isFieldAllowed(field) {
+			return true
+		}
+		reject(field)
+		return false
+	})
+}
+
+// pruneGroup returns a copy of root with only the filters whose field, and compare field if any, pass keep,
+// recursing into groups
+func pruneGroup(root Root, keep func(field string) bool) Root {
+	pruned := root
+	pruned.FieldFilters = make([]FieldFilter, 0, len(root.FieldFilters))
+	for _, filter := range root.FieldFilters {
+		if keep(filter.Field) && (filter.CompareField == "" || keep(filter.CompareField)) {
+			pruned.FieldFilters = append(pruned.FieldFilters, filter)
+		}
+	}
+	if len(root.Groups) > 0 {
+		pruned.Groups = make([]Root, len(root.Groups))
+		for i, group := range root.Groups {
+			pruned.Groups[i] = pruneGroup(group, keep)
+		}
+	}
+	return pruned
+}
+
+// groupFilters returns a copy of root where the filters sharing a non-zero FieldFilter.Group are moved
+// into a nested LogicAnd group, in order of first appearance, recursing into groups
+func groupFilters(root Root) Root {
+	grouped := root
+	grouped.FieldFilters = make([]FieldFilter, 0, len(root.FieldFilters))
+	var groups []Root
+	index := make(map[int]int)
+	for _, filter := range root.FieldFilters {
+		if filter.Group == 0 {
+			grouped.FieldFilters = append(grouped.FieldFilters, filter)
+			continue
+		}
+		i, exists := index[filter.Group]
+		if !exists {
+			i = len(groups)
+			index[filter.Group] = i
+			groups = append(groups, Root{Logic: LogicAnd})
+		}
+		groups[i].FieldFilters = append(groups[i].FieldFilters, filter)
+	}
+	for _, group := range root.Groups {
+		groups = append(groups, groupFilters(group))
+	}
+	grouped.Groups = groups
+	return grouped
+}
+
+// appliedRoot returns a copy of filterRoot as the caller wrote it, without the filters, search fields
+// and sort fields that are dropped because the field is unknown or not allowed, along with those field names.
+// Sort fields repeating an earlier one are dropped too; ignoredSorts lists every sort field dropped.
+// With memory, fields without a getter are unknown (see knownField).
+func (f *Handler[T]) appliedRoot(filterRoot Root, memory bool) (*Root, []string, []string) {
+	var ignored, ignoredSorts []string
+	seen := make(map[string]bool)
+	keep := func(field string) bool {
+		if f.knownField(field, memory) && f.isFieldAllowed(field) {
+			return true
+		}
+		if !seen[field] {
+			seen[field] = true
+			ignored = append(ignored, field)
+		}
+		return false
+	}
+
+	applied := pruneGroup(filterRoot, keep)
+	if filterRoot.Search != nil && len(filterRoot.Search.Fields) > 0 {
+		search := *filterRoot.Search
+		search.Fields = make([]string, 0, len(filterRoot.Search.Fields))
+		for _, field := range filterRoot.Search.Fields {
+			if keep(field) {
+				search.Fields = append(search.Fields, field)
+			}
+		}
+		applied.Search = &search
+	}
+	applied.SortFields = make([]SortField, 0, len(filterRoot.SortFields))
+	sorted := make(map[string]bool)
+	for _, sortField := range filterRoot.SortFields {
+		if (sortField.Field != RelevanceField && !keep(sortField.Field)) || sorted[f.fieldID(sortField.Field)] {
+			ignoredSorts = append(ignoredSorts, sortField.Field)
+			continue
+		}
+		sorted[f.fieldID(sortField.Field)] = true
+		applied.SortFields = append(applied.SortFields, sortField)
+	}
+	return &applied, ignored, ignoredSorts
+}
+
+// uniqueSortFields returns sortFields without those on unknown fields (RelevanceField is known) and those repeating
+// an earlier field (aliases included), so both engines sort by exactly the same fields
+func (f *Handler[T]) uniqueSortFields(sortFields []SortField, memory bool) []SortField {
+	unique := make([]SortField, 0, len(sortFields))
+	sorted := make(map[string]bool, len(sortFields))
+	for _, sortField := range sortFields {
+		id := f.fieldID(sortField.Field)
+		known := sortField.Field == RelevanceField || f.knownField(sortField.Field, memory)
+		if sorted[id] || !known {
+			continue
+		}
+		sorted[id] = true
+		unique = append(unique, sortField)
+	}
+	return unique
+}
+
+// expandSearch replaces filterRoot.Search with an OR group of text filters over the search fields
+// (the allowed text fields by default), ANDed with the filters and groups of filterRoot (which keep their own Logic), and binds RelevanceField
+// sorts to the search
+func (f *Handler[T]) expandSearch(filterRoot Root) Root {
+	search := filterRoot.Search
+	if search == nil || search.Value == "" {
+		filterRoot.Search = nil
+		filterRoot.SortFields = f.resolveRelevance(filterRoot.SortFields, nil, "")
+		return filterRoot
+	}
+
+	fields := search.Fields
+	if len(fields) == 0 {
+		// The default fields leave out those the caller may not filter on, so RejectDisallowedFields
+		// only rejects fields a search names
+		for _, field := range f.textFields {
+			if f.isFieldAllowed(field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	filterRoot.SortFields = f.resolveRelevance(filterRoot.SortFields, fields, search.Value)
+	mode := search.Mode
+	if mode == "" {
+		mode = ModeContains
+	}
+	searchGroup := Root{Logic: LogicOr, FieldFilters: make([]FieldFilter, len(fields))}
+	for i, field := range fields {
+		searchGroup.FieldFilters[i] = FieldFilter{Field: field, Value: search.Value, Mode: mode, DataType: DataTypeText}
+	}
+
+	expanded := filterRoot
+	expanded.Search = nil
+	expanded.Logic = LogicAnd
+	expanded.FieldFilters = nil
+	expanded.Groups = []Root{searchGroup}
+	if len(filterRoot.FieldFilters) > 0 || len(filterRoot.Groups) > 0 {
+		base := Root{FieldFilters: filterRoot.FieldFilters, Logic: filterRoot.Logic, Groups: filterRoot.Groups}
+		expanded.Groups = []Root{base, searchGroup}
+	}
+	return expanded
+}
+
+// Fields describes the fields of T that can be filtered and sorted on, in declaration order, so a
+// frontend can build its filter controls from the JSON encoding of the result. Nested fields are
+// listed down to MaxDepth, fields outside AllowedFields or in DeniedFields are left out, and the
+// values given to RestrictValues are included. Struct, slice and map fields are not listed.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &depth})
+//	json.NewEncoder(w).Encode(handler.Fields())
+func (f *Handler[T]) Fields() []FieldDescriptor {
+	fields := make([]FieldDescriptor, 0, len(f.descriptors))
+	for _, descriptor := range f.descriptors {
+		if !f.isFieldAllowed(descriptor.Path) {
+			continue
+		}
+		if allowed, restricted := f.allowedValues[f.fieldID(descriptor.Path)]; restricted {
+			descriptor.Values = make([]string, 0, len(allowed))
+			for value := range allowed {
+				descriptor.Values = append(descriptor.Values, value)
+			}
+			sort.Strings(descriptor.Values)
+		}
+		fields = append(fields, descriptor)
+	}
+	return fields
+}
+
+// RestrictValues limits the values ModeEqual, ModeNotEqual, ModeIn and ModeNotIn filters on field may use
+// to values (compared exactly, case included). A filter with any other value makes the query fail with an
+// error naming the field and the value, before anything is queried. It panics on an unknown simple field,
+// like AllowedFields, and must be called before the Handler is shared between goroutines.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[Order](filter.GolangFilteringConfig{}).
+//		RestrictValues("status", []string{"active", "pending", "closed"})
+func (f *Handler[T]) RestrictValues(field string, values []string) *Handler[T] {
+	if !strings.Contains(field, ".") && !f.fieldExists(field) {
+		panic(fmt.Sprintf("filter: RestrictValues on unknown field %q", field))
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	if f.allowedValues == nil {
+		f.allowedValues = make(map[string]map[string]bool)
+	}
+	f.allowedValues[f.fieldID(field)] = set
+	return f
+}
+
+// checkValues returns an error for the first filter in root, or its nested groups, whose value
+// is outside the set given to RestrictValues for its field
+func (f *Handler[T]) checkValues(root Root) error {
+	if len(f.allowedValues) == 0 {
+		return nil
+	}
+	for _, filter := range root.FieldFilters {
+		if err := f.checkValue(filter); err != nil {
+			return err
+		}
+	}
+	for _, group := range root.Groups {
+		if err := f.checkValues(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkValue returns an error when the value of filter is outside the set given to RestrictValues for its field
+func (f *Handler[T]) checkValue(filter FieldFilter) error {
+	allowed, restricted := f.allowedValues[f.fieldID(filter.Field)]
+	if !restricted {
+		return nil
+	}
+	var values []any
+	switch filter.Mode {
+	case ModeEqual, ModeNotEqual:
+		values = []any{filter.Value}
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
+		if err != nil {
+			return filterError(filter, err)
+		}
+		values = list
+	default:
+		return nil
+	}
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			str = fmt.Sprint(value)
+		}
+		if !allowed[str] {
+			return &InvalidValueError{Field: filter.Field, Value: value, DataType: filter.DataType, Err: fmt.Errorf("value %q is not allowed", str)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// GormForEach runs the query of DataGormNoPage in batches of batchSize rows (DefaultStreamBatchSize
+// when <= 0) and calls fn with each batch, so background jobs can process every match without loading
+// them all at once. Batches follow the sort fields of filterRoot (primary key order without any), as
+// described on findInBatches. It stops at the first error from fn and returns it unchanged.
+//
+// Example usage:
+//
+//	err := handler.GormForEach(db, filterRoot, 500, func(batch []*User) error {
+//		return mailer.SendAll(batch)
+//	})
+func (f *Handler[T]) GormForEach(
+	db *gorm.DB,
+	filterRoot Root,
+	batchSize int,
+	fn func(batch []*T) error,
+) error {
+	return f.GormForEachCtx(context.Background(), db, filterRoot, batchSize, fn)
+}
+
+// GormForEachCtx is GormForEach with cancellation support: every batch is fetched with ctx,
+// so the call returns ctx's error before the next batch once ctx is done
+func (f *Handler[T]) GormForEachCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	batchSize int,
+	fn func(batch []*T) error,
+) error {
+	_, err := observe(f, ctx, "GormForEach", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (struct{}, error) {
+		db := db.WithContext(ctx)
+		query, order, err := f.gormNoPageQuery(db, filterRoot)
+		if err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, f.findInBatches(db, query, order, batchSize, func(batch []*T) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			report.TotalSize += len(batch)
+			return fn(batch)
+		})
+	})
+	return err
+}
+
+// QueryForEach filters data like DataQueryNoPage and calls fn with each match in sort order,
+// stopping at the first error from fn and returning it unchanged
+func (f *Handler[T]) QueryForEach(
+	data []*T,
+	filterRoot Root,
+	fn func(item *T) error,
+) error {
+	return f.QueryForEachCtx(context.Background(), data, filterRoot, fn)
+}
+
+// QueryForEachCtx is QueryForEach with cancellation support: it returns ctx.Err() instead of
+// calling fn once ctx is done
+func (f *Handler[T]) QueryForEachCtx(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	fn func(item *T) error,
+) error {
+	_, err := observe(f, ctx, "QueryForEach", filterRoot, StrategyMemory, func(report *QueryResultInfo) (struct{}, error) {
+		filteredData, err := f.filterSorted(ctx, data, filterRoot)
+		if err != nil {
+			return struct{}{}, err
+		}
+		filteredData = f.afterFetch(filteredData)
+		report.Scanned = len(data)
+		for _, item := range filteredData {
+			if err := ctx.Err(); err != nil {
+				return struct{}{}, err
+			}
+			report.TotalSize++
+			if err := fn(item); err != nil {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
@@ -0,0 +1,104 @@
+package filter
+
+import (
+	"strings"
+)
+
+// WarmUp generates the getters of every nested field up front. NewFilter only generates those of the
+// top-level fields of T and leaves each relation's nested getters to the first query naming one of its
+// fields ("department.name"), which keeps start-up fast and memory low for wide models with a high
+// MaxDepth; WarmUp moves that cost to start-up for callers who prefer it. It returns the Handler for
+// chaining and is safe to call at any time.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &depth}).WarmUp()
+func (f *Handler[T]) WarmUp() *Handler[T] {
+	for key := range f.nestedFields {
+		f.buildNested(key)
+	}
+	return f
+}
+
+// fieldGetter returns the getter of field, a getter key of T or a field added with RegisterField,
+// matched as is or lowercased, or a key of a map field of T (see mapKeyGetter)
+func (f *Handler[T]) fieldGetter(field string) (func(*T) any, bool) {
+	if getter, exists := f.keyGetter(field); exists {
+		return getter, true
+	}
+	if getter, exists := f.keyGetter(strings.ToLower(field)); exists {
+		return getter, true
+	}
+	return f.mapKeyGetter(field)
+}
+
+// keyGetter returns the getter of the getter key key, generating the nested getters of its
+// top-level field on first use
+func (f *Handler[T]) keyGetter(key string) (func(*T) any, bool) {
+	if getter, exists := f.getters[key]; exists {
+		return getter, true
+	}
+	parent, _, nested := strings.Cut(key, ".")
+	if !nested {
+		return nil, false
+	}
+	return f.nestedGetter(parent, key)
+}
+
+// nestedGetter returns the getter of key, a field nested under the top-level field parent
+func (f *Handler[T]) nestedGetter(parent, key string) (func(*T) any, bool) {
+	if _, exists := f.nestedFields[parent]; !exists {
+		return nil, false
+	}
+	f.buildNested(parent)
+	f.nestedMu.RLock()
+	defer f.nestedMu.RUnlock()
+	getter, exists := f.nestedGetters[key]
+	return getter, exists
+}
+
+// buildNested generates the getters of the fields nested under the top-level field key, once
+func (f *Handler[T]) buildNested(key string) {
+	f.nestedMu.RLock()
+	built := f.nestedBuilt[key]
+	f.nestedMu.RUnlock()
+	if built {
+		return
+	}
+	f.nestedMu.Lock()
+	defer f.nestedMu.Unlock()
+	if f.nestedBuilt[key] {
+		return
+	}
+	generateNestedFieldGetters(f.nestedGetters, f.nestedFields[key], key, f.maxDepth)
+	f.nestedBuilt[key] = true
+}
+
+// columnGetters returns the getters of columns, getter keys of exported columns or keys of map fields
+func (f *Handler[T]) columnGetters(columns []string) []func(*T) any {
+	getters := make([]func(*T) any, len(columns))
+	for i, column := range columns {
+		var exists bool
+		if getters[i], exists = f.keyGetter(column); !exists {
+			getters[i], _ = f.mapKeyGetter(column)
+		}
+	}
+	return getters
+}
+
+// getterKeys returns every getter key, nested ones included, in no particular order
+func (f *Handler[T]) getterKeys() []string {
+	f.WarmUp()
+	f.nestedMu.RLock()
+	defer f.nestedMu.RUnlock()
+	keys := make([]string, 0, len(f.getters)+len(f.nestedGetters))
+	for key := range f.getters {
+		keys = append(keys, key)
+	}
+	for key := range f.nestedGetters {
+		if _, exists := f.getters[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -1,14 +1,16 @@
 package filter
 
 import (
-	"bytes"
-	"encoding/csv"
+	"context"
+	"database/sql/driver"
 	"fmt"
-	"sort"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ApplyPresetConditions applies struct fields as WHERE conditions to the db query.
@@ -28,7 +30,14 @@ func ApplyPresetConditions(db *gorm.DB, conditions any) *gorm.DB {
 	if conditions == nil {
 		return db
 	}
-	return db.Where(conditions)
+	return newSession(db).Where(conditions)
+}
+
+// newSession returns a new session on db so the conditions, joins, and preloads chained by the handler
+// never mutate the caller's handle. This matters when a pre-scoped handle such as db.Where(...)
+// is shared between goroutines: chaining on it directly would write into its statement.
+func newSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{})
 }
 
 // DataGorm performs database-level filtering using GORM queries.
@@ -56,48 +65,146 @@ func (f *Handler[T]) DataGorm(
 	pageIndex int,
 	pageSize int,
 ) (*PaginationResult[T], error) {
-	result := PaginationResult[T]{
-		PageIndex: pageIndex,
-		PageSize:  pageSize,
+	return f.DataGormCtx(context.Background(), db, filterRoot, pageIndex, pageSize)
+}
+
+// DataGormCtx is DataGorm with cancellation support.
+// The context is passed to GORM via db.WithContext so running SQL is cancelled with ctx.
+func (f *Handler[T]) DataGormCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return observe(f, ctx, "DataGorm", filterRoot, StrategyDatabase, func(report *QueryResultInfo) (*PaginationResult[T], error) {
+		result, err := f.dataGorm(ctx, db, filterRoot, pageIndex, pageSize)
+		if err == nil {
+			report.TotalSize = result.TotalSize
+		}
+		return result, err
+	})
+}
+
+// dataGorm implements DataGormCtx
+func (f *Handler[T]) dataGorm(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	// WithContext starts a new session, so the caller's handle is never mutated
+	db = db.WithContext(ctx)
+
+	// Default the page size and use 0-based indexing before anything is reported
+	result := f.newPaginationResult(pageIndex, pageSize)
+	result.AppliedFilters, result.IgnoredFields, result.IgnoredSorts = f.appliedRoot(filterRoot, false)
+
+	filterRoot, err := f.restrictRoot(filterRoot)
+	if err != nil {
+		return nil, err
 	}
 
-	// Set defaults if not provided - use 0-based indexing
-	if result.PageIndex < 0 {
-		result.PageIndex = 0
+	// Build the query with joins and filters - db may already have WHERE conditions, they will be preserved
+	query, toMany, err := f.filteredQuery(db, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get total count before pagination (each record once, even when to-many joins repeat it)
+	totalCount, err := f.countDistinct(db, query, toMany)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count records: %w", err)
 	}
-	if result.PageSize <= 0 {
-		result.PageSize = 30
+	// Aggregate every matching record, before ORDER BY and LIMIT
+	if result.Aggregates, err = f.aggregateGorm(db, query, filterRoot, toMany); err != nil {
+		return nil, err
 	}
+	result.setTotal(int(totalCount))
 
-	// Build the query - db may already have WHERE conditions, they will be preserved
-	query := db.Model(new(T))
+	query = f.pageQuery(db, query, filterRoot, toMany, result.PageIndex, result.PageSize)
 
-	// Auto-join related tables based on field filters and sort fields
-	query = f.autoJoinRelatedTables(query, filterRoot.FieldFilters, filterRoot.SortFields)
+	// Apply preloads (GORM only feature), after counting and aggregating
+	query, err = f.applyPreloads(db, query, filterRoot)
+	if err != nil {
+		return nil, err
+	}
 
-	// Apply preloads (GORM only feature)
-	if len(filterRoot.Preload) > 0 {
-		for _, preloadField := range filterRoot.Preload {
-			query = query.Preload(preloadField)
-		}
+	// Execute query
+	var data []*T
+	if err := query.Find(&data).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
 	}
 
+	result.Data = f.afterFetch(data)
+	return &result, nil
+}
+
+// filteredQuery builds the query of DataGorm up to its WHERE conditions: the model, joins for nested
+// filter, sort, selected and aggregated fields, and the filters of filterRoot. toMany reports whether a
+// join to a to-many relation may repeat records.
+func (f *Handler[T]) filteredQuery(db *gorm.DB, filterRoot Root) (query *gorm.DB, toMany bool, err error) {
+	query = f.modelQuery(db, filterRoot)
+
+	// Collect filters from the root and all nested groups
+	fieldFilters := flattenFieldFilters(filterRoot)
+
+	// Auto-join related tables based on field filters, sort fields, selected and aggregated fields
+	query = f.autoJoinRelatedTables(query, fieldFilters, filterRoot.SortFields, f.joinFields(filterRoot)...)
+
 	// Apply filters
-	if len(filterRoot.FieldFilters) > 0 {
-		query = f.applysGorm(query, filterRoot)
+	query, err = f.applysGorm(query, filterRoot)
+	if err != nil {
+		return nil, false, err
 	}
+	return query, f.joinsToMany(dialectOf(db), filterRoot.SortFields), nil
+}
 
-	// Get total count before pagination
-	var totalCount int64
-	if err := query.Count(&totalCount).Error; err != nil {
-		return nil, fmt.Errorf("failed to count records: %w", err)
+// joinFields returns the selected and aggregated fields of filterRoot, whose relations DataGorm joins
+func (f *Handler[T]) joinFields(filterRoot Root) []string {
+	joinFields := filterRoot.SelectFields
+	for _, aggregation := range filterRoot.Aggregations {
+		joinFields = append(joinFields[:len(joinFields):len(joinFields)], aggregation.Field)
 	}
-	result.TotalSize = int(totalCount)
-	result.TotalPage = (result.TotalSize + result.PageSize - 1) / result.PageSize
+	return joinFields
+}
 
-	// Check if any filters or sorts use nested fields (for table name disambiguation)
+// pageQuery adds to a query built by filteredQuery the grouping of to-many joins, the sort order
+// with the primary key as a tiebreaker, the selected columns and the LIMIT/OFFSET of the page
+func (f *Handler[T]) pageQuery(db *gorm.DB, query *gorm.DB, filterRoot Root, toMany bool, pageIndex, pageSize int) *gorm.DB {
+	d := dialectOf(db)
+	if toMany {
+		query = f.groupByPrimaryKey(db, query)
+	}
+
+	// Get the main table name for disambiguation
+	mainTableName := f.pageTableName(d, filterRoot)
+
+	// Apply sorting, with the primary key as a tiebreaker
+	if sortFields := f.withTiebreaker(filterRoot.SortFields); len(sortFields) > 0 {
+		// User provided sort fields - use them
+		query, _ = f.applyOrder(db, query, sortFields, mainTableName)
+	} else {
+		// No user-provided sort fields - add default sorting for consistent pagination
+		// This ensures pagination results are deterministic and prevents duplicate records across pages
+		query = query.Order(fmt.Sprintf("%s ASC", f.primaryKeyColumn(d, mainTableName)))
+	}
+
+	// Limit fetched columns (after counting, so COUNT(*) is unaffected)
+	if columns := f.selectColumns(d, filterRoot.SelectFields, mainTableName); len(columns) > 0 {
+		query = query.Select(columns)
+	}
+
+	// Apply pagination (0-based indexing)
+	return query.Offset(pageIndex * pageSize).Limit(pageSize)
+}
+
+// pageTableName returns the main table name the WHERE conditions and pageQuery prefix simple fields with,
+// or "" when no filter, sort or joined field of filterRoot is nested, so no JOIN can make them ambiguous
+func (f *Handler[T]) pageTableName(d sqlDialect, filterRoot Root) string {
 	hasNestedFields := false
-	for _, filter := range filterRoot.FieldFilters {
+	for _, filter := range flattenFieldFilters(filterRoot) {
 		if strings.Contains(filter.Field, ".") {
 			hasNestedFields = true
 			break
@@ -111,70 +218,18 @@ func (f *Handler[T]) DataGorm(
 			}
 		}
 	}
-
-	// Get the main table name for disambiguation
-	var mainTableName string
-	if hasNestedFields {
-		stmt := &gorm.Statement{DB: db}
-		if err := stmt.Parse(new(T)); err == nil {
-			mainTableName = stmt.Schema.Table
-		}
-	}
-
-	// Apply sorting
-	if len(filterRoot.SortFields) > 0 {
-		// User provided sort fields - use them
-		for _, sortField := range filterRoot.SortFields {
-			// For simple fields, check if they exist. For nested fields, let GORM handle them.
-			if !strings.Contains(sortField.Field, ".") && !f.fieldExists(sortField.Field) {
-				// Silently ignore non-existent simple sort fields
-				continue
-			}
-
-			order := "ASC"
-			if sortField.Order == SortOrderDesc {
-				order = "DESC"
-			}
-			// Normalize nested field names: "member_profile.name" -> "MemberProfile.name"
-			field := sortField.Field
-			if strings.Contains(field, ".") {
-				parts := strings.Split(field, ".")
-				if len(parts) >= 2 {
-					parts[0] = f.toPascalCase(parts[0])
-					// Quote identifiers to preserve case
-					field = fmt.Sprintf(`"%s"."%s"`, parts[0], parts[1])
-					for i := 2; i < len(parts); i++ {
-						field = fmt.Sprintf(`%s."%s"`, field, parts[i])
-					}
-				}
-			} else if mainTableName != "" {
-				// For non-nested fields, prefix with main table name to avoid ambiguity
-				field = fmt.Sprintf(`"%s"."%s"`, mainTableName, field)
+	if !hasNestedFields {
+		for _, joinField := range f.joinFields(filterRoot) {
+			if strings.Contains(joinField, ".") {
+				hasNestedFields = true
+				break
 			}
-			query = query.Order(fmt.Sprintf("%s %s", field, order))
-		}
-	} else {
-		// No user-provided sort fields - add default sorting for consistent pagination
-		// This ensures pagination results are deterministic and prevents duplicate records across pages
-		if mainTableName != "" {
-			query = query.Order(fmt.Sprintf(`"%s"."id" ASC`, mainTableName))
-		} else {
-			query = query.Order("id ASC")
 		}
 	}
-
-	// Apply pagination (0-based indexing)
-	offset := result.PageIndex * result.PageSize
-	query = query.Offset(int(offset)).Limit(int(result.PageSize))
-
-	// Execute query
-	var data []*T
-	if err := query.Find(&data).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch records: %w", err)
+	if !hasNestedFields {
+		return ""
 	}
-
-	result.Data = data
-	return &result, nil
+	return f.mainTableName(d)
 }
 
 // DataGormNoPage performs database-level filtering using GORM queries without pagination.
@@ -200,27 +255,102 @@ func (f *Handler[T]) DataGormNoPage(
 	db *gorm.DB,
 	filterRoot Root,
 ) ([]*T, error) {
+	return f.DataGormNoPageCtx(context.Background(), db, filterRoot)
+}
+
+// DataGormNoPageCtx is DataGormNoPage with cancellation support.
+// The context is passed to GORM via db.WithContext so running SQL is cancelled with ctx.
+func (f *Handler[T]) DataGormNoPageCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+) ([]*T, error) {
+	return observe(f, ctx, "DataGormNoPage", filterRoot, StrategyDatabase, func(report *QueryResultInfo) ([]*T, error) {
+		data, err := f.dataGormNoPage(ctx, db, filterRoot, report)
+		report.TotalSize = len(data)
+		return data, err
+	})
+}
+
+// dataGormNoPage implements DataGormNoPageCtx, capped at MaxExportRows (see limitExport)
+func (f *Handler[T]) dataGormNoPage(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	report *QueryResultInfo,
+) ([]*T, error) {
+	data, err := f.fetchGormNoPage(ctx, db, filterRoot, report)
+	if err != nil {
+		return nil, err
+	}
+	return f.afterFetch(data), nil
+}
+
+// fetchGormNoPage is dataGormNoPage without the FetchHooks, for callers that run them on fewer rows
+func (f *Handler[T]) fetchGormNoPage(
+	ctx context.Context,
+	db *gorm.DB,
+	filterRoot Root,
+	report *QueryResultInfo,
+) ([]*T, error) {
+	query, _, err := f.gormNoPageQuery(db.WithContext(ctx), f.exportRoot(filterRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute query without pagination
+	var data []*T
+	if err := query.Find(&data).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
+	}
+	return f.limitExport(data, filterRoot, report)
+}
+
+// batchOrder tells findInBatches how gormNoPageQuery sorted its query
+type batchOrder struct {
+	sorted    bool        // Whether any ORDER BY from Root.SortFields was applied
+	keyFields []SortField // The sort fields followed by the primary key when batches can be paged by keyset
+	dialect   sqlDialect  // The dialect and main table name the keyset columns are built for
+	mainTable string
+}
+
+// gormNoPageQuery builds the filtered, joined, sorted and column-limited query used by DataGormNoPage
+// without executing it, and reports how it was sorted.
+func (f *Handler[T]) gormNoPageQuery(db *gorm.DB, filterRoot Root) (query *gorm.DB, order batchOrder, err error) {
+	d := dialectOf(db)
+	filterRoot, err = f.restrictRoot(filterRoot)
+	if err != nil {
+		return nil, order, err
+	}
+
 	// Build the query - db may already have WHERE conditions, they will be preserved
-	query := db.Model(new(T))
+	db = newSession(db)
+	query = f.modelQuery(db, filterRoot)
+
+	// Collect filters from the root and all nested groups
+	fieldFilters := flattenFieldFilters(filterRoot)
 
 	// Auto-join related tables based on field filters and sort fields
-	query = f.autoJoinRelatedTables(query, filterRoot.FieldFilters, filterRoot.SortFields)
+	query = f.autoJoinRelatedTables(query, fieldFilters, filterRoot.SortFields, filterRoot.SelectFields...)
 
 	// Apply preloads (GORM only feature)
-	if len(filterRoot.Preload) > 0 {
-		for _, preloadField := range filterRoot.Preload {
-			query = query.Preload(preloadField)
-		}
+	query, err = f.applyPreloads(db, query, filterRoot)
+	if err != nil {
+		return nil, order, err
 	}
 
 	// Apply filters
-	if len(filterRoot.FieldFilters) > 0 {
-		query = f.applysGorm(query, filterRoot)
+	query, err = f.applysGorm(query, filterRoot)
+	if err != nil {
+		return nil, order, err
+	}
+	if f.joinsToMany(d, filterRoot.SortFields) {
+		query = f.groupByPrimaryKey(db, query)
 	}
 
 	// Check if any filters or sorts use nested fields (for table name disambiguation)
 	hasNestedFields := false
-	for _, filter := range filterRoot.FieldFilters {
+	for _, filter := range fieldFilters {
 		if strings.Contains(filter.Field, ".") {
 			hasNestedFields = true
 			break
@@ -234,56 +364,186 @@ func (f *Handler[T]) DataGormNoPage(
 			}
 		}
 	}
+	if !hasNestedFields {
+		for _, selectField := range filterRoot.SelectFields {
+			if strings.Contains(selectField, ".") {
+				hasNestedFields = true
+				break
+			}
+		}
+	}
 
 	// Get the main table name for disambiguation
 	var mainTableName string
 	if hasNestedFields {
-		stmt := &gorm.Statement{DB: db}
-		if err := stmt.Parse(new(T)); err == nil {
-			mainTableName = stmt.Schema.Table
+		mainTableName = f.mainTableName(d)
+	}
+
+	// Apply sorting, with the primary key as a tiebreaker like DataGorm
+	query, order.sorted = f.applyOrder(db, query, f.withTiebreaker(filterRoot.SortFields), mainTableName)
+	if order.sorted {
+		order.keyFields = f.keysetFields(d, filterRoot.SortFields)
+		order.dialect, order.mainTable = d, mainTableName
+	}
+
+	// Limit fetched columns, keeping the keyset ones findInKeysetBatches reads from the last row of a batch
+	selectFields := filterRoot.SelectFields
+	if len(selectFields) > 0 {
+		selectFields = slices.Clip(selectFields)
+		for _, keyField := range order.keyFields {
+			selectFields = append(selectFields, keyField.Field)
 		}
 	}
+	if columns := f.selectColumns(d, selectFields, mainTableName); len(columns) > 0 {
+		query = query.Select(columns)
+	}
+	if filterRoot.Limit > 0 {
+		// FindInBatches and findInBatches stop once they fetched this many rows
+		query = query.Limit(filterRoot.Limit)
+	}
 
-	// Apply sorting
-	if len(filterRoot.SortFields) > 0 {
-		for _, sortField := range filterRoot.SortFields {
-			// For simple fields, check if they exist. For nested fields, let GORM handle them.
-			if !strings.Contains(sortField.Field, ".") && !f.fieldExists(sortField.Field) {
-				// Silently ignore non-existent simple sort fields
-				continue
-			}
+	return query, order, nil
+}
 
-			order := "ASC"
-			if sortField.Order == SortOrderDesc {
-				order = "DESC"
-			}
-			// Normalize nested field names: "member_profile.name" -> "MemberProfile.name"
-			field := sortField.Field
-			if strings.Contains(field, ".") {
-				parts := strings.Split(field, ".")
-				if len(parts) >= 2 {
-					parts[0] = f.toPascalCase(parts[0])
-					// Quote identifiers to preserve case
-					field = fmt.Sprintf(`"%s"."%s"`, parts[0], parts[1])
-					for i := 2; i < len(parts); i++ {
-						field = fmt.Sprintf(`%s."%s"`, field, parts[i])
-					}
-				}
-			} else if mainTableName != "" {
-				// For non-nested fields, prefix with main table name to avoid ambiguity
-				field = fmt.Sprintf(`"%s"."%s"`, mainTableName, field)
+// keysetFields returns sortFields followed by the primary key when findInBatches can page them by keyset,
+// or nil when it has to fall back to LIMIT/OFFSET: all of them must be columns of T's table that cannot be
+// NULL, which the "after the last row" condition would skip, without RelevanceField or a NullsOrder.
+func (f *Handler[T]) keysetFields(d sqlDialect, sortFields []SortField) []SortField {
+	if f.primaryKey == "" {
+		return nil
+	}
+	keyFields := f.withPrimaryKey(sortFields)
+	for _, keyField := range keyFields {
+		if keyField.Field == RelevanceField || keyField.Nulls != NullsDefault ||
+			strings.Contains(keyField.Field, ".") || f.isToManyField(d, keyField.Field) {
+			return nil
+		}
+		descriptor, ok := f.fieldDescriptor(keyField.Field)
+		if !ok || descriptor.Nullable || descriptor.Computed {
+			return nil
+		}
+		if _, ok := f.getter(keyField.Field); !ok {
+			return nil
+		}
+	}
+	return keyFields
+}
+
+// findInBatches runs a query built by gormNoPageQuery in batches of batchSize rows
+// (DefaultStreamBatchSize when <= 0) and calls fn with each non-empty batch, stopping at the first error.
+// Without sorting, rows are fetched with FindInBatches in primary key order. FindInBatches pages by
+// primary key, which would break a custom sort order, so sorted queries are paged by keyset on the sort
+// fields and the primary key, which doesn't skip rows when fn changes them so they no longer match.
+// Sort orders keysetFields can't page fall back to LIMIT/OFFSET using the primary key as a tie-breaker.
+// All of them stop after the LIMIT of the query (see Root.Limit).
+func (f *Handler[T]) findInBatches(db *gorm.DB, query *gorm.DB, order batchOrder, batchSize int, fn func(batch []*T) error) error {
+	batchSize = streamBatchSize(batchSize)
+
+	if !order.sorted {
+		var batch []*T
+		var fnErr error
+		result := query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			fnErr = fn(f.afterFetch(batch))
+			return fnErr
+		})
+		if fnErr != nil {
+			return fnErr
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to stream records: %w", result.Error)
+		}
+		return nil
+	}
+	if len(order.keyFields) > 0 {
+		return f.findInKeysetBatches(query, order, batchSize, fn)
+	}
+
+	if f.primaryKey != "" {
+		query = query.Order(f.primaryKeyColumn(dialectOf(db), f.mainTableName(dialectOf(db))) + " ASC")
+	}
+	limit := queryLimit(query)
+	for offset := 0; limit <= 0 || offset < limit; offset += batchSize {
+		size := batchSize
+		if limit > 0 {
+			size = min(size, limit-offset)
+		}
+		var batch []*T
+		if err := query.Limit(size).Offset(offset).Find(&batch).Error; err != nil {
+			return fmt.Errorf("failed to stream records: %w", err)
+		}
+		if len(batch) > 0 {
+			if err := fn(f.afterFetch(batch)); err != nil {
+				return err
 			}
-			query = query.Order(fmt.Sprintf("%s %s", field, order))
+		}
+		if len(batch) < size {
+			return nil
 		}
 	}
+	return nil
+}
 
-	// Execute query without pagination
-	var data []*T
-	if err := query.Find(&data).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch records: %w", err)
+// findInKeysetBatches is findInBatches for a sort order keysetFields can page: each batch continues
+// after the sort and primary key values of the last row of the previous one
+func (f *Handler[T]) findInKeysetBatches(query *gorm.DB, order batchOrder, batchSize int, fn func(batch []*T) error) error {
+	// The primary key is last, a tie-breaker when it isn't sorted already
+	primaryKey := order.keyFields[len(order.keyFields)-1]
+	query = query.Order(f.sortColumnExpr(order.dialect, primaryKey, order.mainTable) + " ASC")
+	limit := queryLimit(query)
+	var after []any
+	for fetched := 0; limit <= 0 || fetched < limit; {
+		size := batchSize
+		if limit > 0 {
+			size = min(size, limit-fetched)
+		}
+		batchQuery := query.Session(&gorm.Session{})
+		if after != nil {
+			columns, keyFields, values := f.keysetColumns(order.dialect, order.keyFields, after, order.mainTable)
+			condition, args := buildKeysetCondition(columns, keyFields, values)
+			batchQuery = batchQuery.Where(condition, args...)
+		}
+		var batch []*T
+		if err := batchQuery.Limit(size).Find(&batch).Error; err != nil {
+			return fmt.Errorf("failed to stream records: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		fetched += len(batch)
+
+		// Read the keys before fn and the FetchHooks can change the row
+		last := batch[len(batch)-1]
+		after = make([]any, len(order.keyFields))
+		for i, keyField := range order.keyFields {
+			getter, _ := f.getter(keyField.Field)
+			after[i] = getter(last)
+		}
+		if err := fn(f.afterFetch(batch)); err != nil {
+			return err
+		}
+		if len(batch) < size {
+			return nil
+		}
 	}
+	return nil
+}
 
-	return data, nil
+// queryLimit returns the LIMIT set on query, or 0 without one
+func queryLimit(query *gorm.DB) int {
+	if c, ok := query.Statement.Clauses["LIMIT"]; ok {
+		if limit, ok := c.Expression.(clause.Limit); ok && limit.Limit != nil {
+			return *limit.Limit
+		}
+	}
+	return 0
+}
+
+// streamBatchSize returns batchSize, or DefaultStreamBatchSize when it is not positive
+func streamBatchSize(batchSize int) int {
+	if batchSize <= 0 {
+		return DefaultStreamBatchSize
+	}
+	return batchSize
 }
 
 // GormNoPaginationCSV performs database-level filtering using GORM queries and returns results as CSV bytes.
@@ -310,49 +570,60 @@ func (f *Handler[T]) GormNoPaginationCSV(
 	db *gorm.DB,
 	filterRoot Root,
 ) ([]byte, error) {
-	// Use DataGormNoPage to get filtered results
-	filteredData, err := f.DataGormNoPage(db, filterRoot)
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter data: %w", err)
-	}
+	return f.GormNoPaginationCSVWithOptions(db, filterRoot, DefaultCSVOptions())
+}
 
-	// Write headers using field names from the getters map
-	fieldNames := make([]string, 0, len(f.getters))
-	for fieldName := range f.getters {
-		fieldNames = append(fieldNames, fieldName)
-	}
-	sort.Strings(fieldNames) // Ensure deterministic column ordering
+// GormNoPaginationCSVWithOptions is GormNoPaginationCSV with a custom delimiter, optional headers,
+// explicit column selection and ordering, and the text written for nil values.
+// Columns not listed in opts.Columns are omitted; unknown column names return an error.
+// The relations of nested columns ("work_shift.name") are preloaded.
+//
+// Example usage:
+//
+//	csvData, err := handler.GormNoPaginationCSVWithOptions(db, filterRoot, filter.CSVOptions{
+//	    Delimiter: ';',
+//	    Columns:   []string{"id", "name", "email"},
+//	})
+func (f *Handler[T]) GormNoPaginationCSVWithOptions(
+	db *gorm.DB,
+	filterRoot Root,
+	opts CSVOptions,
+) ([]byte, error) {
+	return observe(f, dbContext(db), "GormNoPaginationCSV", filterRoot, StrategyDatabase, func(report *QueryResultInfo) ([]byte, error) {
+		return f.gormCSV(db, filterRoot, opts, report)
+	})
+}
 
-	// Build CSV content using encoding/csv
-	var buf bytes.Buffer
-	csvWriter := csv.NewWriter(&buf)
+// gormCSV implements GormNoPaginationCSVWithOptions
+func (f *Handler[T]) gormCSV(db *gorm.DB, filterRoot Root, opts CSVOptions, report *QueryResultInfo) ([]byte, error) {
+	// Validate columns before querying
+	columns, err := f.csvColumns(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	// Write headers
-	if err := csvWriter.Write(fieldNames); err != nil {
-		return nil, fmt.Errorf("failed to write CSV headers: %w", err)
+	// Use DataGormNoPage to get filtered results
+	filteredData, err := f.dataGormNoPage(dbContext(db), db, f.withColumnPreloads(db, filterRoot, columns), report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter data: %w", err)
 	}
+	report.TotalSize = len(filteredData)
 
-	// Write data rows
-	for _, item := range filteredData {
-		record := make([]string, len(fieldNames))
-		for i, fieldName := range fieldNames {
-			// Get the value using the getter for this field
-			getter := f.getters[fieldName]
-			value := getter(item)
-			record[i] = fmt.Sprintf("%v", value)
-		}
+	return f.csvBytes(filteredData, opts)
+}
 
-		if err := csvWriter.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+// gormExport filters like DataGormNoPage and encodes the matching rows with encode,
+// reported to the observer as method
+func (f *Handler[T]) gormExport(method string, db *gorm.DB, filterRoot Root, encode func([]*T) ([]byte, error)) ([]byte, error) {
+	return observe(f, dbContext(db), method, filterRoot, StrategyDatabase, func(report *QueryResultInfo) ([]byte, error) {
+		filteredData, err := f.dataGormNoPage(dbContext(db), db, filterRoot, report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter data: %w", err)
 		}
-	}
-
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return nil, fmt.Errorf("CSV writer error: %w", err)
-	}
+		report.TotalSize = len(filteredData)
 
-	return buf.Bytes(), nil
+		return encode(filteredData)
+	})
 }
 
 // DataGormWithPreset is a convenience method that combines ApplyPresetConditions and DataGorm.
@@ -379,7 +650,7 @@ func (f *Handler[T]) DataGormWithPreset(
 ) (*PaginationResult[T], error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call regular DataGorm with the modified db
@@ -408,7 +679,7 @@ func (f *Handler[T]) DataGormNoPageWithPreset(
 ) ([]*T, error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call DataGormNoPage with the modified db
@@ -437,7 +708,7 @@ func (f *Handler[T]) GormNoPaginationCSVWithPreset(
 ) ([]byte, error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call GormNoPaginationCSV with the modified db
@@ -471,82 +742,85 @@ func (f *Handler[T]) GormNoPaginationCSVCustom(
 	filterRoot Root,
 	customGetter func(*T) map[string]any,
 ) ([]byte, error) {
-	// Apply filters to database query
-	filteredDB := f.applysGorm(db, filterRoot)
+	return f.GormNoPaginationCSVCustomWithOptions(db, filterRoot, customGetter, DefaultCSVOptions())
+}
 
-	// Apply sorting
-	if len(filterRoot.SortFields) > 0 {
-		for _, sortField := range filterRoot.SortFields {
-			// For simple fields, check if they exist. For nested fields, let GORM handle them.
-			if !strings.Contains(sortField.Field, ".") && !f.fieldExists(sortField.Field) {
-				// Silently ignore non-existent simple sort fields
-				continue
-			}
+// GormNoPaginationCSVCustomWithOptions is GormNoPaginationCSVCustom with CSVOptions.
+// opts.Columns selects and orders the customGetter keys; names missing from the first record return an error.
+// With no matching records the output is empty, or only the header row when opts.Columns is set.
+func (f *Handler[T]) GormNoPaginationCSVCustomWithOptions(
+	db *gorm.DB,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+	opts CSVOptions,
+) ([]byte, error) {
+	return observe(f, dbContext(db), "GormNoPaginationCSVCustom", filterRoot, StrategyDatabase, func(report *QueryResultInfo) ([]byte, error) {
+		return f.gormCSVCustom(db, filterRoot, customGetter, opts, report)
+	})
+}
 
-			order := "ASC"
-			if sortField.Order == SortOrderDesc {
-				order = "DESC"
-			}
-			filteredDB = filteredDB.Order(fmt.Sprintf("%s %s", sortField.Field, order))
-		}
+// gormCSVCustom implements GormNoPaginationCSVCustomWithOptions
+func (f *Handler[T]) gormCSVCustom(
+	db *gorm.DB,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+	opts CSVOptions,
+	report *QueryResultInfo,
+) ([]byte, error) {
+	d := dialectOf(db)
+	filterRoot, err := f.restrictRoot(f.exportRoot(filterRoot))
+	if err != nil {
+		return nil, err
 	}
 
-	// Execute query to get all matching records
-	var results []*T
-	if err := filteredDB.Find(&results).Error; err != nil {
-		return nil, fmt.Errorf("failed to query database: %w", err)
-	}
+	// Auto-join related tables based on field filters and sort fields
+	fieldFilters := flattenFieldFilters(filterRoot)
+	db = newSession(db)
+	filteredDB := f.autoJoinRelatedTables(f.modelQuery(db, filterRoot), fieldFilters, filterRoot.SortFields)
 
-	if len(results) == 0 {
-		// If no data, return empty CSV
-		return []byte(""), nil
+	// Apply filters to database query
+	filteredDB, err = f.applysGorm(filteredDB, filterRoot)
+	if err != nil {
+		return nil, err
 	}
-
-	// Get headers from the first item using the custom getter
-	firstItemFields := customGetter(results[0])
-
-	// Sort field names for deterministic column ordering
-	fieldNames := make([]string, 0, len(firstItemFields))
-	for fieldName := range firstItemFields {
-		fieldNames = append(fieldNames, fieldName)
+	if f.joinsToMany(d, filterRoot.SortFields) {
+		filteredDB = f.groupByPrimaryKey(db, filteredDB)
 	}
-	sort.Strings(fieldNames)
 
-	// Build CSV content using encoding/csv
-	var buf bytes.Buffer
-	csvWriter := csv.NewWriter(&buf)
-
-	// Write headers
-	if err := csvWriter.Write(fieldNames); err != nil {
-		return nil, fmt.Errorf("failed to write CSV headers: %w", err)
+	// Get the main table name for disambiguation when nested sorts or filters trigger JOINs
+	var mainTableName string
+	for _, sortField := range filterRoot.SortFields {
+		if strings.Contains(sortField.Field, ".") {
+			mainTableName = f.mainTableName(d)
+			break
+		}
 	}
-
-	// Write data rows
-	for _, item := range results {
-		itemFields := customGetter(item)
-		record := make([]string, len(fieldNames))
-
-		for i, fieldName := range fieldNames {
-			// Get the value for this field from the custom getter result
-			if value, exists := itemFields[fieldName]; exists {
-				record[i] = fmt.Sprintf("%v", value)
-			} else {
-				// If field doesn't exist in this item's result, use empty string
-				record[i] = ""
-			}
+	for _, filter := range fieldFilters {
+		if strings.Contains(filter.Field, ".") {
+			mainTableName = f.mainTableName(d)
+			break
 		}
+	}
 
-		if err := csvWriter.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
-		}
+	// Apply sorting, with the primary key as a tiebreaker like DataGorm
+	filteredDB, _ = f.applyOrder(db, filteredDB, f.withTiebreaker(filterRoot.SortFields), mainTableName)
+	if filterRoot.Limit > 0 {
+		filteredDB = filteredDB.Limit(filterRoot.Limit)
 	}
 
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return nil, fmt.Errorf("CSV writer error: %w", err)
+	// Execute query to get all matching records
+	var results []*T
+	if err := filteredDB.Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to query database: %w", err)
+	}
+	results, err = f.limitExport(results, filterRoot, report)
+	if err != nil {
+		return nil, err
 	}
+	results = f.afterFetch(results)
+	report.TotalSize = len(results)
 
-	return buf.Bytes(), nil
+	return csvBytesCustom(results, customGetter, opts)
 }
 
 // GormNoPaginationCSVCustomWithPreset is a convenience method that combines preset conditions with GormNoPaginationCSVCustom.
@@ -585,68 +859,343 @@ func (f *Handler[T]) GormNoPaginationCSVCustomWithPreset(
 ) ([]byte, error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call GormNoPaginationCSVCustom with the modified db
 	return f.GormNoPaginationCSVCustom(db, filterRoot, customGetter)
 }
 
-func (f *Handler[T]) applysGorm(db *gorm.DB, filterRoot Root) *gorm.DB {
-	if len(filterRoot.FieldFilters) == 0 {
-		return db
+// columnExpr returns the quoted column expression used to filter or sort by field.
+// Nested field names are normalized to the relation name ("member_profile.name" -> "MemberProfile"."name", see relationField),
+// and simple fields are prefixed with the main table name when JOINs may make them ambiguous.
+// Registered column mappings replace the last path segment with the database column name.
+// Identifiers are quoted by the dialect d (backticks on MySQL and SQLite, double quotes on PostgreSQL).
+// Fields added with RegisterField are their parenthesized SQL expression, and JSON paths
+// ("metadata->plan") extract their value as text from the column (see jsonPathExpr).
+func (f *Handler[T]) columnExpr(d sqlDialect, field string, mainTableName string) string {
+	if path, ok := f.mapKeyPath(field); ok {
+		field = path
+	}
+	if column, keys, ok := splitJSONPath(field); ok {
+		return jsonPathExpr(d, f.columnExpr(d, column, mainTableName), keys, DataTypeText)
+	}
+	if strings.Contains(field, ".") {
+		parts := strings.Split(field, ".")
+		if column, exists := f.columnName(d, field); exists {
+			parts[len(parts)-1] = column
+		}
+		// GORM uses the struct field name as the JOIN alias
+		parts[0] = f.relationAlias(d, parts[0])
+		for i, part := range parts {
+			parts[i] = quoteIdentifier(d, part)
+		}
+		return strings.Join(parts, ".")
+	}
+	if expression, computed := f.computedField(field); computed {
+		return "(" + expression + ")"
+	}
+	if column, exists := f.columnName(d, field); exists {
+		field = column
+	}
+	if mainTableName != "" {
+		// For non-nested fields, prefix with main table name to avoid ambiguity when JOINs are present
+		return quoteIdentifier(d, mainTableName) + "." + quoteIdentifier(d, field)
 	}
+	return field
+}
 
-	// Check if any filters use nested fields (which trigger JOINs)
-	hasNestedFields := false
-	for _, filter := range filterRoot.FieldFilters {
-		if strings.Contains(filter.Field, ".") {
-			hasNestedFields = true
-			break
+// columnName returns the database column of field: an explicit ColumnMappings entry or `filter:"column:..."` tag,
+// else the column in T's GORM schema (honoring `gorm:"column:..."` tags and the naming strategy)
+func (f *Handler[T]) columnName(d sqlDialect, field string) (string, bool) {
+	if column, exists := f.columns[field]; exists {
+		return column, true
+	}
+	return f.schemaColumn(d, field)
+}
+
+// schemaColumn looks field up in T's GORM schema, following relations for nested fields
+// ("department.name" -> the Name column of the Department relation's schema)
+func (f *Handler[T]) schemaColumn(d sqlDialect, field string) (string, bool) {
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return "", false
+	}
+
+	// Known fields resolve through their Go field path; deeper nested fields by name
+	parts := strings.Split(field, ".")
+	if path, exists := f.fieldPaths[field]; exists {
+		parts = strings.Split(path, ".")
+	} else if path, exists := f.fieldPaths[strings.ToLower(field)]; exists {
+		parts = strings.Split(path, ".")
+	} else if len(parts) == 1 {
+		return "", false
+	}
+
+	fieldSchema := modelSchema
+	for _, segment := range parts[:len(parts)-1] {
+		rel := fieldSchema.Relationships.Relations[f.relationField(d, fieldSchema, segment)]
+		if rel == nil {
+			return "", false
 		}
+		fieldSchema = rel.FieldSchema
 	}
+	schemaField := fieldSchema.LookUpField(parts[len(parts)-1])
+	if schemaField == nil || schemaField.DBName == "" {
+		return "", false
+	}
+	return schemaField.DBName, true
+}
 
-	// Get the main table name for disambiguation
-	var mainTableName string
-	if hasNestedFields {
-		// Get table name from GORM
-		stmt := &gorm.Statement{DB: db}
-		if err := stmt.Parse(new(T)); err == nil {
-			mainTableName = stmt.Schema.Table
+// mainTableName returns the table name of T, or "" if the model cannot be parsed
+func (f *Handler[T]) mainTableName(d sqlDialect) string {
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return ""
+	}
+	return modelSchema.Table
+}
+
+// quoteIdentifier quotes a single identifier using the dialect d
+func quoteIdentifier(d sqlDialect, name string) string {
+	var builder strings.Builder
+	d.quoteTo(&builder, name)
+	return builder.String()
+}
+
+// selectColumns maps Root.SelectFields to column expressions for query.Select.
+// The primary key field is always included so records stay identifiable and preloadable.
+// Unknown simple fields are ignored; nested fields are loaded through their auto-join,
+// which fetches all columns of the related table, so they add no columns here.
+func (f *Handler[T]) selectColumns(d sqlDialect, selectFields []string, mainTableName string) []string {
+	if len(selectFields) == 0 {
+		return nil
+	}
+	columns := make([]string, 0, len(selectFields)+1)
+	seen := make(map[string]bool)
+	add := func(field string) {
+		column := f.columnExpr(d, field, mainTableName)
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	if f.primaryKey != "" {
+		add(f.primaryKey)
+	}
+	for _, field := range selectFields {
+		if _, computed := f.computedField(field); computed || strings.Contains(field, ".") || strings.Contains(field, jsonPathSeparator) || !f.fieldExists(field) {
+			continue
 		}
+		add(field)
 	}
+	return columns
+}
+
+// modelQuery starts a query on T's table from db, without GORM's soft-delete scope when
+// filterRoot.IncludeDeleted is set, and runs the GormHooks on it
+func (f *Handler[T]) modelQuery(db *gorm.DB, filterRoot Root) *gorm.DB {
+	query := db.Model(new(T))
+	if filterRoot.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	return f.beforeGorm(query, filterRoot)
+}
+
+// whereCondition is a condition applysGorm adds with db.Where
+type whereCondition struct {
+	sql    string
+	values []any
+}
+
+// applysGorm applies the filters of filterRoot (including nested groups) and its ChildCounts as WHERE conditions.
+// In strict mode, invalid filter values and unsupported modes return an error instead of being skipped.
+func (f *Handler[T]) applysGorm(db *gorm.DB, filterRoot Root) (*gorm.DB, error) {
+	conditions, err := f.compiledConditions(dialectOf(db), filterRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, condition := range conditions {
+		db = db.Where(condition.sql, condition.values...)
+	}
+	return db, nil
+}
+
+// compiledConditions returns the whereConditions of filterRoot. Those of a Root from the compile cache
+// are cached per dialect and table.
+func (f *Handler[T]) compiledConditions(d sqlDialect, filterRoot Root) ([]whereCondition, error) {
+	var key string
+	if filterRoot.compileKey != "" {
+		key = "gorm:" + d.name + ":" + f.mainTableName(d) + ":" + filterRoot.compileKey
+	}
+	if cached, ok := f.compiled.get(key); ok {
+		return cached.([]whereCondition), nil
+	}
+	conditions, err := f.whereConditions(d, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+	f.compiled.put(key, conditions)
+	return conditions, nil
+}
+
+// whereConditions builds the conditions applysGorm adds
+func (f *Handler[T]) whereConditions(d sqlDialect, filterRoot Root) ([]whereCondition, error) {
+	fieldFilters := flattenFieldFilters(filterRoot)
+	if len(fieldFilters) == 0 {
+		return f.childCountConditions(d, filterRoot)
+	}
+	var conditions []whereCondition
+	strict := f.isStrict(filterRoot)
+
+	// Get the main table name for disambiguation. A join for a nested sort or selected field
+	// makes simple fields ambiguous too, under either logic, so this is the table name pageQuery uses.
+	mainTableName := f.pageTableName(d, filterRoot)
 
 	if filterRoot.Logic == LogicAnd {
 		for _, filter := range filterRoot.FieldFilters {
-			// For simple fields, check if they exist. For nested fields, let GORM handle them.
-			if strings.Contains(filter.Field, ".") || f.fieldExists(filter.Field) {
-				db = f.applyGormWithTableName(db, filter, mainTableName)
+			// Skip unknown fields, nested ones included, as DataQuery does
+			if f.knownField(filter.Field, false) {
+				condition, values, err := f.buildRecordCondition(d, filter, mainTableName)
+				if err != nil {
+					if strict || isInvertedRange(err) {
+						return nil, err
+					}
+					// Silently ignore invalid filters in non-strict mode
+					continue
+				}
+				conditions = append(conditions, whereCondition{sql: condition, values: values})
+			}
+			// Silently ignore non-existent fields
+		}
+		for _, group := range filterRoot.Groups {
+			condition, values, err := f.buildGroupCondition(d, group, mainTableName, strict, false)
+			if err != nil {
+				return nil, err
+			}
+			if condition != "" {
+				conditions = append(conditions, whereCondition{sql: condition, values: values})
 			}
-			// Silently ignore non-existent simple fields
 		}
 	} else {
 		var orConditions []string
 		var orValues []any
 
 		for _, filter := range filterRoot.FieldFilters {
-			// For simple fields, check if they exist. For nested fields, let GORM handle them.
-			if strings.Contains(filter.Field, ".") || f.fieldExists(filter.Field) {
-				condition, values := f.buildConditionWithTableName(filter, mainTableName)
-				if condition != "" {
-					orConditions = append(orConditions, condition)
-					orValues = append(orValues, values...)
+			// Skip unknown fields, nested ones included, as DataQuery does
+			if f.knownField(filter.Field, false) {
+				condition, values, err := f.buildRecordCondition(d, filter, mainTableName)
+				if err != nil {
+					if strict || isInvertedRange(err) {
+						return nil, err
+					}
+					// Silently ignore invalid filters in non-strict mode
+					continue
 				}
+				orConditions = append(orConditions, condition)
+				orValues = append(orValues, values...)
 			}
 			// Silently ignore non-existent fields
 		}
+		for _, group := range filterRoot.Groups {
+			condition, values, err := f.buildGroupCondition(d, group, mainTableName, strict, false)
+			if err != nil {
+				return nil, err
+			}
+			if condition != "" {
+				orConditions = append(orConditions, condition)
+				orValues = append(orValues, values...)
+			}
+		}
 		if len(orConditions) > 0 {
-			db = db.Where(strings.Join(orConditions, " OR "), orValues...)
+			conditions = append(conditions, whereCondition{sql: strings.Join(orConditions, " OR "), values: orValues})
 		}
 	}
-	return db
+	counts, err := f.childCountConditions(d, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+	return append(conditions, counts...), nil
+}
+
+// isCaseSensitive reports whether a text filter should match case-sensitively.
+// FieldFilter.CaseSensitive overrides GolangFilteringConfig.CaseSensitive when set.
+func (f *Handler[T]) isCaseSensitive(filter FieldFilter) bool {
+	if filter.CaseSensitive != nil {
+		return *filter.CaseSensitive
+	}
+	return f.caseSensitive
+}
+
+// isStrict reports whether invalid filters should return errors for this query.
+// Root.StrictValidation overrides GolangFilteringConfig.StrictValidation when set.
+func (f *Handler[T]) isStrict(filterRoot Root) bool {
+	if filterRoot.StrictValidation != nil {
+		return *filterRoot.StrictValidation
+	}
+	return f.strictValidation
+}
+
+// buildGroupCondition builds a parenthesized SQL condition for a nested filter group.
+// Returns an empty condition when the group (and its children) has no valid filters.
+// related builds the conditions on the related rows of a ChildCountFilter's subquery, where to-many
+// fields are read from the counted row rather than by buildRecordCondition's EXISTS subqueries.
+func (f *Handler[T]) buildGroupCondition(d sqlDialect, group Root, mainTableName string, strict bool, related bool) (string, []any, error) {
+	build := f.buildRecordCondition
+	if related {
+		build = f.buildConditionWithTableName
+	}
+	var conditions []string
+	var values []any
+
+	for _, filter := range group.FieldFilters {
+		// Skip unknown fields, nested ones included, as DataQuery does
+		if f.knownField(filter.Field, false) {
+			condition, filterValues, err := build(d, filter, mainTableName)
+			if err != nil {
+				if strict || isInvertedRange(err) {
+					return "", nil, err
+				}
+				continue
+			}
+			conditions = append(conditions, "("+condition+")")
+			values = append(values, filterValues...)
+		}
+	}
+	for _, child := range group.Groups {
+		condition, childValues, err := f.buildGroupCondition(d, child, mainTableName, strict, related)
+		if err != nil {
+			return "", nil, err
+		}
+		if condition != "" {
+			conditions = append(conditions, condition)
+			values = append(values, childValues...)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	joiner := " OR "
+	if group.Logic == LogicAnd {
+		joiner = " AND "
+	}
+	return "(" + strings.Join(conditions, joiner) + ")", values, nil
+}
+
+// flattenFieldFilters returns the field filters of root and all of its nested groups
+func flattenFieldFilters(root Root) []FieldFilter {
+	if len(root.Groups) == 0 {
+		return root.FieldFilters
+	}
+	filters := append([]FieldFilter{}, root.FieldFilters...)
+	for _, group := range root.Groups {
+		filters = append(filters, flattenFieldFilters(group)...)
+	}
+	return filters
 }
 
-// toPascalCase converts snake_case or lowercase to PascalCase
+// toPascalCase converts snake_case or lowercase to PascalCase, the relation name relationField falls back to
 // Examples: "member_profile" -> "MemberProfile", "currency" -> "Currency"
 func (f *Handler[T]) toPascalCase(s string) string {
 	if len(s) == 0 {
@@ -663,367 +1212,716 @@ func (f *Handler[T]) toPascalCase(s string) string {
 	return strings.Join(parts, "")
 }
 
-// applyGormWithTableName applies a single filter with table name disambiguation
-func (f *Handler[T]) applyGormWithTableName(db *gorm.DB, filter FieldFilter, mainTableName string) *gorm.DB {
-	condition, values := f.buildConditionWithTableName(filter, mainTableName)
-	if condition != "" {
-		db = db.Where(condition, values...)
+// buildRecordCondition builds the condition of filter on each record of T: that of buildConditionWithTableName,
+// which reads a to-many field from one related row, in an EXISTS subquery over the record's related rows
+func (f *Handler[T]) buildRecordCondition(d sqlDialect, filter FieldFilter, mainTableName string) (string, []any, error) {
+	condition, values, err := f.buildConditionWithTableName(d, filter, mainTableName)
+	if err != nil || isExistsMode(filter.Mode) || !f.isToManyField(d, filter.Field) {
+		return condition, values, err
 	}
-	return db
+	condition, values = f.relatedRowsCondition(d, filter, condition, values)
+	return condition, values, nil
 }
 
 // buildConditionWithTableName builds SQL condition with optional table name prefix for non-nested fields
-func (f *Handler[T]) buildConditionWithTableName(filter FieldFilter, mainTableName string) (string, []any) {
-	field := filter.Field
-	value := filter.Value
-
-	// Check if this is a nested field
-	isNestedField := strings.Contains(field, ".")
-
-	// For nested fields, we need to normalize the relationship name to match GORM's struct field names
-	// Example: "currency.currency_code" should become "Currency.currency_code"
-	// We also need to quote identifiers to preserve case for PostgreSQL
-	if isNestedField {
-		parts := strings.Split(field, ".")
-		if len(parts) >= 2 {
-			// Convert the first part (relationship name) to PascalCase to match struct field name
-			// GORM uses the struct field name for JOINs
-			parts[0] = f.toPascalCase(parts[0])
-			// Quote identifiers to preserve case in PostgreSQL
-			// Format: "RelationName"."field_name"
-			field = fmt.Sprintf(`"%s"."%s"`, parts[0], parts[1])
-			// For more than 2 parts, append remaining parts
-			for i := 2; i < len(parts); i++ {
-				field = fmt.Sprintf(`%s."%s"`, field, parts[i])
-			}
+// Returns an error naming the field when the value cannot be parsed or the mode is not supported for the data type.
+func (f *Handler[T]) buildConditionWithTableName(d sqlDialect, filter FieldFilter, mainTableName string) (string, []any, error) {
+	for _, field := range []string{filter.Field, filter.CompareField} {
+		if field != "" && !f.hasSQL(field) {
+			return "", nil, fmt.Errorf("computed field %s has no SQL expression (see RegisterFieldSQL)", field)
 		}
-	} else if mainTableName != "" {
-		// For non-nested fields, prefix with main table name to avoid ambiguity when JOINs are present
-		// Quote both table and field names
-		field = fmt.Sprintf(`"%s"."%s"`, mainTableName, field)
 	}
+	if filter.CompareField != "" {
+		condition, err := f.buildCompareCondition(d, filter, mainTableName)
+		return condition, nil, err
+	}
+	if isExistsMode(filter.Mode) {
+		return f.buildExistsCondition(d, filter, mainTableName)
+	}
+	if path, ok := f.mapKeyPath(filter.Field); ok {
+		if _, _, valid := splitJSONPath(path); !valid {
+			return "", nil, fmt.Errorf("map key of field %s must be an identifier to be read from its JSON column", filter.Field)
+		}
+		filter.Field = path
+	}
+	filter, err := f.numberFilter(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	field := f.columnExpr(d, filter.Field, mainTableName)
+	if column, keys, ok := splitJSONPath(filter.Field); ok {
+		field = jsonPathExpr(d, f.columnExpr(d, column, mainTableName), keys, filter.DataType)
+	}
+	value := filter.Value
 
+	var condition string
+	var values []any
 	switch filter.DataType {
 	case DataTypeNumber:
-		return f.buildNumberCondition(field, filter.Mode, value)
+		condition, values, err = f.buildNumberCondition(d, field, filter.Mode, value)
 	case DataTypeText:
-		return f.buildTextCondition(field, filter.Mode, value)
+		condition, values, err = f.buildTextCondition(d, field, filter.Mode, value, f.isCaseSensitive(filter))
 	case DataTypeBool:
-		return f.buildBoolCondition(field, filter.Mode, value)
+		condition, values, err = f.buildBoolCondition(field, filter.Mode, value)
 	case DataTypeDate:
-		return f.buildDateCondition(field, filter.Mode, value)
+		condition, values, err = f.buildDateCondition(field, filter.Mode, value)
+		// Bind instants in UTC so databases comparing timestamps as text (SQLite) order values from any zone correctly
+		for i, v := range values {
+			if t, ok := v.(time.Time); ok {
+				values[i] = t.UTC()
+			}
+		}
 	case DataTypeTime:
-		return f.buildTimeCondition(field, filter.Mode, value)
+		dataType, _ := f.fieldDataType(filter.Field)
+		condition, values, err = f.buildTimeCondition(d, field, filter.Mode, value, dataType == DataTypeDate)
+	case DataTypeUUID:
+		condition, values, err = f.buildUUIDCondition(field, filter.Field, filter.Mode, value)
 	default:
-		return "", nil
+		return "", nil, &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("unsupported data type %s for field %s", filter.DataType, filter.Field)}
+	}
+	if err != nil {
+		return "", nil, filterError(filter, err)
+	}
+	if condition == "" {
+		return "", nil, &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType}
+	}
+	// "col != ?" is unknown rather than true for NULL, so negative modes match NULL explicitly, as in memory
+	if isNegativeMode(filter.Mode) {
+		condition = fmt.Sprintf("(%s IS NULL OR (%s))", field, condition)
 	}
+	return condition, values, nil
 }
 
-// buildNumberCondition builds SQL condition for number filters
-func (f *Handler[T]) buildNumberCondition(field string, mode Mode, value any) (string, []any) {
+// buildNumberCondition builds SQL condition for number filters.
+// ModeContains, ModeStartsWith and ModeEndsWith match the column cast to text, for partial reference numbers.
+// Integer values are bound as int64 (uint64 above its range), so IDs above 2^53 keep every digit.
+func (f *Handler[T]) buildNumberCondition(d sqlDialect, field string, mode Mode, value any) (string, []any, error) {
 	switch mode {
+	case ModeContains, ModeStartsWith, ModeEndsWith:
+		str, err := formatNumberText(value)
+		if err != nil {
+			return "", nil, err
+		}
+		str = likeEscape(d, str)
+		pattern := "%" + str + "%"
+		if mode == ModeStartsWith {
+			pattern = str + "%"
+		} else if mode == ModeEndsWith {
+			pattern = "%" + str
+		}
+		castType := "TEXT"
+		if d.name == "mysql" {
+			// MySQL only casts to CHAR
+			castType = "CHAR"
+		}
+		return fmt.Sprintf("CAST(%s AS %s) LIKE ?%s", field, castType, likeEscapeClause(d)), []any{pattern}, nil
+	case ModeIsEmpty:
+		return fmt.Sprintf("%s IS NULL", field), []any{}, nil
+	case ModeIsNotEmpty:
+		return fmt.Sprintf("%s IS NOT NULL", field), []any{}, nil
 	case ModeEqual:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s = ?", field), []any{num}
+		return fmt.Sprintf("%s = ?", field), []any{num.sqlValue()}, nil
 	case ModeNotEqual:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s != ?", field), []any{num}
+		return fmt.Sprintf("%s != ?", field), []any{num.sqlValue()}, nil
 	case ModeGT:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s > ?", field), []any{num}
+		return fmt.Sprintf("%s > ?", field), []any{num.sqlValue()}, nil
 	case ModeGTE:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s >= ?", field), []any{num}
+		return fmt.Sprintf("%s >= ?", field), []any{num.sqlValue()}, nil
 	case ModeLT:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s < ?", field), []any{num}
+		return fmt.Sprintf("%s < ?", field), []any{num.sqlValue()}, nil
 	case ModeLTE:
-		num, err := parseNumber(value)
+		num, err := parseNumberTarget(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		return fmt.Sprintf("%s <= ?", field), []any{num}
+		return fmt.Sprintf("%s <= ?", field), []any{num.sqlValue()}, nil
 	case ModeRange:
 		rangeVal, err := parseRangeNumber(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
+		}
+		rng, _ := toRange(value)
+		from, _ := parseNumberTarget(rng.From)
+		to, _ := parseNumberTarget(rng.To)
+		if rangeVal.FromExclusive || rangeVal.ToExclusive {
+			fromOp, toOp := rangeOperators(rangeVal.FromExclusive, rangeVal.ToExclusive)
+			return fmt.Sprintf("%s %s ? AND %s %s ?", field, fromOp, field, toOp), []any{from.sqlValue(), to.sqlValue()}, nil
+		}
+		return fmt.Sprintf("%s BETWEEN ? AND ?", field), []any{from.sqlValue(), to.sqlValue()}, nil
+	case ModeIn, ModeNotIn:
+		list, err := parseList(value)
+		if err != nil {
+			return "", nil, err
+		}
+		nums := make([]any, 0, len(list))
+		for _, item := range list {
+			num, err := parseNumberTarget(item)
+			if err != nil {
+				return "", nil, err
+			}
+			nums = append(nums, num.sqlValue())
 		}
-		return fmt.Sprintf("%s BETWEEN ? AND ?", field), []any{rangeVal.From, rangeVal.To}
+		condition, values := buildInCondition(field, mode, nums)
+		return condition, values, nil
 	}
-	return "", nil
+	return "", nil, nil
 }
 
-// buildTextCondition builds SQL condition for text filters
-func (f *Handler[T]) buildTextCondition(field string, mode Mode, value any) (string, []any) {
-	// Handle Range mode separately since value is a Range struct, not a string
-	if mode == ModeRange {
-		rangeVal, ok := value.(Range)
-		if !ok {
-			return "", nil
+// buildInCondition builds an IN / NOT IN condition, handling empty lists explicitly
+// since "IN ()" is not valid SQL
+func buildInCondition[V any](field string, mode Mode, values []V) (string, []any) {
+	if len(values) == 0 {
+		if mode == ModeIn {
+			return "1 = 0", []any{}
 		}
-		fromStr, err := parseText(rangeVal.From)
+		return "1 = 1", []any{}
+	}
+	if mode == ModeNotIn {
+		return fmt.Sprintf("%s NOT IN (?)", field), []any{values}
+	}
+	return fmt.Sprintf("%s IN (?)", field), []any{values}
+}
+
+// rangeOperators returns the SQL comparison operators for the lower and upper bound of a range
+func rangeOperators(fromExclusive, toExclusive bool) (string, string) {
+	fromOp, toOp := ">=", "<="
+	if fromExclusive {
+		fromOp = ">"
+	}
+	if toExclusive {
+		toOp = "<"
+	}
+	return fromOp, toOp
+}
+
+// likeEscaper escapes the wildcards of LIKE with a backslash, so a pattern filter matches its value
+// literally as in memory. SQL Server's LIKE also reads [ as the start of a character class.
+var (
+	likeEscaper          = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	sqlServerLikeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`)
+)
+
+// likeEscape escapes value for a LIKE pattern on the dialect d, see likeEscapeClause
+func likeEscape(d sqlDialect, value string) string {
+	if d.name == "sqlserver" {
+		return sqlServerLikeEscaper.Replace(value)
+	}
+	return likeEscaper.Replace(value)
+}
+
+// likeEscapeClause returns the ESCAPE clause following a LIKE of a pattern escaped by likeEscape. MySQL
+// escapes LIKE patterns with a backslash by default, and reads '\' as an unterminated string.
+func likeEscapeClause(d sqlDialect) string {
+	if d.name == "mysql" {
+		return ""
+	}
+	return ` ESCAPE '\'`
+}
+
+// globEscaper escapes the wildcards of SQLite's GLOB, so a case-sensitive pattern filter matches its value literally
+var globEscaper = strings.NewReplacer("[", "[[]", "*", "[*]", "?", "[?]")
+
+// buildTextCondition builds SQL condition for text filters. When useILike holds, case-insensitive pattern
+// modes use ILIKE instead of LOWER() on both sides. Case-sensitive filters compare the raw column so
+// an index on it can be used, except that MySQL compares it as binary, as its default collations
+// ignore case, and SQLite matches patterns with GLOB, as its LIKE ignores case.
+func (f *Handler[T]) buildTextCondition(d sqlDialect, field string, mode Mode, value any, caseSensitive bool) (string, []any, error) {
+	column := field
+	if caseSensitive && d.name == "mysql" {
+		column = fmt.Sprintf("CAST(%s AS BINARY)", field)
+	}
+	// Wrap both sides in LOWER() for case-insensitive matching
+	lower := func(expr string) string {
+		if caseSensitive {
+			return expr
+		}
+		return fmt.Sprintf("LOWER(%s)", expr)
+	}
+
+	// Handle Range mode separately since value is a Range struct, not a string. The bounds are folded
+	// like the column, so the range compares lowercase text as DataQuery does unless caseSensitive is set.
+	if mode == ModeRange {
+		rangeVal, err := parseRangeText(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		toStr, err := parseText(rangeVal.To)
+		if !caseSensitive {
+			rangeVal.From, rangeVal.To = strings.ToLower(rangeVal.From), strings.ToLower(rangeVal.To)
+		}
+		fromOp, toOp := rangeOperators(rangeVal.FromExclusive, rangeVal.ToExclusive)
+		return fmt.Sprintf("%s %s ? AND %s %s ?", lower(column), fromOp, lower(column), toOp), []any{rangeVal.From, rangeVal.To}, nil
+	}
+
+	// Handle In/NotIn separately since value is a list
+	if mode == ModeIn || mode == ModeNotIn {
+		list, err := parseList(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
+		}
+		strs := make([]string, 0, len(list))
+		for _, item := range list {
+			str, err := parseText(item)
+			if err != nil {
+				return "", nil, err
+			}
+			if !caseSensitive {
+				str = strings.ToLower(str)
+			}
+			strs = append(strs, str)
 		}
-		return fmt.Sprintf("%s BETWEEN ? AND ?", field), []any{fromStr, toStr}
+		condition, values := buildInCondition(lower(column), mode, strs)
+		return condition, values, nil
 	}
 
 	// For all other modes, parse value as text
 	str, err := parseText(value)
 	if err != nil {
-		return "", nil
+		return "", nil, err
+	}
+
+	if caseSensitive && d.name == "sqlite" {
+		glob := globEscaper.Replace(str)
+		switch mode {
+		case ModeContains:
+			return fmt.Sprintf("%s GLOB ?", field), []any{"*" + glob + "*"}, nil
+		case ModeNotContains:
+			return fmt.Sprintf("%s NOT GLOB ?", field), []any{"*" + glob + "*"}, nil
+		case ModeStartsWith:
+			return fmt.Sprintf("%s GLOB ?", field), []any{glob + "*"}, nil
+		case ModeEndsWith:
+			return fmt.Sprintf("%s GLOB ?", field), []any{"*" + glob}, nil
+		}
+	}
+
+	// Pattern modes match the value literally, as in memory
+	pattern, escape := likeEscape(d, str), likeEscapeClause(d)
+	if f.useILike(d) && !caseSensitive {
+		switch mode {
+		case ModeContains:
+			return fmt.Sprintf("%s ILIKE ?%s", field, escape), []any{"%" + pattern + "%"}, nil
+		case ModeNotContains:
+			return fmt.Sprintf("%s NOT ILIKE ?%s", field, escape), []any{"%" + pattern + "%"}, nil
+		case ModeStartsWith:
+			return fmt.Sprintf("%s ILIKE ?%s", field, escape), []any{pattern + "%"}, nil
+		case ModeEndsWith:
+			return fmt.Sprintf("%s ILIKE ?%s", field, escape), []any{"%" + pattern}, nil
+		}
 	}
 
 	switch mode {
 	case ModeEqual:
-		return fmt.Sprintf("LOWER(%s) = LOWER(?)", field), []any{str}
+		return fmt.Sprintf("%s = %s", lower(column), lower("?")), []any{str}, nil
 	case ModeNotEqual:
-		return fmt.Sprintf("LOWER(%s) != LOWER(?)", field), []any{str}
+		return fmt.Sprintf("%s != %s", lower(column), lower("?")), []any{str}, nil
 	case ModeContains:
-		return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", field), []any{"%" + str + "%"}
+		return fmt.Sprintf("%s LIKE %s%s", lower(column), lower("?"), escape), []any{"%" + pattern + "%"}, nil
 	case ModeNotContains:
-		return fmt.Sprintf("LOWER(%s) NOT LIKE LOWER(?)", field), []any{"%" + str + "%"}
+		return fmt.Sprintf("%s NOT LIKE %s%s", lower(column), lower("?"), escape), []any{"%" + pattern + "%"}, nil
 	case ModeStartsWith:
-		return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", field), []any{str + "%"}
+		return fmt.Sprintf("%s LIKE %s%s", lower(column), lower("?"), escape), []any{pattern + "%"}, nil
 	case ModeEndsWith:
-		return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", field), []any{"%" + str}
+		return fmt.Sprintf("%s LIKE %s%s", lower(column), lower("?"), escape), []any{"%" + pattern}, nil
 	case ModeIsEmpty:
-		return fmt.Sprintf("(%s IS NULL OR %s = '')", field, field), []any{}
+		return fmt.Sprintf("(%s IS NULL OR %s = '')", field, field), []any{}, nil
 	case ModeIsNotEmpty:
-		return fmt.Sprintf("(%s IS NOT NULL AND %s != '')", field, field), []any{}
+		return fmt.Sprintf("(%s IS NOT NULL AND %s != '')", field, field), []any{}, nil
 	case ModeGT:
 		// Support for text comparison (useful for time strings like "08:00:00")
-		return fmt.Sprintf("%s > ?", field), []any{str}
+		return fmt.Sprintf("%s > ?", field), []any{str}, nil
 	case ModeGTE, ModeAfter:
 		// Support for text comparison (useful for time strings like "08:00:00")
-		return fmt.Sprintf("%s >= ?", field), []any{str}
+		return fmt.Sprintf("%s >= ?", field), []any{str}, nil
 	case ModeLT, ModeBefore:
 		// Support for text comparison (useful for time strings like "08:00:00")
-		return fmt.Sprintf("%s < ?", field), []any{str}
+		return fmt.Sprintf("%s < ?", field), []any{str}, nil
 	case ModeLTE:
 		// Support for text comparison (useful for time strings like "08:00:00")
-		return fmt.Sprintf("%s <= ?", field), []any{str}
+		return fmt.Sprintf("%s <= ?", field), []any{str}, nil
 	}
-	return "", nil
+	return "", nil, nil
 }
 
 // buildBoolCondition builds SQL condition for boolean filters
-func (f *Handler[T]) buildBoolCondition(field string, mode Mode, value any) (string, []any) {
+func (f *Handler[T]) buildBoolCondition(field string, mode Mode, value any) (string, []any, error) {
+	switch mode {
+	case ModeIsEmpty:
+		return fmt.Sprintf("%s IS NULL", field), []any{}, nil
+	case ModeIsNotEmpty:
+		return fmt.Sprintf("%s IS NOT NULL", field), []any{}, nil
+	}
 	boolVal, err := parseBool(value)
 	if err != nil {
-		return "", nil
+		return "", nil, err
 	}
 	switch mode {
 	case ModeEqual:
-		return fmt.Sprintf("%s = ?", field), []any{boolVal}
+		return fmt.Sprintf("%s = ?", field), []any{boolVal}, nil
 	case ModeNotEqual:
-		return fmt.Sprintf("%s != ?", field), []any{boolVal}
+		return fmt.Sprintf("%s != ?", field), []any{boolVal}, nil
+	}
+	return "", nil, nil
+}
+
+// buildUUIDCondition builds SQL condition for UUID filters on the column field of filterField.
+// Values are bound as uuidBinder picks for the field's Go type.
+func (f *Handler[T]) buildUUIDCondition(field string, filterField string, mode Mode, value any) (string, []any, error) {
+	bind := f.uuidBinder(filterField)
+	switch mode {
+	case ModeIsEmpty:
+		return fmt.Sprintf("%s IS NULL", field), []any{}, nil
+	case ModeIsNotEmpty:
+		return fmt.Sprintf("%s IS NOT NULL", field), []any{}, nil
+	case ModeEqual, ModeNotEqual:
+		id, err := parseUUID(value)
+		if err != nil {
+			return "", nil, err
+		}
+		if mode == ModeNotEqual {
+			return fmt.Sprintf("%s != ?", field), []any{bind(id)}, nil
+		}
+		return fmt.Sprintf("%s = ?", field), []any{bind(id)}, nil
+	case ModeIn, ModeNotIn:
+		list, err := parseList(value)
+		if err != nil {
+			return "", nil, err
+		}
+		ids := make([]any, 0, len(list))
+		for _, item := range list {
+			id, err := parseUUID(item)
+			if err != nil {
+				return "", nil, err
+			}
+			ids = append(ids, bind(id))
+		}
+		condition, values := buildInCondition(field, mode, ids)
+		return condition, values, nil
 	}
-	return "", nil
+	return "", nil, nil
+}
+
+// uuidBinder returns how buildUUIDCondition binds a UUID filtering field, after the field's Go type: a
+// [16]byte type implementing driver.Valuer (uuid.UUID, or a type storing BINARY(16)) binds a value of that
+// type, so the driver stores and compares it as the column holds it, and a plain [16]byte binds its 16
+// bytes. Strings and fields of unknown type bind the canonical lowercase form.
+func (f *Handler[T]) uuidBinder(field string) func(id [16]byte) any {
+	t, ok := fieldGoType(reflect.TypeFor[T](), f.fieldID(field))
+	if ok && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if !ok || t.Kind() != reflect.Array || t.Len() != 16 || t.Elem().Kind() != reflect.Uint8 {
+		return func(id [16]byte) any { return formatUUID(id) }
+	}
+	valuerType := reflect.TypeFor[driver.Valuer]()
+	switch {
+	case t.Implements(valuerType):
+		return func(id [16]byte) any { return reflect.ValueOf(id).Convert(t).Interface() }
+	case reflect.PointerTo(t).Implements(valuerType):
+		return func(id [16]byte) any {
+			value := reflect.New(t)
+			value.Elem().Set(reflect.ValueOf(id).Convert(t))
+			return value.Interface()
+		}
+	}
+	return func(id [16]byte) any { return id[:] }
 }
 
 // buildDateCondition builds SQL condition for date/datetime filters
-func (f *Handler[T]) buildDateCondition(field string, mode Mode, value any) (string, []any) {
+func (f *Handler[T]) buildDateCondition(field string, mode Mode, value any) (string, []any, error) {
 	switch mode {
+	case ModeIsEmpty:
+		// Zero time is treated as empty to match the in-memory behavior for non-pointer time.Time fields
+		return fmt.Sprintf("(%s IS NULL OR %s = ?)", field, field), []any{time.Time{}}, nil
+	case ModeIsNotEmpty:
+		return fmt.Sprintf("(%s IS NOT NULL AND %s != ?)", field, field), []any{time.Time{}}, nil
 	case ModeEqual:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
+		if hasTime && f.datePrecision > 0 {
+			start, end := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s >= ? AND %s < ?", field, field), []any{start, end}, nil
+		}
 		if hasTime {
-			return fmt.Sprintf("%s = ?", field), []any{t}
+			return fmt.Sprintf("%s = ?", field), []any{t}, nil
 		}
 		startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 		endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
-		return fmt.Sprintf("%s BETWEEN ? AND ?", field), []any{startOfDay, endOfDay}
+		return fmt.Sprintf("%s BETWEEN ? AND ?", field), []any{startOfDay, endOfDay}, nil
 	case ModeNotEqual:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
+		if hasTime && f.datePrecision > 0 {
+			start, end := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("(%s < ? OR %s >= ?)", field, field), []any{start, end}, nil
+		}
 		if hasTime {
-			return fmt.Sprintf("%s != ?", field), []any{t}
+			return fmt.Sprintf("%s != ?", field), []any{t}, nil
 		}
 		startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 		endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
-		return fmt.Sprintf("(%s < ? OR %s > ?)", field, field), []any{startOfDay, endOfDay}
+		return fmt.Sprintf("(%s < ? OR %s > ?)", field, field), []any{startOfDay, endOfDay}, nil
 	case ModeGTE:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
 		if hasTime {
-			return fmt.Sprintf("%s >= ?", field), []any{t}
+			start, _ := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s >= ?", field), []any{start}, nil
 		} else {
 			startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-			return fmt.Sprintf("%s >= ?", field), []any{startOfDay}
+			return fmt.Sprintf("%s >= ?", field), []any{startOfDay}, nil
 		}
 	case ModeLT:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
 		if hasTime {
-			return fmt.Sprintf("%s < ?", field), []any{t}
+			start, _ := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s < ?", field), []any{start}, nil
 		} else {
 			startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-			return fmt.Sprintf("%s < ?", field), []any{startOfDay}
+			return fmt.Sprintf("%s < ?", field), []any{startOfDay}, nil
 		}
 	case ModeLTE:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
+		if hasTime && f.datePrecision > 0 {
+			_, end := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s < ?", field), []any{end}, nil
+		}
 		if hasTime {
-			return fmt.Sprintf("%s <= ?", field), []any{t}
+			return fmt.Sprintf("%s <= ?", field), []any{t}, nil
 		} else {
 			endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
-			return fmt.Sprintf("%s <= ?", field), []any{endOfDay}
+			return fmt.Sprintf("%s <= ?", field), []any{endOfDay}, nil
 		}
 	case ModeBefore:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
 		if hasTime {
-			return fmt.Sprintf("%s < ?", field), []any{t}
+			start, _ := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s < ?", field), []any{start}, nil
 		} else {
 			startOfDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-			return fmt.Sprintf("%s < ?", field), []any{startOfDay}
+			return fmt.Sprintf("%s < ?", field), []any{startOfDay}, nil
 		}
 	case ModeAfter:
 		t, err := parseDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTime := hasTimeComponent(t)
+		if hasTime && f.datePrecision > 0 {
+			_, end := truncatedBounds(t, f.datePrecision)
+			return fmt.Sprintf("%s >= ?", field), []any{end}, nil
+		}
 		if hasTime {
-			return fmt.Sprintf("%s > ?", field), []any{t}
+			return fmt.Sprintf("%s > ?", field), []any{t}, nil
 		} else {
 			endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
-			return fmt.Sprintf("%s > ?", field), []any{endOfDay}
+			return fmt.Sprintf("%s > ?", field), []any{endOfDay}, nil
 		}
 	case ModeRange:
 		rangeVal, err := parseRangeDateTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		hasTimeFrom := hasTimeComponent(rangeVal.From)
 		hasTimeTo := hasTimeComponent(rangeVal.To)
-
+		fromOp, toOp := rangeOperators(rangeVal.FromExclusive, rangeVal.ToExclusive)
+
+		if hasTimeFrom && hasTimeTo && f.datePrecision > 0 {
+			// Both bounds stand for the whole unit of precision they fall in, an exclusive one leaving it out
+			fromStart, fromEnd := truncatedBounds(rangeVal.From, f.datePrecision)
+			toStart, toEnd := truncatedBounds(rangeVal.To, f.datePrecision)
+			from, to := fromStart, toEnd
+			if rangeVal.FromExclusive {
+				from = fromEnd
+			}
+			if rangeVal.ToExclusive {
+				to = toStart
+			}
+			return fmt.Sprintf("%s >= ? AND %s < ?", field, field), []any{from, to}, nil
+		}
 		if hasTimeFrom && hasTimeTo {
 			// Both dates have time components, use exact timestamps
-			return fmt.Sprintf("%s >= ? AND %s <= ?", field, field), []any{rangeVal.From, rangeVal.To}
+			return fmt.Sprintf("%s %s ? AND %s %s ?", field, fromOp, field, toOp), []any{rangeVal.From, rangeVal.To}, nil
 		} else {
-			// Date-only range: include entire days from start of From day to end of To day
-			startOfFromDay := time.Date(rangeVal.From.Year(), rangeVal.From.Month(), rangeVal.From.Day(), 0, 0, 0, 0, rangeVal.From.Location())
-			endOfToDay := time.Date(rangeVal.To.Year(), rangeVal.To.Month(), rangeVal.To.Day(), 23, 59, 59, 999999999, rangeVal.To.Location())
-			return fmt.Sprintf("%s >= ? AND %s <= ?", field, field), []any{startOfFromDay, endOfToDay}
-		}
-	}
-	return "", nil
-}
-
-// buildTimeCondition builds SQL condition for time filters
-func (f *Handler[T]) buildTimeCondition(field string, mode Mode, value any) (string, []any) {
-	switch mode {
-	case ModeEqual:
-		t, err := parseTime(value)
-		if err != nil {
-			return "", nil
+			// Date-only range: include entire days from start of From day to end of To day.
+			// An exclusive bound leaves out its whole day: after the end of From, before the start of To.
+			from := time.Date(rangeVal.From.Year(), rangeVal.From.Month(), rangeVal.From.Day(), 0, 0, 0, 0, rangeVal.From.Location())
+			if rangeVal.FromExclusive {
+				from = time.Date(rangeVal.From.Year(), rangeVal.From.Month(), rangeVal.From.Day(), 23, 59, 59, 999999999, rangeVal.From.Location())
+			}
+			to := time.Date(rangeVal.To.Year(), rangeVal.To.Month(), rangeVal.To.Day(), 23, 59, 59, 999999999, rangeVal.To.Location())
+			if rangeVal.ToExclusive {
+				to = time.Date(rangeVal.To.Year(), rangeVal.To.Month(), rangeVal.To.Day(), 0, 0, 0, 0, rangeVal.To.Location())
+			}
+			return fmt.Sprintf("%s %s ? AND %s %s ?", field, fromOp, field, toOp), []any{from, to}, nil
 		}
-		// Format time as HH:MM:SS for SQLite TEXT comparison
-		// Use time() function to extract time from datetime columns
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) = ?", field), []any{timeStr}
-	case ModeNotEqual:
-		t, err := parseTime(value)
+	case ModeIn, ModeNotIn:
+		list, err := parseList(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) != ?", field), []any{timeStr}
-	case ModeGT:
-		t, err := parseTime(value)
-		if err != nil {
-			return "", nil
+		if len(list) == 0 {
+			condition, values := buildInCondition(field, mode, list)
+			return condition, values, nil
 		}
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) > ?", field), []any{timeStr}
-	case ModeGTE, ModeAfter:
-		t, err := parseTime(value)
-		if err != nil {
-			return "", nil
+		// Each list item uses the same semantics as ModeEqual/ModeNotEqual (whole-day match for date-only values)
+		itemMode, joiner := ModeEqual, " OR "
+		if mode == ModeNotIn {
+			itemMode, joiner = ModeNotEqual, " AND "
 		}
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) >= ?", field), []any{timeStr}
-	case ModeLT, ModeBefore:
-		t, err := parseTime(value)
-		if err != nil {
-			return "", nil
+		conditions := make([]string, 0, len(list))
+		values := make([]any, 0, len(list)*2)
+		for _, item := range list {
+			condition, itemValues, err := f.buildDateCondition(field, itemMode, item)
+			if err != nil {
+				return "", nil, err
+			}
+			conditions = append(conditions, condition)
+			values = append(values, itemValues...)
 		}
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) < ?", field), []any{timeStr}
-	case ModeLTE:
+		return "(" + strings.Join(conditions, joiner) + ")", values, nil
+	}
+	return "", nil, nil
+}
+
+// buildTimeCondition builds SQL condition for time-of-day filters, comparing the time of day of the
+// column (see timeOfDayExpr) to "HH:MM:SS" values. On datetime columns (time.Time fields) the zero time
+// is no value rather than midnight, as in memory (see compileTime), so it only matches ModeNotEqual.
+func (f *Handler[T]) buildTimeCondition(d sqlDialect, field string, mode Mode, value any, datetime bool) (string, []any, error) {
+	expr := timeOfDayExpr(d, field)
+	var condition string
+	var values []any
+	switch mode {
+	case ModeEqual, ModeNotEqual, ModeGT, ModeGTE, ModeAfter, ModeLT, ModeBefore, ModeLTE:
 		t, err := parseTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
-		timeStr := t.Format("15:04:05")
-		return fmt.Sprintf("time(%s) <= ?", field), []any{timeStr}
+		condition, values = fmt.Sprintf("%s %s ?", expr, timeOperators[mode]), []any{t.Format("15:04:05")}
 	case ModeRange:
 		rangeVal, err := parseRangeTime(value)
 		if err != nil {
-			return "", nil
+			return "", nil, err
 		}
 		fromStr := rangeVal.From.Format("15:04:05")
 		toStr := rangeVal.To.Format("15:04:05")
-		return fmt.Sprintf("time(%s) BETWEEN ? AND ?", field), []any{fromStr, toStr}
+		if rangeVal.FromExclusive || rangeVal.ToExclusive {
+			fromOp, toOp := rangeOperators(rangeVal.FromExclusive, rangeVal.ToExclusive)
+			condition = fmt.Sprintf("%s %s ? AND %s %s ?", expr, fromOp, expr, toOp)
+		} else {
+			condition = fmt.Sprintf("%s BETWEEN ? AND ?", expr)
+		}
+		values = []any{fromStr, toStr}
+	default:
+		return "", nil, nil
+	}
+	if !datetime {
+		return condition, values, nil
 	}
-	return "", nil
+	if mode == ModeNotEqual {
+		return fmt.Sprintf("(%s = ? OR %s)", field, condition), append([]any{time.Time{}}, values...), nil
+	}
+	return fmt.Sprintf("(%s != ? AND %s)", field, condition), append([]any{time.Time{}}, values...), nil
+}
+
+// useILike reports whether case-insensitive pattern filters are written with ILIKE on the dialect d
+func (f *Handler[T]) useILike(d sqlDialect) bool {
+	switch f.textMatch {
+	case TextMatchLower:
+		return false
+	case TextMatchILike:
+		return true
+	}
+	return d.name == "postgres"
 }
 
-// autoJoinRelatedTables automatically joins related tables when filters or sort fields reference nested fields
-func (f *Handler[T]) autoJoinRelatedTables(db *gorm.DB, filters []FieldFilter, sortFields []SortField) *gorm.DB {
+// timeOperators maps the comparison modes of time filters to their SQL operator.
+// Unlike CompareField filters, ModeAfter includes its bound, as ModeGTE does.
+var timeOperators = map[Mode]string{
+	ModeEqual:    "=",
+	ModeNotEqual: "!=",
+	ModeGT:       ">",
+	ModeGTE:      ">=",
+	ModeAfter:    ">=",
+	ModeLT:       "<",
+	ModeBefore:   "<",
+	ModeLTE:      "<=",
+}
+
+// timeOfDayExpr returns the expression extracting the time of day of column for the dialect d:
+// TIME(col) on MySQL, CAST(col AS TIME) on PostgreSQL and SQL Server, and time(substr(col, 1, 19)) on
+// SQLite, where the substr drops the "+08:00" offset the driver writes after the wall clock so time()
+// does not convert it to UTC, matching the in-memory comparison (see timeOfDay).
+// Each also accepts text columns holding "HH:MM:SS", so such columns compare like time columns.
+func timeOfDayExpr(d sqlDialect, column string) string {
+	switch d.name {
+	case "mysql":
+		return fmt.Sprintf("TIME(%s)", column)
+	case "postgres", "sqlserver":
+		return fmt.Sprintf("CAST(%s AS TIME)", column)
+	}
+	return fmt.Sprintf("time(substr(%s, 1, 19))", column)
+}
+
+// autoJoinRelatedTables automatically joins related tables when filters, sort fields, or selected fields reference nested fields.
+// Keys of map fields ("attributes.color") are read from T's own JSON column and join nothing.
+func (f *Handler[T]) autoJoinRelatedTables(db *gorm.DB, filters []FieldFilter, sortFields []SortField, selectFields ...string) *gorm.DB {
+	d := dialectOf(db)
 	joinedTables := make(map[string]bool)
 
 	// Check filters for nested fields
 	for _, filter := range filters {
 		// For GORM operations, allow nested fields even if they're not in getters map
 		// GORM can handle nested relations through auto-joins
-		if strings.Contains(filter.Field, ".") {
+		// Filters on to-many relations are EXISTS subqueries rather than joins
+		if strings.Contains(filter.Field, ".") && !f.isMapKey(filter.Field) && !f.isToManyField(d, filter.Field) {
 			parts := strings.Split(filter.Field, ".")
 			if len(parts) >= 2 {
-				// Convert snake_case/lowercase to PascalCase (e.g., "member_profile" -> "MemberProfile")
-				tableName := f.toPascalCase(parts[0])
+				// Resolve the relation's Go field name (e.g., "member_profile" -> "MemberProfile")
+				tableName := f.relationAlias(d, parts[0])
 				if !joinedTables[tableName] {
 					// GORM will auto-join based on the relationship
-					db = db.Joins(tableName)
+					db = f.joinRelation(db, tableName)
 					joinedTables[tableName] = true
 				}
 			}
@@ -1034,19 +1932,30 @@ func (f *Handler[T]) autoJoinRelatedTables(db *gorm.DB, filters []FieldFilter, s
 	for _, sortField := range sortFields {
 		// For GORM operations, allow nested fields even if they're not in getters map
 		// GORM can handle nested relations through auto-joins
-		if strings.Contains(sortField.Field, ".") {
+		if strings.Contains(sortField.Field, ".") && !f.isMapKey(sortField.Field) {
 			parts := strings.Split(sortField.Field, ".")
 			if len(parts) >= 2 {
-				// Convert snake_case/lowercase to PascalCase
-				tableName := f.toPascalCase(parts[0])
+				// Resolve the relation's Go field name
+				tableName := f.relationAlias(d, parts[0])
 				if !joinedTables[tableName] {
 					// GORM will auto-join based on the relationship
-					db = db.Joins(tableName)
+					db = f.joinRelation(db, tableName)
 					joinedTables[tableName] = true
 				}
 			}
 		}
 	}
 
+	// Check selected fields for nested fields
+	for _, selectField := range selectFields {
+		if strings.Contains(selectField, ".") && !f.isMapKey(selectField) {
+			tableName := f.relationAlias(d, strings.Split(selectField, ".")[0])
+			if !joinedTables[tableName] {
+				db = f.joinRelation(db, tableName)
+				joinedTables[tableName] = true
+			}
+		}
+	}
+
 	return db
 }
@@ -1,12 +1,19 @@
 package filter
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	sanitizepkg "github.com/kennygrant/sanitize"
+	"gorm.io/gorm/schema"
 )
 
 var dateTimeLayouts = []string{
@@ -52,7 +59,8 @@ var timeLayouts = []string{
 }
 
 func parseNumber(value any) (float64, error) {
-	// Handle nil values from nested pointers
+	// Handle nil values from nested pointers and nullable (pointer) columns
+	value = derefValue(value)
 	if value == nil {
 		return 0, nil
 	}
@@ -72,26 +80,105 @@ func parseNumber(value any) (float64, error) {
 		num = float64(v)
 	case int32:
 		num = float64(v)
+	case uint32:
+		num = float64(v)
+	case uint64:
+		num = float64(v)
 	case int64:
 		num = float64(v)
 	case float32:
 		num = float64(v)
 	case float64:
 		num = v
+	case json.Number:
+		return parseNumericString(string(v))
+	case string:
+		// Frontends and map[string]any round-trips often send numbers as strings
+		return parseNumericString(v)
 	default:
-		return 0, fmt.Errorf("invalid number type for field %s", value)
+		return parseCustomNumber(value)
+	}
+	return num, nil
+}
+
+// parseCustomNumber parses named number types (type Priority int), and types such as decimal.Decimal
+// whose driver.Valuer value or String() is a number
+func parseCustomNumber(value any) (float64, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err == nil && v != nil {
+			if _, nested := v.(driver.Valuer); !nested {
+				return parseNumber(v)
+			}
+		}
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		if num, err := parseNumericString(stringer.String()); err == nil {
+			return num, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid number type for field %s", value)
+}
+
+// parseNumericString parses a decimal string such as "42", "42.5", or "-3"
+func parseNumericString(value string) (float64, error) {
+	num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(num) || math.IsInf(num, 0) {
+		return 0, fmt.Errorf("invalid number %q", value)
 	}
 	return num, nil
 }
 
+// formatNumberText returns the decimal text of a number, without exponent or trailing zeros, as
+// CAST(col AS TEXT) gives for integer columns. Strings, such as a partial reference number, are returned unchanged.
+func formatNumberText(value any) (string, error) {
+	switch v := derefValue(value).(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	rv := reflect.ValueOf(derefValue(value))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	num, err := parseNumber(value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(num, 'f', -1, 64), nil
+}
+
 func parseText(value any) (string, error) {
-	// Handle nil values from nested pointers
+	// Handle nil values from nested pointers and nullable (pointer) columns
+	value = derefValue(value)
 	if value == nil {
 		return "", nil
 	}
 	str, ok := value.(string)
 	if !ok {
-		return "", fmt.Errorf("invalid text type for field %s", value)
+		// Named string types such as type Status string
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.String {
+			return "", fmt.Errorf("invalid text type for field %s", value)
+		}
+		str = rv.String()
 	}
 	// Don't sanitize - GORM's parameterized queries handle SQL injection protection
 	// Sanitizing converts spaces to hyphens which breaks text searches
@@ -99,7 +186,8 @@ func parseText(value any) (string, error) {
 }
 
 func parseTime(value any) (time.Time, error) {
-	// Handle nil values from nested pointers
+	// Handle nil values from nested pointers and nullable (pointer) columns
+	value = derefValue(value)
 	if value == nil {
 		return time.Time{}, nil
 	}
@@ -144,13 +232,24 @@ func parseTime(value any) (time.Time, error) {
 		}
 	}
 
-	// Normalize to time-only in UTC
-	timeOnly := time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
-	return timeOnly, nil
+	return timeOfDay(t), nil
+}
+
+// timeOfDay returns the wall-clock time of day of t, read in t's own zone, on 0000-01-01 UTC. Every
+// DataTypeTime value goes through it, so 09:30 in Local, UTC or +08:00 compare equal whatever the zone
+// or monotonic clock reading of the struct value or filter value, and Equal never compares instants.
+func timeOfDay(t time.Time) time.Time {
+	return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
 }
 
 func parseDateTime(value any) (time.Time, error) {
-	// Handle nil values from nested pointers
+	return parseDateTimeIn(value, time.UTC)
+}
+
+// parseDateTimeIn is parseDateTime with strings that carry no zone (e.g. "2025-11-03") interpreted in loc
+func parseDateTimeIn(value any, loc *time.Location) (time.Time, error) {
+	// Handle nil values from nested pointers and nullable (pointer) columns
+	value = derefValue(value)
 	if value == nil {
 		return time.Time{}, nil
 	}
@@ -159,7 +258,7 @@ func parseDateTime(value any) (time.Time, error) {
 		return v, nil
 	case string:
 		for _, layout := range dateTimeLayouts {
-			t, err := time.Parse(layout, v)
+			t, err := time.ParseInLocation(layout, v, loc)
 			if err == nil {
 				return t, nil
 			}
@@ -170,22 +269,64 @@ func parseDateTime(value any) (time.Time, error) {
 	}
 }
 
-func parseRangeNumber(value any) (RangeNumber, error) {
-	var rng Range
-
-	// Handle struct type (when used directly in Go code)
-	if r, ok := value.(Range); ok {
-		rng = r
-	} else if m, ok := value.(map[string]interface{}); ok {
-		// Handle map type (when parsed from JSON)
-		fromVal, hasFrom := m["from"]
-		toVal, hasTo := m["to"]
+// invertedRangeError reports a range whose From is after its To. Unlike other invalid values it is
+// returned by the GORM path even without StrictValidation, so DataQuery and DataGorm fail alike
+// instead of the database path silently dropping the condition.
+type invertedRangeError struct {
+	kind string // "date" or "time", or "text", "number" or "duration" from RootBuilder
+}
+
+func (e invertedRangeError) Error() string {
+	return fmt.Sprintf("range from %s cannot be after to %s", e.kind, e.kind)
+}
+
+// isInvertedRange reports whether err is or wraps an invertedRangeError
+func isInvertedRange(err error) bool {
+	var inverted invertedRangeError
+	return errors.As(err, &inverted)
+}
+
+// toRange converts a Range, or a range object decoded from JSON, into a Range
+func toRange(value any) (Range, error) {
+	switch v := value.(type) {
+	case Range:
+		return v, nil
+	case map[string]any:
+		fromVal, hasFrom := v["from"]
+		toVal, hasTo := v["to"]
 		if !hasFrom || !hasTo {
-			return RangeNumber{}, fmt.Errorf("range must have both 'from' and 'to' fields")
+			return Range{}, rangeError("range must have both 'from' and 'to' fields")
 		}
-		rng = Range{From: fromVal, To: toVal}
-	} else {
-		return RangeNumber{}, fmt.Errorf("invalid range type for field %v (type: %T)", value, value)
+		fromExclusive, err := rangeFlag(v, "fromExclusive")
+		if err != nil {
+			return Range{}, err
+		}
+		toExclusive, err := rangeFlag(v, "toExclusive")
+		if err != nil {
+			return Range{}, err
+		}
+		return Range{From: fromVal, To: toVal, FromExclusive: fromExclusive, ToExclusive: toExclusive}, nil
+	}
+	return Range{}, rangeError("invalid range type for field %v (type: %T)", value, value)
+}
+
+// rangeFlag reads an optional boolean key of a range object
+func rangeFlag(m map[string]any, key string) (bool, error) {
+	raw, ok := m[key]
+	if !ok || raw == nil {
+		return false, nil
+	}
+	b, ok := raw.(bool)
+	if !ok {
+		return false, rangeError("range '%s' must be a boolean (type: %T)", key, raw)
+	}
+	return b, nil
+}
+
+func parseRangeNumber(value any) (RangeNumber, error) {
+	rng, err := toRange(value)
+	if err != nil {
+		return RangeNumber{}, err
 	}
 	from, err := parseNumber(rng.From)
 	if err != nil {
@@ -196,27 +337,38 @@ func parseRangeNumber(value any) (RangeNumber, error) {
 		return RangeNumber{}, err
 	}
 	return RangeNumber{
-		From: from,
-		To:   to,
+		From:          from,
+		To:            to,
+		FromExclusive: rng.FromExclusive,
+		ToExclusive:   rng.ToExclusive,
+	}, nil
+}
+
+func parseRangeText(value any) (RangeText, error) {
+	rng, err := toRange(value)
+	if err != nil {
+		return RangeText{}, err
+	}
+	from, err := parseText(rng.From)
+	if err != nil {
+		return RangeText{}, err
+	}
+	to, err := parseText(rng.To)
+	if err != nil {
+		return RangeText{}, err
+	}
+	return RangeText{
+		From:          from,
+		To:            to,
+		FromExclusive: rng.FromExclusive,
+		ToExclusive:   rng.ToExclusive,
 	}, nil
 }
 
 func parseRangeDateTime(value any) (RangeDate, error) {
-	var rng Range
-
-	// Handle struct type (when used directly in Go code)
-	if r, ok := value.(Range); ok {
-		rng = r
-	} else if m, ok := value.(map[string]interface{}); ok {
-		// Handle map type (when parsed from JSON)
-		fromVal, hasFrom := m["from"]
-		toVal, hasTo := m["to"]
-		if !hasFrom || !hasTo {
-			return RangeDate{}, fmt.Errorf("range must have both 'from' and 'to' fields")
-		}
-		rng = Range{From: fromVal, To: toVal}
-	} else {
-		return RangeDate{}, fmt.Errorf("invalid range type for field %v (type: %T)", value, value)
+	rng, err := toRange(value)
+	if err != nil {
+		return RangeDate{}, err
 	}
 	from, err := parseDateTime(rng.From)
 	if err != nil {
@@ -227,30 +379,20 @@ func parseRangeDateTime(value any) (RangeDate, error) {
 		return RangeDate{}, err
 	}
 	if from.After(to) {
-		return RangeDate{}, fmt.Errorf("range from date cannot be after to date")
+		return RangeDate{}, &InvalidRangeError{Err: invertedRangeError{kind: "date"}}
 	}
 	return RangeDate{
-		From: from,
-		To:   to,
+		From:          from,
+		To:            to,
+		FromExclusive: rng.FromExclusive,
+		ToExclusive:   rng.ToExclusive,
 	}, nil
 }
 
 func parseRangeTime(value any) (RangeDate, error) {
-	var rng Range
-
-	// Handle struct type (when used directly in Go code)
-	if r, ok := value.(Range); ok {
-		rng = r
-	} else if m, ok := value.(map[string]interface{}); ok {
-		// Handle map type (when parsed from JSON)
-		fromVal, hasFrom := m["from"]
-		toVal, hasTo := m["to"]
-		if !hasFrom || !hasTo {
-			return RangeDate{}, fmt.Errorf("range must have both 'from' and 'to' fields")
-		}
-		rng = Range{From: fromVal, To: toVal}
-	} else {
-		return RangeDate{}, fmt.Errorf("invalid range type for field %v (type: %T)", value, value)
+	rng, err := toRange(value)
+	if err != nil {
+		return RangeDate{}, err
 	}
 	from, err := parseTime(rng.From)
 	if err != nil {
@@ -263,25 +405,247 @@ func parseRangeTime(value any) (RangeDate, error) {
 
 	// Validate that from <= to
 	if from.After(to) {
-		return RangeDate{}, fmt.Errorf("range from time cannot be after to time")
+		return RangeDate{}, &InvalidRangeError{Err: invertedRangeError{kind: "time"}}
 	}
 
 	return RangeDate{
-		From: from,
-		To:   to,
+		From:          from,
+		To:            to,
+		FromExclusive: rng.FromExclusive,
+		ToExclusive:   rng.ToExclusive,
 	}, nil
 }
 
+// parseList converts a list filter value ([]any, []string, []float64, JSON array, etc.) into []any
+func parseList(value any) ([]any, error) {
+	if value == nil {
+		return nil, fmt.Errorf("list value cannot be nil")
+	}
+	if list, ok := value.([]any); ok {
+		return list, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("invalid list type %T: %v", value, value)
+	}
+	list := make([]any, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, nil
+}
+
+// parseUUID converts a UUID filter or field value into its 16 bytes. It accepts any [16]byte type
+// (including uuid.UUID), strings in canonical, braced, URN or unhyphenated form, fmt.Stringer values
+// and pointers to those.
+func parseUUID(value any) ([16]byte, error) {
+	var id [16]byte
+	if value == nil {
+		return id, fmt.Errorf("uuid value cannot be nil")
+	}
+	switch v := value.(type) {
+	case [16]byte:
+		return v, nil
+	case string:
+		return parseUUIDString(v)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return id, fmt.Errorf("uuid value cannot be nil")
+		}
+		rv = rv.Elem()
+	}
+	switch {
+	case rv.Kind() == reflect.Array && rv.Len() == 16 && rv.Type().Elem().Kind() == reflect.Uint8:
+		reflect.Copy(reflect.ValueOf(id[:]), rv)
+		return id, nil
+	case rv.Kind() == reflect.String:
+		return parseUUIDString(rv.String())
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return parseUUIDString(stringer.String())
+	}
+	return id, fmt.Errorf("invalid uuid type %T", value)
+}
+
+// parseUUIDString parses "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", optionally wrapped in braces
+// or prefixed with "urn:uuid:", or the same 32 hex digits without hyphens
+func parseUUIDString(s string) ([16]byte, error) {
+	var id [16]byte
+	text := strings.TrimSpace(s)
+	if len(text) >= 9 && strings.EqualFold(text[:9], "urn:uuid:") {
+		text = text[9:]
+	} else if len(text) == 38 && text[0] == '{' && text[37] == '}' {
+		text = text[1:37]
+	}
+	switch len(text) {
+	case 36:
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return id, fmt.Errorf("invalid uuid %q", s)
+		}
+		text = text[:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	case 32:
+	default:
+		return id, fmt.Errorf("invalid uuid %q", s)
+	}
+	for i := range id {
+		b, err := strconv.ParseUint(text[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return id, fmt.Errorf("invalid uuid %q", s)
+		}
+		id[i] = byte(b)
+	}
+	return id, nil
+}
+
+// formatUUID returns the canonical lowercase hyphenated form of a UUID
+func formatUUID(id [16]byte) string {
+	const hex = "0123456789abcdef"
+	buf := make([]byte, 0, 36)
+	for i, b := range id {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			buf = append(buf, '-')
+		}
+		buf = append(buf, hex[b>>4], hex[b&0x0f])
+	}
+	return string(buf)
+}
+
 func parseBool(value any) (bool, error) {
-	// Handle nil values from nested pointers
+	// Handle nil values from nested pointers and nullable (pointer) columns
+	value = derefValue(value)
 	if value == nil {
 		return false, nil
 	}
-	b, ok := value.(bool)
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		// Query strings and JSON payloads often carry booleans as text
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1", "yes":
+			return true, nil
+		case "false", "0", "no":
+			return false, nil
+		}
+		return false, fmt.Errorf("invalid boolean value %q", v)
+	}
+	// Numeric 0/1 (including json.Number)
+	if num, err := parseNumber(value); err == nil {
+		switch num {
+		case 1:
+			return true, nil
+		case 0:
+			return false, nil
+		}
+		return false, fmt.Errorf("invalid boolean value %v", value)
+	}
+	return false, fmt.Errorf("invalid boolean type for field %s", value)
+}
+
+// missingValue is returned by nested getters when a pointer on the path to the field is nil.
+// It is distinct from a nil leaf value so a row without a parent record (e.g. Department == nil)
+// only matches ModeIsEmpty and the negative modes on "department.name", mirroring LEFT JOIN NULLs in SQL.
+type missingValue struct{}
+
+// String prints missing nested values like nil values
+func (missingValue) String() string {
+	return "<nil>"
+}
+
+// isMissing reports whether value came from a nested getter whose parent pointer is nil
+func isMissing(value any) bool {
+	_, ok := value.(missingValue)
+	return ok
+}
+
+// manyValues is returned by getters of fields under a slice of structs (e.g. "items.sku" on Items []OrderItem),
+// holding the field of every element. A filter matches when any element matches, and an empty slice
+// behaves like a nil parent, mirroring the LEFT JOIN of a to-many relation in SQL.
+type manyValues []any
+
+// sortValue reduces a manyValues to the single value it sorts by: the smallest ascending and the
+// largest descending, like MIN/MAX in DataGorm. Empty slices sort as missing; other values are unchanged.
+func sortValue(value any, order SortOrder, compareText textCompare) any {
+	values, ok := value.(manyValues)
 	if !ok {
-		return false, fmt.Errorf("invalid boolean type for field %s", value)
+		return value
 	}
-	return b, nil
+	if len(values) == 0 {
+		return missingValue{}
+	}
+	best := values[0]
+	for _, v := range values[1:] {
+		cmp := compareValues(v, best, compareText)
+		if order == SortOrderDesc {
+			cmp = -cmp
+		}
+		if cmp < 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+// sortKey dereferences a pointer so nullable columns sort by their value; a nil pointer sorts as missing
+func sortKey(value any) any {
+	if value == nil {
+		return missingValue{}
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer {
+		return value
+	}
+	if rv.IsNil() {
+		return missingValue{}
+	}
+	return rv.Elem().Interface()
+}
+
+// isNullSortKey reports whether a sort key belongs to the NULL bucket of NullsFirst and NullsLast:
+// a missing value (nil pointer or nil parent) or the zero time
+func isNullSortKey(value any) bool {
+	if t, ok := value.(time.Time); ok {
+		return t.IsZero()
+	}
+	return isMissing(value)
+}
+
+// derefValue returns the value a non-nil pointer points to and nil for a nil pointer, so nullable
+// columns such as *string or *int parse like their element type. Other values are returned unchanged.
+func derefValue(value any) any {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer {
+		return value
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	return rv.Elem().Interface()
+}
+
+// isNilValue reports whether value is nil or a nil pointer (e.g. an unset nullable column)
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// isEmptyDate reports whether a date value is nil, a nil pointer, or the zero time
+func isEmptyDate(value any) bool {
+	if isNilValue(value) {
+		return true
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v.IsZero()
+	case *time.Time:
+		return v.IsZero()
+	}
+	return false
 }
 
 func hasTimeComponent(t time.Time) bool {
@@ -291,11 +655,36 @@ func hasTimeComponent(t time.Time) bool {
 	return true
 }
 
-func compareValues(a, b any) int {
+// compareValues orders a and b for sorting, comparing strings with compareText
+func compareValues(a, b any, compareText textCompare) int {
+	// Nullable (pointer) columns compare by value; nil pointers and missing nested values
+	// sort first, like NULLs in ascending SQL order
+	a, b = sortKey(a), sortKey(b)
+	if missingA, missingB := isMissing(a), isMissing(b); missingA || missingB {
+		if missingA && missingB {
+			return 0
+		}
+		if missingA {
+			return -1
+		}
+		return 1
+	}
+
 	// Try to parse both values to standardized types
+	// Strings are never compared numerically so text fields keep lexical order
+	_, isStringA := a.(string)
+	_, isStringB := b.(string)
+	// Integers compare exactly, so IDs above 2^53 that share a float64 keep their order
+	if !isStringA && !isStringB {
+		intA, okA := parseInteger(a)
+		intB, okB := parseInteger(b)
+		if okA && okB {
+			return intA.compare(intB)
+		}
+	}
 	numA, errA := parseNumber(a)
 	numB, errB := parseNumber(b)
-	if errA == nil && errB == nil {
+	if errA == nil && errB == nil && !isStringA && !isStringB {
 		if numA < numB {
 			return -1
 		} else if numA > numB {
@@ -307,7 +696,7 @@ func compareValues(a, b any) int {
 	strA, errA := parseText(a)
 	strB, errB := parseText(b)
 	if errA == nil && errB == nil {
-		return strings.Compare(strA, strB)
+		return compareText(strA, strB)
 	}
 
 	boolA, errA := parseBool(a)
@@ -350,64 +739,161 @@ func compareValues(a, b any) int {
 	return 0
 }
 
-// generateGetters automatically generates field getters using reflection
-func generateGetters[T any](maxDepth int) map[string]func(*T) any {
+// generateGetters automatically generates the getters of the top-level fields of T using reflection.
+// The getters of nested fields are left to generateNestedFieldGetters, for the struct and slice fields
+// it returns keyed by their getter key, when maxDepth > 1.
+func generateGetters[T any](maxDepth int) (map[string]func(*T) any, map[string]reflect.StructField) {
 	var zero T
 	t := reflect.TypeOf(zero)
 	if t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
 	getters := make(map[string]func(*T) any)
+	nested := make(map[string]reflect.StructField)
 	if t.Kind() != reflect.Struct {
-		return getters
+		return getters, nested
 	}
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if !field.IsExported() {
-			continue
-		}
-		fieldName := field.Name
-		key := fieldName
-		if jsonTag := field.Tag.Get("json"); jsonTag != "" {
-			tagValue := strings.Split(jsonTag, ",")[0]
-			if tagValue != "" && tagValue != "-" {
-				key = tagValue
-			}
-		}
-		lowerKey := strings.ToLower(fieldName)
-		fieldIndex := i
+	for _, field := range visibleFields(t) {
+		keys := fieldKeys(field)
+		key := keys[0]
+		fieldIndex := field.Index
 		getter := func(v *T) any {
 			val := reflect.ValueOf(v)
 			if val.Kind() == reflect.Pointer {
 				val = val.Elem()
 			}
-			return val.Field(fieldIndex).Interface()
+			fieldVal, ok := fieldByIndex(val, fieldIndex)
+			if !ok {
+				return missingValue{}
+			}
+			return fieldVal.Interface()
 		}
 
-		getters[key] = getter
-		if key != lowerKey {
-			getters[lowerKey] = getter
+		for _, k := range keys {
+			getters[k] = getter
 		}
 
-		// Handle nested structs (both direct and pointer types)
-		// Use configurable depth limit to avoid circular references
 		fieldType := field.Type
 		if fieldType.Kind() == reflect.Pointer {
 			fieldType = fieldType.Elem()
 		}
-		if fieldType.Kind() == reflect.Struct && maxDepth > 1 {
-			generateNestedGetters(getters, field, fieldIndex, key, field.Type.Kind() == reflect.Pointer, 1, maxDepth)
+		if _, ok := sliceElemStruct(field.Type); (ok || fieldType.Kind() == reflect.Struct) && maxDepth > 1 {
+			nested[key] = field
+		}
+	}
+
+	return getters, nested
+}
+
+// generateNestedFieldGetters adds the getters of the fields nested under field, a top-level field of T
+// with getter key key, to getters
+func generateNestedFieldGetters[T any](getters map[string]func(*T) any, field reflect.StructField, key string, maxDepth int) {
+	// Handle nested structs (both direct and pointer types)
+	// Use configurable depth limit to avoid circular references
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.Struct {
+		generateNestedGetters(getters, field, field.Index, key, field.Type.Kind() == reflect.Pointer, 1, maxDepth)
+	}
+	// Handle slices of structs (has-many and many2many relations), one level deep
+	if elemType, ok := sliceElemStruct(field.Type); ok {
+		generateSliceGetters(getters, elemType, field.Index, key)
+	}
+}
+
+// visibleFields returns the exported fields of t with their index paths. Like encoding/json and GORM,
+// the fields of an embedded struct (or struct pointer) without a json name are promoted to the top level,
+// and a field of the outer struct hides a promoted field with the same name.
+func visibleFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embeddedType, ok := promotedEmbed(field); ok {
+			for _, promoted := range visibleFields(embeddedType) {
+				promoted.Index = append([]int{i}, promoted.Index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		}
+		if field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+
+	// Keep the shallowest field of each name, as Go does
+	depth := make(map[string]int, len(fields))
+	for _, field := range fields {
+		if d, exists := depth[field.Name]; !exists || len(field.Index) < d {
+			depth[field.Name] = len(field.Index)
+		}
+	}
+	visible := fields[:0]
+	for _, field := range fields {
+		if len(field.Index) == depth[field.Name] {
+			visible = append(visible, field)
+		}
+	}
+	return visible
+}
+
+// promotedEmbed returns the struct type of an exported embedded field whose fields are promoted
+func promotedEmbed(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || !field.IsExported() {
+		return nil, false
+	}
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return nil, false
+	}
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct || fieldType == reflect.TypeOf(time.Time{}) {
+		return nil, false
+	}
+	return fieldType, true
+}
+
+// fieldKeys returns the getter keys of a field: its json name (or Go name) and lowercase Go name.
+// A promoted field without a json name also gets GORM's column name (e.g. "created_at" for gorm.Model.CreatedAt).
+func fieldKeys(field reflect.StructField) []string {
+	key := field.Name
+	hasJSONName := false
+	if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+		tagValue := strings.Split(jsonTag, ",")[0]
+		if tagValue != "" && tagValue != "-" {
+			key = tagValue
+			hasJSONName = true
+		}
+	}
+	keys := []string{key}
+	if lowerKey := strings.ToLower(field.Name); lowerKey != key {
+		keys = append(keys, lowerKey)
+	}
+	if len(field.Index) > 1 && !hasJSONName {
+		if column := (schema.NamingStrategy{}).ColumnName("", field.Name); !slices.Contains(keys, column) {
+			keys = append(keys, column)
 		}
 	}
+	return keys
+}
 
-	return getters
+// fieldByIndex returns the (possibly promoted) field at index, reporting false when an embedded pointer is nil
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	if len(index) == 1 {
+		return val.Field(index[0]), true
+	}
+	fieldVal, err := val.FieldByIndexErr(index)
+	return fieldVal, err == nil
 }
 
 // generateNestedGetters generates getters for nested struct fields with depth limit
 func generateNestedGetters[T any](
 	getters map[string]func(*T) any,
 	parentField reflect.StructField,
-	parentIndex int,
+	parentIndex []int,
 	parentKey string,
 	isPointer bool,
 	depth int,
@@ -451,12 +937,15 @@ func generateNestedGetters[T any](
 			if val.Kind() == reflect.Pointer {
 				val = val.Elem()
 			}
-			parentVal := val.Field(parentIndex)
+			parentVal, ok := fieldByIndex(val, parentIndex)
+			if !ok {
+				return missingValue{}
+			}
 
 			// Handle pointer to struct
 			if isPointer {
 				if parentVal.IsNil() {
-					return nil
+					return missingValue{}
 				}
 				parentVal = parentVal.Elem()
 			}
@@ -482,8 +971,74 @@ func generateNestedGetters[T any](
 	}
 }
 
+// sliceElemStruct returns the struct type of a slice's elements ([]S or []*S), excluding time.Time
+func sliceElemStruct(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Slice {
+		return nil, false
+	}
+	elemType := t.Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct || elemType == reflect.TypeOf(time.Time{}) {
+		return nil, false
+	}
+	return elemType, true
+}
+
+// generateSliceGetters generates getters for the fields of a slice's struct elements.
+// Each getter returns a manyValues with the field of every non-nil element.
+func generateSliceGetters[T any](getters map[string]func(*T) any, elemType reflect.Type, sliceIndex []int, sliceKey string) {
+	for i := 0; i < elemType.NumField(); i++ {
+		elemField := elemType.Field(i)
+		if !elemField.IsExported() {
+			continue
+		}
+
+		elemKey := elemField.Name
+		if jsonTag := elemField.Tag.Get("json"); jsonTag != "" {
+			tagValue := strings.Split(jsonTag, ",")[0]
+			if tagValue != "" && tagValue != "-" {
+				elemKey = tagValue
+			}
+		}
+
+		compositeKey := sliceKey + "." + elemKey
+		compositeLowerKey := sliceKey + "." + strings.ToLower(elemField.Name)
+
+		elemIndex := i
+		sliceGetter := func(v *T) any {
+			val := reflect.ValueOf(v)
+			if val.Kind() == reflect.Pointer {
+				val = val.Elem()
+			}
+			sliceVal, ok := fieldByIndex(val, sliceIndex)
+			if !ok {
+				return manyValues{}
+			}
+			values := make(manyValues, 0, sliceVal.Len())
+			for j := 0; j < sliceVal.Len(); j++ {
+				elem := sliceVal.Index(j)
+				if elem.Kind() == reflect.Pointer {
+					if elem.IsNil() {
+						continue
+					}
+					elem = elem.Elem()
+				}
+				values = append(values, elem.Field(elemIndex).Interface())
+			}
+			return values
+		}
+
+		getters[compositeKey] = sliceGetter
+		if compositeKey != compositeLowerKey {
+			getters[compositeLowerKey] = sliceGetter
+		}
+	}
+}
+
 // generateNestedGettersRecursive handles deeply nested struct fields with depth limit
-func generateNestedGettersRecursive[T any](getters map[string]func(*T) any, parentField reflect.StructField, rootIndex, parentIndex int, parentKey string, rootIsPointer, parentIsPointer bool, depth int, maxDepth int) {
+func generateNestedGettersRecursive[T any](getters map[string]func(*T) any, parentField reflect.StructField, rootIndex []int, parentIndex int, parentKey string, rootIsPointer, parentIsPointer bool, depth int, maxDepth int) {
 	if depth > maxDepth {
 		return // Stop at maximum depth
 	}
@@ -521,10 +1076,13 @@ func generateNestedGettersRecursive[T any](getters map[string]func(*T) any, pare
 			}
 
 			// Navigate to root parent
-			rootVal := val.Field(rootIndex)
+			rootVal, ok := fieldByIndex(val, rootIndex)
+			if !ok {
+				return missingValue{}
+			}
 			if rootIsPointer {
 				if rootVal.IsNil() {
-					return nil
+					return missingValue{}
 				}
 				rootVal = rootVal.Elem()
 			}
@@ -533,7 +1091,7 @@ func generateNestedGettersRecursive[T any](getters map[string]func(*T) any, pare
 			parentVal := rootVal.Field(parentIndex)
 			if parentIsPointer {
 				if parentVal.IsNil() {
-					return nil
+					return missingValue{}
 				}
 				parentVal = parentVal.Elem()
 			}
@@ -548,6 +1106,266 @@ func generateNestedGettersRecursive[T any](getters map[string]func(*T) any, pare
 	}
 }
 
+// generateColumnMappings collects `filter:"column:<name>"` struct tags, keyed like the getters
+func generateColumnMappings[T any](maxDepth int) map[string]string {
+	columns := make(map[string]string)
+	walkFields[T](maxDepth, func(keys []string, _ string, field reflect.StructField) {
+		if column := parseColumnTag(field.Tag.Get("filter")); column != "" {
+			for _, key := range keys {
+				columns[key] = column
+			}
+		}
+	})
+	return columns
+}
+
+// generateFieldPaths maps every getter key to its Go field path (e.g. "tax_id" and "taxid" -> "TaxID"),
+// so aliases of the same field can be recognized
+func generateFieldPaths[T any](maxDepth int) map[string]string {
+	paths := make(map[string]string)
+	walkFields[T](maxDepth, func(keys []string, path string, _ reflect.StructField) {
+		for _, key := range keys {
+			paths[key] = path
+		}
+	})
+	return paths
+}
+
+// generateTextFields returns the getter keys of the top-level string fields of T, in declaration order
+func generateTextFields[T any]() []string {
+	var fields []string
+	walkFields[T](1, func(keys []string, _ string, field reflect.StructField) {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.String {
+			fields = append(fields, keys[0])
+		}
+	})
+	return fields
+}
+
+// generateRelationFields returns the getter keys (aliases included) of the relation fields of T:
+// structs and slices of structs whose values have no text form, which the default exports leave out
+// in favor of their nested columns
+func generateRelationFields[T any](maxDepth int) map[string]bool {
+	relations := make(map[string]bool)
+	walkFields[T](maxDepth, func(keys []string, _ string, field reflect.StructField) {
+		if isRelationType(field.Type) {
+			for _, key := range keys {
+				relations[key] = true
+			}
+		}
+	})
+	return relations
+}
+
+// isRelationType reports whether t is a struct (or a slice of structs) with no text form: not a time,
+// a driver.Valuer or a fmt.Stringer, all of which export as values
+func isRelationType(t reflect.Type) bool {
+	if elemType, ok := sliceElemStruct(t); ok {
+		t = elemType
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.ConvertibleTo(timeType) {
+		return false
+	}
+	if field, ok := t.FieldByName("Time"); ok && field.Anonymous && field.Type == timeType {
+		return false
+	}
+	valuerType := reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		if candidate.Implements(valuerType) || candidate.Implements(stringerType) {
+			return false
+		}
+	}
+	return true
+}
+
+// generateDescriptors describes the fields of T that have a getter and a filterable type, in declaration order.
+// fieldGoType returns the Go type of the field at the Go field path (e.g. "Department.ID") of t, looking
+// through pointers and slices of structs on the way
+func fieldGoType(t reflect.Type, path string) (reflect.Type, bool) {
+	if path == "" {
+		return nil, false
+	}
+	for _, name := range strings.Split(path, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if elemType, ok := sliceElemStruct(t); ok {
+			t = elemType
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return nil, false
+		}
+		t = field.Type
+	}
+	return t, true
+}
+
+// walkFields visits the fields generateGetters and generateNestedFieldGetters make getters for, so none of
+// them needs to be generated.
+func generateDescriptors[T any](maxDepth int) []FieldDescriptor {
+	var zero T
+	rootType := reflect.TypeOf(zero)
+	if rootType != nil && rootType.Kind() == reflect.Pointer {
+		rootType = rootType.Elem()
+	}
+	var descriptors []FieldDescriptor
+	walkFields[T](maxDepth, func(keys []string, path string, field reflect.StructField) {
+		dataType, ok := suggestDataType(field.Type)
+		if !ok {
+			return
+		}
+		key := keys[0]
+		descriptors = append(descriptors, FieldDescriptor{
+			Name:     key[strings.LastIndex(key, ".")+1:],
+			Path:     key,
+			GoType:   field.Type.String(),
+			DataType: dataType,
+			Nested:   strings.Contains(key, "."),
+			Nullable: isNullablePath(rootType, path),
+		})
+	})
+	return descriptors
+}
+
+// suggestDataType returns the DataType matching a field's Go type, reporting false for
+// types no DataType can filter (structs, slices, maps)
+func suggestDataType(t reflect.Type) (DataType, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return DataTypeDate, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return DataTypeText, true
+	case reflect.Bool:
+		return DataTypeBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return DataTypeNumber, true
+	case reflect.Array:
+		if t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 {
+			return DataTypeUUID, true
+		}
+	}
+	return "", false
+}
+
+// isNullablePath reports whether the field at the Go field path (e.g. "Department.Name") can be nil:
+// the field is a pointer, or it is reached through a pointer (embedded or not) or a slice
+func isNullablePath(t reflect.Type, path string) bool {
+	nullable := false
+	for _, name := range strings.Split(path, ".") {
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return nullable
+		}
+		for i := 1; i < len(field.Index); i++ {
+			if t.FieldByIndex(field.Index[:i]).Type.Kind() == reflect.Pointer {
+				nullable = true
+			}
+		}
+		t = field.Type
+		if t.Kind() == reflect.Pointer {
+			nullable = true
+			t = t.Elem()
+		}
+		if elemType, ok := sliceElemStruct(t); ok {
+			nullable = true
+			t = elemType
+		}
+	}
+	return nullable
+}
+
+// walkFields visits exported struct fields of T with their getter keys and Go field path,
+// mirroring the keys and depth of generateGetters
+func walkFields[T any](maxDepth int, visit func(keys []string, path string, field reflect.StructField)) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		walkStructFields(t, "", "", 1, maxDepth, visit)
+	}
+}
+
+// walkStructFields is the recursive step of walkFields
+func walkStructFields(t reflect.Type, keyPrefix, pathPrefix string, depth int, maxDepth int, visit func(keys []string, path string, field reflect.StructField)) {
+	var fields []reflect.StructField
+	if depth == 1 {
+		// Only the root struct promotes embedded fields, like generateGetters
+		fields = visibleFields(t)
+	} else {
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.IsExported() {
+				fields = append(fields, field)
+			}
+		}
+	}
+	for _, field := range fields {
+		keys := fieldKeys(field)
+		for i := range keys {
+			keys[i] = keyPrefix + keys[i]
+		}
+		path := pathPrefix + field.Name
+		visit(keys, path, field)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		// Nested getters exist for two levels below the root once maxDepth > 1
+		if fieldType.Kind() == reflect.Struct && maxDepth > 1 && depth < 3 {
+			walkStructFields(fieldType, keys[0]+".", path+".", depth+1, maxDepth, visit)
+		}
+		// Slice element fields only have getters one level below the root
+		if elemType, ok := sliceElemStruct(field.Type); ok && maxDepth > 1 && depth == 1 {
+			walkStructFields(elemType, keys[0]+".", path+".", depth+1, 1, visit)
+		}
+	}
+}
+
+// parseColumnTag extracts the column name from a filter struct tag like "column:ref_number"
+func parseColumnTag(tag string) string {
+	for _, option := range strings.Split(tag, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(option), ":")
+		if found && strings.TrimSpace(name) == "column" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// isValidColumnName reports whether name is a plain SQL identifier safe to interpolate into queries
+func isValidColumnName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 func Sanitize(input string) string {
 	// Use kennygrant/sanitize package which handles:
 	// - HTML/XSS sanitization
@@ -568,34 +1386,77 @@ func Sanitize(input string) string {
 	return strings.TrimSpace(sanitized)
 }
 
-// fieldExists checks if a field (including nested fields) exists in the getters map
+// fieldExists checks if a field (including nested fields) exists in the getters map,
+// or is a JSON path into one that does
 func (f *Handler[T]) fieldExists(field string) bool {
 	if f.getters == nil {
 		return false
 	}
+	_, exists := f.getter(field)
+	return exists
+}
 
-	// Check direct field access
-	if _, exists := f.getters[field]; exists {
-		return true
+// withTiebreaker returns sortFields with the primary key appended in ascending order, unless it is
+// already sorted on or the tiebreaker is disabled, so records with equal sort values keep a fixed order
+// and never move between pages
+func (f *Handler[T]) withTiebreaker(sortFields []SortField) []SortField {
+	if !f.sortTiebreaker {
+		return sortFields
 	}
+	return f.withPrimaryKey(sortFields)
+}
 
-	// Check lowercase version
-	if _, exists := f.getters[strings.ToLower(field)]; exists {
-		return true
+// withPrimaryKey returns sortFields with the primary key appended in ascending order, unless it is already
+// sorted on or T has none. Unlike withTiebreaker it ignores DisableSortTiebreaker, for the keysets of
+// cursors and batches, which need a unique key.
+func (f *Handler[T]) withPrimaryKey(sortFields []SortField) []SortField {
+	if f.primaryKey == "" {
+		return sortFields
 	}
-
-	return false
+	primaryKeyID := f.fieldID(f.primaryKey)
+	for _, sortField := range sortFields {
+		if f.fieldID(sortField.Field) == primaryKeyID {
+			return sortFields
+		}
+	}
+	withKey := make([]SortField, len(sortFields), len(sortFields)+1)
+	copy(withKey, sortFields)
+	return append(withKey, SortField{Field: f.primaryKey, Order: SortOrderAsc, tiebreaker: true})
 }
 
-func (f *Handler[T]) compareItems(a, b *T, sortFields []SortField) int {
-	for _, sortField := range sortFields {
-		getter, exists := f.getters[sortField.Field]
+// compareItems orders a and b by sortFields, comparing the strings of sortFields[i] with texts[i] (see textComparers)
+func (f *Handler[T]) compareItems(a, b *T, sortFields []SortField, texts []textCompare) int {
+	for i, sortField := range sortFields {
+		if sortField.Field == RelevanceField {
+			if sortField.search == nil {
+				continue
+			}
+			// Best match first
+			if cmp := f.relevanceScore(b, sortField.search) - f.relevanceScore(a, sortField.search); cmp != 0 {
+				return cmp
+			}
+			continue
+		}
+		getter, exists := f.getter(sortField.Field)
 		if !exists {
 			continue
 		}
-		valA := getter(a)
-		valB := getter(b)
-		cmp := compareValues(valA, valB)
+		valA := sortKey(sortValue(getter(a), sortField.Order, texts[i]))
+		valB := sortKey(sortValue(getter(b), sortField.Order, texts[i]))
+		if sortField.Nulls != NullsDefault {
+			// The NULL bucket keeps its place whatever the direction
+			nullA, nullB := isNullSortKey(valA), isNullSortKey(valB)
+			if nullA != nullB {
+				if nullA == (sortField.Nulls == NullsFirst) {
+					return -1
+				}
+				return 1
+			}
+			if nullA {
+				continue
+			}
+		}
+		cmp := compareValues(valA, valB, texts[i])
 		if sortField.Order == SortOrderDesc {
 			cmp = -cmp
 		}
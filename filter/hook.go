@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Hook customizes every query of a Handler, e.g. to scope rows to a tenant or redact fields, see
+// Handler.Use. A hook implements one or more of GormHook, QueryHook and FetchHook.
+type Hook any
+
+// GormHook changes the GORM query of every database path before the filters are applied
+type GormHook interface {
+	// BeforeGorm returns db with extra conditions (e.g. a tenant WHERE); db is already scoped to the model
+	BeforeGorm(db *gorm.DB, filterRoot Root) *gorm.DB
+}
+
+// QueryHook is the in-memory analog of GormHook
+type QueryHook[T any] interface {
+	// BeforeQuery returns the items of data the query may see; data must not be modified
+	BeforeQuery(data []*T, filterRoot Root) []*T
+}
+
+// FetchHook post-processes the records a query returns or exports
+type FetchHook[T any] interface {
+	// AfterFetch returns the records to return or export in place of items. In memory, items are the
+	// caller's own records, so a hook changing fields must change copies.
+	AfterFetch(items []*T) []*T
+}
+
+// Use adds hook to the Handler; hooks run in the order they were added. GormHook runs on every
+// database path (DataGorm, DataGormNoPage, the counts, facets, cursors, exports and Hybrid's fetch),
+// QueryHook on every in-memory path, and FetchHook on the records of every query and export
+// (the page for the paginated methods, each batch for streams and ForEach), but not on the
+// records counts and facets only look at. It panics when hook implements none of them, and must
+// be called before the Handler is shared between goroutines.
+//
+// Example usage:
+//
+//	handler.Use(tenantHook{orgID: user.OrganizationID})
+func (f *Handler[T]) Use(hook Hook) *Handler[T] {
+	gormHook, isGorm := hook.(GormHook)
+	queryHook, isQuery := hook.(QueryHook[T])
+	fetchHook, isFetch := hook.(FetchHook[T])
+	if !isGorm && !isQuery && !isFetch {
+		panic(fmt.Sprintf("filter: Use with %T, which implements no hook interface", hook))
+	}
+	if isGorm {
+		f.gormHooks = append(f.gormHooks, gormHook)
+	}
+	if isQuery {
+		f.queryHooks = append(f.queryHooks, queryHook)
+	}
+	if isFetch {
+		f.fetchHooks = append(f.fetchHooks, fetchHook)
+	}
+	return f
+}
+
+// beforeGorm runs the GormHooks on db
+func (f *Handler[T]) beforeGorm(db *gorm.DB, filterRoot Root) *gorm.DB {
+	for _, hook := range f.gormHooks {
+		db = hook.BeforeGorm(db, filterRoot)
+	}
+	return db
+}
+
+// beforeQuery runs the QueryHooks on data
+func (f *Handler[T]) beforeQuery(data []*T, filterRoot Root) []*T {
+	for _, hook := range f.queryHooks {
+		data = hook.BeforeQuery(data, filterRoot)
+	}
+	return data
+}
+
+// afterFetch runs the FetchHooks on items
+func (f *Handler[T]) afterFetch(items []*T) []*T {
+	for _, hook := range f.fetchHooks {
+		items = hook.AfterFetch(items)
+	}
+	return items
+}
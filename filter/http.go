@@ -0,0 +1,130 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DefaultHybridThreshold is the Hybrid threshold of the package-level HandleList
+const DefaultHybridThreshold = 10_000
+
+// MaxListRequestBytes is the largest JSON body HandleList reads from a POST request
+const MaxListRequestBytes = 1 << 20
+
+// HandleList returns an http.HandlerFunc listing T with the Handler added with Register, see
+// Handler.HandleList. It panics when T is not registered.
+//
+// Example usage:
+//
+//	filter.Register[User](filter.GolangFilteringConfig{MaxPageSize: 500})
+//	http.Handle("GET /users", filter.HandleList[User](db))
+func HandleList[T any](db *gorm.DB) http.HandlerFunc {
+	return For[T]().HandleList(db, DefaultHybridThreshold)
+}
+
+// HandleList returns an http.HandlerFunc answering with a page of T as JSON, filtered by Hybrid on db
+// with threshold. The Root is read from the JSON body of POST requests, or else from the base64
+// "filter" query parameter (no filters when absent), and the page from the "pageIndex" (0-based) and
+// "pageSize" query parameters. An invalid Root or page answers 400 with {"error": "..."}, reporting
+// every invalid filter as ValidateRoot does, and a body over MaxListRequestBytes 413. A query failing
+// with an invalid filter error (see isRequestError) answers 400 too, and any other failed query 500
+// with a generic message, as its error may show SQL; the Observer receives the error.
+//
+// Example usage:
+//
+//	http.Handle("/users", handler.HandleList(db.Where("organization_id = ?", orgID), 10000))
+func (f *Handler[T]) HandleList(db *gorm.DB, threshold int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filterRoot, pageIndex, pageSize, err := parseListRequest(w, r)
+		if err == nil {
+			err = f.validateRoot(filterRoot, false, false)
+		}
+		if err != nil {
+			status := http.StatusBadRequest
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+		result, err := f.HybridCtx(r.Context(), db, threshold, filterRoot, pageIndex, pageSize)
+		if err != nil {
+			// HybridCtx reported err to the Observer
+			if isRequestError(err) {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// isRequestError reports whether a query failed because of the request rather than the server: an
+// error of one of the categories of invalid filters (see ErrInvalidFilter), which never shows SQL
+func isRequestError(err error) bool {
+	for _, category := range []error{ErrUnknownField, ErrInvalidValue, ErrUnsupportedMode, ErrInvalidRange,
+		ErrFieldNotAllowed, ErrInvalidFilter, ErrInvalidCursor} {
+		if errors.Is(err, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseListRequest reads the Root and page of a HandleList request, reading at most
+// MaxListRequestBytes of its body
+func parseListRequest(w http.ResponseWriter, r *http.Request) (filterRoot Root, pageIndex, pageSize int, err error) {
+	filterRoot = Root{Logic: LogicAnd}
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxListRequestBytes))
+		if err != nil {
+			return Root{}, 0, 0, fmt.Errorf("failed to read request body: %w", err)
+		}
+		if len(strings.TrimSpace(string(body))) > 0 {
+			if filterRoot, err = ParseRootFromJSON(body); err != nil {
+				return Root{}, 0, 0, err
+			}
+		}
+	} else if encoded := r.URL.Query().Get("filter"); encoded != "" {
+		if filterRoot, err = ParseRootFromBase64(encoded); err != nil {
+			return Root{}, 0, 0, err
+		}
+	}
+	if pageIndex, err = queryInt(r, "pageIndex"); err != nil {
+		return Root{}, 0, 0, err
+	}
+	if pageSize, err = queryInt(r, "pageSize"); err != nil {
+		return Root{}, 0, 0, err
+	}
+	return filterRoot, pageIndex, pageSize, nil
+}
+
+// queryInt returns the integer query parameter name of r, 0 when absent
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+// writeJSON writes value as a JSON response with status
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
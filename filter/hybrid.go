@@ -1,10 +1,14 @@
 package filter
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // Hybrid intelligently chooses between in-memory (DataQuery) and database (DataGorm)
@@ -14,6 +18,10 @@ import (
 // - If DataQuery is chosen (small dataset): fetches data using existing conditions, then filters in-memory
 // - If DataGorm is chosen (large dataset): combines existing conditions with filterRoot filters in SQL
 //
+// The in-memory path preloads every relation a nested field reads, has-many and many2many ones included,
+// while DataGorm only loads the belongs-to and has-one relations it joins; list the others in
+// Root.Preload for rows that carry the same relations on both paths.
+//
 // Example with pre-existing conditions:
 //
 //	db := gormDB.Where("organization_id = ? AND branch_id = ?", orgID, branchID)
@@ -27,44 +35,158 @@ func (f *Handler[T]) Hybrid(
 	pageIndex int,
 	pageSize int,
 ) (*PaginationResult[T], error) {
-	// Get table name from the model
-	stmt := &gorm.Statement{DB: db}
-	if err := stmt.Parse(new(T)); err != nil {
-		return nil, fmt.Errorf("failed to parse model: %w", err)
-	}
-	tableName := stmt.Table
+	return f.HybridCtx(context.Background(), db, threshold, filterRoot, pageIndex, pageSize)
+}
 
-	// Estimate row count based on database type
-	// NOTE: Estimation uses the full table, not filtered by existing WHERE conditions
-	// This is intentional - we want to estimate total table size for strategy selection
-	estimatedRows, err := f.estimateTableRows(db, tableName)
-	if err != nil {
-		// If estimation fails, fall back to database filtering
-		return f.DataGorm(db, filterRoot, pageIndex, pageSize)
-	}
+// HybridCtx is Hybrid with cancellation support.
+// The context is passed to GORM for estimation and fetching, and to the in-memory workers.
+func (f *Handler[T]) HybridCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return f.HybridWithOptions(ctx, db, threshold, filterRoot, pageIndex, pageSize, HybridOptions{})
+}
 
-	// Decide which strategy to use
-	if estimatedRows <= int64(threshold) {
-		// Use in-memory filtering for better performance on small datasets
-		// IMPORTANT: This respects any pre-existing WHERE conditions on db
-		// Example: if db has .Where("org_id = ?", 123), only records matching that will be fetched
-		var allData []*T
+// HybridWithOptions is HybridCtx with a customizable strategy decision.
+// The chosen path is reported in the result's Strategy field, e.g. for metrics.
+//
+// Example usage:
+//
+//	opts := filter.HybridOptions{
+//	    StrategyFunc: func(estimatedRows int64, root filter.Root) filter.Strategy {
+//	        if len(root.Preload) > 0 || estimatedRows <= 10000 {
+//	            return filter.StrategyMemory
+//	        }
+//	        return filter.StrategyDatabase
+//	    },
+//	}
+//	result, err := handler.HybridWithOptions(ctx, db, 10000, filterRoot, pageIndex, pageSize, opts)
+//	metrics.Inc("filter_strategy", string(result.Strategy))
+func (f *Handler[T]) HybridWithOptions(
+	ctx context.Context,
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+	opts HybridOptions,
+) (*PaginationResult[T], error) {
+	return observe(f, ctx, "Hybrid", filterRoot, "", func(report *QueryResultInfo) (*PaginationResult[T], error) {
+		// WithContext starts a new session, so the caller's handle is never mutated
+		db := db.WithContext(ctx)
 
-		// Apply preload relationships before fetching data
-		queryDB := db
-		for _, relation := range filterRoot.Preload {
-			queryDB = queryDB.Preload(relation)
+		strategy, err := f.chooseStrategy(db, threshold, filterRoot, opts)
+		if err != nil {
+			return nil, err
 		}
+		return f.hybridPage(ctx, db, strategy, filterRoot, pageIndex, pageSize, opts, report, func() ([]*T, error) {
+			return f.fetchAllForMemory(db, filterRoot)
+		})
+	})
+}
 
-		if err := queryDB.Find(&allData).Error; err != nil {
-			return nil, fmt.Errorf("failed to fetch data for in-memory filtering: %w", err)
+// HybridWithData is Hybrid for callers that already hold the rows in memory, such as a cached slice.
+// The strategy is chosen from len(data) instead of a table estimate. The in-memory path filters data
+// directly, skipping the fetch (and Root.Preload, so data should already hold any relations filtered on),
+// while the database path runs DataGorm on db. The chosen path is reported in the result's Strategy field.
+// data is never modified.
+//
+// Example usage:
+//
+//	result, err := handler.HybridWithData(db, cachedUsers, 10000, filterRoot, pageIndex, pageSize)
+//	log.Printf("hybrid strategy: %s", result.Strategy)
+func (f *Handler[T]) HybridWithData(
+	db *gorm.DB,
+	data []*T,
+	threshold int,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return f.HybridWithDataCtx(context.Background(), db, data, threshold, filterRoot, pageIndex, pageSize, HybridOptions{})
+}
+
+// HybridWithDataCtx is HybridWithData with cancellation support and a customizable strategy decision,
+// whose StrategyFunc receives len(data) as the estimated rows.
+func (f *Handler[T]) HybridWithDataCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	data []*T,
+	threshold int,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+	opts HybridOptions,
+) (*PaginationResult[T], error) {
+	return observe(f, ctx, "HybridWithData", filterRoot, "", func(report *QueryResultInfo) (*PaginationResult[T], error) {
+		// Report invalid filters before querying anything
+		if _, err := f.restrictRoot(filterRoot); err != nil {
+			return nil, err
 		}
-		return f.DataQuery(allData, filterRoot, pageIndex, pageSize)
-	}
+		strategy, err := pickStrategy(int64(len(data)), threshold, filterRoot, opts)
+		if err != nil {
+			return nil, err
+		}
+		return f.hybridPage(ctx, db.WithContext(ctx), strategy, filterRoot, pageIndex, pageSize, opts, report, func() ([]*T, error) {
+			return data, nil
+		})
+	})
+}
 
-	// Use database filtering for large datasets
-	// DataGorm will combine existing WHERE conditions with filterRoot filters
-	return f.DataGorm(db, filterRoot, pageIndex, pageSize)
+// hybridPage runs the chosen strategy: DataQuery over the rows returned by load, or DataGorm on db,
+// also when DataQuery cannot read every field or fails with opts.FallbackToGormOnError. The strategy, total size and rows scanned in
+// memory are recorded in report.
+func (f *Handler[T]) hybridPage(
+	ctx context.Context,
+	db *gorm.DB,
+	strategy Strategy,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+	opts HybridOptions,
+	report *QueryResultInfo,
+	load func() ([]*T, error),
+) (*PaginationResult[T], error) {
+	strategy = f.readableStrategy(strategy, filterRoot, opts)
+	report.Strategy = strategy
+	var result *PaginationResult[T]
+	if strategy == StrategyMemory {
+		// Use in-memory filtering for better performance on small datasets
+		allData, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if err = f.checkMemory(filterRoot); err == nil {
+			result, err = f.dataQuery(ctx, allData, filterRoot, pageIndex, pageSize)
+		}
+		if err != nil {
+			if !fallBack(ctx, err, opts, report) {
+				return nil, err
+			}
+			if result, err = f.dataGorm(ctx, db, filterRoot, pageIndex, pageSize); err != nil {
+				return nil, err
+			}
+			result.Fallback = true
+			strategy = StrategyDatabase
+		} else {
+			report.Scanned = len(allData)
+		}
+	} else {
+		// Use database filtering for large datasets
+		// DataGorm will combine existing WHERE conditions with filterRoot filters
+		var err error
+		result, err = f.dataGorm(ctx, db, filterRoot, pageIndex, pageSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Strategy = strategy
+	report.TotalSize = result.TotalSize
+	return result, nil
 }
 
 // DataHybridNoPage intelligently chooses between in-memory (DataQueryNoPage) and database (DataGormNoPage)
@@ -85,44 +207,205 @@ func (f *Handler[T]) DataHybridNoPage(
 	threshold int,
 	filterRoot Root,
 ) ([]*T, error) {
-	// Get table name from the model
-	stmt := &gorm.Statement{DB: db}
-	if err := stmt.Parse(new(T)); err != nil {
-		return nil, fmt.Errorf("failed to parse model: %w", err)
+	return f.DataHybridNoPageCtx(context.Background(), db, threshold, filterRoot)
+}
+
+// DataHybridNoPageCtx is DataHybridNoPage with cancellation support.
+// The context is passed to GORM for estimation and fetching, and to the in-memory workers.
+func (f *Handler[T]) DataHybridNoPageCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+) ([]*T, error) {
+	data, _, err := f.DataHybridNoPageWithOptions(ctx, db, threshold, filterRoot, HybridOptions{})
+	return data, err
+}
+
+// DataHybridNoPageWithOptions is DataHybridNoPageCtx with a customizable strategy decision.
+// It also returns the strategy that was used.
+func (f *Handler[T]) DataHybridNoPageWithOptions(
+	ctx context.Context,
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+	opts HybridOptions,
+) ([]*T, Strategy, error) {
+	var strategy Strategy
+	data, err := observe(f, ctx, "DataHybridNoPage", filterRoot, "", func(report *QueryResultInfo) ([]*T, error) {
+		var data []*T
+		var err error
+		data, strategy, err = f.dataHybridNoPage(ctx, db, threshold, filterRoot, opts, report)
+		return data, err
+	})
+	return data, strategy, err
+}
+
+// dataHybridNoPage implements DataHybridNoPageWithOptions, recording the outcome in report
+func (f *Handler[T]) dataHybridNoPage(
+	ctx context.Context,
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+	opts HybridOptions,
+	report *QueryResultInfo,
+) ([]*T, Strategy, error) {
+	// WithContext starts a new session, so the caller's handle is never mutated
+	db = db.WithContext(ctx)
+
+	strategy, err := f.chooseStrategy(db, threshold, filterRoot, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	strategy = f.readableStrategy(strategy, filterRoot, opts)
+	report.Strategy = strategy
+
+	var data []*T
+	if strategy == StrategyMemory {
+		// Use in-memory filtering for better performance on small datasets
+		allData, err := f.fetchAllForMemory(db, filterRoot)
+		if err != nil {
+			return nil, "", err
+		}
+		if err = f.checkMemory(filterRoot); err == nil {
+			data, err = f.dataQueryNoPage(ctx, allData, filterRoot, report)
+		}
+		if err != nil {
+			if !fallBack(ctx, err, opts, report) {
+				return nil, "", err
+			}
+			if data, err = f.dataGormNoPage(ctx, db, filterRoot, report); err != nil {
+				return nil, "", err
+			}
+			strategy = StrategyDatabase
+		} else {
+			report.Scanned = len(allData)
+		}
+	} else {
+		// Use database filtering for large datasets
+		// DataGormNoPage will combine existing WHERE conditions with filterRoot filters
+		data, err = f.dataGormNoPage(ctx, db, filterRoot, report)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	report.TotalSize = len(data)
+	return data, strategy, nil
+}
+
+// checkMemory returns an error for the filter, search and sort fields of filterRoot the in-memory path
+// cannot read: fields without a getter that DataGorm reads through T's GORM relations (see knownField),
+// which DataQuery skips
+func (f *Handler[T]) checkMemory(filterRoot Root) error {
+	var missing []string
+	seen := make(map[string]bool)
+	check := func(field string) bool {
+		if !seen[field] && f.knownField(field, false) && !f.knownField(field, true) {
+			seen[field] = true
+			missing = append(missing, field)
+		}
+		return true
+	}
+	pruneGroup(filterRoot, check)
+	if filterRoot.Search != nil {
+		for _, field := range filterRoot.Search.Fields {
+			check(field)
+		}
+	}
+	for _, sortField := range filterRoot.SortFields {
+		check(sortField.Field)
+	}
+	if len(missing) > 0 {
+		return &UnknownFieldError{Field: missing[0], Fields: missing,
+			reason: "no in-memory getter for fields: " + strings.Join(missing, ", ")}
+	}
+	return nil
+}
+
+// readableStrategy returns StrategyDatabase for StrategyMemory when the in-memory path cannot read every
+// field of filterRoot (see checkMemory), so those filters are never skipped; with opts.FallbackToGormOnError
+// the in-memory path is kept, so the database answers as a reported fallback
+func (f *Handler[T]) readableStrategy(strategy Strategy, filterRoot Root, opts HybridOptions) Strategy {
+	if strategy == StrategyMemory && !opts.FallbackToGormOnError && f.checkMemory(filterRoot) != nil {
+		return StrategyDatabase
+	}
+	return strategy
+}
+
+// fallBack reports whether Hybrid retries the failure err of its in-memory path through DataGorm,
+// recording err in report when it does
+func fallBack(ctx context.Context, err error, opts HybridOptions, report *QueryResultInfo) bool {
+	if !opts.FallbackToGormOnError || ctx.Err() != nil {
+		return false
+	}
+	report.FallbackErr = err
+	report.Strategy = StrategyDatabase
+	return true
+}
+
+// chooseStrategy estimates the table size and picks the hybrid strategy.
+// If estimation fails, the database strategy is used.
+func (f *Handler[T]) chooseStrategy(db *gorm.DB, threshold int, filterRoot Root, opts HybridOptions) (Strategy, error) {
+	// Report invalid filters before estimating or fetching anything
+	if _, err := f.restrictRoot(filterRoot); err != nil {
+		return "", err
+	}
+
+	if _, err := f.modelSchema(dialectOf(db)); err != nil {
+		return "", fmt.Errorf("failed to parse model: %w", err)
 	}
-	tableName := stmt.Table
 
 	// Estimate row count based on database type
 	// NOTE: Estimation uses the full table, not filtered by existing WHERE conditions
 	// This is intentional - we want to estimate total table size for strategy selection
-	estimatedRows, err := f.estimateTableRows(db, tableName)
+	estimatedRows, err := f.estimateRows(db, opts)
 	if err != nil {
 		// If estimation fails, fall back to database filtering
-		return f.DataGormNoPage(db, filterRoot)
+		return StrategyDatabase, nil
 	}
+	return pickStrategy(estimatedRows, threshold, filterRoot, opts)
+}
 
-	// Decide which strategy to use
-	if estimatedRows <= int64(threshold) {
-		// Use in-memory filtering for better performance on small datasets
-		// IMPORTANT: This respects any pre-existing WHERE conditions on db
-		// Example: if db has .Where("org_id = ?", 123), only records matching that will be fetched
-		var allData []*T
-
-		// Apply preload relationships before fetching data
-		queryDB := db
-		for _, relation := range filterRoot.Preload {
-			queryDB = queryDB.Preload(relation)
+// pickStrategy applies opts.StrategyFunc, or the threshold when it is nil, to the estimated row count
+func pickStrategy(estimatedRows int64, threshold int, filterRoot Root, opts HybridOptions) (Strategy, error) {
+	if opts.StrategyFunc != nil {
+		switch strategy := opts.StrategyFunc(estimatedRows, filterRoot); strategy {
+		case StrategyMemory, StrategyDatabase:
+			return strategy, nil
+		default:
+			return "", fmt.Errorf("unknown hybrid strategy %q", strategy)
 		}
+	}
 
-		if err := queryDB.Find(&allData).Error; err != nil {
-			return nil, fmt.Errorf("failed to fetch data for in-memory filtering: %w", err)
-		}
-		return f.DataQueryNoPage(allData, filterRoot)
+	if estimatedRows <= int64(threshold) {
+		return StrategyMemory, nil
 	}
+	return StrategyDatabase, nil
+}
 
-	// Use database filtering for large datasets
-	// DataGormNoPage will combine existing WHERE conditions with filterRoot filters
-	return f.DataGormNoPage(db, filterRoot)
+// fetchAllForMemory loads every row for in-memory filtering.
+// IMPORTANT: This respects any pre-existing WHERE conditions on db
+// Example: if db has .Where("org_id = ?", 123), only records matching that will be fetched
+func (f *Handler[T]) fetchAllForMemory(db *gorm.DB, filterRoot Root) ([]*T, error) {
+	var allData []*T
+
+	// Apply preload relationships before fetching data, including the relations nested
+	// filter and sort fields read, so their getters never see an unloaded (nil) relation.
+	// The requested preloads come last, so their conditions replace those of the same relation.
+	// Soft-deleted rows are only fetched when they are wanted
+	queryDB := f.modelQuery(db, filterRoot)
+	for _, relation := range f.relationPreloads(db, filterRoot) {
+		queryDB = queryDB.Preload(relation)
+	}
+	queryDB, err := f.applyPreloads(db, queryDB, filterRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := queryDB.Find(&allData).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch data for in-memory filtering: %w", err)
+	}
+	return allData, nil
 }
 
 // HybridCSV intelligently chooses between in-memory (DataQueryNoPageCSV) and database (GormNoPaginationCSV)
@@ -143,44 +426,49 @@ func (f *Handler[T]) HybridCSV(
 	threshold int,
 	filterRoot Root,
 ) ([]byte, error) {
-	// Get table name from the model
-	stmt := &gorm.Statement{DB: db}
-	if err := stmt.Parse(new(T)); err != nil {
+	return observe(f, dbContext(db), "HybridCSV", filterRoot, "", func(report *QueryResultInfo) ([]byte, error) {
+		return f.hybridCSV(db, threshold, filterRoot, report)
+	})
+}
+
+// hybridCSV implements HybridCSV, recording the outcome in report
+func (f *Handler[T]) hybridCSV(db *gorm.DB, threshold int, filterRoot Root, report *QueryResultInfo) ([]byte, error) {
+	// Report invalid filters before estimating or fetching anything
+	if _, err := f.restrictRoot(filterRoot); err != nil {
+		return nil, err
+	}
+
+	if _, err := f.modelSchema(dialectOf(db)); err != nil {
 		return nil, fmt.Errorf("failed to parse model: %w", err)
 	}
-	tableName := stmt.Table
 
 	// Estimate row count based on database type
 	// NOTE: Estimation uses the full table, not filtered by existing WHERE conditions
 	// This is intentional - we want to estimate total table size for strategy selection
-	estimatedRows, err := f.estimateTableRows(db, tableName)
+	estimatedRows, err := f.EstimateRows(db)
 	if err != nil {
 		// If estimation fails, fall back to database filtering with CSV export
-		return f.GormNoPaginationCSV(db, filterRoot)
+		report.Strategy = StrategyDatabase
+		return f.gormCSV(db, filterRoot, DefaultCSVOptions(), report)
 	}
 
-	// Decide which strategy to use
-	if estimatedRows <= int64(threshold) {
+	// Decide which strategy to use, the database one when the in-memory path cannot read every field
+	if estimatedRows <= int64(threshold) && f.checkMemory(filterRoot) == nil {
 		// Use in-memory filtering for better performance on small datasets
 		// IMPORTANT: This respects any pre-existing WHERE conditions on db
 		// Example: if db has .Where("org_id = ?", 123), only records matching that will be fetched
-		var allData []*T
-
-		// Apply preload relationships before fetching data
-		queryDB := db
-		for _, relation := range filterRoot.Preload {
-			queryDB = queryDB.Preload(relation)
+		report.Strategy = StrategyMemory
+		allData, err := f.fetchAllForMemory(db, filterRoot)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := queryDB.Find(&allData).Error; err != nil {
-			return nil, fmt.Errorf("failed to fetch data for in-memory filtering: %w", err)
-		}
-		return f.DataQueryNoPageCSV(allData, filterRoot)
+		return f.dataQueryCSV(allData, filterRoot, DefaultCSVOptions(), report)
 	}
 
 	// Use database filtering for large datasets with CSV export
 	// GormNoPaginationCSV will combine existing WHERE conditions with filterRoot filters
-	return f.GormNoPaginationCSV(db, filterRoot)
+	report.Strategy = StrategyDatabase
+	return f.gormCSV(db, filterRoot, DefaultCSVOptions(), report)
 }
 
 // HybridCSVWithPreset is a convenience method that combines preset conditions with HybridCSV.
@@ -206,7 +494,7 @@ func (f *Handler[T]) HybridCSVWithPreset(
 ) ([]byte, error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call HybridCSV with the modified db
@@ -245,30 +533,48 @@ func (f *Handler[T]) HybridCSVCustom(
 	filterRoot Root,
 	customGetter func(*T) map[string]any,
 ) ([]byte, error) {
-	// Get table name from the model
-	stmt := &gorm.Statement{DB: db}
-	if err := stmt.Parse(new(T)); err != nil {
+	return observe(f, dbContext(db), "HybridCSVCustom", filterRoot, "", func(report *QueryResultInfo) ([]byte, error) {
+		return f.hybridCSVCustom(db, threshold, filterRoot, customGetter, report)
+	})
+}
+
+// hybridCSVCustom implements HybridCSVCustom, recording the outcome in report
+func (f *Handler[T]) hybridCSVCustom(
+	db *gorm.DB,
+	threshold int,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+	report *QueryResultInfo,
+) ([]byte, error) {
+	// Report invalid filters before estimating or fetching anything
+	if _, err := f.restrictRoot(filterRoot); err != nil {
+		return nil, err
+	}
+
+	if _, err := f.modelSchema(dialectOf(db)); err != nil {
 		return nil, fmt.Errorf("failed to parse model: %w", err)
 	}
-	tableName := stmt.Table
 
 	// Estimate row count based on database type
-	estimatedRows, err := f.estimateTableRows(db, tableName)
+	estimatedRows, err := f.EstimateRows(db)
 	if err != nil {
 		// If estimation fails, fall back to database filtering with CSV export
-		return f.GormNoPaginationCSVCustom(db, filterRoot, customGetter)
+		report.Strategy = StrategyDatabase
+		return f.gormCSVCustom(db, filterRoot, customGetter, DefaultCSVOptions(), report)
 	}
 
-	if int(estimatedRows) <= threshold {
+	if int(estimatedRows) <= threshold && f.checkMemory(filterRoot) == nil {
 		// Small table: use in-memory filtering with custom CSV export
-		var allData []*T
-		if err := db.Find(&allData).Error; err != nil {
-			return nil, fmt.Errorf("failed to retrieve data: %w", err)
+		report.Strategy = StrategyMemory
+		allData, err := f.fetchAllForMemory(db, filterRoot)
+		if err != nil {
+			return nil, err
 		}
-		return f.DataQueryNoPageCSVCustom(allData, filterRoot, customGetter)
+		return f.dataQueryCSVCustom(allData, filterRoot, customGetter, DefaultCSVOptions(), report)
 	} else {
 		// Large table: use database filtering with custom CSV export
-		return f.GormNoPaginationCSVCustom(db, filterRoot, customGetter)
+		report.Strategy = StrategyDatabase
+		return f.gormCSVCustom(db, filterRoot, customGetter, DefaultCSVOptions(), report)
 	}
 }
 
@@ -306,113 +612,98 @@ func (f *Handler[T]) HybridCSVCustomWithPreset(
 ) ([]byte, error) {
 	// Apply preset conditions to db
 	if presetConditions != nil {
-		db = db.Where(presetConditions)
+		db = newSession(db).Where(presetConditions)
 	}
 
 	// Call HybridCSVCustom with the modified db
 	return f.HybridCSVCustom(db, threshold, filterRoot, customGetter)
 }
 
-// estimateTableRows returns an estimated row count for a table.
-// It uses database-specific methods for fast estimation without scanning the entire table.
-// NOTE: This estimates the FULL table size, ignoring any WHERE conditions on the db parameter.
-func (f *Handler[T]) estimateTableRows(db *gorm.DB, tableName string) (int64, error) {
-	// Get the database driver name
-	dialectName := db.Name()
+// EstimateRows returns an estimate of the number of rows in T's table, as used by Hybrid to choose
+// a strategy. It reads the planner statistics where available (pg_class.reltuples on PostgreSQL,
+// INFORMATION_SCHEMA.TABLES on MySQL, sqlite_stat1 on SQLite after ANALYZE, sys.partitions on
+// SQL Server) and falls back to COUNT(*) when there are none. WHERE conditions on db are ignored,
+// so the estimate covers the whole table.
+//
+// Example usage:
+//
+//	rows, err := handler.EstimateRows(db)
+//	if err == nil && rows <= threshold {
+//	    // Hybrid will filter in memory
+//	}
+func (f *Handler[T]) EstimateRows(db *gorm.DB) (int64, error) {
+	modelSchema, err := f.modelSchema(dialectOf(db))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse model: %w", err)
+	}
+	return estimateTableRows(db, modelSchema.Table)
+}
 
-	type Estimate struct {
-		Rows int64
+// estimateRows runs opts.Estimator, or EstimateRows when it is nil
+func (f *Handler[T]) estimateRows(db *gorm.DB, opts HybridOptions) (int64, error) {
+	if opts.Estimator != nil {
+		return opts.Estimator(db)
 	}
-	var est Estimate
+	return f.EstimateRows(db)
+}
 
+// estimateTableRows returns an estimated row count for a table.
+// It uses database-specific statistics for fast estimation without scanning the entire table,
+// and counts the rows when the statistics are missing (e.g. the table was never analyzed).
+// NOTE: This estimates the FULL table size, ignoring any WHERE conditions on the db parameter.
+func estimateTableRows(db *gorm.DB, tableName string) (int64, error) {
 	// Create a fresh session without any WHERE conditions for estimation
 	// We want to estimate the full table size, not filtered results
 	freshDB := db.Session(&gorm.Session{NewDB: true})
 
-	switch dialectName {
+	var estimate struct {
+		Estimate sql.NullInt64
+	}
+	switch db.Name() {
 	case "postgres":
-		// PostgreSQL: Use pg_class for instant estimation
-		query := fmt.Sprintf(`
-			SELECT reltuples::BIGINT AS rows
-			FROM pg_class
-			WHERE relname = '%s'
-		`, tableName)
-
-		if err := freshDB.Raw(query).Scan(&est).Error; err != nil {
+		// PostgreSQL: reltuples is -1 (or 0 before version 14) until the table is vacuumed or analyzed
+		query := `SELECT reltuples::BIGINT AS estimate FROM pg_class WHERE relname = ?`
+		if err := freshDB.Raw(query, tableName).Scan(&estimate).Error; err != nil {
 			return 0, fmt.Errorf("postgres estimation failed: %w", err)
 		}
-		return est.Rows, nil
 
 	case "mysql":
-		// MySQL/MariaDB: Use INFORMATION_SCHEMA
-		query := `
-			SELECT TABLE_ROWS AS rows
-			FROM INFORMATION_SCHEMA.TABLES
-			WHERE TABLE_SCHEMA = DATABASE()
-			  AND TABLE_NAME = ?
-		`
-
-		if err := freshDB.Raw(query, tableName).Scan(&est).Error; err != nil {
+		// MySQL/MariaDB: TABLE_ROWS is an estimate for InnoDB
+		query := `SELECT TABLE_ROWS AS estimate FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`
+		if err := freshDB.Raw(query, tableName).Scan(&estimate).Error; err != nil {
 			return 0, fmt.Errorf("mysql estimation failed: %w", err)
 		}
-		return est.Rows, nil
 
 	case "sqlite":
-		// SQLite: Query sqlite_stat1 if available, otherwise fall back to COUNT(*)
-		// First try sqlite_stat1 (if ANALYZE has been run)
-		// Note: Suppress error logging since sqlite_stat1 may not exist
-		query := fmt.Sprintf(`
-			SELECT stat AS rows
-			FROM sqlite_stat1
-			WHERE tbl = '%s'
-			LIMIT 1
-		`, tableName)
-
-		var statRows string
-		// Use a session with disabled logging for this query to avoid "no such table" warnings
-		silentDB := freshDB.Session(&gorm.Session{Logger: freshDB.Logger.LogMode(1)}) // Silent mode
-		err := silentDB.Raw(query).Scan(&statRows).Error
-
-		// If sqlite_stat1 exists and has data, parse it
-		if err == nil && statRows != "" {
-			// Parse the first number from the stat string
-			parts := strings.Split(statRows, " ")
-			if len(parts) > 0 {
-				var rowCount int64
-				if _, scanErr := fmt.Sscanf(parts[0], "%d", &rowCount); scanErr == nil {
-					return rowCount, nil
-				}
+		// SQLite: sqlite_stat1 only exists once ANALYZE has been run. Its stat column starts with
+		// the row count. Logging is silenced so a missing sqlite_stat1 table logs no warning.
+		var stat string
+		silentDB := freshDB.Session(&gorm.Session{Logger: freshDB.Logger.LogMode(logger.Silent)})
+		err := silentDB.Raw(`SELECT stat FROM sqlite_stat1 WHERE tbl = ? LIMIT 1`, tableName).Scan(&stat).Error
+		if fields := strings.Fields(stat); err == nil && len(fields) > 0 {
+			if rows, parseErr := strconv.ParseInt(fields[0], 10, 64); parseErr == nil {
+				estimate.Estimate = sql.NullInt64{Int64: rows, Valid: true}
 			}
 		}
 
-		// Fall back to COUNT(*) for SQLite
-		// This works even if sqlite_stat1 doesn't exist
-		var countRows int64
-		if err := freshDB.Table(tableName).Count(&countRows).Error; err != nil {
-			return 0, fmt.Errorf("sqlite count failed: %w", err)
-		}
-		return countRows, nil
-
 	case "sqlserver":
-		// SQL Server: Use system views
-		query := fmt.Sprintf(`
-			SELECT SUM(p.rows) AS rows
-			FROM sys.partitions p
+		// SQL Server: row counts of the heap or clustered index
+		query := `SELECT SUM(p.rows) AS estimate FROM sys.partitions p
 			INNER JOIN sys.objects o ON p.object_id = o.object_id
-			WHERE o.name = '%s'
-			  AND p.index_id IN (0, 1)
-		`, tableName)
-
-		if err := freshDB.Raw(query).Scan(&est).Error; err != nil {
+			WHERE o.name = ? AND p.index_id IN (0, 1)`
+		if err := freshDB.Raw(query, tableName).Scan(&estimate).Error; err != nil {
 			return 0, fmt.Errorf("sqlserver estimation failed: %w", err)
 		}
-		return est.Rows, nil
+	}
 
-	default:
-		// Unsupported database: fall back to COUNT(*)
-		if err := freshDB.Table(tableName).Count(&est.Rows).Error; err != nil {
-			return 0, fmt.Errorf("count fallback failed: %w", err)
-		}
-		return est.Rows, nil
+	if estimate.Estimate.Valid && estimate.Estimate.Int64 > 0 {
+		return estimate.Estimate.Int64, nil
+	}
+
+	// No statistics (or an unsupported database): fall back to COUNT(*)
+	var count int64
+	if err := freshDB.Table(tableName).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count fallback failed: %w", err)
 	}
+	return count, nil
 }
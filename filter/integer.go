@@ -0,0 +1,132 @@
+package filter
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// integer is an integral number held exactly, as the sign and magnitude of an int64 or uint64, so IDs
+// above 2^53 (e.g. snowflake IDs) compare without the precision float64 loses
+type integer struct {
+	neg bool
+	abs uint64
+}
+
+// integerOf returns n as an integer
+func integerOf(n int64) integer {
+	if n < 0 {
+		return integer{neg: true, abs: uint64(-(n + 1)) + 1}
+	}
+	return integer{abs: uint64(n)}
+}
+
+// parseInteger parses value as an exact integer: Go integer kinds (and pointers to them) and integer
+// strings or json.Numbers such as "9223372036854775807". Floats and other values return false; they
+// compare as float64 (see parseNumber).
+func parseInteger(value any) (integer, bool) {
+	switch v := derefValue(value).(type) {
+	case int:
+		return integerOf(int64(v)), true
+	case int64:
+		return integerOf(v), true
+	case uint64:
+		return integer{abs: v}, true
+	case string:
+		return parseIntegerString(strings.TrimSpace(v))
+	case json.Number:
+		return parseIntegerString(string(v))
+	case nil, float64, float32:
+		return integer{}, false
+	}
+	rv := reflect.ValueOf(derefValue(value))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return integerOf(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return integer{abs: rv.Uint()}, true
+	}
+	return integer{}, false
+}
+
+// parseIntegerString parses s as a base 10 int64 or, above its range, uint64
+func parseIntegerString(s string) (integer, bool) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return integerOf(n), true
+	}
+	if n, err := strconv.ParseUint(strings.TrimPrefix(s, "+"), 10, 64); err == nil {
+		return integer{abs: n}, true
+	}
+	return integer{}, false
+}
+
+// compare returns -1, 0 or +1 as i is less than, equal to or greater than j
+func (i integer) compare(j integer) int {
+	switch {
+	case i.neg != j.neg:
+		if i.neg {
+			return -1
+		}
+		return 1
+	case i.neg:
+		return cmp.Compare(j.abs, i.abs)
+	}
+	return cmp.Compare(i.abs, j.abs)
+}
+
+// sqlValue returns i as the int64 bound in SQL or, above its range, as an inline decimal literal:
+// database/sql's default converter rejects uint64 values above the int64 range
+func (i integer) sqlValue() any {
+	switch {
+	case i.neg:
+		return -int64(i.abs-1) - 1
+	case i.abs > math.MaxInt64:
+		return clause.Expr{SQL: strconv.FormatUint(i.abs, 10)}
+	}
+	return int64(i.abs)
+}
+
+// numberTarget is a number filter value. It compares exactly with integer row values when it is an
+// integer itself, and as float64 otherwise.
+type numberTarget struct {
+	num   float64
+	int   integer
+	isInt bool
+}
+
+// parseNumberTarget parses value as a numberTarget, failing like parseNumber
+func parseNumberTarget(value any) (numberTarget, error) {
+	num, err := parseNumber(value)
+	if err != nil {
+		return numberTarget{}, err
+	}
+	i, isInt := parseInteger(value)
+	return numberTarget{num: num, int: i, isInt: isInt}, nil
+}
+
+// compare returns -1, 0 or +1 as the row value is less than, equal to or greater than t
+func (t numberTarget) compare(value any) (int, error) {
+	if t.isInt {
+		if i, ok := parseInteger(value); ok {
+			return i.compare(t.int), nil
+		}
+	}
+	num, err := parseNumber(value)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(num, t.num), nil
+}
+
+// sqlValue returns the value t binds in SQL: the exact integer when it is one, its float64 otherwise
+func (t numberTarget) sqlValue() any {
+	if t.isInt {
+		return t.int.sqlValue()
+	}
+	return t.num
+}
@@ -0,0 +1,402 @@
+package filter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GormNoPaginationJSON performs database-level filtering like DataGormNoPage and returns
+// the results as a JSON array. Field names follow the struct's json tags.
+// An empty result is encoded as [].
+func (f *Handler[T]) GormNoPaginationJSON(
+	db *gorm.DB,
+	filterRoot Root,
+) ([]byte, error) {
+	return f.GormNoPaginationJSONWithOptions(db, filterRoot, JSONOptions{})
+}
+
+// GormNoPaginationJSONWithOptions is GormNoPaginationJSON with JSONOptions, such as fields left out
+// of every record.
+//
+// Example usage:
+//
+//	jsonData, err := handler.GormNoPaginationJSONWithOptions(db, filterRoot, filter.JSONOptions{
+//	    ExcludeFields: []string{"salary", "tax_id"},
+//	})
+func (f *Handler[T]) GormNoPaginationJSONWithOptions(
+	db *gorm.DB,
+	filterRoot Root,
+	opts JSONOptions,
+) ([]byte, error) {
+	return f.gormExport("GormNoPaginationJSON", db, filterRoot, func(items []*T) ([]byte, error) {
+		return jsonArray(items, f.jsonRecord(opts))
+	})
+}
+
+// GormNoPaginationJSONCustom is GormNoPaginationJSON with each record built by customGetter.
+// Map keys become the JSON object keys (encoded in sorted order).
+//
+// Example usage:
+//
+//	jsonData, err := handler.GormNoPaginationJSONCustom(db, filterRoot, func(user *User) map[string]any {
+//	    return map[string]any{
+//	        "fullName": user.FirstName + " " + user.LastName,
+//	        "email":    user.Email,
+//	    }
+//	})
+func (f *Handler[T]) GormNoPaginationJSONCustom(
+	db *gorm.DB,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+) ([]byte, error) {
+	return f.gormExport("GormNoPaginationJSONCustom", db, filterRoot, func(items []*T) ([]byte, error) {
+		return jsonArray(items, func(item *T) any { return customGetter(item) })
+	})
+}
+
+// GormNDJSONStream performs database-level filtering and writes newline-delimited JSON to w,
+// one json.Marshal'ed record per line. Rows are fetched in batches of DefaultStreamBatchSize
+// (see findInBatches for the ordering) and w is flushed after every batch.
+// If an error occurs mid-stream, the records already written are flushed before it is returned.
+func (f *Handler[T]) GormNDJSONStream(
+	db *gorm.DB,
+	filterRoot Root,
+	w io.Writer,
+) error {
+	return f.GormNDJSONStreamWithOptions(db, filterRoot, w, JSONOptions{})
+}
+
+// GormNDJSONStreamWithOptions is GormNDJSONStream with JSONOptions
+func (f *Handler[T]) GormNDJSONStreamWithOptions(
+	db *gorm.DB,
+	filterRoot Root,
+	w io.Writer,
+	opts JSONOptions,
+) error {
+	return f.gormNDJSONStream("GormNDJSONStream", db, filterRoot, w, f.jsonRecord(opts))
+}
+
+// GormNDJSONStreamCustom is GormNDJSONStream with each record built by customGetter
+func (f *Handler[T]) GormNDJSONStreamCustom(
+	db *gorm.DB,
+	filterRoot Root,
+	w io.Writer,
+	customGetter func(*T) map[string]any,
+) error {
+	return f.gormNDJSONStream("GormNDJSONStreamCustom", db, filterRoot, w, func(item *T) any { return customGetter(item) })
+}
+
+// DataQueryNoPageJSON performs in-memory filtering like DataQueryNoPage and returns
+// the results as a JSON array. Field names follow the struct's json tags.
+// An empty result is encoded as [].
+func (f *Handler[T]) DataQueryNoPageJSON(
+	data []*T,
+	filterRoot Root,
+) ([]byte, error) {
+	return f.DataQueryNoPageJSONWithOptions(data, filterRoot, JSONOptions{})
+}
+
+// DataQueryNoPageJSONWithOptions is DataQueryNoPageJSON with JSONOptions
+func (f *Handler[T]) DataQueryNoPageJSONWithOptions(
+	data []*T,
+	filterRoot Root,
+	opts JSONOptions,
+) ([]byte, error) {
+	return f.dataQueryExport("DataQueryNoPageJSON", data, filterRoot, func(items []*T) ([]byte, error) {
+		return jsonArray(items, f.jsonRecord(opts))
+	})
+}
+
+// DataQueryNoPageJSONCustom is DataQueryNoPageJSON with each record built by customGetter
+func (f *Handler[T]) DataQueryNoPageJSONCustom(
+	data []*T,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+) ([]byte, error) {
+	return f.dataQueryExport("DataQueryNoPageJSONCustom", data, filterRoot, func(items []*T) ([]byte, error) {
+		return jsonArray(items, func(item *T) any { return customGetter(item) })
+	})
+}
+
+// DataQueryNDJSONStream performs in-memory filtering and writes newline-delimited JSON to w,
+// one json.Marshal'ed record per line, flushing after every DefaultStreamBatchSize records.
+// If an error occurs mid-stream, the records already written are flushed before it is returned.
+func (f *Handler[T]) DataQueryNDJSONStream(
+	data []*T,
+	filterRoot Root,
+	w io.Writer,
+) error {
+	return f.DataQueryNDJSONStreamWithOptions(data, filterRoot, w, JSONOptions{})
+}
+
+// DataQueryNDJSONStreamWithOptions is DataQueryNDJSONStream with JSONOptions
+func (f *Handler[T]) DataQueryNDJSONStreamWithOptions(
+	data []*T,
+	filterRoot Root,
+	w io.Writer,
+	opts JSONOptions,
+) error {
+	return f.dataQueryNDJSONStream("DataQueryNDJSONStream", data, filterRoot, w, f.jsonRecord(opts))
+}
+
+// DataQueryNDJSONStreamCustom is DataQueryNDJSONStream with each record built by customGetter
+func (f *Handler[T]) DataQueryNDJSONStreamCustom(
+	data []*T,
+	filterRoot Root,
+	w io.Writer,
+	customGetter func(*T) map[string]any,
+) error {
+	return f.dataQueryNDJSONStream("DataQueryNDJSONStreamCustom", data, filterRoot, w, func(item *T) any { return customGetter(item) })
+}
+
+// gormNDJSONStream writes the records built by toRecord for each matching row as NDJSON,
+// reported to the observer as method
+func (f *Handler[T]) gormNDJSONStream(method string, db *gorm.DB, filterRoot Root, w io.Writer, toRecord func(*T) any) error {
+	_, err := observe(f, dbContext(db), method, filterRoot, StrategyDatabase, func(report *QueryResultInfo) (struct{}, error) {
+		query, order, err := f.gormNoPageQuery(db, f.exportRoot(filterRoot))
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to filter data: %w", err)
+		}
+		if err := f.checkExportLimit(db, query, filterRoot); err != nil {
+			return struct{}{}, err
+		}
+
+		bufWriter := bufio.NewWriter(w)
+		err = f.findInBatches(db, query, order, DefaultStreamBatchSize, func(batch []*T) error {
+			batch, err := f.limitExportBatch(batch, report.TotalSize, filterRoot, report)
+			if err != nil {
+				return err
+			}
+			if err := writeNDJSON(bufWriter, batch, toRecord); err != nil {
+				return err
+			}
+			report.TotalSize += len(batch)
+			return flushNDJSON(bufWriter, nil)
+		})
+		return struct{}{}, flushNDJSON(bufWriter, err)
+	})
+	return err
+}
+
+// dataQueryNDJSONStream writes the records built by toRecord for each matching item as NDJSON,
+// reported to the observer as method
+func (f *Handler[T]) dataQueryNDJSONStream(method string, data []*T, filterRoot Root, w io.Writer, toRecord func(*T) any) error {
+	_, err := observe(f, context.Background(), method, filterRoot, StrategyMemory, func(report *QueryResultInfo) (struct{}, error) {
+		filteredData, err := f.dataQueryNoPage(context.Background(), data, filterRoot, report)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to filter data: %w", err)
+		}
+		report.Scanned = len(data)
+
+		bufWriter := bufio.NewWriter(w)
+		for start := 0; start < len(filteredData); start += DefaultStreamBatchSize {
+			end := min(start+DefaultStreamBatchSize, len(filteredData))
+			if err := writeNDJSON(bufWriter, filteredData[start:end], toRecord); err != nil {
+				return struct{}{}, flushNDJSON(bufWriter, err)
+			}
+			report.TotalSize += end - start
+			if err := flushNDJSON(bufWriter, nil); err != nil {
+				return struct{}{}, err
+			}
+		}
+
+		return struct{}{}, flushNDJSON(bufWriter, nil)
+	})
+	return err
+}
+
+// jsonArray marshals the records built by toRecord as a JSON array ([] when items is empty)
+func jsonArray[T any](items []*T, toRecord func(*T) any) ([]byte, error) {
+	records := make([]any, len(items))
+	for i, item := range items {
+		records[i] = toRecord(item)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return data, nil
+}
+
+// writeNDJSON writes one json.Marshal'ed record per line
+func writeNDJSON[T any](bufWriter *bufio.Writer, items []*T, toRecord func(*T) any) error {
+	for _, item := range items {
+		line, err := json.Marshal(toRecord(item))
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON record: %w", err)
+		}
+		line = append(line, '\n')
+		if _, err := bufWriter.Write(line); err != nil {
+			return fmt.Errorf("failed to write JSON record: %w", err)
+		}
+	}
+	return nil
+}
+
+// flushNDJSON flushes what has been written so far and returns err,
+// or the flush error when err is nil
+func flushNDJSON(bufWriter *bufio.Writer, err error) error {
+	flushErr := bufWriter.Flush()
+	if err != nil {
+		return err
+	}
+	if flushErr != nil {
+		return fmt.Errorf("failed to write JSON: %w", flushErr)
+	}
+	return nil
+}
+
+// jsonRecord returns the toRecord function of the JSON exports of T with opts
+func (f *Handler[T]) jsonRecord(opts JSONOptions) func(*T) any {
+	var paths [][]string
+	for _, field := range opts.ExcludeFields {
+		if keys, ok := jsonKeys(reflect.TypeFor[T](), field); ok {
+			paths = append(paths, keys)
+		}
+	}
+	if len(paths) == 0 {
+		return func(item *T) any { return item }
+	}
+	return func(item *T) any { return maskedRecord{value: item, paths: paths} }
+}
+
+// jsonKeys returns the object keys leading to field in the JSON encoding of t ("team.name" -> ["team", "name"]),
+// matching each segment against the keys of the fields encoding/json writes at that level (see fieldKeys).
+// It reports false when a segment matches no field.
+func jsonKeys(t reflect.Type, field string) ([]string, bool) {
+	var keys []string
+	for _, segment := range strings.Split(field, ".") {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		found := false
+		for _, structField := range visibleFields(t) {
+			name := strings.Split(structField.Tag.Get("json"), ",")[0]
+			if name == "-" || !slices.Contains(fieldKeys(structField), segment) {
+				continue
+			}
+			if name == "" {
+				name = structField.Name
+			}
+			keys = append(keys, name)
+			t = structField.Type
+			found = true
+			break
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// maskedRecord encodes value without the object members at paths, key paths from jsonKeys
+type maskedRecord struct {
+	value any
+	paths [][]string
+}
+
+// MarshalJSON encodes the record's value and removes the members at its paths
+func (r maskedRecord) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.value)
+	if err != nil {
+		return nil, err
+	}
+	return maskJSON(data, r.paths)
+}
+
+// maskJSON returns the JSON value data without the object members at paths, keeping the order of
+// the others. Arrays have paths removed from each element.
+func maskJSON(data []byte, paths [][]string) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	switch data[0] {
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('[')
+		for i, element := range elements {
+			masked, err := maskJSON(element, paths)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(masked)
+		}
+		buf.WriteByte(']')
+	case '{':
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('{')
+		first := true
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := token.(string)
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+
+			var nested [][]string
+			drop := false
+			for _, path := range paths {
+				if path[0] != key {
+					continue
+				}
+				if len(path) == 1 {
+					drop = true
+					break
+				}
+				nested = append(nested, path[1:])
+			}
+			if drop {
+				continue
+			}
+			if len(nested) > 0 {
+				if value, err = maskJSON(value, nested); err != nil {
+					return nil, err
+				}
+			}
+
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	default:
+		return data, nil
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// jsonPathSeparator separates a JSON column from the keys read from it ("metadata->plan")
+const jsonPathSeparator = "->"
+
+// jsonKeyPattern limits JSON path keys to identifiers, since they are inlined into SQL
+var jsonKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// splitJSONPath splits a JSON path field ("metadata->billing->plan") into its column and keys.
+// It returns false for any other field, and for paths with an empty, nested or non-identifier part.
+func splitJSONPath(field string) (string, []string, bool) {
+	column, path, found := strings.Cut(field, jsonPathSeparator)
+	if !found || column == "" || strings.Contains(column, ".") {
+		return "", nil, false
+	}
+	keys := strings.Split(path, jsonPathSeparator)
+	for _, key := range keys {
+		if !jsonKeyPattern.MatchString(key) {
+			return "", nil, false
+		}
+	}
+	return column, keys, true
+}
+
+// getter returns the getter of field: a field of T, a field added with RegisterField, or a JSON path
+// into one of them. It returns false for an unknown field.
+func (f *Handler[T]) getter(field string) (func(*T) any, bool) {
+	if getter, exists := f.fieldGetter(field); exists {
+		return getter, true
+	}
+	column, keys, ok := splitJSONPath(field)
+	if !ok {
+		return nil, false
+	}
+	getter, exists := f.getter(column)
+	if !exists {
+		return nil, false
+	}
+	return func(item *T) any {
+		return jsonPathValue(getter(item), keys)
+	}, true
+}
+
+// jsonPathValue reads keys from a JSON document: a JSON string or []byte, a map[string]any,
+// or any value encoding/json can marshal. Strings and bools are returned as is and numbers as
+// json.Number, so they compare as text or numbers alike; objects and arrays are returned as
+// compact JSON text, like the ->> operator does. Missing keys and invalid documents read as nil.
+func jsonPathValue(document any, keys []string) any {
+	document = derefValue(document)
+	if document == nil {
+		return nil
+	}
+	var raw []byte
+	switch value := document.(type) {
+	case missingValue, manyValues:
+		return nil
+	case string:
+		raw = []byte(value)
+	case []byte:
+		raw = value
+	case json.RawMessage:
+		raw = value
+	default:
+		rv := reflect.ValueOf(document)
+		switch {
+		case rv.Kind() == reflect.String:
+			raw = []byte(rv.String())
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			raw = rv.Bytes()
+		default:
+			encoded, err := json.Marshal(document)
+			if err != nil {
+				return nil
+			}
+			raw = encoded
+		}
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	for _, key := range keys {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if value, ok = object[key]; !ok {
+			return nil
+		}
+	}
+	switch value.(type) {
+	case map[string]any, []any:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		return string(encoded)
+	}
+	return value
+}
+
+// jsonPathExpr returns the SQL expression reading keys from the JSON column expression column,
+// unquoted to text: column #>> '{a,b}' on PostgreSQL, JSON_UNQUOTE(JSON_EXTRACT(...)) on MySQL and
+// json_extract(...) on SQLite, which returns numbers and booleans (as 1 and 0) as is. Numbers are
+// cast on PostgreSQL and MySQL, booleans cast on PostgreSQL and compared to 'true' on MySQL, so they
+// compare and sort like the bound values of dataType. The keys are identifiers (see splitJSONPath) and safe to inline.
+func jsonPathExpr(d sqlDialect, column string, keys []string, dataType DataType) string {
+	switch d.name {
+	case "postgres":
+		expr := fmt.Sprintf("(CAST(%s AS jsonb) #>> '{%s}')", column, strings.Join(keys, ","))
+		switch dataType {
+		case DataTypeNumber:
+			return fmt.Sprintf("CAST(%s AS numeric)", expr)
+		case DataTypeBool:
+			return fmt.Sprintf("CAST(%s AS boolean)", expr)
+		}
+		return expr
+	case "mysql":
+		expr := fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '$.%s'))", column, strings.Join(keys, "."))
+		switch dataType {
+		case DataTypeNumber:
+			return fmt.Sprintf("CAST(%s AS DECIMAL(65,30))", expr)
+		case DataTypeBool:
+			return fmt.Sprintf("(%s = 'true')", expr)
+		}
+		return expr
+	}
+	return fmt.Sprintf("json_extract(%s, '$.%s')", column, strings.Join(keys, "."))
+}
@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+)
+
+// generateMapFields returns the getter keys of the top-level fields of T that are maps with string keys
+// (or pointers to them), such as Attributes map[string]string, whose values are read as "attributes.color"
+func generateMapFields[T any]() map[string]bool {
+	mapFields := make(map[string]bool)
+	t := reflect.TypeOf(new(T)).Elem()
+	if t.Kind() != reflect.Struct {
+		return mapFields
+	}
+	for _, field := range visibleFields(t) {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Map || fieldType.Key().Kind() != reflect.String {
+			continue
+		}
+		for _, key := range fieldKeys(field) {
+			mapFields[key] = true
+		}
+	}
+	return mapFields
+}
+
+// mapKey splits field into the getter key of a map field of T and the map key it reads
+// ("attributes.color" -> "attributes", "color"). The field name is matched as is or lowercased,
+// the map key always as is. It returns false for any other field.
+func (f *Handler[T]) mapKey(field string) (string, string, bool) {
+	column, key, found := strings.Cut(field, ".")
+	if !found || key == "" {
+		return "", "", false
+	}
+	if f.mapFields[column] {
+		return column, key, true
+	}
+	if column = strings.ToLower(column); f.mapFields[column] {
+		return column, key, true
+	}
+	return "", "", false
+}
+
+// mapKeyGetter returns the getter of field when it reads a key of a map field of T. A nil map and a
+// missing key read as a missing value, so they only match ModeIsEmpty and the negative modes, like a
+// nil parent of a nested field.
+func (f *Handler[T]) mapKeyGetter(field string) (func(*T) any, bool) {
+	column, key, ok := f.mapKey(field)
+	if !ok {
+		return nil, false
+	}
+	getter := f.getters[column]
+	return func(item *T) any {
+		m := reflect.ValueOf(derefValue(getter(item)))
+		if m.Kind() != reflect.Map || m.IsNil() {
+			return missingValue{}
+		}
+		value := m.MapIndex(reflect.ValueOf(key).Convert(m.Type().Key()))
+		if !value.IsValid() {
+			return missingValue{}
+		}
+		return value.Interface()
+	}, true
+}
+
+// mapKeyPath returns the JSON path the GORM path reads field from when it is a key of a map field of T
+// ("attributes.color" -> "attributes->color"), as such maps are stored in a JSON column
+// (gorm:"serializer:json"). The path is only valid (see splitJSONPath) when the key is an identifier.
+func (f *Handler[T]) mapKeyPath(field string) (string, bool) {
+	column, key, ok := f.mapKey(field)
+	if !ok {
+		return "", false
+	}
+	return column + jsonPathSeparator + key, true
+}
+
+// isMapKey reports whether field reads a key of a map field of T
+func (f *Handler[T]) isMapKey(field string) bool {
+	_, _, ok := f.mapKey(field)
+	return ok
+}
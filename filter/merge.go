@@ -0,0 +1,147 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergeOptions tunes how MergeRoots combines two Roots
+type MergeOptions struct {
+	// ReplaceSortFields sorts by overlay's sort fields alone when it has any, instead of following
+	// them with base's remaining ones
+	ReplaceSortFields bool
+}
+
+// MergeRoots combines base (e.g. a saved filter) and overlay (e.g. ad-hoc refinements from the UI)
+// into one Root matching the records that match both:
+//
+//   - Filters and groups are combined with LogicAnd. A Root whose Logic is LogicAnd, or that has a
+//     single filter or group, contributes them as they are; any other Root (LogicOr, or no Logic,
+//     which combines with OR) becomes one nested group keeping its Logic, so it is never mixed up
+//     with the other Root's conditions.
+//   - Sort fields are overlay's followed by base's on other fields, so overlay overrides the order of
+//     a field both sort by (see MergeOptions.ReplaceSortFields). Fields compare case-insensitively.
+//   - Preload is the union of both, in order; Preloads are both, overlay's replacing base's for the
+//     same relation. Aggregations are the union of both and ChildCounts are all ANDed.
+//   - Search is whichever Root searches for a term, and SelectFields are overlay's when set, base's
+//     otherwise. A Root holds one search, so two different searches cannot be merged.
+//   - StrictValidation is overlay's when set; IncludeDeleted and TruncateExport are set when either
+//     sets them; MaxDepth and Limit are the lower of those set.
+//
+// It returns an error for a Logic other than LogicAnd, LogicOr or empty, for two different searches,
+// and for two different TimeZones, in which the date values of one Root would change meaning. Neither Root is modified.
+//
+// Example usage:
+//
+//	root, err := filter.MergeRoots(savedFilter, adHocFilter, filter.MergeOptions{})
+//	if err != nil {
+//	    return err
+//	}
+//	result, err := handler.DataGorm(db, root, pageIndex, pageSize)
+func MergeRoots(base, overlay Root, opts MergeOptions) (Root, error) {
+	for _, root := range []Root{base, overlay} {
+		switch root.Logic {
+		case "", LogicAnd, LogicOr:
+		default:
+			return Root{}, &InvalidFilterError{Err: fmt.Errorf("unknown logic '%s'", root.Logic)}
+		}
+	}
+	if base.TimeZone != "" && overlay.TimeZone != "" && base.TimeZone != overlay.TimeZone {
+		return Root{}, fmt.Errorf("cannot merge roots in different time zones: %s and %s", base.TimeZone, overlay.TimeZone)
+	}
+
+	merged := Root{Logic: LogicAnd, TimeZone: base.TimeZone}
+	if overlay.TimeZone != "" {
+		merged.TimeZone = overlay.TimeZone
+	}
+	for _, root := range []Root{base, overlay} {
+		switch conditions := len(root.FieldFilters) + len(root.Groups); {
+		case conditions == 0:
+		case root.Logic == LogicAnd || conditions == 1:
+			merged.FieldFilters = append(merged.FieldFilters, root.FieldFilters...)
+			merged.Groups = append(merged.Groups, root.Groups...)
+		default:
+			merged.Groups = append(merged.Groups, Root{Logic: root.Logic, FieldFilters: root.FieldFilters, Groups: root.Groups})
+		}
+	}
+
+	sorted := make(map[string]bool)
+	for _, sortFields := range [][]SortField{overlay.SortFields, base.SortFields} {
+		for _, sortField := range sortFields {
+			if key := strings.ToLower(sortField.Field); !sorted[key] {
+				sorted[key] = true
+				merged.SortFields = append(merged.SortFields, sortField)
+			}
+		}
+		if opts.ReplaceSortFields && len(overlay.SortFields) > 0 {
+			break
+		}
+	}
+
+	preloaded := make(map[string]bool)
+	for _, relation := range append(append([]string(nil), base.Preload...), overlay.Preload...) {
+		if key := strings.ToLower(relation); !preloaded[key] {
+			preloaded[key] = true
+			merged.Preload = append(merged.Preload, relation)
+		}
+	}
+	overridden := make(map[string]bool, len(overlay.Preloads))
+	for _, preload := range overlay.Preloads {
+		overridden[strings.ToLower(preload.Relation)] = true
+	}
+	for _, preload := range base.Preloads {
+		if !overridden[strings.ToLower(preload.Relation)] {
+			merged.Preloads = append(merged.Preloads, preload)
+		}
+	}
+	merged.Preloads = append(merged.Preloads, overlay.Preloads...)
+
+	aggregated := make(map[Aggregation]bool)
+	for _, aggregation := range append(append([]Aggregation(nil), base.Aggregations...), overlay.Aggregations...) {
+		key := Aggregation{Field: strings.ToLower(aggregation.Field), Func: aggregation.Func}
+		if !aggregated[key] {
+			aggregated[key] = true
+			merged.Aggregations = append(merged.Aggregations, aggregation)
+		}
+	}
+	merged.ChildCounts = append(append([]ChildCountFilter(nil), base.ChildCounts...), overlay.ChildCounts...)
+
+	merged.Search = base.Search
+	if searches(overlay.Search) {
+		if searches(base.Search) && !reflect.DeepEqual(base.Search, overlay.Search) {
+			return Root{}, &InvalidFilterError{Err: fmt.Errorf("cannot merge two searches: %q and %q",
+				base.Search.Value, overlay.Search.Value)}
+		}
+		merged.Search = overlay.Search
+	}
+	merged.SelectFields = append([]string(nil), base.SelectFields...)
+	if len(overlay.SelectFields) > 0 {
+		merged.SelectFields = append([]string(nil), overlay.SelectFields...)
+	}
+	merged.StrictValidation = base.StrictValidation
+	if overlay.StrictValidation != nil {
+		merged.StrictValidation = overlay.StrictValidation
+	}
+	merged.IncludeDeleted = base.IncludeDeleted || overlay.IncludeDeleted
+	merged.TruncateExport = base.TruncateExport || overlay.TruncateExport
+	merged.MaxDepth = lowerLimit(base.MaxDepth, overlay.MaxDepth)
+	merged.Limit = lowerLimit(base.Limit, overlay.Limit)
+	return merged, nil
+}
+
+// searches reports whether search looks for a term, as an empty Value skips the search
+func searches(search *SearchFilter) bool {
+	return search != nil && search.Value != ""
+}
+
+// lowerLimit returns the lower of two limits where <= 0 means no limit
+func lowerLimit(a, b int) int {
+	switch {
+	case a <= 0:
+		return max(b, 0)
+	case b <= 0 || a < b:
+		return a
+	}
+	return b
+}
@@ -0,0 +1,34 @@
+module github.com/Lands-Horizon-Corp/golang-filtering/filter/metrics
+
+go 1.25.4
+
+require (
+	github.com/Lands-Horizon-Corp/golang-filtering v0.0.0-00010101000000-000000000000
+	github.com/prometheus/client_golang v1.23.2
+	gorm.io/driver/sqlite v1.6.0
+	gorm.io/gorm v1.31.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/kennygrant/sanitize v1.2.4 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/mattn/go-sqlite3 v1.14.32 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.66.1 // indirect
+	github.com/prometheus/procfs v0.16.1 // indirect
+	go.yaml.in/yaml/v2 v2.4.2 // indirect
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	google.golang.org/protobuf v1.36.8 // indirect
+)
+
+// No tagged release of the root module includes the Observer API yet, so it is built from this
+// checkout. Replace this with a require of the first tag that does before publishing the module.
+replace github.com/Lands-Horizon-Corp/golang-filtering => ../..
@@ -0,0 +1,101 @@
+// Package metrics records the queries and exports of filter handlers as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a filter.Observer that records every query and export of the handlers it is set on:
+//
+//   - filter_queries_total{method,strategy}: queries and exports finished, failed or not
+//   - filter_query_duration_seconds{method,strategy}: their duration
+//   - filter_rows_returned{method}: the records matched by those that succeeded (QueryResultInfo.TotalSize)
+//   - filter_errors_total{kind}: those that failed, by the kind of their error (see ErrorKind)
+//
+// The method and strategy labels are QueryInfo.Method and QueryResultInfo.Strategy, the path the
+// Hybrid methods chose. One Collector can be shared by any number of handlers.
+type Collector struct {
+	queries  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	rows     *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewCollector returns a Collector registered on reg (prometheus.DefaultRegisterer when nil).
+// Like prometheus.MustRegister, it panics when the metrics are already registered on reg.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[User](filter.GolangFilteringConfig{
+//	    Observer: metrics.NewCollector(prometheus.DefaultRegisterer),
+//	})
+func NewCollector(reg prometheus.Registerer) *Collector {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	c := &Collector{
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "filter_queries_total",
+			Help: "Filter queries and exports finished, by method and strategy.",
+		}, []string{"method", "strategy"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "filter_query_duration_seconds",
+			Help:    "Duration of filter queries and exports, by method and strategy.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "strategy"}),
+		rows: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "filter_rows_returned",
+			Help:    "Records matched by successful filter queries and exports, by method.",
+			Buckets: prometheus.ExponentialBuckets(1, 10, 7),
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "filter_errors_total",
+			Help: "Failed filter queries and exports, by kind of error.",
+		}, []string{"kind"}),
+	}
+	reg.MustRegister(c.queries, c.duration, c.rows, c.errors)
+	return c
+}
+
+// OnQueryStart implements filter.Observer, recording the query when it finishes
+func (c *Collector) OnQueryStart(info filter.QueryInfo) func(filter.QueryResultInfo) {
+	return func(result filter.QueryResultInfo) {
+		strategy := string(result.Strategy)
+		c.queries.WithLabelValues(info.Method, strategy).Inc()
+		c.duration.WithLabelValues(info.Method, strategy).Observe(result.Duration.Seconds())
+		if result.Err != nil {
+			c.errors.WithLabelValues(ErrorKind(result.Err)).Inc()
+			return
+		}
+		c.rows.WithLabelValues(info.Method).Observe(float64(result.TotalSize))
+	}
+}
+
+// ErrorKind returns the kind label err is counted under in filter_errors_total: "unknown_field",
+// "invalid_value", "unsupported_mode" and "invalid_range" for the typed errors of invalid filters,
+// "validation" for other validation errors (e.g. an unknown logic), "canceled" and "deadline_exceeded"
+// for context errors, and "other" for the rest, such as database errors.
+func ErrorKind(err error) string {
+	var validation *filter.ValidationError
+	switch {
+	case errors.Is(err, filter.ErrUnknownField):
+		return "unknown_field"
+	case errors.Is(err, filter.ErrInvalidValue):
+		return "invalid_value"
+	case errors.Is(err, filter.ErrUnsupportedMode):
+		return "unsupported_mode"
+	case errors.Is(err, filter.ErrInvalidRange):
+		return "invalid_range"
+	case errors.As(err, &validation):
+		return "validation"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	}
+	return "other"
+}
@@ -0,0 +1,143 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// order is the model the Collector tests query
+type order struct {
+	ID       uint    `gorm:"primaryKey" json:"id"`
+	Customer string  `json:"customer"`
+	Total    float64 `json:"total"`
+}
+
+func generateOrders() []*order {
+	return []*order{
+		{ID: 1, Customer: "Alice", Total: 120},
+		{ID: 2, Customer: "Bob", Total: 40},
+		{ID: 3, Customer: "Carol", Total: 75},
+		{ID: 4, Customer: "Dave", Total: 10},
+	}
+}
+
+func setupOrderDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&order{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateOrders()).Error; err != nil {
+		t.Fatalf("Failed to create orders: %v", err)
+	}
+	return db
+}
+
+// TestMetricsCollector tests that a Collector set as the Observer counts queries by method and strategy,
+// records the records they matched and counts failed queries by kind of error
+func TestMetricsCollector(t *testing.T) {
+	db := setupOrderDB(t)
+	reg := prometheus.NewRegistry()
+	handler := filter.NewFilter[order](filter.GolangFilteringConfig{
+		StrictValidation: true,
+		Observer:         metrics.NewCollector(reg),
+	})
+	all := filter.Root{Logic: filter.LogicAnd}
+	bigOrders := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "total", Value: 50, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+	}}
+	invalid := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "total", Value: "abc", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+	}}
+	memory := filter.HybridOptions{StrategyFunc: func(int64, filter.Root) filter.Strategy { return filter.StrategyMemory }}
+
+	if _, err := handler.DataQuery(generateOrders(), all, 0, 10); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if _, err := handler.DataQuery(generateOrders(), bigOrders, 0, 10); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if _, err := handler.DataGorm(db, bigOrders, 0, 10); err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if _, err := handler.HybridWithOptions(t.Context(), db, 1000, all, 0, 10, memory); err != nil {
+		t.Fatalf("Hybrid failed: %v", err)
+	}
+	if _, err := handler.DataGorm(db, invalid, 0, 10); !errors.Is(err, filter.ErrInvalidValue) {
+		t.Fatalf("Expected an invalid value error, got %v", err)
+	}
+
+	expected := `
+# HELP filter_errors_total Failed filter queries and exports, by kind of error.
+# TYPE filter_errors_total counter
+filter_errors_total{kind="invalid_value"} 1
+# HELP filter_queries_total Filter queries and exports finished, by method and strategy.
+# TYPE filter_queries_total counter
+filter_queries_total{method="DataGorm",strategy="database"} 2
+filter_queries_total{method="DataQuery",strategy="memory"} 2
+filter_queries_total{method="Hybrid",strategy="memory"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "filter_queries_total", "filter_errors_total"); err != nil {
+		t.Errorf("Unexpected counters: %v", err)
+	}
+
+	// All 4 orders, then the 2 over 50; the failed DataGorm records no rows
+	rows := map[string][2]float64{"DataQuery": {2, 6}, "DataGorm": {1, 2}, "Hybrid": {1, 4}}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		switch family.GetName() {
+		case "filter_rows_returned":
+			for _, metric := range family.GetMetric() {
+				method := metric.GetLabel()[0].GetValue()
+				got := [2]float64{float64(metric.GetHistogram().GetSampleCount()), metric.GetHistogram().GetSampleSum()}
+				if got != rows[method] {
+					t.Errorf("Expected %s to record %v queries and rows, got %v", method, rows[method], got)
+				}
+				delete(rows, method)
+			}
+		case "filter_query_duration_seconds":
+			if count := len(family.GetMetric()); count != 3 {
+				t.Errorf("Expected durations for 3 method and strategy pairs, got %d", count)
+			}
+		}
+	}
+	if len(rows) != 0 {
+		t.Errorf("Expected rows recorded for every method, missing %v", rows)
+	}
+}
+
+// TestMetricsErrorKind tests the kind labels of typed, validation, context and other errors
+func TestMetricsErrorKind(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&filter.UnknownFieldError{Field: "nope"}, "unknown_field"},
+		{fmt.Errorf("filter 0: %w", &filter.UnsupportedModeError{Field: "total"}), "unsupported_mode"},
+		{errors.Join(&filter.ValidationError{Field: "total", Err: &filter.InvalidRangeError{Field: "total"}}), "invalid_range"},
+		{&filter.ValidationError{Err: errors.New("unknown logic: xor")}, "validation"},
+		{fmt.Errorf("query failed: %w", context.DeadlineExceeded), "deadline_exceeded"},
+		{context.Canceled, "canceled"},
+		{errors.New("database is locked"), "other"},
+	}
+	for _, tt := range tests {
+		if got := metrics.ErrorKind(tt.err); got != tt.expected {
+			t.Errorf("Expected kind %s for %v, got %s", tt.expected, tt.err, got)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package filter
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryInfo describes a query or export as it starts, see Observer
+type QueryInfo struct {
+	// Context is the ctx passed to the ...Ctx methods (context.Background() when called through their
+	// variants without ctx), the context set on db (db.WithContext) for the GORM methods without a Ctx
+	// variant, and context.Background() for the other in-memory methods
+	Context     context.Context
+	Method      string   // Public method called, without the Ctx/WithOptions/WithPreset suffix (e.g. "DataGorm", "HybridCSV")
+	FilterCount int      // Field filters in the Root, including nested groups
+	SortCount   int      // Sort fields in the Root
+	Strategy    Strategy // Path the method always takes; empty for the Hybrid methods, which report theirs on completion
+}
+
+// QueryResultInfo describes a finished query or export, see Observer
+type QueryResultInfo struct {
+	Duration time.Duration
+	// TotalSize is the number of matching records: across all pages for the paginated methods, on the
+	// page for the cursor methods, the sum of the counts for the Facet methods, the rows written for exports,
+	// and the records passed to fn for the ForEach methods
+	TotalSize int
+	Scanned   int      // Records examined in memory (0 when filtered in SQL)
+	Strategy  Strategy // Path taken
+	Truncated bool     // The no-page query or export stopped at GolangFilteringConfig.MaxExportRows (see Root.TruncateExport)
+	Err       error
+	// FallbackErr is the in-memory error after which Hybrid fell back to the database strategy
+	// (see HybridOptions.FallbackToGormOnError); Strategy is then StrategyDatabase
+	FallbackErr error
+}
+
+// Observer is notified around every query and export of a Handler, e.g. to log, record metrics or
+// trace. Methods that delegate to another public method (DataGorm to DataGormCtx, HybridCSV to
+// GormNoPaginationCSV) are reported once, under the method the caller invoked.
+type Observer interface {
+	// OnQueryStart is called before any work and returns the function called with the outcome,
+	// or nil when the outcome is not needed
+	OnQueryStart(info QueryInfo) func(QueryResultInfo)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(info QueryInfo) func(QueryResultInfo)
+
+// OnQueryStart calls fn(info)
+func (fn ObserverFunc) OnQueryStart(info QueryInfo) func(QueryResultInfo) {
+	return fn(info)
+}
+
+// observe runs a public method under f.observer: run does the work and fills in TotalSize, Scanned
+// and, for the Hybrid methods, Strategy. Without an observer run is called directly.
+func observe[T, R any](
+	f *Handler[T],
+	ctx context.Context,
+	method string,
+	filterRoot Root,
+	strategy Strategy,
+	run func(report *QueryResultInfo) (R, error),
+) (R, error) {
+	report := QueryResultInfo{Strategy: strategy}
+	if f.observer == nil {
+		return run(&report)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	finish := f.observer.OnQueryStart(QueryInfo{
+		Context:     ctx,
+		Method:      method,
+		FilterCount: len(flattenFieldFilters(filterRoot)),
+		SortCount:   len(filterRoot.SortFields),
+		Strategy:    strategy,
+	})
+	start := time.Now()
+	result, err := run(&report)
+	report.Duration = time.Since(start)
+	report.Err = err
+	if finish != nil {
+		finish(report)
+	}
+	return result, err
+}
+
+// dbContext returns the context set on db, for QueryInfo of methods without a ctx parameter
+func dbContext(db *gorm.DB) context.Context {
+	if db != nil && db.Statement != nil && db.Statement.Context != nil {
+		return db.Statement.Context
+	}
+	return context.Background()
+}
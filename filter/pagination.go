@@ -0,0 +1,131 @@
+package filter
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DefaultMaxExportRows is the GolangFilteringConfig.MaxExportRows used when it is zero
+const DefaultMaxExportRows = 100_000
+
+// ExportLimitError is returned by the no-page queries and exports when more records match than
+// GolangFilteringConfig.MaxExportRows, unless Root.TruncateExport is set
+type ExportLimitError struct {
+	Limit int // GolangFilteringConfig.MaxExportRows in effect
+}
+
+func (e *ExportLimitError) Error() string {
+	return fmt.Sprintf("result exceeds export limit of %d rows", e.Limit)
+}
+
+// defaultPageSize is the number of records per page when pageSize <= 0
+const defaultPageSize = 30
+
+// newPaginationResult returns the result for the page at pageIndex, reading a negative pageIndex as 0,
+// pageSize <= 0 as defaultPageSize and capping it at MaxPageSize, so every path reports the page it
+// actually returns
+func (f *Handler[T]) newPaginationResult(pageIndex, pageSize int) PaginationResult[T] {
+	pageIndex, pageSize = normalizePage(pageIndex, pageSize)
+	pageSize, clamped := f.clampPageSize(pageSize)
+	return PaginationResult[T]{
+		PageIndex:       pageIndex,
+		PageSize:        pageSize,
+		PageSizeClamped: clamped,
+		HasPrev:         pageIndex > 0,
+	}
+}
+
+// clampPageSize caps pageSize at MaxPageSize, reporting whether it was capped
+func (f *Handler[T]) clampPageSize(pageSize int) (int, bool) {
+	if f.maxPageSize > 0 && pageSize > f.maxPageSize {
+		return f.maxPageSize, true
+	}
+	return pageSize, false
+}
+
+// limitItems returns the first limit items of data, or all of them when limit <= 0 (see Root.Limit)
+func limitItems[T any](data []*T, limit int) []*T {
+	if limit > 0 && len(data) > limit {
+		return data[:limit]
+	}
+	return data
+}
+
+// exportRoot returns filterRoot with its Limit lowered to one record past MaxExportRows, so fetching it
+// tells whether the export limit is exceeded without fetching every match (filterRoot when no lower)
+func (f *Handler[T]) exportRoot(filterRoot Root) Root {
+	if f.maxExportRows <= 0 || (filterRoot.Limit > 0 && filterRoot.Limit <= f.maxExportRows) {
+		return filterRoot
+	}
+	filterRoot.Limit = f.maxExportRows + 1
+	return filterRoot
+}
+
+// limitExport applies MaxExportRows to data, fetched with exportRoot(filterRoot): past the limit it returns
+// the first MaxExportRows records and sets report.Truncated when filterRoot.TruncateExport, and an
+// *ExportLimitError otherwise
+func (f *Handler[T]) limitExport(data []*T, filterRoot Root, report *QueryResultInfo) ([]*T, error) {
+	if f.maxExportRows <= 0 || len(data) <= f.maxExportRows {
+		return data, nil
+	}
+	if !filterRoot.TruncateExport {
+		return nil, &ExportLimitError{Limit: f.maxExportRows}
+	}
+	report.Truncated = true
+	return data[:f.maxExportRows], nil
+}
+
+// limitExportBatch is limitExport for batch, the records a stream fetched with exportRoot(filterRoot)
+// after the written ones
+func (f *Handler[T]) limitExportBatch(batch []*T, written int, filterRoot Root, report *QueryResultInfo) ([]*T, error) {
+	if f.maxExportRows <= 0 || written+len(batch) <= f.maxExportRows {
+		return batch, nil
+	}
+	if !filterRoot.TruncateExport {
+		return nil, &ExportLimitError{Limit: f.maxExportRows}
+	}
+	report.Truncated = true
+	return batch[:f.maxExportRows-written], nil
+}
+
+// checkExportLimit returns the *ExportLimitError of limitExportBatch before a stream writes any row when
+// query, built from exportRoot(filterRoot), matches more than MaxExportRows records, so a failed export
+// leaves no partial output. The LIMIT of exportRoot caps the count at MaxExportRows + 1 rows.
+func (f *Handler[T]) checkExportLimit(db *gorm.DB, query *gorm.DB, filterRoot Root) error {
+	if f.maxExportRows <= 0 || filterRoot.TruncateExport {
+		return nil
+	}
+	d := dialectOf(db)
+	// GORM adds the columns of joined belongs-to relations to any SELECT, so the rows are counted
+	// in a derived table
+	matched := query.Session(&gorm.Session{}).Select(f.primaryKeyColumn(d, f.mainTableName(d)))
+	var count int64
+	if err := db.Session(&gorm.Session{NewDB: true}).Table("(?) AS matched_rows", matched).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count records: %w", err)
+	}
+	if count > int64(f.maxExportRows) {
+		return &ExportLimitError{Limit: f.maxExportRows}
+	}
+	return nil
+}
+
+// normalizePage reads a negative pageIndex as 0 and pageSize <= 0 as defaultPageSize
+func normalizePage(pageIndex, pageSize int) (int, int) {
+	if pageIndex < 0 {
+		pageIndex = 0
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return pageIndex, pageSize
+}
+
+// setTotal sets TotalSize and the page counts from the number of matching records. TotalPage is at
+// least 1, so an empty result is page 1 of 1, and OutOfRange reports a PageIndex past the last page.
+func (r *PaginationResult[T]) setTotal(totalSize int) {
+	r.TotalSize = totalSize
+	r.TotalPage = max(1, (totalSize+r.PageSize-1)/r.PageSize)
+	r.HasNext = r.PageIndex < r.TotalPage-1
+	r.OutOfRange = r.PageIndex >= r.TotalPage
+}
@@ -0,0 +1,224 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// knownModes lists every supported filter mode
+var knownModes = []Mode{
+	ModeEqual, ModeNotEqual, ModeContains, ModeNotContains, ModeStartsWith, ModeEndsWith,
+	ModeIsEmpty, ModeIsNotEmpty, ModeGT, ModeGTE, ModeLT, ModeLTE, ModeRange,
+	ModeBefore, ModeAfter, ModeIn, ModeNotIn, ModeExists, ModeNotExists,
+}
+
+// knownDataTypes lists every supported data type
+var knownDataTypes = []DataType{
+	DataTypeNumber, DataTypeText, DataTypeBool, DataTypeDate, DataTypeTime, DataTypeUUID, DataTypeDuration,
+}
+
+// ParseRootFromJSON decodes a JSON filter payload into a Root.
+// Mode, DataType, Logic and sort Order values are validated and matched case-insensitively
+// (e.g. "isempty" becomes ModeIsEmpty). An empty Logic becomes LogicOr, as queries treat it, and an empty
+// sort Order defaults to SortOrderAsc. Range values decoded as JSON objects are converted to Range.
+// Numbers are decoded as json.Number, so integers above 2^53 (e.g. snowflake IDs) keep every digit.
+//
+// Example usage:
+//
+//	root, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"age","value":30,"mode":"gte","dataType":"number"}],"logic":"and"}`))
+func ParseRootFromJSON(data []byte) (Root, error) {
+	var root Root
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return Root{}, &InvalidFilterError{Err: fmt.Errorf("invalid filter JSON: %w", err)}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return Root{}, &InvalidFilterError{Err: errors.New("invalid filter JSON: unexpected data after the filter")}
+	}
+	if err := normalizeRoot(&root); err != nil {
+		return Root{}, err
+	}
+	return root, nil
+}
+
+// ParseRootFromBase64 decodes a base64-encoded JSON filter payload into a Root.
+// Both standard and URL-safe encodings are accepted, with or without padding.
+//
+// Example usage:
+//
+//	root, err := filter.ParseRootFromBase64(c.QueryParam("filter"))
+func ParseRootFromBase64(encoded string) (Root, error) {
+	encoded = strings.TrimSpace(encoded)
+	encodings := []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	}
+	for _, encoding := range encodings {
+		if data, err := encoding.DecodeString(encoded); err == nil {
+			return ParseRootFromJSON(data)
+		}
+	}
+	return Root{}, &InvalidFilterError{Err: errors.New("invalid base64 filter payload")}
+}
+
+// normalizeRoot validates and canonicalizes enum values in root and its nested groups
+func normalizeRoot(root *Root) error {
+	logic, err := normalizeLogic(root.Logic)
+	if err != nil {
+		return err
+	}
+	root.Logic = logic
+
+	for i := range root.FieldFilters {
+		if err := normalizeFieldFilter(&root.FieldFilters[i]); err != nil {
+			return err
+		}
+	}
+
+	if root.Search != nil && root.Search.Mode != "" {
+		mode, ok := lookupMode(root.Search.Mode)
+		if !ok {
+			return &InvalidFilterError{Err: fmt.Errorf("unknown search mode '%s'", root.Search.Mode)}
+		}
+		root.Search.Mode = mode
+	}
+
+	for i := range root.SortFields {
+		sortField := &root.SortFields[i]
+		switch strings.ToLower(string(sortField.Order)) {
+		case "", string(SortOrderAsc):
+			sortField.Order = SortOrderAsc
+		case string(SortOrderDesc):
+			sortField.Order = SortOrderDesc
+		default:
+			return &InvalidFilterError{Field: sortField.Field, Err: fmt.Errorf("unknown sort order '%s'", sortField.Order)}
+		}
+		switch strings.ToLower(string(sortField.Nulls)) {
+		case string(NullsDefault):
+			sortField.Nulls = NullsDefault
+		case string(NullsFirst):
+			sortField.Nulls = NullsFirst
+		case string(NullsLast):
+			sortField.Nulls = NullsLast
+		default:
+			return &InvalidFilterError{Field: sortField.Field, Err: fmt.Errorf("unknown nulls order '%s'", sortField.Nulls)}
+		}
+		if err := checkLanguage(sortField.Language); err != nil {
+			return &InvalidFilterError{Field: sortField.Field, Err: fmt.Errorf("unknown language '%s'", sortField.Language)}
+		}
+	}
+
+	for i := range root.Aggregations {
+		aggregation := &root.Aggregations[i]
+		aggregation.Func = AggregateFunc(strings.ToLower(string(aggregation.Func)))
+		if _, ok := aggregateSQL[aggregation.Func]; !ok {
+			return &InvalidFilterError{Field: aggregation.Field, Err: fmt.Errorf("unknown aggregate function '%s'", aggregation.Func)}
+		}
+	}
+
+	for i := range root.ChildCounts {
+		count := &root.ChildCounts[i]
+		// The count is compared like a number field named after the relation
+		filter := FieldFilter{Field: count.Relation, Value: count.Value, Mode: count.Mode, DataType: DataTypeNumber}
+		if err := normalizeFieldFilter(&filter); err != nil {
+			return err
+		}
+		count.Mode, count.Value = filter.Mode, filter.Value
+		if err := normalizeRoot(&count.Where); err != nil {
+			return err
+		}
+	}
+
+	for i := range root.Groups {
+		if err := normalizeRoot(&root.Groups[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeLogic validates a logic value, making an empty value LogicOr
+func normalizeLogic(logic Logic) (Logic, error) {
+	switch strings.ToLower(string(logic)) {
+	case string(LogicAnd):
+		return LogicAnd, nil
+	case "", string(LogicOr):
+		return LogicOr, nil
+	default:
+		return logic, &InvalidFilterError{Err: fmt.Errorf("unknown logic '%s'", logic)}
+	}
+}
+
+// normalizeFieldFilter validates the mode and data type of a filter and converts its value
+func normalizeFieldFilter(filter *FieldFilter) error {
+	if filter.Field == "" {
+		return &InvalidFilterError{Err: errors.New("filter field cannot be empty")}
+	}
+
+	mode, ok := lookupMode(filter.Mode)
+	if !ok {
+		return &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("unknown mode '%s' on field '%s'", filter.Mode, filter.Field)}
+	}
+	filter.Mode = mode
+
+	// Exists filters check a relation, not a value, so they need no data type
+	if isExistsMode(filter.Mode) && filter.DataType == "" {
+		return nil
+	}
+	dataType, ok := lookupDataType(filter.DataType)
+	if !ok {
+		return &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("unknown data type '%s' on field '%s'", filter.DataType, filter.Field)}
+	}
+	filter.DataType = dataType
+
+	// Range values arrive as map[string]interface{} from JSON
+	if filter.Mode == ModeRange {
+		m, ok := filter.Value.(map[string]any)
+		if !ok {
+			return &InvalidRangeError{Field: filter.Field, Err: errors.New("not an object with 'from' and 'to'"),
+				reason: fmt.Sprintf("range value on field '%s' must be an object with 'from' and 'to'", filter.Field)}
+		}
+		_, hasFrom := m["from"]
+		_, hasTo := m["to"]
+		if !hasFrom || !hasTo {
+			return &InvalidRangeError{Field: filter.Field, Err: errors.New("missing 'from' or 'to'"),
+				reason: fmt.Sprintf("range value on field '%s' must have both 'from' and 'to'", filter.Field)}
+		}
+		rng, err := toRange(m)
+		if err != nil {
+			return &InvalidRangeError{Field: filter.Field, Err: err, reason: fmt.Sprintf("range value on field '%s': %v", filter.Field, err)}
+		}
+		filter.Value = rng
+	}
+	return nil
+}
+
+// lookupMode matches a mode case-insensitively against the known modes
+func lookupMode(mode Mode) (Mode, bool) {
+	for _, known := range knownModes {
+		if strings.EqualFold(string(known), string(mode)) {
+			return known, true
+		}
+	}
+	return mode, false
+}
+
+// lookupDataType matches a data type case-insensitively against the known data types
+func lookupDataType(dataType DataType) (DataType, bool) {
+	for _, known := range knownDataTypes {
+		if strings.EqualFold(string(known), string(dataType)) {
+			return known, true
+		}
+	}
+	return dataType, false
+}
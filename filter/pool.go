@@ -0,0 +1,41 @@
+package filter
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// slicePool recycles the []*T buffers in-memory queries match into, keyed by capacity class
+// (the next power of two), see GolangFilteringConfig.PoolBuffers. A nil *slicePool allocates
+// every buffer and drops released ones, so callers never check whether pooling is enabled.
+type slicePool[T any] struct {
+	classes [bits.UintSize]sync.Pool // Class c holds buffers with capacity 1<<c
+}
+
+// get returns an empty buffer with room for at least n items
+func (p *slicePool[T]) get(n int) []*T {
+	if p == nil || n <= 0 {
+		return make([]*T, 0, max(n, 0))
+	}
+	class := bits.Len(uint(n - 1))
+	if buf, ok := p.classes[class].Get().(*[]*T); ok {
+		return (*buf)[:0]
+	}
+	return make([]*T, 0, 1<<class)
+}
+
+// put releases buf for reuse by a later get. Its items are cleared first so the pool never keeps
+// records alive (past len(buf) a pooled buffer is always nil); buf must not be used afterwards.
+func (p *slicePool[T]) put(buf []*T) {
+	if p == nil || cap(buf) == 0 {
+		return
+	}
+	clear(buf)
+	class := bits.Len(uint(cap(buf))) - 1
+	if cap(buf) != 1<<class {
+		// Not allocated by get (e.g. grown by append); file it under the class it fully covers
+		buf = buf[: 0 : 1<<class]
+	}
+	buf = buf[:0]
+	p.classes[class].Put(&buf)
+}
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// preloads returns the Preload and Preloads of filterRoot with their relations resolved to GORM's
+// preload paths of Go field names ("orders.items" -> "Orders.Items"), and lists the relations that
+// are not relations of T. clause.Associations ("*") is kept as the last segment of a path.
+// Without any, T is not parsed, so it need not be a GORM model.
+func (f *Handler[T]) preloads(d sqlDialect, filterRoot Root) ([]Preload, []string, error) {
+	if len(filterRoot.Preload) == 0 && len(filterRoot.Preloads) == 0 {
+		return nil, nil, nil
+	}
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	all := make([]Preload, 0, len(filterRoot.Preload)+len(filterRoot.Preloads))
+	for _, relation := range filterRoot.Preload {
+		all = append(all, Preload{Relation: relation})
+	}
+	all = append(all, filterRoot.Preloads...)
+
+	var resolved []Preload
+	var unknown []string
+	for _, preload := range all {
+		segments := strings.Split(preload.Relation, ".")
+		fieldSchema := modelSchema
+		names := make([]string, 0, len(segments))
+		for i, segment := range segments {
+			if segment == clause.Associations && i == len(segments)-1 {
+				names = append(names, segment)
+				break
+			}
+			name := f.relationField(d, fieldSchema, segment)
+			rel := fieldSchema.Relationships.Relations[name]
+			if rel == nil {
+				names = nil
+				break
+			}
+			names = append(names, name)
+			fieldSchema = rel.FieldSchema
+		}
+		if names == nil {
+			unknown = append(unknown, preload.Relation)
+			continue
+		}
+		resolved = append(resolved, Preload{Relation: strings.Join(names, "."), Conditions: preload.Conditions})
+	}
+	return resolved, unknown, nil
+}
+
+// applyPreloads adds the Preload and Preloads of filterRoot to query. Relations that are not relations
+// of T are an *UnknownFieldError with StrictValidation or RejectUnknownFields, and are skipped otherwise,
+// so GORM never fails the fetch on them. Models GORM cannot parse are preloaded as given.
+func (f *Handler[T]) applyPreloads(db *gorm.DB, query *gorm.DB, filterRoot Root) (*gorm.DB, error) {
+	if len(filterRoot.Preload) == 0 && len(filterRoot.Preloads) == 0 {
+		return query, nil
+	}
+	preloads, unknown, err := f.preloads(dialectOf(db), filterRoot)
+	if err != nil {
+		for _, relation := range filterRoot.Preload {
+			query = query.Preload(relation)
+		}
+		for _, preload := range filterRoot.Preloads {
+			query = query.Preload(preload.Relation, preload.Conditions...)
+		}
+		return query, nil
+	}
+	if len(unknown) > 0 && (f.isStrict(filterRoot) || f.rejectUnknown) {
+		return nil, unknownPreloadError(unknown)
+	}
+	for _, preload := range preloads {
+		query = query.Preload(preload.Relation, preload.Conditions...)
+	}
+	return query, nil
+}
+
+// unknownPreloadError returns the error for preload relations that are not relations of T
+func unknownPreloadError(unknown []string) error {
+	if len(unknown) == 1 {
+		return &UnknownFieldError{Field: unknown[0], reason: "unknown preload relation " + unknown[0]}
+	}
+	return &UnknownFieldError{Field: unknown[0], Fields: unknown,
+		reason: fmt.Sprintf("unknown preload relations: %s", strings.Join(unknown, ", "))}
+}
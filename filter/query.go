@@ -1,17 +1,21 @@
 package filter
 
 import (
-	"bytes"
-	"encoding/csv"
+	"context"
 	"fmt"
 	"runtime"
 	"sort"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 )
 
+// ctxCheckInterval is how many items a worker processes between context cancellation checks
+const ctxCheckInterval = 1024
+
+// parallelThreshold is the smallest slice filterParallel splits across worker goroutines
+const parallelThreshold = 1000
+
 // DataQuery performs in-memory filtering with parallel processing.
 // It filters the provided data slice based on the filter configuration and returns paginated results.
 func (f *Handler[T]) DataQuery(
@@ -20,165 +24,73 @@ func (f *Handler[T]) DataQuery(
 	pageIndex int,
 	pageSize int,
 ) (*PaginationResult[T], error) {
-	result := PaginationResult[T]{
-		PageIndex: pageIndex,
-		PageSize:  pageSize,
-	}
-
-	// Set defaults if not provided - use 0-based indexing
-	if result.PageIndex < 0 {
-		result.PageIndex = 0
-	}
-	if result.PageSize <= 0 {
-		result.PageSize = 30
-	}
-
-	if len(data) == 0 {
-		result.Data = data // Reuse the empty slice
-		return &result, nil
-	}
+	return f.DataQueryCtx(context.Background(), data, filterRoot, pageIndex, pageSize)
+}
 
-	type filterGetter struct {
-		filter FieldFilter
-		getter func(*T) any
-	}
-	valids := make([]filterGetter, 0, len(filterRoot.FieldFilters))
-	for _, filter := range filterRoot.FieldFilters {
-		if getter, exists := f.getters[filter.Field]; exists {
-			valids = append(valids, filterGetter{filter: filter, getter: getter})
+// DataQueryCtx is DataQuery with cancellation support.
+// Workers check ctx periodically and the call returns ctx.Err() promptly once ctx is done.
+func (f *Handler[T]) DataQueryCtx(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return observe(f, ctx, "DataQuery", filterRoot, StrategyMemory, func(report *QueryResultInfo) (*PaginationResult[T], error) {
+		result, err := f.dataQuery(ctx, data, filterRoot, pageIndex, pageSize)
+		if err == nil {
+			report.TotalSize, report.Scanned = result.TotalSize, len(data)
 		}
-	}
-
-	numCPU := runtime.NumCPU()
-	chunkSize := (len(data) + numCPU - 1) / numCPU
-
-	// Pre-allocate result slices with exact capacity to avoid reallocations
-	resultChunks := make([][]*T, numCPU)
-	for i := range numCPU {
-		resultChunks[i] = make([]*T, 0, chunkSize)
-	}
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var filterErr error
-
-	// Atomic counter for progress tracking
-	var processedCount int64
-
-	for i := range numCPU {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
+		return result, err
+	})
+}
 
-			start := workerID * chunkSize
-			end := min(start+chunkSize, len(data))
-			if start >= len(data) {
-				return
-			}
+// dataQuery implements DataQueryCtx
+func (f *Handler[T]) dataQuery(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	// Default the page size and use 0-based indexing before anything is reported
+	result := f.newPaginationResult(pageIndex, pageSize)
+	result.AppliedFilters, result.IgnoredFields, result.IgnoredSorts = f.appliedRoot(filterRoot, true)
 
-			localed := resultChunks[workerID] // Reuse pre-allocated slice
-
-			for _, item := range data[start:end] {
-				// If no filters are provided, include all items
-				if len(valids) == 0 {
-					localed = append(localed, item)
-				} else {
-					matches := filterRoot.Logic == LogicAnd
-					for _, fg := range valids {
-						value := fg.getter(item)
-						var match bool
-						var err error
-						switch fg.filter.DataType {
-						case DataTypeNumber:
-							match, _, err = f.applyNumber(value, fg.filter)
-						case DataTypeText:
-							match, _, err = f.applyText(value, fg.filter)
-						case DataTypeDate:
-							match, _, err = f.applyDate(value, fg.filter)
-						case DataTypeBool:
-							match, _, err = f.applyBool(value, fg.filter)
-						case DataTypeTime:
-							match, _, err = f.applyTime(value, fg.filter)
-						default:
-							err = fmt.Errorf("unsupported data type: %s", fg.filter.DataType)
-						}
-						if err != nil {
-							mu.Lock()
-							if filterErr == nil {
-								filterErr = err
-							}
-							mu.Unlock()
-							return
-						}
-						if match != (filterRoot.Logic == LogicAnd) {
-							matches = match
-							break
-						}
-					}
-					if matches {
-						localed = append(localed, item) // Only append pointers, no data cloning
-					}
-				}
-				atomic.AddInt64(&processedCount, 1)
-			}
-			resultChunks[workerID] = localed
-		}(i)
+	filterRoot, err := f.restrictMemoryRoot(filterRoot)
+	if err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
-
-	if filterErr != nil {
-		return nil, filterErr
+	filteredData, err := f.filterMatches(ctx, data, filterRoot, true)
+	if err != nil {
+		return nil, err
 	}
-
-	// Calculate total size first
-	totalSize := 0
-	for _, chunk := range resultChunks {
-		totalSize += len(chunk)
+	if len(data) == 0 {
+		result.Data = filteredData // Reuse the empty slice
+		result.setTotal(0)
+		return &result, nil
 	}
-
-	// Pre-allocate exactly the size needed - no reallocation
-	filteredData := make([]*T, 0, totalSize)
-	for _, chunk := range resultChunks {
-		filteredData = append(filteredData, chunk...) // Only copying pointers, not data
+	// Only the page is returned, copied out of pooled memory below
+	defer f.buffers.put(filteredData)
+
+	// Sort after filtering. Without sort fields (and with DisableSortTiebreaker or no primary key),
+	// the matches keep their order in data, whatever the number of workers.
+	if sortFields := f.withTiebreaker(filterRoot.SortFields); len(sortFields) > 0 {
+		// User provided sort fields, then the primary key - use them
+		texts := f.textComparers(sortFields)
+		sort.SliceStable(filteredData, func(i, j int) bool {
+			return f.compareItems(filteredData[i], filteredData[j], sortFields, texts) < 0
+		})
 	}
 
-	// Sort after filtering
-	if len(filterRoot.SortFields) > 0 {
-		// User provided sort fields - use them
-		sort.Slice(filteredData, func(i, j int) bool {
-			return f.compareItems(filteredData[i], filteredData[j], filterRoot.SortFields) < 0
-		})
-	} else {
-		// No user-provided sort fields - add default sorting for consistent pagination
-		// This ensures pagination results are deterministic and prevents duplicate records across pages
-		sort.Slice(filteredData, func(i, j int) bool {
-			// Default sort by ID field if it exists, otherwise maintain insertion order
-			if idGetter, exists := f.getters["id"]; exists {
-				idA := idGetter(filteredData[i])
-				idB := idGetter(filteredData[j])
-				// Try to compare as numbers first, then as strings
-				if numA, okA := idA.(uint); okA {
-					if numB, okB := idB.(uint); okB {
-						return numA < numB
-					}
-				}
-				if numA, okA := idA.(int); okA {
-					if numB, okB := idB.(int); okB {
-						return numA < numB
-					}
-				}
-				// Fallback to string comparison
-				return fmt.Sprintf("%v", idA) < fmt.Sprintf("%v", idB)
-			}
-			// If no ID field, maintain original order (no sorting needed for consistency in memory)
-			return false
-		})
+	// Aggregate every matching record, before pagination
+	if result.Aggregates, err = f.aggregateItems(filteredData, filterRoot.Aggregations); err != nil {
+		return nil, err
 	}
 
 	// Apply pagination
-	result.TotalSize = len(filteredData)
-	result.TotalPage = (result.TotalSize + result.PageSize - 1) / result.PageSize
+	result.setTotal(len(filteredData))
 
 	// Calculate start and end indices for the requested page (0-based indexing)
 	startIdx := result.PageIndex * result.PageSize
@@ -197,6 +109,11 @@ func (f *Handler[T]) DataQuery(
 	// Return only the requested page - this is a slice view, not a copy
 	// No data cloning, just sharing pointers to the same underlying data
 	result.Data = filteredData[startIdx:endIdx]
+	if f.buffers != nil {
+		// filteredData goes back to the pool, so the page must not share its memory
+		result.Data = append(make([]*T, 0, endIdx-startIdx), result.Data...)
+	}
+	result.Data = f.afterFetch(result.Data)
 	return &result, nil
 }
 
@@ -206,101 +123,217 @@ func (f *Handler[T]) DataQueryNoPage(
 	data []*T,
 	filterRoot Root,
 ) ([]*T, error) {
-	if len(data) == 0 {
-		return data, nil // Return the empty slice directly
+	return f.DataQueryNoPageCtx(context.Background(), data, filterRoot)
+}
+
+// DataQueryNoPageCtx is DataQueryNoPage with cancellation support.
+// Workers check ctx periodically and the call returns ctx.Err() promptly once ctx is done.
+func (f *Handler[T]) DataQueryNoPageCtx(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+) ([]*T, error) {
+	return observe(f, ctx, "DataQueryNoPage", filterRoot, StrategyMemory, func(report *QueryResultInfo) ([]*T, error) {
+		filteredData, err := f.dataQueryNoPage(ctx, data, filterRoot, report)
+		report.TotalSize, report.Scanned = len(filteredData), len(data)
+		return filteredData, err
+	})
+}
+
+// dataQueryNoPage implements DataQueryNoPageCtx, capped at MaxExportRows (see limitExport)
+func (f *Handler[T]) dataQueryNoPage(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+	report *QueryResultInfo,
+) ([]*T, error) {
+	filteredData, err := f.filterSorted(ctx, data, f.exportRoot(filterRoot))
+	if err != nil {
+		return nil, err
+	}
+	filteredData, err = f.limitExport(filteredData, filterRoot, report)
+	if err != nil {
+		return nil, err
 	}
+	return f.afterFetch(filteredData), nil
+}
 
-	type filterGetter struct {
-		filter FieldFilter
-		getter func(*T) any
+// filterSorted is dataQueryNoPage without the FetchHooks, for QueryForEach
+func (f *Handler[T]) filterSorted(
+	ctx context.Context,
+	data []*T,
+	filterRoot Root,
+) ([]*T, error) {
+	filterRoot, err := f.restrictMemoryRoot(filterRoot)
+	if err != nil {
+		return nil, err
 	}
-	valids := make([]filterGetter, 0, len(filterRoot.FieldFilters))
-	for _, filter := range filterRoot.FieldFilters {
-		if getter, exists := f.getters[filter.Field]; exists {
-			valids = append(valids, filterGetter{filter: filter, getter: getter})
-		}
+
+	filteredData, err := f.filterParallel(ctx, data, filterRoot)
+	if err != nil {
+		return nil, err
 	}
 
-	numCPU := runtime.NumCPU()
-	chunkSize := (len(data) + numCPU - 1) / numCPU
+	// Sort after filtering, with the primary key as a tiebreaker like DataQuery
+	if sortFields := f.withTiebreaker(filterRoot.SortFields); len(sortFields) > 0 {
+		// Stable like DataQuery, so ties keep their order in data without a tiebreaker
+		texts := f.textComparers(sortFields)
+		sort.SliceStable(filteredData, func(i, j int) bool {
+			return f.compareItems(filteredData[i], filteredData[j], sortFields, texts) < 0
+		})
+	}
 
-	// Pre-allocate result slices with exact capacity to avoid reallocations
-	resultChunks := make([][]*T, numCPU)
-	for i := range numCPU {
-		resultChunks[i] = make([]*T, 0, chunkSize)
+	return limitItems(filteredData, filterRoot.Limit), nil
+}
+
+// filterUnsorted returns the items of data matching filterRoot in data order, for the counts, facets
+// and cursors, which do not depend on the order of the matches
+func (f *Handler[T]) filterUnsorted(ctx context.Context, data []*T, filterRoot Root) ([]*T, error) {
+	filterRoot, err := f.restrictMemoryRoot(filterRoot)
+	if err != nil {
+		return nil, err
 	}
+	return f.filterParallel(ctx, data, filterRoot)
+}
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var filterErr error
+// filterParallel returns the items of data matching the filters of filterRoot, which must already have
+// gone through restrictMemoryRoot, in data order, leaving out soft-deleted items unless
+// filterRoot.IncludeDeleted. Slices of at least parallelThreshold items are split into one chunk per
+// worker; smaller ones are filtered on the calling goroutine, where spawning workers costs more than
+// it saves. It is the single matching loop behind every in-memory query and export.
+func (f *Handler[T]) filterParallel(ctx context.Context, data []*T, filterRoot Root) ([]*T, error) {
+	return f.filterMatches(ctx, data, filterRoot, false)
+}
 
-	// Atomic counter for progress tracking
-	var processedCount int64
+// compiledRoot is what compileRoot caches for a Root
+type compiledRoot[T any] struct {
+	group  filterGroup[T]
+	counts []childCount[T]
+}
 
-	for i := range numCPU {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
+// compileRoot returns the filter group and ChildCounts of filterRoot compiled for matching items,
+// from the compile cache when filterRoot came out of it
+func (f *Handler[T]) compileRoot(filterRoot Root) (filterGroup[T], []childCount[T], error) {
+	if cached, ok := f.compiled.get("memory:" + filterRoot.compileKey); ok && filterRoot.compileKey != "" {
+		compiled := cached.(compiledRoot[T])
+		return compiled.group, compiled.counts, nil
+	}
+	group, err := f.buildFilterGroup(filterRoot)
+	if err != nil {
+		return filterGroup[T]{}, nil, err
+	}
+	counts, err := f.buildChildCounts(filterRoot)
+	if err != nil {
+		return filterGroup[T]{}, nil, err
+	}
+	if filterRoot.compileKey != "" {
+		f.compiled.put("memory:"+filterRoot.compileKey, compiledRoot[T]{group: group, counts: counts})
+	}
+	return group, counts, nil
+}
 
-			start := workerID * chunkSize
-			end := min(start+chunkSize, len(data))
-			if start >= len(data) {
-				return
-			}
+// filterMatches implements filterParallel. The per-worker buffers come from f.buffers and are released
+// once merged; with pooled, the merged slice comes from f.buffers too, and the caller must release it
+// with f.buffers.put after copying out what it returns.
+func (f *Handler[T]) filterMatches(ctx context.Context, data []*T, filterRoot Root, pooled bool) ([]*T, error) {
+	data = f.beforeQuery(data, filterRoot)
+
+	// Parse filter values up front so invalid filters fail regardless of the data
+	group, counts, err := f.compileRoot(filterRoot)
+	if err != nil {
+		return nil, err
+	}
 
-			localed := resultChunks[workerID] // Reuse pre-allocated slice
-
-			for _, item := range data[start:end] {
-				// If no filters are provided, include all items
-				if len(valids) == 0 {
-					localed = append(localed, item)
-				} else {
-					matches := filterRoot.Logic == LogicAnd
-					for _, fg := range valids {
-						value := fg.getter(item)
-						var match bool
-						var err error
-						switch fg.filter.DataType {
-						case DataTypeNumber:
-							match, _, err = f.applyNumber(value, fg.filter)
-						case DataTypeText:
-							match, _, err = f.applyText(value, fg.filter)
-						case DataTypeDate:
-							match, _, err = f.applyDate(value, fg.filter)
-						case DataTypeBool:
-							match, _, err = f.applyBool(value, fg.filter)
-						case DataTypeTime:
-							match, _, err = f.applyTime(value, fg.filter)
-						default:
-							err = fmt.Errorf("unsupported data type: %s", fg.filter.DataType)
-						}
-						if err != nil {
-							mu.Lock()
-							if filterErr == nil {
-								filterErr = err
-							}
-							mu.Unlock()
-							return
-						}
-						if match != (filterRoot.Logic == LogicAnd) {
-							matches = match
-							break
-						}
-					}
-					if matches {
-						localed = append(localed, item) // Only append pointers, no data cloning
-					}
+	if len(data) == 0 {
+		if pooled {
+			// data may be a hook's view of the caller's slice, which must never reach the pool
+			return f.buffers.get(0), nil
+		}
+		return data, nil // Reuse the empty slice
+	}
+
+	workers := f.maxWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if len(data) < parallelThreshold {
+		workers = 1
+	}
+	chunkSize := (len(data) + workers - 1) / workers
+	excludeDeleted := f.isDeleted != nil && !filterRoot.IncludeDeleted
+
+	// Pre-allocate result slices with exact capacity to avoid reallocations
+	resultChunks := make([][]*T, workers)
+	errs := make([]error, workers)
+
+	filterChunk := func(workerID int) {
+		start := workerID * chunkSize
+		end := min(start+chunkSize, len(data))
+		if start >= len(data) {
+			return
+		}
+
+		localed := f.buffers.get(end - start)
+		for idx, item := range data[start:end] {
+			// Periodically stop if the context has been cancelled
+			if idx%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					errs[workerID] = err
+					return
 				}
-				atomic.AddInt64(&processedCount, 1)
 			}
-			resultChunks[workerID] = localed
-		}(i)
+
+			// Soft-deleted items are skipped like GORM's default scope does
+			if excludeDeleted && f.isDeleted(item) {
+				continue
+			}
+			// If no filters are provided, include all items
+			if group.isEmpty() && len(counts) == 0 {
+				localed = append(localed, item)
+				continue
+			}
+			matches := true
+			var err error
+			if !group.isEmpty() {
+				matches, err = f.matchGroup(item, group)
+			}
+			if matches && err == nil && len(counts) > 0 {
+				matches, err = matchChildCounts(item, counts)
+			}
+			if err != nil {
+				errs[workerID] = err
+				return
+			}
+			if matches {
+				localed = append(localed, item) // Only append pointers, no data cloning
+			}
+		}
+		resultChunks[workerID] = localed
 	}
 
-	wg.Wait()
+	if workers == 1 {
+		filterChunk(0)
+	} else {
+		var wg sync.WaitGroup
+		for i := range workers {
+			wg.Add(1)
+			go func(workerID int) {
+				defer wg.Done()
+				filterChunk(workerID)
+			}(i)
+		}
+		wg.Wait()
+	}
 
-	if filterErr != nil {
-		return nil, filterErr
+	defer func() {
+		for _, chunk := range resultChunks {
+			f.buffers.put(chunk)
+		}
+	}()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Calculate total size first
@@ -310,18 +343,15 @@ func (f *Handler[T]) DataQueryNoPage(
 	}
 
 	// Pre-allocate exactly the size needed - no reallocation
-	filteredData := make([]*T, 0, totalSize)
+	var filteredData []*T
+	if pooled {
+		filteredData = f.buffers.get(totalSize)
+	} else {
+		filteredData = make([]*T, 0, totalSize)
+	}
 	for _, chunk := range resultChunks {
 		filteredData = append(filteredData, chunk...) // Only copying pointers, not data
 	}
-
-	// Sort after filtering
-	if len(filterRoot.SortFields) > 0 {
-		sort.Slice(filteredData, func(i, j int) bool {
-			return f.compareItems(filteredData[i], filteredData[j], filterRoot.SortFields) < 0
-		})
-	}
-
 	return filteredData, nil
 }
 
@@ -332,50 +362,51 @@ func (f *Handler[T]) DataQueryNoPageCSV(
 	data []*T,
 	filterRoot Root,
 ) ([]byte, error) {
-	// Use DataQueryNoPage to get filtered results
-	filteredData, err := f.DataQueryNoPage(data, filterRoot)
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter data: %w", err)
-	}
+	return f.DataQueryNoPageCSVWithOptions(data, filterRoot, DefaultCSVOptions())
+}
 
-	// Build CSV content using encoding/csv
-	var csvBuffer strings.Builder
-	csvWriter := csv.NewWriter(&csvBuffer)
+// DataQueryNoPageCSVWithOptions is DataQueryNoPageCSV with a custom delimiter, optional headers,
+// explicit column selection and ordering, and the text written for nil values.
+// Columns not listed in opts.Columns are omitted; unknown column names return an error.
+func (f *Handler[T]) DataQueryNoPageCSVWithOptions(
+	data []*T,
+	filterRoot Root,
+	opts CSVOptions,
+) ([]byte, error) {
+	return observe(f, context.Background(), "DataQueryNoPageCSV", filterRoot, StrategyMemory, func(report *QueryResultInfo) ([]byte, error) {
+		return f.dataQueryCSV(data, filterRoot, opts, report)
+	})
+}
 
-	// Sort field names for deterministic column ordering
-	fieldNames := make([]string, 0, len(f.getters))
-	for fieldName := range f.getters {
-		fieldNames = append(fieldNames, fieldName)
+// dataQueryCSV implements DataQueryNoPageCSVWithOptions
+func (f *Handler[T]) dataQueryCSV(data []*T, filterRoot Root, opts CSVOptions, report *QueryResultInfo) ([]byte, error) {
+	// Validate columns before filtering
+	if _, err := f.csvColumns(opts); err != nil {
+		return nil, err
 	}
-	sort.Strings(fieldNames)
 
-	// Write headers
-	if err := csvWriter.Write(fieldNames); err != nil {
-		return nil, fmt.Errorf("failed to write CSV headers: %w", err)
+	// Use DataQueryNoPage to get filtered results
+	filteredData, err := f.dataQueryNoPage(context.Background(), data, filterRoot, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter data: %w", err)
 	}
+	report.TotalSize, report.Scanned = len(filteredData), len(data)
 
-	// Write data rows
-	for _, item := range filteredData {
-		record := make([]string, len(fieldNames))
-		for i, fieldName := range fieldNames {
-			// Get the value using the getter for this field
-			getter := f.getters[fieldName]
-			value := getter(item)
-			record[i] = fmt.Sprintf("%v", value)
-		}
+	return f.csvBytes(filteredData, opts)
+}
 
-		if err := csvWriter.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+// dataQueryExport filters like DataQueryNoPage and encodes the matching items with encode,
+// reported to the observer as method
+func (f *Handler[T]) dataQueryExport(method string, data []*T, filterRoot Root, encode func([]*T) ([]byte, error)) ([]byte, error) {
+	return observe(f, context.Background(), method, filterRoot, StrategyMemory, func(report *QueryResultInfo) ([]byte, error) {
+		filteredData, err := f.dataQueryNoPage(context.Background(), data, filterRoot, report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter data: %w", err)
 		}
-	}
-
-	// Flush the writer to ensure all data is written
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return nil, fmt.Errorf("CSV writer error: %w", err)
-	}
+		report.TotalSize, report.Scanned = len(filteredData), len(data)
 
-	return []byte(csvBuffer.String()), nil
+		return encode(filteredData)
+	})
 }
 
 // DataQueryNoPageCSVCustom performs in-memory filtering with parallel processing and returns results as CSV bytes.
@@ -404,441 +435,736 @@ func (f *Handler[T]) DataQueryNoPageCSVCustom(
 	data []*T,
 	filterRoot Root,
 	customGetter func(*T) map[string]any,
+) ([]byte, error) {
+	return f.DataQueryNoPageCSVCustomWithOptions(data, filterRoot, customGetter, DefaultCSVOptions())
+}
+
+// DataQueryNoPageCSVCustomWithOptions is DataQueryNoPageCSVCustom with CSVOptions.
+// opts.Columns selects and orders the customGetter keys; names missing from the first record return an error.
+// With no matching records the output is empty, or only the header row when opts.Columns is set.
+func (f *Handler[T]) DataQueryNoPageCSVCustomWithOptions(
+	data []*T,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+	opts CSVOptions,
+) ([]byte, error) {
+	return observe(f, context.Background(), "DataQueryNoPageCSVCustom", filterRoot, StrategyMemory, func(report *QueryResultInfo) ([]byte, error) {
+		return f.dataQueryCSVCustom(data, filterRoot, customGetter, opts, report)
+	})
+}
+
+// dataQueryCSVCustom implements DataQueryNoPageCSVCustomWithOptions
+func (f *Handler[T]) dataQueryCSVCustom(
+	data []*T,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+	opts CSVOptions,
+	report *QueryResultInfo,
 ) ([]byte, error) {
 	// Use DataQueryNoPage to get filtered results
-	filteredData, err := f.DataQueryNoPage(data, filterRoot)
+	filteredData, err := f.dataQueryNoPage(context.Background(), data, filterRoot, report)
 	if err != nil {
 		return nil, fmt.Errorf("failed to filter data: %w", err)
 	}
+	report.TotalSize, report.Scanned = len(filteredData), len(data)
 
-	if len(filteredData) == 0 {
-		// If no data, we can't determine headers, return empty CSV with no headers
-		return []byte(""), nil
-	}
-
-	// Get headers from the first item using the custom getter
-	firstItemFields := customGetter(filteredData[0])
+	return csvBytesCustom(filteredData, customGetter, opts)
+}
 
-	// Sort field names for deterministic column ordering
-	fieldNames := make([]string, 0, len(firstItemFields))
-	for fieldName := range firstItemFields {
-		fieldNames = append(fieldNames, fieldName)
-	}
-	sort.Strings(fieldNames)
+// predicate reports whether a field value matches a compiled filter
+type predicate func(value any) (bool, error)
 
-	// Build CSV content using encoding/csv
-	var buf bytes.Buffer
-	csvWriter := csv.NewWriter(&buf)
+// filterGetter pairs the getter resolved for a filter's field with the filter's compiled predicate.
+// Filters with a CompareField match with matchPair against the value of compare instead.
+type filterGetter[T any] struct {
+	getter    func(*T) any
+	match     predicate
+	compare   func(*T) any
+	matchPair func(a, b any) (bool, error)
+}
 
-	// Write headers
-	if err := csvWriter.Write(fieldNames); err != nil {
-		return nil, fmt.Errorf("failed to write CSV headers: %w", err)
-	}
+// filterGroup is a filter tree with getters resolved and filters compiled once per query
+type filterGroup[T any] struct {
+	logic   Logic
+	filters []filterGetter[T]
+	groups  []filterGroup[T]
+}
 
-	// Write data rows
-	for _, item := range filteredData {
-		itemFields := customGetter(item)
-		record := make([]string, len(fieldNames))
+// isEmpty reports whether the group has no filters to evaluate
+func (g filterGroup[T]) isEmpty() bool {
+	return len(g.filters) == 0 && len(g.groups) == 0
+}
 
-		for i, fieldName := range fieldNames {
-			// Get the value for this field from the custom getter result
-			if value, exists := itemFields[fieldName]; exists {
-				record[i] = fmt.Sprintf("%v", value)
-			} else {
-				// If field doesn't exist in this item's result, use empty string
-				record[i] = ""
+// buildFilterGroup resolves getters and compiles the filters of root and its nested groups.
+// Filters on unknown fields and groups without any valid filters are skipped.
+// Invalid filter values and unsupported modes return an error before any row is evaluated,
+// so the outcome never depends on the data.
+func (f *Handler[T]) buildFilterGroup(root Root) (filterGroup[T], error) {
+	group := filterGroup[T]{
+		logic:   root.Logic,
+		filters: make([]filterGetter[T], 0, len(root.FieldFilters)),
+	}
+	for _, filter := range root.FieldFilters {
+		getter, exists := f.getter(filter.Field)
+		if !exists {
+			continue
+		}
+		if filter.CompareField != "" {
+			if err := f.checkCompareFilter(filter); err != nil {
+				return filterGroup[T]{}, err
 			}
+			compare, _ := f.getter(filter.CompareField)
+			group.filters = append(group.filters, filterGetter[T]{getter: getter, compare: compare, matchPair: compileCompare(filter)})
+			continue
 		}
-
-		if err := csvWriter.Write(record); err != nil {
-			return nil, fmt.Errorf("failed to write CSV record: %w", err)
+		match, err := f.compileFilter(filter)
+		if err != nil {
+			return filterGroup[T]{}, err
 		}
+		if isExistsMode(filter.Mode) {
+			getter = f.existsGetter(filter.Field, getter)
+		}
+		group.filters = append(group.filters, filterGetter[T]{getter: getter, match: match})
 	}
-
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return nil, fmt.Errorf("CSV writer error: %w", err)
+	for _, child := range root.Groups {
+		childGroup, err := f.buildFilterGroup(child)
+		if err != nil {
+			return filterGroup[T]{}, err
+		}
+		if !childGroup.isEmpty() {
+			group.groups = append(group.groups, childGroup)
+		}
 	}
-
-	return buf.Bytes(), nil
+	return group, nil
 }
 
-// applyNumber applies a number filter and returns whether the value matches the filter
-func (f *Handler[T]) applyNumber(value any, filter FieldFilter) (bool, float64, error) {
-	num, err := parseNumber(value)
-	if err != nil {
-		return false, 0, err
-	}
-	switch filter.Mode {
-	case ModeEqual:
-		value, err := parseNumber(filter.Value)
-		if err != nil {
-			return false, num, err
+// matchGroup evaluates a filter group against a single item, recursing into nested groups
+func (f *Handler[T]) matchGroup(item *T, group filterGroup[T]) (bool, error) {
+	isAnd := group.logic == LogicAnd
+	for _, fg := range group.filters {
+		var match bool
+		var err error
+		if fg.matchPair != nil {
+			match, err = fg.matchPair(fg.getter(item), fg.compare(item))
+		} else {
+			match, err = fg.match(fg.getter(item))
 		}
-		return num == value, num, nil
-	case ModeNotEqual:
-		value, err := parseNumber(filter.Value)
 		if err != nil {
-			return false, num, err
-		}
-		return num != value, num, nil
-	case ModeContains:
-		return false, num, fmt.Errorf("contains filter not supported for number field %s", filter.Field)
-	case ModeNotContains:
-		return false, num, fmt.Errorf("not contains filter not supported for number field %s", filter.Field)
-	case ModeStartsWith:
-		return false, num, fmt.Errorf("starts with filter not supported for number field %s", filter.Field)
-	case ModeEndsWith:
-		return false, num, fmt.Errorf("ends with filter not supported for number field %s", filter.Field)
-	case ModeIsEmpty:
-		return false, num, fmt.Errorf("is empty filter not supported for number field %s", filter.Field)
-	case ModeIsNotEmpty:
-		return false, num, fmt.Errorf("is not empty filter not supported for number field %s", filter.Field)
-	case ModeGT:
-		value, err := parseNumber(filter.Value)
-		if err != nil {
-			return false, num, err
+			return false, err
 		}
-		return num > value, num, nil
-	case ModeGTE:
-		value, err := parseNumber(filter.Value)
-		if err != nil {
-			return false, num, err
+		if match != isAnd {
+			return match, nil
 		}
-		return num >= value, num, nil
-	case ModeLT:
-		value, err := parseNumber(filter.Value)
-		if err != nil {
-			return false, num, err
-		}
-		return num < value, num, nil
-	case ModeLTE:
-		value, err := parseNumber(filter.Value)
+	}
+	for _, child := range group.groups {
+		match, err := f.matchGroup(item, child)
 		if err != nil {
-			return false, num, err
+			return false, err
 		}
-		return num <= value, num, nil
-	case ModeRange:
-		value, err := parseRangeNumber(filter.Value)
-		if err != nil {
-			return false, num, err
+		if match != isAnd {
+			return match, nil
 		}
-		return num >= value.From && num <= value.To, num, nil
-	case ModeBefore:
-		return false, num, fmt.Errorf("before filter not supported for number field %s", filter.Field)
-	case ModeAfter:
-		return false, num, fmt.Errorf("after filter not supported for number field %s", filter.Field)
-	default:
-		return false, num, fmt.Errorf("unsupported filter mode: %s", filter.Mode)
 	}
+	return isAnd, nil
 }
 
-// applyText applies a text filter and returns whether the value matches the filter
-// All text comparisons are case-insensitive
-func (f *Handler[T]) applyText(value any, filter FieldFilter) (bool, string, error) {
-	data, err := parseText(value)
+// compileFilter parses the value of filter once and returns a predicate for its data type and mode
+func (f *Handler[T]) compileFilter(filter FieldFilter) (predicate, error) {
+	if isExistsMode(filter.Mode) {
+		return f.compileExists(filter)
+	}
+	var match predicate
+	filter, err := f.numberFilter(filter)
 	if err != nil {
-		return false, "", err
+		return nil, filterError(filter, err)
 	}
+	switch filter.DataType {
+	case DataTypeNumber:
+		match, err = compileNumber(filter)
+	case DataTypeText:
+		match, err = compileText(filter, f.isCaseSensitive(filter))
+	case DataTypeBool:
+		match, err = compileBool(filter)
+	case DataTypeDate:
+		match, err = compileDate(filter, f.datePrecision)
+	case DataTypeTime:
+		match, err = compileTime(filter)
+	case DataTypeUUID:
+		match, err = compileUUID(filter)
+	default:
+		err = &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("unsupported data type: %s", filter.DataType)}
+	}
+	if err != nil {
+		return nil, filterError(filter, err)
+	}
+
+	// A nil value, or a nil parent on a nested path, only matches ModeIsEmpty and the negative modes,
+	// for every data type. This mirrors SQL, where negative modes are built as "col IS NULL OR ...".
+	matchesNil := filter.Mode == ModeIsEmpty || isNegativeMode(filter.Mode)
+	matchValue := func(value any) (bool, error) {
+		if isMissing(value) || isNilValue(value) {
+			return matchesNil, nil
+		}
+		return match(value)
+	}
+	return func(value any) (bool, error) {
+		// A field under a slice matches when any element matches; an empty slice is like a nil parent
+		if values, ok := value.(manyValues); ok {
+			if len(values) == 0 {
+				return matchesNil, nil
+			}
+			for _, v := range values {
+				matched, err := matchValue(v)
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+		return matchValue(value)
+	}, nil
+}
+
+// isNegativeMode reports whether mode negates a comparison. Such filters also match nil values
+// (NULL in SQL), so a negative mode selects exactly the rows its positive mode does not.
+func isNegativeMode(mode Mode) bool {
+	return mode == ModeNotEqual || mode == ModeNotContains || mode == ModeNotIn
+}
 
-	// Convert to lowercase for case-insensitive comparison
-	dataLower := strings.ToLower(data)
+// modeNames are the wording of modes in "not supported" errors
+var modeNames = map[Mode]string{
+	ModeContains:    "contains",
+	ModeNotContains: "not contains",
+	ModeStartsWith:  "starts with",
+	ModeEndsWith:    "ends with",
+	ModeGT:          "greater than",
+	ModeGTE:         "greater than or equal",
+	ModeLT:          "less than",
+	ModeLTE:         "less than or equal",
+	ModeRange:       "range",
+	ModeBefore:      "before",
+	ModeAfter:       "after",
+	ModeIn:          "in",
+	ModeNotIn:       "not in",
+}
+
+// unsupportedMode returns the error for a mode that kind fields (e.g. "number") do not support
+func unsupportedMode(filter FieldFilter, kind string) error {
+	err := &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType}
+	if name, exists := modeNames[filter.Mode]; exists {
+		err.reason = fmt.Sprintf("%s filter not supported for %s field %s", name, kind, filter.Field)
+	} else {
+		err.reason = fmt.Sprintf("unsupported filter mode: %s", filter.Mode)
+	}
+	return err
+}
 
+// nilPredicate matches nil values (ModeIsEmpty) or non-nil values (ModeIsNotEmpty)
+func nilPredicate(isEmpty func(any) bool, want bool) predicate {
+	return func(value any) (bool, error) {
+		return isEmpty(value) == want, nil
+	}
+}
+
+// compileNumber compiles a number filter; row values are parsed with parseNumber, and compared exactly
+// when both they and the filter value are integers (see numberTarget)
+func compileNumber(filter FieldFilter) (predicate, error) {
+	var cmp func(value any) (bool, error)
 	switch filter.Mode {
-	case ModeEqual:
-		substr, err := parseText(filter.Value)
+	// Emptiness is checked before parsing so nullable (pointer) fields can be matched
+	case ModeIsEmpty, ModeIsNotEmpty:
+		return nilPredicate(isNilValue, filter.Mode == ModeIsEmpty), nil
+	case ModeEqual, ModeNotEqual, ModeGT, ModeGTE, ModeLT, ModeLTE:
+		target, err := parseNumberTarget(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		return dataLower == strings.ToLower(substr), data, nil
-	case ModeNotEqual:
-		substr, err := parseText(filter.Value)
-		if err != nil {
-			return false, data, err
+		var match func(c int) bool
+		switch filter.Mode {
+		case ModeEqual:
+			match = func(c int) bool { return c == 0 }
+		case ModeNotEqual:
+			match = func(c int) bool { return c != 0 }
+		case ModeGT:
+			match = func(c int) bool { return c > 0 }
+		case ModeGTE:
+			match = func(c int) bool { return c >= 0 }
+		case ModeLT:
+			match = func(c int) bool { return c < 0 }
+		case ModeLTE:
+			match = func(c int) bool { return c <= 0 }
 		}
-		return dataLower != strings.ToLower(substr), data, nil
-	case ModeContains:
-		substr, err := parseText(filter.Value)
-		if err != nil {
-			return false, data, err
+		cmp = func(value any) (bool, error) {
+			c, err := target.compare(value)
+			return err == nil && match(c), err
 		}
-		return strings.Contains(dataLower, strings.ToLower(substr)), data, nil
-	case ModeNotContains:
-		substr, err := parseText(filter.Value)
+	case ModeRange:
+		rangeVal, err := parseRangeNumber(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		return !strings.Contains(dataLower, strings.ToLower(substr)), data, nil
-	case ModeStartsWith:
-		substr, err := parseText(filter.Value)
+		rng, _ := toRange(filter.Value)
+		from, _ := parseNumberTarget(rng.From)
+		to, _ := parseNumberTarget(rng.To)
+		cmp = func(value any) (bool, error) {
+			fromCmp, err := from.compare(value)
+			if err != nil {
+				return false, err
+			}
+			toCmp, err := to.compare(value)
+			if err != nil {
+				return false, err
+			}
+			if fromCmp < 0 || toCmp > 0 {
+				return false, nil
+			}
+			return !(rangeVal.FromExclusive && fromCmp == 0) && !(rangeVal.ToExclusive && toCmp == 0), nil
+		}
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
+		}
+		// Integer items match integer rows exactly; every item matches other rows by its float64
+		ints := make(map[integer]bool, len(list))
+		floats := make(map[float64]bool)
+		all := make(map[float64]bool, len(list))
+		for _, item := range list {
+			target, err := parseNumberTarget(item)
+			if err != nil {
+				return nil, err
+			}
+			if target.isInt {
+				ints[target.int] = true
+			} else {
+				floats[target.num] = true
+			}
+			all[target.num] = true
+		}
+		want := filter.Mode == ModeIn
+		cmp = func(value any) (bool, error) {
+			if i, ok := parseInteger(value); ok {
+				num, _ := parseNumber(value)
+				return (ints[i] || floats[num]) == want, nil
+			}
+			num, err := parseNumber(value)
+			if err != nil {
+				return false, err
+			}
+			return all[num] == want, nil
 		}
-		return strings.HasPrefix(dataLower, strings.ToLower(substr)), data, nil
-	case ModeEndsWith:
-		substr, err := parseText(filter.Value)
+	case ModeContains, ModeStartsWith, ModeEndsWith:
+		// Matched against the decimal text of the number, like CAST(col AS TEXT) LIKE ? in SQL
+		target, err := formatNumberText(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		return strings.HasSuffix(dataLower, strings.ToLower(substr)), data, nil
-	case ModeIsEmpty:
-		return data == "", data, nil
-	case ModeIsNotEmpty:
-		return data != "", data, nil
-	case ModeGT:
-		return false, data, fmt.Errorf("greater than filter not supported for text field %s", filter.Field)
-	case ModeGTE:
-		return false, data, fmt.Errorf("greater than or equal filter not supported for text field %s", filter.Field)
-	case ModeLT:
-		return false, data, fmt.Errorf("less than filter not supported for text field %s", filter.Field)
-	case ModeLTE:
-		return false, data, fmt.Errorf("less than or equal filter not supported for text field %s", filter.Field)
-	case ModeRange:
-		return false, data, fmt.Errorf("range filter not supported for text field %s", filter.Field)
-	case ModeBefore:
-		return false, data, fmt.Errorf("before filter not supported for text field %s", filter.Field)
-	case ModeAfter:
-		return false, data, fmt.Errorf("after filter not supported for text field %s", filter.Field)
+		matchText := strings.Contains
+		if filter.Mode == ModeStartsWith {
+			matchText = strings.HasPrefix
+		} else if filter.Mode == ModeEndsWith {
+			matchText = strings.HasSuffix
+		}
+		return func(value any) (bool, error) {
+			text, err := formatNumberText(value)
+			if err != nil {
+				return false, err
+			}
+			return matchText(text, target), nil
+		}, nil
 	default:
-		return false, data, fmt.Errorf("unsupported filter mode: %s", filter.Mode)
+		return nil, unsupportedMode(filter, "number")
 	}
+	return cmp, nil
 }
 
-// applyBool applies a boolean filter and returns whether the value matches the filter
-func (f *Handler[T]) applyBool(value any, filter FieldFilter) (bool, bool, error) {
-	data, err := parseBool(value)
-	if err != nil {
-		return false, data, err
-	}
-	val, err := parseBool(filter.Value)
-	if err != nil {
-		return false, data, err
+// compileText compiles a text filter; comparisons are case-insensitive unless caseSensitive is set
+func compileText(filter FieldFilter, caseSensitive bool) (predicate, error) {
+	// Fold both sides to lowercase for case-insensitive comparison
+	fold := strings.ToLower
+	if caseSensitive {
+		fold = func(s string) string { return s }
 	}
 
+	var cmp func(data string) bool
 	switch filter.Mode {
-	case ModeEqual:
-		return data == val, data, nil
-	case ModeNotEqual:
-		return data != val, data, nil
-	case ModeContains:
-		return false, data, fmt.Errorf("contains filter not supported for boolean field %s", filter.Field)
-	case ModeNotContains:
-		return false, data, fmt.Errorf("not contains filter not supported for boolean field %s", filter.Field)
-	case ModeStartsWith:
-		return false, data, fmt.Errorf("starts with filter not supported for boolean field %s", filter.Field)
-	case ModeEndsWith:
-		return false, data, fmt.Errorf("ends with filter not supported for boolean field %s", filter.Field)
 	case ModeIsEmpty:
-		return false, data, fmt.Errorf("is empty filter not supported for boolean field %s", filter.Field)
+		cmp = func(data string) bool { return data == "" }
 	case ModeIsNotEmpty:
-		return false, data, fmt.Errorf("is not empty filter not supported for boolean field %s", filter.Field)
-	case ModeGT:
-		return false, data, fmt.Errorf("greater than filter not supported for boolean field %s", filter.Field)
-	case ModeGTE:
-		return false, data, fmt.Errorf("greater than or equal filter not supported for boolean field %s", filter.Field)
-	case ModeLT:
-		return false, data, fmt.Errorf("less than filter not supported for boolean field %s", filter.Field)
-	case ModeLTE:
-		return false, data, fmt.Errorf("less than or equal filter not supported for boolean field %s", filter.Field)
+		cmp = func(data string) bool { return data != "" }
+	case ModeEqual, ModeNotEqual, ModeContains, ModeNotContains, ModeStartsWith, ModeEndsWith:
+		target, err := parseText(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		target = fold(target)
+		switch filter.Mode {
+		case ModeEqual:
+			cmp = func(data string) bool { return data == target }
+		case ModeNotEqual:
+			cmp = func(data string) bool { return data != target }
+		case ModeContains:
+			cmp = func(data string) bool { return strings.Contains(data, target) }
+		case ModeNotContains:
+			cmp = func(data string) bool { return !strings.Contains(data, target) }
+		case ModeStartsWith:
+			cmp = func(data string) bool { return strings.HasPrefix(data, target) }
+		case ModeEndsWith:
+			cmp = func(data string) bool { return strings.HasSuffix(data, target) }
+		}
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		set := make(map[string]bool, len(list))
+		for _, item := range list {
+			str, err := parseText(item)
+			if err != nil {
+				return nil, err
+			}
+			set[fold(str)] = true
+		}
+		want := filter.Mode == ModeIn
+		cmp = func(data string) bool { return set[data] == want }
 	case ModeRange:
-		return false, data, fmt.Errorf("range filter not supported for boolean field %s", filter.Field)
-	case ModeBefore:
-		return false, data, fmt.Errorf("before filter not supported for boolean field %s", filter.Field)
-	case ModeAfter:
-		return false, data, fmt.Errorf("after filter not supported for boolean field %s", filter.Field)
+		// Compared byte-wise after folding, like LOWER(col) >= LOWER(?) in SQL
+		rangeVal, err := parseRangeText(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		from, to := fold(rangeVal.From), fold(rangeVal.To)
+		cmp = func(data string) bool {
+			if data < from || data > to {
+				return false
+			}
+			return !(rangeVal.FromExclusive && data == from) && !(rangeVal.ToExclusive && data == to)
+		}
 	default:
-		return false, data, fmt.Errorf("unsupported filter mode: %s", filter.Mode)
+		return nil, unsupportedMode(filter, "text")
 	}
+
+	return func(value any) (bool, error) {
+		data, err := parseText(value)
+		if err != nil {
+			return false, err
+		}
+		return cmp(fold(data)), nil
+	}, nil
 }
 
-// applyDate applies a date filter and returns whether the value matches the filter
-func (f *Handler[T]) applyDate(value any, filter FieldFilter) (bool, time.Time, error) {
-	data, err := parseDateTime(value)
-	if err != nil {
-		return false, time.Time{}, err
+// compileBool compiles a boolean filter; row values are parsed with parseBool
+func compileBool(filter FieldFilter) (predicate, error) {
+	switch filter.Mode {
+	// Emptiness is checked before parsing so nullable (pointer) fields can be matched
+	case ModeIsEmpty, ModeIsNotEmpty:
+		return nilPredicate(isNilValue, filter.Mode == ModeIsEmpty), nil
+	case ModeEqual, ModeNotEqual:
+		target, err := parseBool(filter.Value)
+		if err != nil {
+			return nil, &InvalidValueError{Field: filter.Field, Value: filter.Value, DataType: filter.DataType, Err: err}
+		}
+		want := filter.Mode == ModeEqual
+		return func(value any) (bool, error) {
+			data, err := parseBool(value)
+			if err != nil {
+				return false, err
+			}
+			return (data == target) == want, nil
+		}, nil
+	default:
+		return nil, unsupportedMode(filter, "boolean")
 	}
-	hasTime := hasTimeComponent(data)
+}
 
+// compileUUID compiles a UUID filter. Field values that are not UUIDs (e.g. an empty string)
+// equal no filter value, so they fail ModeEqual and ModeIn and pass ModeNotEqual and ModeNotIn.
+func compileUUID(filter FieldFilter) (predicate, error) {
+	var set map[[16]byte]bool
 	switch filter.Mode {
-	case ModeEqual:
-		filterVal, err := parseDateTime(filter.Value)
+	// Emptiness is checked before parsing so nullable (pointer) fields can be matched
+	case ModeIsEmpty, ModeIsNotEmpty:
+		return nilPredicate(isNilValue, filter.Mode == ModeIsEmpty), nil
+	case ModeEqual, ModeNotEqual:
+		target, err := parseUUID(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, &InvalidValueError{Field: filter.Field, Value: filter.Value, DataType: filter.DataType, Err: err}
 		}
-		if hasTime {
-			return data.Equal(filterVal), data, nil
-		} else {
-			startOfDay := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, data.Location())
-			endOfDay := time.Date(data.Year(), data.Month(), data.Day(), 23, 59, 59, 999999999, data.Location())
-			return !filterVal.Before(startOfDay) && !filterVal.After(endOfDay), data, nil
+		set = map[[16]byte]bool{target: true}
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		set = make(map[[16]byte]bool, len(list))
+		for _, item := range list {
+			id, err := parseUUID(item)
+			if err != nil {
+				return nil, &InvalidValueError{Field: filter.Field, Value: item, DataType: filter.DataType, Err: err}
+			}
+			set[id] = true
 		}
-	case ModeNotEqual:
-		filterVal, err := parseDateTime(filter.Value)
+	default:
+		return nil, unsupportedMode(filter, "uuid")
+	}
+
+	want := filter.Mode == ModeEqual || filter.Mode == ModeIn
+	return func(value any) (bool, error) {
+		id, err := parseUUID(value)
 		if err != nil {
-			return false, data, err
+			return !want, nil
 		}
-		if hasTime {
-			return !data.Equal(filterVal), data, nil
-		} else {
-			startOfDay := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, data.Location())
-			endOfDay := time.Date(data.Year(), data.Month(), data.Day(), 23, 59, 59, 999999999, data.Location())
-			return filterVal.Before(startOfDay) || filterVal.After(endOfDay), data, nil
-		}
-	case ModeContains:
-		return false, data, fmt.Errorf("contains filter not supported for date field %s", filter.Field)
-	case ModeNotContains:
-		return false, data, fmt.Errorf("not contains filter not supported for date field %s", filter.Field)
-	case ModeStartsWith:
-		return false, data, fmt.Errorf("starts with filter not supported for date field %s", filter.Field)
-	case ModeEndsWith:
-		return false, data, fmt.Errorf("ends with filter not supported for date field %s", filter.Field)
-	case ModeIsEmpty:
-		return false, data, fmt.Errorf("is empty filter not supported for date field %s", filter.Field)
-	case ModeIsNotEmpty:
-		return false, data, fmt.Errorf("is not empty filter not supported for date field %s", filter.Field)
-	case ModeGT:
-		return false, data, fmt.Errorf("greater than filter not supported for date field %s", filter.Field)
+		return set[id] == want, nil
+	}, nil
+}
+
+// compileDate compiles a date filter.
+// Date-only filter values are compared by whole day (in the value's location), as in DataGorm,
+// and so are row values without a time component. With a precision, values with a time component
+// stand for the whole unit they fall in (see GolangFilteringConfig.DatePrecision).
+func compileDate(filter FieldFilter, precision time.Duration) (predicate, error) {
+	startOfDay := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	endOfDay := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+	}
+
+	var cmp func(data time.Time, hasTime bool) bool
+	switch filter.Mode {
+	// Emptiness is checked before parsing so nullable (pointer) fields can be matched
+	case ModeIsEmpty, ModeIsNotEmpty:
+		return nilPredicate(isEmptyDate, filter.Mode == ModeIsEmpty), nil
+	case ModeEqual, ModeNotEqual:
+		target, err := parseDateTime(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		want := filter.Mode == ModeEqual
+		equal := dateEqual(target, precision)
+		cmp = func(data time.Time, hasTime bool) bool { return equal(data, hasTime) == want }
 	case ModeGTE:
-		filterVal, err := parseDateTime(filter.Value)
+		target, err := parseDateTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		if hasTime {
-			return data.Equal(filterVal) || data.After(filterVal), data, nil
-		} else {
-			startOfDay := time.Date(filterVal.Year(), filterVal.Month(), filterVal.Day(), 0, 0, 0, 0, filterVal.Location())
-			return data.Equal(startOfDay) || data.After(startOfDay), data, nil
+		dayStart, exact := startOfDay(target), hasTimeComponent(target)
+		start, _ := truncatedBounds(target, precision)
+		cmp = func(data time.Time, hasTime bool) bool {
+			if hasTime && exact {
+				return !data.Before(start)
+			}
+			return !data.Before(dayStart)
 		}
-	case ModeLT:
-		filterVal, err := parseDateTime(filter.Value)
+	case ModeLT, ModeBefore:
+		target, err := parseDateTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		if hasTime {
-			return data.Before(filterVal), data, nil
-		} else {
-			startOfDay := time.Date(filterVal.Year(), filterVal.Month(), filterVal.Day(), 0, 0, 0, 0, filterVal.Location())
-			return data.Before(startOfDay), data, nil
+		dayStart, exact := startOfDay(target), hasTimeComponent(target)
+		start, _ := truncatedBounds(target, precision)
+		cmp = func(data time.Time, hasTime bool) bool {
+			if hasTime && exact {
+				return data.Before(start)
+			}
+			return data.Before(dayStart)
 		}
 	case ModeLTE:
-		filterVal, err := parseDateTime(filter.Value)
+		target, err := parseDateTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		if hasTime {
-			return data.Equal(filterVal) || data.Before(filterVal), data, nil
-		} else {
-			endOfDay := time.Date(filterVal.Year(), filterVal.Month(), filterVal.Day(), 23, 59, 59, 999999999, filterVal.Location())
-			return data.Equal(endOfDay) || data.Before(endOfDay), data, nil
+		dayEnd, exact := endOfDay(target), hasTimeComponent(target)
+		_, end := truncatedBounds(target, precision)
+		cmp = func(data time.Time, hasTime bool) bool {
+			if hasTime && exact && precision > 0 {
+				return data.Before(end)
+			}
+			if hasTime && exact {
+				return !data.After(target)
+			}
+			return !data.After(dayEnd)
+		}
+	case ModeAfter:
+		target, err := parseDateTime(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		// After the end of the day
+		dayEnd, exact := endOfDay(target), hasTimeComponent(target)
+		_, end := truncatedBounds(target, precision)
+		cmp = func(data time.Time, hasTime bool) bool {
+			if hasTime && exact && precision > 0 {
+				return !data.Before(end)
+			}
+			if hasTime && exact {
+				return data.After(target)
+			}
+			return data.After(dayEnd)
 		}
 	case ModeRange:
 		rangeVal, err := parseRangeDateTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-
-		// Check if filter range values have time components
-		hasTimeFrom := hasTimeComponent(rangeVal.From)
-		hasTimeTo := hasTimeComponent(rangeVal.To)
-
-		if hasTimeFrom && hasTimeTo {
-			// Both range boundaries have time - do exact timestamp comparison
-			return !data.Before(rangeVal.From) && !data.After(rangeVal.To), data, nil
-		} else {
-			// Date-only range - compare against full day boundaries
-			startOfFromDay := time.Date(rangeVal.From.Year(), rangeVal.From.Month(), rangeVal.From.Day(), 0, 0, 0, 0, rangeVal.From.Location())
-			endOfToDay := time.Date(rangeVal.To.Year(), rangeVal.To.Month(), rangeVal.To.Day(), 23, 59, 59, 999999999, rangeVal.To.Location())
-			return !data.Before(startOfFromDay) && !data.After(endOfToDay), data, nil
+		from, to := rangeVal.From, rangeVal.To
+		if hasTimeComponent(from) && hasTimeComponent(to) && precision > 0 {
+			// Both bounds stand for the whole unit of precision they fall in, an exclusive one leaving it out
+			fromStart, fromEnd := truncatedBounds(from, precision)
+			toStart, toEnd := truncatedBounds(to, precision)
+			lower, upper := fromStart, toEnd
+			if rangeVal.FromExclusive {
+				lower = fromEnd
+			}
+			if rangeVal.ToExclusive {
+				upper = toStart
+			}
+			cmp = func(data time.Time, _ bool) bool {
+				return !data.Before(lower) && data.Before(upper)
+			}
+			break
 		}
-	case ModeBefore:
-		filterVal, err := parseDateTime(filter.Value)
-		if err != nil {
-			return false, data, err
+		if !hasTimeComponent(from) || !hasTimeComponent(to) {
+			// Date-only range - compare against full day boundaries, leaving out the whole day of an exclusive bound
+			from, to = startOfDay(from), endOfDay(to)
+			if rangeVal.FromExclusive {
+				from = endOfDay(rangeVal.From)
+			}
+			if rangeVal.ToExclusive {
+				to = startOfDay(rangeVal.To)
+			}
 		}
-		if hasTime {
-			return data.Before(filterVal), data, nil
-		} else {
-			startOfDay := time.Date(filterVal.Year(), filterVal.Month(), filterVal.Day(), 0, 0, 0, 0, filterVal.Location())
-			return data.Before(startOfDay), data, nil
+		cmp = func(data time.Time, _ bool) bool {
+			if data.Before(from) || data.After(to) {
+				return false
+			}
+			return !(rangeVal.FromExclusive && data.Equal(from)) && !(rangeVal.ToExclusive && data.Equal(to))
 		}
-	case ModeAfter:
-		filterVal, err := parseDateTime(filter.Value)
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
 		}
-		if hasTime {
-			return data.After(filterVal), data, nil
-		} else {
-			// After the end of the day
-			endOfDay := time.Date(filterVal.Year(), filterVal.Month(), filterVal.Day(), 23, 59, 59, 999999999, filterVal.Location())
-			return data.After(endOfDay), data, nil
+		matchers := make([]func(data time.Time, hasTime bool) bool, len(list))
+		for i, item := range list {
+			target, err := parseDateTime(item)
+			if err != nil {
+				return nil, err
+			}
+			matchers[i] = dateEqual(target, precision)
+		}
+		want := filter.Mode == ModeIn
+		// Each list item uses the same semantics as ModeEqual (whole-day match for date-only values)
+		cmp = func(data time.Time, hasTime bool) bool {
+			for _, equal := range matchers {
+				if equal(data, hasTime) {
+					return want
+				}
+			}
+			return !want
 		}
 	default:
-		return false, data, fmt.Errorf("unsupported filter mode: %s", filter.Mode)
+		return nil, unsupportedMode(filter, "date")
 	}
-}
 
-// applyTime applies a time filter and returns whether the value matches the filter
-func (f *Handler[T]) applyTime(value any, filter FieldFilter) (bool, time.Time, error) {
-	data, err := parseTime(value)
-	if err != nil {
-		return false, time.Time{}, err
-	}
-	switch filter.Mode {
-	case ModeEqual:
-		filterVal, err := parseTime(filter.Value)
+	return func(value any) (bool, error) {
+		data, err := parseDateTime(value)
 		if err != nil {
-			return false, data, err
+			return false, err
 		}
-		return data.Equal(filterVal), data, nil
+		return cmp(data, hasTimeComponent(data)), nil
+	}, nil
+}
 
-	case ModeNotEqual:
-		filterVal, err := parseTime(filter.Value)
-		if err != nil {
-			return false, data, err
+// dateEqual returns a matcher reporting whether a row value equals target, mirroring ModeEqual in SQL:
+// a date-only target matches the whole day (in the target's location), a target with a time component
+// the whole unit of precision it falls in (itself only when precision <= 0), and a row value without
+// a time component matches a target falling on that day
+func dateEqual(target time.Time, precision time.Duration) func(data time.Time, hasTime bool) bool {
+	if !hasTimeComponent(target) {
+		dayStart := time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, target.Location())
+		dayEnd := time.Date(target.Year(), target.Month(), target.Day(), 23, 59, 59, 999999999, target.Location())
+		return func(data time.Time, _ bool) bool {
+			return !data.Before(dayStart) && !data.After(dayEnd)
 		}
-		return !data.Equal(filterVal), data, nil
-
-	case ModeGTE, ModeAfter:
-		filterVal, err := parseTime(filter.Value)
-		if err != nil {
-			return false, data, err
+	}
+	start, end := truncatedBounds(target, precision)
+	return func(data time.Time, hasTime bool) bool {
+		if hasTime && precision > 0 {
+			return !data.Before(start) && data.Before(end)
 		}
-		return !data.Before(filterVal), data, nil
-
-	case ModeLTE:
-		filterVal, err := parseTime(filter.Value)
-		if err != nil {
-			return false, data, err
+		if hasTime {
+			return data.Equal(target)
 		}
-		return !data.After(filterVal), data, nil
+		startOfDay := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, data.Location())
+		endOfDay := time.Date(data.Year(), data.Month(), data.Day(), 23, 59, 59, 999999999, data.Location())
+		return !target.Before(startOfDay) && !target.After(endOfDay)
+	}
+}
 
-	case ModeLT, ModeBefore:
-		filterVal, err := parseTime(filter.Value)
-		if err != nil {
-			return false, data, err
-		}
-		return data.Before(filterVal), data, nil
+// truncatedBounds returns the instants [start, end) t stands for at precision, e.g. the whole second
+// of 14:30:00.5 for time.Second; with precision <= 0 both are t
+func truncatedBounds(t time.Time, precision time.Duration) (time.Time, time.Time) {
+	if precision <= 0 {
+		return t, t
+	}
+	start := t.Truncate(precision)
+	return start, start.Add(precision)
+}
 
-	case ModeGT:
-		filterVal, err := parseTime(filter.Value)
+// compileTime compiles a time-of-day filter; row values are parsed with parseTime, except the zero
+// time.Time, which only ModeNotEqual matches (see buildTimeCondition)
+func compileTime(filter FieldFilter) (predicate, error) {
+	var cmp func(data time.Time) bool
+	switch filter.Mode {
+	case ModeEqual, ModeNotEqual, ModeGTE, ModeAfter, ModeLTE, ModeLT, ModeBefore, ModeGT:
+		target, err := parseTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
+		}
+		switch filter.Mode {
+		case ModeEqual:
+			cmp = func(data time.Time) bool { return data.Equal(target) }
+		case ModeNotEqual:
+			cmp = func(data time.Time) bool { return !data.Equal(target) }
+		case ModeGTE, ModeAfter:
+			cmp = func(data time.Time) bool { return !data.Before(target) }
+		case ModeLTE:
+			cmp = func(data time.Time) bool { return !data.After(target) }
+		case ModeLT, ModeBefore:
+			cmp = func(data time.Time) bool { return data.Before(target) }
+		case ModeGT:
+			cmp = func(data time.Time) bool { return data.After(target) }
 		}
-		return data.After(filterVal), data, nil
-
 	case ModeRange:
 		rangeVal, err := parseRangeTime(filter.Value)
 		if err != nil {
-			return false, data, err
+			return nil, err
+		}
+		cmp = func(data time.Time) bool {
+			if data.Before(rangeVal.From) || data.After(rangeVal.To) {
+				return false
+			}
+			return !(rangeVal.FromExclusive && data.Equal(rangeVal.From)) && !(rangeVal.ToExclusive && data.Equal(rangeVal.To))
 		}
-		return !data.Before(rangeVal.From) && !data.After(rangeVal.To), data, nil
-
 	case ModeContains, ModeNotContains, ModeStartsWith, ModeEndsWith,
 		ModeIsEmpty, ModeIsNotEmpty:
-		return false, data, fmt.Errorf("filter mode %s not supported for time field %s", filter.Mode, filter.Field)
-
+		return nil, &UnsupportedModeError{Field: filter.Field, Mode: filter.Mode, DataType: filter.DataType,
+			reason: fmt.Sprintf("filter mode %s not supported for time field %s", filter.Mode, filter.Field)}
 	default:
-		return false, data, fmt.Errorf("unsupported filter mode: %s", filter.Mode)
+		return nil, unsupportedMode(filter, "time")
 	}
+
+	return func(value any) (bool, error) {
+		// The zero time.Time is no value rather than midnight, so it matches like a nil value
+		if isEmptyDate(value) {
+			return filter.Mode == ModeNotEqual, nil
+		}
+		data, err := parseTime(value)
+		if err != nil {
+			return false, err
+		}
+		return cmp(data), nil
+	}, nil
 }
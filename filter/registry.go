@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Registry holds one Handler per model type, for applications with many models that would rather
+// look handlers up by type than pass them around. It is safe for concurrent use. Register and For use
+// a package-level Registry; create your own with NewRegistry to keep handlers out of global state, or
+// skip registries altogether and keep the handlers NewFilter returns.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type]any // Model type -> *Handler of it
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[reflect.Type]any)}
+}
+
+// defaultRegistry is the Registry of Register, For and Lookup
+var defaultRegistry = NewRegistry()
+
+// Register creates the Handler of T with config, as NewFilter does, and adds it to the package-level
+// Registry. It panics when T is already registered.
+//
+// Example usage:
+//
+//	func init() {
+//	    filter.Register[User](filter.GolangFilteringConfig{MaxPageSize: 500})
+//	    filter.Register[Account](filter.GolangFilteringConfig{})
+//	}
+//
+//	result, err := filter.For[User]().DataGorm(db, filterRoot, pageIndex, pageSize)
+func Register[T any](config GolangFilteringConfig) *Handler[T] {
+	return RegisterIn[T](defaultRegistry, config)
+}
+
+// For returns the Handler of T added with Register. It panics when T is not registered.
+func For[T any]() *Handler[T] {
+	return ForIn[T](defaultRegistry)
+}
+
+// Lookup is For returning an error instead of panicking when T is not registered
+func Lookup[T any]() (*Handler[T], error) {
+	return LookupIn[T](defaultRegistry)
+}
+
+// RegisterIn is Register for registry r
+func RegisterIn[T any](r *Registry, config GolangFilteringConfig) *Handler[T] {
+	t := reflect.TypeFor[T]()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.handlers[t]; exists {
+		panic(fmt.Sprintf("filter: handler for %s already registered", t))
+	}
+	handler := NewFilter[T](config)
+	r.handlers[t] = handler
+	return handler
+}
+
+// ForIn is For for registry r
+func ForIn[T any](r *Registry) *Handler[T] {
+	handler, err := LookupIn[T](r)
+	if err != nil {
+		panic(err.Error())
+	}
+	return handler
+}
+
+// LookupIn is Lookup for registry r
+func LookupIn[T any](r *Registry) (*Handler[T], error) {
+	t := reflect.TypeFor[T]()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, exists := r.handlers[t]
+	if !exists {
+		return nil, fmt.Errorf("filter: no handler registered for %s", t)
+	}
+	return handler.(*Handler[T]), nil
+}
@@ -0,0 +1,446 @@
+package filter
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// relationship returns the GORM relationship of T named by the first segment of a nested field
+// ("items.sku" -> Items), or nil for simple fields and unknown relations
+func (f *Handler[T]) relationship(d sqlDialect, field string) *schema.Relationship {
+	name, _, nested := strings.Cut(field, ".")
+	if !nested {
+		return nil
+	}
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return nil
+	}
+	return modelSchema.Relationships.Relations[f.relationField(d, modelSchema, name)]
+}
+
+// relationAlias returns the Go field name of the relation of T named by segment, the first segment of a
+// nested field, which GORM uses as the relation's JOIN alias (see relationField)
+func (f *Handler[T]) relationAlias(d sqlDialect, segment string) string {
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return f.toPascalCase(segment)
+	}
+	return f.relationField(d, modelSchema, segment)
+}
+
+// relationField returns the Go field name of the relation of s named by segment, a segment of a nested
+// field: the relation whose Go field name, json name or column name under d's naming strategy is segment
+// ("api_key" -> APIKey), else the one whose Go field name matches segment ignoring case and underscores
+// ("io_config" -> IOConfig). It falls back to toPascalCase(segment) when no relation matches, leaving
+// unknown names for GORM to report.
+func (f *Handler[T]) relationField(d sqlDialect, s *schema.Schema, segment string) string {
+	if _, exists := s.Relationships.Relations[segment]; exists {
+		return segment
+	}
+	names := slices.Sorted(maps.Keys(s.Relationships.Relations))
+	for _, name := range names {
+		rel := s.Relationships.Relations[name]
+		if rel.Field != nil && strings.Split(rel.Field.Tag.Get("json"), ",")[0] == segment {
+			return name
+		}
+		if d.namer != nil && d.namer.ColumnName("", name) == segment {
+			return name
+		}
+	}
+	squashed := strings.ReplaceAll(segment, "_", "")
+	for _, name := range names {
+		if strings.EqualFold(name, squashed) {
+			return name
+		}
+	}
+	return f.toPascalCase(segment)
+}
+
+// isToManyField reports whether field is nested under a has-many or many2many relation,
+// where a parent row joins to any number of related rows
+func (f *Handler[T]) isToManyField(d sqlDialect, field string) bool {
+	rel := f.relationship(d, field)
+	return rel != nil && (rel.Type == schema.HasMany || rel.Type == schema.Many2Many)
+}
+
+// isSliceField reports whether field is nested under a slice of structs of T (e.g. "items.sku"),
+// so its getter returns the values of every element
+func (f *Handler[T]) isSliceField(field string) bool {
+	path, exists := f.fieldPaths[field]
+	if !exists {
+		return false
+	}
+	name, _, nested := strings.Cut(path, ".")
+	if !nested {
+		return false
+	}
+	t := reflect.TypeOf(new(T)).Elem()
+	sliceField, exists := t.FieldByName(name)
+	return exists && sliceField.Type.Kind() == reflect.Slice
+}
+
+// joinsToMany reports whether any sort field joins a to-many relation (filters on one are EXISTS
+// subqueries, see relatedRowsCondition). Such joins repeat parent rows, so queries group by the primary
+// key and count distinct primary keys.
+func (f *Handler[T]) joinsToMany(d sqlDialect, sortFields []SortField) bool {
+	for _, sortField := range sortFields {
+		if f.isToManyField(d, sortField.Field) {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedRowsCondition wraps condition, built by filter on the joined column of a to-many relation, into an
+// EXISTS subquery over the related rows of each record, so each filter on the relation is met by a related
+// row of its own: "items.sku = A AND items.sku = B" matches a record with both items, as in memory. A
+// negative or ModeIsEmpty filter, which matches the NULLs of a record without related rows, also matches
+// records without related rows.
+func (f *Handler[T]) relatedRowsCondition(d sqlDialect, filter FieldFilter, condition string, values []any) (string, []any) {
+	rel := f.relationship(d, filter.Field)
+	alias := f.relationAlias(d, strings.Split(filter.Field, ".")[0])
+	subquery, args := relatedRowsSubquery(d, rel, alias, "1")
+	condition = fmt.Sprintf("EXISTS (%s AND (%s))", subquery, condition)
+	args = append(args, values...)
+	if isNegativeMode(filter.Mode) || filter.Mode == ModeIsEmpty {
+		subquery, missingArgs := relatedRowsSubquery(d, rel, alias, "1")
+		condition = fmt.Sprintf("(%s OR NOT EXISTS (%s))", condition, subquery)
+		args = append(args, missingArgs...)
+	}
+	return condition, args
+}
+
+// joinRelation joins the relation of T with the given PascalCase name, aliased by that name.
+// Belongs-to and has-one relations use GORM's Joins, which also loads the related record.
+// Has-many and many2many relations get explicit LEFT JOINs that only make the related columns
+// available to ORDER BY, because GORM cannot scan a to-many join into the parent.
+func (f *Handler[T]) joinRelation(db *gorm.DB, name string) *gorm.DB {
+	d := dialectOf(db)
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return db.Joins(name)
+	}
+	rel := modelSchema.Relationships.Relations[name]
+	if rel == nil {
+		return db.Joins(name)
+	}
+
+	alias := quoteIdentifier(d, name)
+	mainTable := quoteIdentifier(d, rel.Schema.Table)
+	switch rel.Type {
+	case schema.HasMany:
+		var conditions []string
+		var args []any
+		for _, ref := range rel.References {
+			switch {
+			case ref.OwnPrimaryKey:
+				conditions = append(conditions, fmt.Sprintf("%s.%s = %s.%s",
+					mainTable, quoteIdentifier(d, ref.PrimaryKey.DBName),
+					alias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+			case ref.PrimaryValue != "":
+				// Polymorphic relations also match the owner type
+				conditions = append(conditions, fmt.Sprintf("%s.%s = ?", alias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+				args = append(args, ref.PrimaryValue)
+			}
+		}
+		conditions = append(conditions, softDeleteConditions(d, rel.FieldSchema, alias)...)
+		join := fmt.Sprintf("LEFT JOIN %s %s ON %s", quoteIdentifier(d, rel.FieldSchema.Table), alias, strings.Join(conditions, " AND "))
+		return db.Joins(join, args...)
+
+	case schema.Many2Many:
+		// Join the join table on the parent's keys, then the related table on the join table's keys
+		joinAlias := quoteIdentifier(d, name+"__"+rel.JoinTable.Table)
+		var joinConditions, relatedConditions []string
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey {
+				joinConditions = append(joinConditions, fmt.Sprintf("%s.%s = %s.%s",
+					mainTable, quoteIdentifier(d, ref.PrimaryKey.DBName),
+					joinAlias, quoteIdentifier(d, ref.ForeignKey.DBName)))
+			} else {
+				relatedConditions = append(relatedConditions, fmt.Sprintf("%s.%s = %s.%s",
+					joinAlias, quoteIdentifier(d, ref.ForeignKey.DBName),
+					alias, quoteIdentifier(d, ref.PrimaryKey.DBName)))
+			}
+		}
+		relatedConditions = append(relatedConditions, softDeleteConditions(d, rel.FieldSchema, alias)...)
+		db = db.Joins(fmt.Sprintf("LEFT JOIN %s %s ON %s",
+			quoteIdentifier(d, rel.JoinTable.Table), joinAlias, strings.Join(joinConditions, " AND ")))
+		return db.Joins(fmt.Sprintf("LEFT JOIN %s %s ON %s",
+			quoteIdentifier(d, rel.FieldSchema.Table), alias, strings.Join(relatedConditions, " AND ")))
+	}
+	return db.Joins(name)
+}
+
+// relationPreloads returns the GORM preload paths (e.g. "WorkShift" or "Department.Manager") of the relations
+// read by the nested filter, search, sort and aggregation fields, the exists filters and the ChildCounts of filterRoot, so the in-memory strategy of
+// Hybrid evaluates and returns loaded structs, as the joins of the database strategy do. Segments that are
+// not relations of T are left out. Has-many and many2many relations are preloaded too, which DataGorm only
+// returns loaded when Root.Preload lists them, as its joins load belongs-to and has-one relations alone.
+func (f *Handler[T]) relationPreloads(db *gorm.DB, filterRoot Root) []string {
+	d := dialectOf(db)
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return nil
+	}
+	var preloads []string
+	seen := make(map[string]bool)
+	add := func(field string) {
+		if preload := f.relationPath(d, modelSchema, field); preload != "" && !seen[preload] {
+			seen[preload] = true
+			preloads = append(preloads, preload)
+		}
+	}
+
+	for _, filter := range flattenFieldFilters(filterRoot) {
+		if isExistsMode(filter.Mode) {
+			// Exists filters read the relation itself
+			name, ok := f.existsRelation(filter.Field)
+			if ok && modelSchema.Relationships.Relations[name] != nil && !seen[name] {
+				seen[name] = true
+				preloads = append(preloads, name)
+			}
+			continue
+		}
+		add(filter.Field)
+	}
+	if filterRoot.Search != nil {
+		for _, field := range filterRoot.Search.Fields {
+			add(field)
+		}
+	}
+	for _, sortField := range filterRoot.SortFields {
+		add(sortField.Field)
+	}
+	for _, aggregation := range filterRoot.Aggregations {
+		add(aggregation.Field)
+	}
+	for _, count := range filterRoot.ChildCounts {
+		name, ok := f.relationName(count.Relation)
+		if ok && modelSchema.Relationships.Relations[name] != nil && !seen[name] {
+			seen[name] = true
+			preloads = append(preloads, name)
+		}
+	}
+	return preloads
+}
+
+// relationPath returns the GORM preload path of the relations a nested field reads
+// ("work_shift.name" -> "WorkShift"), or "" when it reads none
+func (f *Handler[T]) relationPath(d sqlDialect, modelSchema *schema.Schema, field string) string {
+	if !strings.Contains(field, ".") {
+		return ""
+	}
+	// Known fields resolve through their Go field path; deeper nested fields by name
+	parts := strings.Split(field, ".")
+	if path, exists := f.fieldPaths[field]; exists {
+		parts = strings.Split(path, ".")
+	} else if path, exists := f.fieldPaths[strings.ToLower(field)]; exists {
+		parts = strings.Split(path, ".")
+	}
+	fieldSchema := modelSchema
+	var relations []string
+	for _, segment := range parts[:len(parts)-1] {
+		name := f.relationField(d, fieldSchema, segment)
+		rel := fieldSchema.Relationships.Relations[name]
+		if rel == nil {
+			break
+		}
+		relations = append(relations, name)
+		fieldSchema = rel.FieldSchema
+	}
+	return strings.Join(relations, ".")
+}
+
+// withColumnPreloads returns filterRoot with Preload extended by the relations the nested export
+// columns read ("work_shift.name"), so the GORM exports write their values instead of empty cells.
+// Relations filterRoot already preloads, with or without conditions, are kept as they are.
+func (f *Handler[T]) withColumnPreloads(db *gorm.DB, filterRoot Root, columns []string) Root {
+	d := dialectOf(db)
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return filterRoot
+	}
+	resolved, _, err := f.preloads(d, filterRoot)
+	if err != nil {
+		return filterRoot
+	}
+	seen := make(map[string]bool)
+	for _, preload := range resolved {
+		seen[preload.Relation] = true
+	}
+	var preloads []string
+	for _, column := range columns {
+		if preload := f.relationPath(d, modelSchema, column); preload != "" && !seen[preload] {
+			seen[preload] = true
+			preloads = append(preloads, preload)
+		}
+	}
+	if len(preloads) > 0 {
+		filterRoot.Preload = append(slices.Clip(filterRoot.Preload), preloads...)
+	}
+	return filterRoot
+}
+
+// softDeleteConditions skips soft-deleted related rows, as GORM does for its own joins
+func softDeleteConditions(d sqlDialect, related *schema.Schema, alias string) []string {
+	var conditions []string
+	for _, field := range related.Fields {
+		if field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			conditions = append(conditions, fmt.Sprintf("%s.%s IS NULL", alias, quoteIdentifier(d, field.DBName)))
+		}
+	}
+	return conditions
+}
+
+// primaryKeyColumn returns the column of T's primary key as GORM detects it (a `gorm:"primaryKey"`
+// field, else ID), qualified by mainTableName like columnExpr, or the id column when GORM cannot parse T
+func (f *Handler[T]) primaryKeyColumn(d sqlDialect, mainTableName string) string {
+	if modelSchema, err := f.modelSchema(d); err == nil && modelSchema.PrioritizedPrimaryField != nil {
+		return f.columnExpr(d, strings.ToLower(modelSchema.PrioritizedPrimaryField.Name), mainTableName)
+	}
+	return f.columnExpr(d, "id", mainTableName)
+}
+
+// groupByPrimaryKey collapses the rows repeated by to-many joins into one row per record. The primary
+// keys of the belongs-to and has-one relations joined by GORM are grouped by too: they have one row per
+// record, and PostgreSQL only accepts the columns GORM selects from them, and sorts by, when their
+// table's primary key is grouped.
+func (f *Handler[T]) groupByPrimaryKey(db *gorm.DB, query *gorm.DB) *gorm.DB {
+	d := dialectOf(db)
+	query = query.Group(f.primaryKeyColumn(d, f.mainTableName(d)))
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return query
+	}
+	for _, join := range query.Statement.Joins {
+		rel := modelSchema.Relationships.Relations[join.Name]
+		if rel == nil || rel.Type != schema.BelongsTo && rel.Type != schema.HasOne {
+			continue
+		}
+		for _, primaryField := range rel.FieldSchema.PrimaryFields {
+			query = query.Group(quoteIdentifier(d, join.Name) + "." + quoteIdentifier(d, primaryField.DBName))
+		}
+	}
+	return query
+}
+
+// countDistinct counts the records matched by query, counting each primary key once
+// when to-many joins repeat rows, with the key qualified by the main table. The count runs on a
+// copy of query without ORDER BY, which cannot change the count but is kept by GORM on grouped
+// queries, where an unqualified column of the caller's db (e.g. "id DESC") is ambiguous once
+// relations are joined. query is left unchanged.
+func (f *Handler[T]) countDistinct(db *gorm.DB, query *gorm.DB, toMany bool) (int64, error) {
+	counting := query.Session(&gorm.Session{Initialized: true})
+	delete(counting.Statement.Clauses, "ORDER BY")
+
+	var count int64
+	if !toMany {
+		err := counting.Count(&count).Error
+		return count, err
+	}
+	err := counting.Distinct(f.primaryKeyColumn(dialectOf(db), f.mainTableName(dialectOf(db)))).Count(&count).Error
+	return count, err
+}
+
+// sortColumnExpr returns the column expression sortField sorts by: its columnExpr, with JSON paths and
+// map keys of DataTypeNumber cast to numbers (see SortField.DataType)
+func (f *Handler[T]) sortColumnExpr(d sqlDialect, sortField SortField, mainTableName string) string {
+	field := sortField.Field
+	if path, ok := f.mapKeyPath(field); ok {
+		field = path
+	}
+	if column, keys, ok := splitJSONPath(field); ok && sortField.DataType == DataTypeNumber {
+		return jsonPathExpr(d, f.columnExpr(d, column, mainTableName), keys, DataTypeNumber)
+	}
+	return f.columnExpr(d, sortField.Field, mainTableName)
+}
+
+// foldsCase reports whether sortField sorts text case-insensitively, as textComparer does in memory
+// without CaseSensitive, a Collator or a Language: SQL then sorts by LOWER(column) and the column.
+// The primary key appended as a tiebreaker never does, so its index can serve the sort.
+func (f *Handler[T]) foldsCase(sortField SortField) bool {
+	if sortField.tiebreaker || f.caseSensitive || f.collator != nil || sortField.Language != "" {
+		return false
+	}
+	dataType, ok := f.fieldDataType(sortField.Field)
+	return ok && dataType == DataTypeText
+}
+
+// sortColumns returns the column expressions sortField sorts by in SQL: its sortColumnExpr, preceded
+// by its lowercase value when it folds case (see foldsCase), like compareFold compares
+func (f *Handler[T]) sortColumns(d sqlDialect, sortField SortField, mainTableName string) []string {
+	column := f.sortColumnExpr(d, sortField, mainTableName)
+	if f.foldsCase(sortField) {
+		return []string{"LOWER(" + column + ")", column}
+	}
+	return []string{column}
+}
+
+// keysetColumns returns the columns, sort fields and values of the keyset condition and ORDER BY of
+// keyFields, whose values may be nil: a field that folds case (see foldsCase) is compared by its
+// lowercase value, then by its value
+func (f *Handler[T]) keysetColumns(d sqlDialect, keyFields []SortField, values []any, mainTableName string) ([]string, []SortField, []any) {
+	var columns []string
+	var fields []SortField
+	var args []any
+	for i, keyField := range keyFields {
+		sortColumns := f.sortColumns(d, keyField, mainTableName)
+		for j, column := range sortColumns {
+			columns = append(columns, column)
+			fields = append(fields, keyField)
+			if values == nil {
+				continue
+			}
+			value := values[i]
+			if j == 0 && len(sortColumns) > 1 {
+				if s, ok := derefValue(value).(string); ok {
+					value = strings.ToLower(s)
+				}
+			}
+			args = append(args, value)
+		}
+	}
+	return columns, fields, args
+}
+
+// orderExpr returns the ORDER BY expression for sortField, by its sortColumns.
+// A field under a to-many relation has several values per record, so the query is grouped by
+// the primary key and records sort by their smallest value ascending or their largest value descending.
+// NullsFirst and NullsLast use NULLS FIRST/LAST on PostgreSQL and a leading IS NULL sort key elsewhere.
+func (f *Handler[T]) orderExpr(d sqlDialect, sortField SortField, mainTableName string) string {
+	columns := f.sortColumns(d, sortField, mainTableName)
+	direction := "ASC"
+	if sortField.Order == SortOrderDesc {
+		direction = "DESC"
+	}
+	if f.isToManyField(d, sortField.Field) {
+		if direction == "DESC" {
+			columns = []string{"MAX(" + columns[0] + ")"}
+		} else {
+			columns = []string{"MIN(" + columns[0] + ")"}
+		}
+	}
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		keys[i] = column + " " + direction
+		if sortField.Nulls != NullsDefault && d.name == "postgres" {
+			keys[i] += " NULLS " + strings.ToUpper(string(sortField.Nulls))
+		}
+	}
+	order := strings.Join(keys, ", ")
+	if sortField.Nulls == NullsDefault || d.name == "postgres" {
+		return order
+	}
+	nullKey := "CASE WHEN " + columns[0] + " IS NULL THEN 0 ELSE 1 END"
+	if sortField.Nulls == NullsLast {
+		nullKey = "CASE WHEN " + columns[0] + " IS NULL THEN 1 ELSE 0 END"
+	}
+	return nullKey + ", " + order
+}
@@ -0,0 +1,203 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RelevanceField is a sort field ordering records by how well they match Root.Search: an exact match of
+// the search term in any search field first, then a field starting with it, then a field containing it.
+// Records with the same score keep the order of the following sort fields. It always sorts best match
+// first (Order and Nulls are ignored) and is dropped from queries without a search term.
+//
+// Example usage:
+//
+//	root := filter.Root{
+//		Search:     &filter.SearchFilter{Fields: []string{"name", "notes"}, Value: "ann"},
+//		SortFields: []filter.SortField{{Field: filter.RelevanceField}, {Field: "name", Order: filter.SortOrderAsc}},
+//	}
+const RelevanceField = "_relevance"
+
+// Relevance scores of a record, the best of its search fields
+const (
+	relevanceNone     = 0
+	relevanceContains = 1
+	relevancePrefix   = 2
+	relevanceExact    = 3
+)
+
+// relevanceSearch is the search a RelevanceField sort scores records against
+type relevanceSearch struct {
+	fields        []string // Search fields with a single value per record
+	value         string   // Search term, lowercased unless caseSensitive
+	caseSensitive bool
+}
+
+// resolveRelevance returns sortFields with each RelevanceField sort bound to the search over fields,
+// or without them when there is no search term. Fields under to-many relations have several values
+// per record and are not scored. sortFields is not modified.
+func (f *Handler[T]) resolveRelevance(sortFields []SortField, fields []string, value string) []SortField {
+	if !slices.ContainsFunc(sortFields, func(sortField SortField) bool { return sortField.Field == RelevanceField }) {
+		return sortFields
+	}
+	resolved := make([]SortField, 0, len(sortFields))
+	var search *relevanceSearch
+	for _, sortField := range sortFields {
+		if sortField.Field != RelevanceField {
+			resolved = append(resolved, sortField)
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if search == nil {
+			search = &relevanceSearch{value: value, caseSensitive: f.caseSensitive}
+			if !f.caseSensitive {
+				search.value = strings.ToLower(value)
+			}
+			for _, field := range fields {
+				if f.fieldExists(field) && f.isFieldAllowed(field) && !f.isSliceField(field) {
+					search.fields = append(search.fields, field)
+				}
+			}
+		}
+		sortField.search = search
+		resolved = append(resolved, sortField)
+	}
+	return resolved
+}
+
+// relevanceScore returns the score of item for search
+func (f *Handler[T]) relevanceScore(item *T, search *relevanceSearch) int {
+	best := relevanceNone
+	for _, field := range search.fields {
+		getter, exists := f.getter(field)
+		if !exists {
+			continue
+		}
+		text, err := parseText(getter(item))
+		if err != nil {
+			continue
+		}
+		if !search.caseSensitive {
+			text = strings.ToLower(text)
+		}
+		switch {
+		case text == search.value:
+			return relevanceExact
+		case strings.HasPrefix(text, search.value):
+			best = max(best, relevancePrefix)
+		case strings.Contains(text, search.value):
+			best = max(best, relevanceContains)
+		}
+	}
+	return best
+}
+
+// relevanceExpr returns the ORDER BY item scoring rows for search as relevanceScore does, a CASE
+// expression over the search fields with SQL, or nil when none has
+func (f *Handler[T]) relevanceExpr(d sqlDialect, search *relevanceSearch, mainTableName string) clause.Expression {
+	var columns []string
+	for _, field := range search.fields {
+		if !f.hasSQL(field) {
+			continue
+		}
+		column := f.columnExpr(d, field, mainTableName)
+		if !search.caseSensitive {
+			column = fmt.Sprintf("LOWER(%s)", column)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	var vars []any
+	sql.WriteString("CASE")
+	for _, level := range []struct {
+		score   int
+		op      string
+		pattern string
+	}{
+		{relevanceExact, "= ?", search.value},
+		{relevancePrefix, "LIKE ?" + likeEscapeClause(d), likeEscape(d, search.value) + "%"},
+		{relevanceContains, "LIKE ?" + likeEscapeClause(d), "%" + likeEscape(d, search.value) + "%"},
+	} {
+		conditions := make([]string, len(columns))
+		for i, column := range columns {
+			conditions[i] = fmt.Sprintf("%s %s", column, level.op)
+			vars = append(vars, level.pattern)
+		}
+		fmt.Fprintf(&sql, " WHEN %s THEN %d", strings.Join(conditions, " OR "), level.score)
+	}
+	fmt.Fprintf(&sql, " ELSE %d END DESC", relevanceNone)
+	return clause.Expr{SQL: sql.String(), Vars: vars, WithoutParentheses: true}
+}
+
+// orderList is an ORDER BY list whose items may bind values
+type orderList []clause.Expression
+
+// Build writes the items separated by commas
+func (list orderList) Build(builder clause.Builder) {
+	for i, item := range list {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		item.Build(builder)
+	}
+}
+
+// applyOrder adds an ORDER BY on sortFields to query, skipping unknown simple fields and computed fields
+// without SQL, and reports whether any sort field was applied. GORM drops the values of an ORDER BY
+// expression once other columns are merged into it, so with a RelevanceField sort the whole ORDER BY,
+// including any the caller's query already had, is written as one expression.
+func (f *Handler[T]) applyOrder(db *gorm.DB, query *gorm.DB, sortFields []SortField, mainTableName string) (*gorm.DB, bool) {
+	items, bound := f.orderItems(dialectOf(db), sortFields, mainTableName)
+	if len(items) == 0 {
+		return query, false
+	}
+	if !bound {
+		for _, item := range items {
+			query = query.Order(item.(clause.Expr).SQL)
+		}
+		return query, true
+	}
+	if existing, ok := query.Statement.Clauses["ORDER BY"].Expression.(clause.OrderBy); ok {
+		items = append(orderList{existing}, items...)
+	}
+	return query.Order(clause.OrderBy{Expression: items}), true
+}
+
+// orderItems returns the ORDER BY items of applyOrder, and whether any of them binds values
+func (f *Handler[T]) orderItems(d sqlDialect, sortFields []SortField, mainTableName string) (orderList, bool) {
+	var items orderList
+	bound := false
+	for _, sortField := range sortFields {
+		if sortField.Field == RelevanceField {
+			if sortField.search == nil {
+				continue
+			}
+			if expr := f.relevanceExpr(d, sortField.search, mainTableName); expr != nil {
+				items = append(items, expr)
+				bound = true
+			}
+			continue
+		}
+		// Map keys are read from their JSON column
+		if path, ok := f.mapKeyPath(sortField.Field); ok {
+			sortField.Field = path
+		}
+		// For simple fields, check if they exist. For nested fields, let GORM handle them.
+		if !strings.Contains(sortField.Field, ".") && (!f.fieldExists(sortField.Field) || !f.hasSQL(sortField.Field)) {
+			// Silently ignore non-existent simple sort fields and computed fields without SQL
+			continue
+		}
+		items = append(items, clause.Expr{SQL: f.orderExpr(d, sortField, mainTableName)})
+	}
+	return items, bound
+}
@@ -0,0 +1,113 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// parsedSchema is a cached result of modelSchema
+type parsedSchema struct {
+	schema *schema.Schema
+	err    error
+}
+
+// modelSchema returns the parsed GORM schema of T. It is parsed once per naming strategy and
+// reused by later queries, since the table name and relations of T cannot change. A failure is
+// cached too: GORM logs an error for every field it cannot parse, such as those of a T that is
+// not a GORM model, which in-memory queries would otherwise log on every call.
+func (f *Handler[T]) modelSchema(d sqlDialect) (*schema.Schema, error) {
+	namer := d.namer
+	if namer == nil {
+		namer = schema.NamingStrategy{}
+	}
+	// Custom naming strategies that cannot be map keys are parsed on every call
+	cacheable := reflect.TypeOf(namer).Comparable()
+	if cacheable {
+		if cached, ok := f.schemas.Load(namer); ok {
+			parsed := cached.(parsedSchema)
+			return parsed.schema, parsed.err
+		}
+	}
+
+	modelSchema, err := schema.Parse(new(T), &sync.Map{}, namer)
+	if cacheable {
+		f.schemas.Store(namer, parsedSchema{schema: modelSchema, err: err})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return modelSchema, nil
+}
+
+// primaryKeyField returns the getter key of T's primary key as GORM detects it (the one `gorm:"primaryKey"`
+// field, or the autoIncrement one of several, else ID), or "" when T has no such field. It reads the tags
+// rather than parsing T with GORM, which logs an error for any type that is not a GORM model.
+// The key is the lowercase Go name, which both getters and schemaColumn resolve.
+func primaryKeyField[T any](getters map[string]func(*T) any) string {
+	var key string
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Struct {
+		var primary []reflect.StructField
+		for _, field := range visibleFields(t) {
+			settings := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+			if isTrueSetting(settings, "PRIMARYKEY") || isTrueSetting(settings, "PRIMARY_KEY") {
+				primary = append(primary, field)
+			}
+		}
+		for _, field := range primary {
+			settings := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+			if len(primary) == 1 || isTrueSetting(settings, "AUTOINCREMENT") {
+				key = strings.ToLower(field.Name)
+				break
+			}
+		}
+	}
+	if _, exists := getters[key]; exists {
+		return key
+	}
+	if _, exists := getters["id"]; exists {
+		return "id"
+	}
+	return ""
+}
+
+// isTrueSetting reports whether a GORM tag setting is present and not false, as GORM reads it
+func isTrueSetting(settings map[string]string, name string) bool {
+	value, ok := settings[name]
+	return ok && value != "" && !strings.EqualFold(value, "false")
+}
+
+// softDeleteChecker returns a function reporting whether an item is soft-deleted, from a DeletedAt field
+// of T (promoted ones included) of type gorm.DeletedAt or *time.Time, or nil when T has no such field
+func softDeleteChecker[T any]() func(*T) bool {
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	if modelType.Kind() != reflect.Struct {
+		return nil
+	}
+	field, ok := modelType.FieldByName("DeletedAt")
+	if !ok {
+		return nil
+	}
+	deletedAt := func(item *T) (reflect.Value, bool) {
+		// A nil embedded pointer on the way means the field is unset
+		value, err := reflect.ValueOf(item).Elem().FieldByIndexErr(field.Index)
+		return value, err == nil
+	}
+	switch field.Type {
+	case reflect.TypeOf(gorm.DeletedAt{}):
+		return func(item *T) bool {
+			value, ok := deletedAt(item)
+			return ok && value.Interface().(gorm.DeletedAt).Valid
+		}
+	case reflect.TypeOf((*time.Time)(nil)):
+		return func(item *T) bool {
+			value, ok := deletedAt(item)
+			return ok && !value.IsNil()
+		}
+	}
+	return nil
+}
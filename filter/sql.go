@@ -0,0 +1,227 @@
+package filter
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// BuildSQL returns the WHERE conditions and ORDER BY list DataGorm would use for root, for services
+// querying with database/sql or sqlx instead of GORM. It needs no *gorm.DB: opts names the dialect,
+// which picks the identifier quoting and dialect-specific expressions, and T's GORM tags and
+// opts.NamingStrategy name its table and columns. The conditions include the soft-delete check of
+// models with a gorm.DeletedAt field unless root.IncludeDeleted is set, as GORM adds it, and args
+// holds the values of where followed by those of orderBy (relevance sorts bind the search term).
+// Placeholders are ? on every dialect; rebind them for PostgreSQL (e.g. sqlx.Rebind).
+//
+// where is empty when nothing is filtered, and orderBy when nothing is sorted and T has no primary key.
+// Nested fields read the relation's table under the Go field name of the relation ("Department"."name"),
+// so the query must join it under that alias, and group by the primary key to sort by a has-many or
+// many2many relation, whose filters are EXISTS subqueries.
+// GormHooks and Preload do not apply.
+//
+// Example usage:
+//
+//	where, args, orderBy, err := handler.BuildSQL(filterRoot, filter.SQLOptions{Dialect: "postgres"})
+//	query := "SELECT * FROM users"
+//	if where != "" {
+//	    query += " WHERE " + where
+//	}
+//	err = db.Select(&users, db.Rebind(query+" ORDER BY "+orderBy), args...)
+func (f *Handler[T]) BuildSQL(root Root, opts SQLOptions) (where string, args []any, orderBy string, err error) {
+	d, err := sqlOptionsDialect(opts)
+	if err != nil {
+		return "", nil, "", err
+	}
+	root, err = f.restrictRoot(root)
+	if err != nil {
+		return "", nil, "", err
+	}
+	conditions, err := f.compiledConditions(d, root)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	exprs := make([]clause.Expression, 0, len(conditions)+1)
+	for _, condition := range conditions {
+		exprs = append(exprs, clause.Expr{SQL: condition.sql, Vars: condition.values})
+	}
+	if !root.IncludeDeleted {
+		exprs = append(exprs, f.softDeleteExprs(d)...)
+	}
+	writer := &sqlWriter{d: d}
+	if len(exprs) > 0 {
+		clause.Where{Exprs: exprs}.Build(writer)
+	}
+	where = writer.String()
+
+	// Sort like the page query of DataGorm, with the primary key as a tiebreaker
+	mainTableName := f.pageTableName(d, root)
+	order := clause.OrderBy{}
+	if sortFields := f.withTiebreaker(root.SortFields); len(sortFields) > 0 {
+		items, bound := f.orderItems(d, sortFields, mainTableName)
+		if bound {
+			order.Expression = items
+		} else {
+			for _, item := range items {
+				order.Columns = append(order.Columns, orderColumn(item.(clause.Expr).SQL))
+			}
+		}
+	} else if f.primaryKey != "" {
+		order.Columns = []clause.OrderByColumn{orderColumn(fmt.Sprintf("%s ASC", f.primaryKeyColumn(d, mainTableName)))}
+	}
+	writer.Reset()
+	order.Build(writer)
+	return where, writer.vars, writer.String(), nil
+}
+
+// orderColumn returns an ORDER BY item written as is, as db.Order adds a string
+func orderColumn(sql string) clause.OrderByColumn {
+	return clause.OrderByColumn{Column: clause.Column{Name: sql, Raw: true}}
+}
+
+// sqlDialect is what building SQL needs to know about the database: the name of its dialect as GORM's
+// Dialector reports it ("sqlite", "mysql", "postgres", "sqlserver"), how it quotes identifiers, and the
+// naming strategy mapping T's Go names to tables and columns. DataGorm and friends take it from their
+// *gorm.DB (see dialectOf) and BuildSQL from its SQLOptions, so the WHERE and ORDER BY builders need no *gorm.DB.
+type sqlDialect struct {
+	name    string
+	quoteTo func(writer clause.Writer, str string)
+	namer   schema.Namer
+}
+
+// dialectOf returns the dialect of db
+func dialectOf(db *gorm.DB) sqlDialect {
+	return sqlDialect{name: db.Dialector.Name(), quoteTo: db.Dialector.QuoteTo, namer: db.NamingStrategy}
+}
+
+// sqlOptionsDialect returns the dialect opts selects, quoting identifiers like GORM's drivers
+func sqlOptionsDialect(opts SQLOptions) (sqlDialect, error) {
+	d := sqlDialect{name: opts.Dialect, namer: opts.NamingStrategy}
+	if d.name == "" {
+		d.name = "sqlite"
+	}
+	if d.namer == nil {
+		d.namer = schema.NamingStrategy{}
+	}
+	switch d.name {
+	case "sqlite", "mysql":
+		d.quoteTo = quoteWith('`')
+	case "postgres", "sqlserver":
+		d.quoteTo = quoteWith('"')
+	default:
+		return sqlDialect{}, fmt.Errorf("unsupported SQL dialect %q", opts.Dialect)
+	}
+	return d, nil
+}
+
+// quoteWith returns a quoteTo wrapping each dot-separated part of an identifier in quote,
+// doubling the quotes inside it ("users.id" -> "users"."id")
+func quoteWith(quote byte) func(writer clause.Writer, str string) {
+	escaped := string([]byte{quote, quote})
+	return func(writer clause.Writer, str string) {
+		for i, part := range strings.Split(str, ".") {
+			if i > 0 {
+				writer.WriteByte('.')
+			}
+			writer.WriteByte(quote)
+			writer.WriteString(strings.ReplaceAll(part, string(quote), escaped))
+			writer.WriteByte(quote)
+		}
+	}
+}
+
+// softDeleteExprs returns the condition GORM adds to skip soft-deleted rows of T, if T has a
+// gorm.DeletedAt field: "users"."deleted_at" IS NULL
+func (f *Handler[T]) softDeleteExprs(d sqlDialect) []clause.Expression {
+	modelSchema, err := f.modelSchema(d)
+	if err != nil {
+		return nil
+	}
+	conditions := softDeleteConditions(d, modelSchema, quoteIdentifier(d, modelSchema.Table))
+	exprs := make([]clause.Expression, len(conditions))
+	for i, condition := range conditions {
+		exprs[i] = clause.Expr{SQL: condition}
+	}
+	return exprs
+}
+
+// sqlWriter builds clauses into SQL with ? placeholders, as a GORM statement does, collecting their values
+type sqlWriter struct {
+	strings.Builder
+	d    sqlDialect
+	vars []any
+}
+
+// WriteQuoted writes a column or table name, quoted unless raw
+func (w *sqlWriter) WriteQuoted(field any) {
+	switch field := field.(type) {
+	case clause.Column:
+		if field.Raw {
+			w.WriteString(field.Name)
+			return
+		}
+		w.d.quoteTo(w, field.Name)
+	case clause.Table:
+		w.d.quoteTo(w, field.Name)
+	default:
+		w.d.quoteTo(w, fmt.Sprint(field))
+	}
+}
+
+// AddVar writes a placeholder for each of vars, expanding slices into a parenthesized list as GORM does
+func (w *sqlWriter) AddVar(writer clause.Writer, vars ...any) {
+	for i, v := range vars {
+		if i > 0 {
+			writer.WriteByte(',')
+		}
+		switch v := v.(type) {
+		case clause.Expression:
+			v.Build(w)
+		case driver.Valuer, []byte:
+			w.bind(writer, v)
+		case []any:
+			if len(v) == 0 {
+				writer.WriteString("(NULL)")
+				continue
+			}
+			writer.WriteByte('(')
+			w.AddVar(writer, v...)
+			writer.WriteByte(')')
+		default:
+			rv := reflect.ValueOf(v)
+			if kind := rv.Kind(); (kind != reflect.Slice && kind != reflect.Array) || rv.Type().Elem().Kind() == reflect.Uint8 {
+				w.bind(writer, v)
+				continue
+			}
+			if rv.Len() == 0 {
+				writer.WriteString("(NULL)")
+				continue
+			}
+			writer.WriteByte('(')
+			for j := 0; j < rv.Len(); j++ {
+				if j > 0 {
+					writer.WriteByte(',')
+				}
+				w.AddVar(writer, rv.Index(j).Interface())
+			}
+			writer.WriteByte(')')
+		}
+	}
+}
+
+// bind writes a placeholder for v
+func (w *sqlWriter) bind(writer clause.Writer, v any) {
+	w.vars = append(w.vars, v)
+	writer.WriteByte('?')
+}
+
+// AddError returns err; the clauses built by BuildSQL report no errors
+func (w *sqlWriter) AddError(err error) error {
+	return err
+}
@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransformValue registers fn to rewrite the values of filters on field before they are matched,
+// in DataQuery, DataGorm, Hybrid and the exports alike, so input normalization such as trimming or
+// formatting phone numbers lives in one place. fn receives each value separately: the value of a
+// comparison, each bound of a ModeRange Range, and each item of a ModeIn or ModeNotIn list. nil values
+// and ModeIsEmpty / ModeIsNotEmpty filters are left alone. An error from fn makes the query fail as
+// an invalid filter value. Stored values are never transformed. It panics on an unknown simple field,
+// like AllowedFields, and must be called before the Handler is shared between goroutines.
+//
+// Example usage:
+//
+//	handler := filter.NewFilter[Customer](filter.GolangFilteringConfig{}).
+//		TransformValue("phone", func(v any) (any, error) {
+//			s, ok := v.(string)
+//			if !ok {
+//				return nil, fmt.Errorf("phone must be a string")
+//			}
+//			return normalizePhone(s)
+//		})
+func (f *Handler[T]) TransformValue(field string, fn func(value any) (any, error)) *Handler[T] {
+	if !strings.Contains(field, ".") && !f.fieldExists(field) {
+		panic(fmt.Sprintf("filter: TransformValue on unknown field %q", field))
+	}
+	if f.transforms == nil {
+		f.transforms = make(map[string]func(any) (any, error))
+	}
+	f.transforms[f.fieldID(field)] = fn
+	return f
+}
+
+// transformValues returns a copy of root where the values of filters on fields with a TransformValue
+// function are replaced by its result, including nested groups. The caller's Root is never modified.
+func (f *Handler[T]) transformValues(root Root) (Root, error) {
+	if len(f.transforms) == 0 {
+		return root, nil
+	}
+	transformed := root
+	copied := false
+	for i, filter := range root.FieldFilters {
+		fn, exists := f.transforms[f.fieldID(filter.Field)]
+		if !exists || filter.Mode == ModeIsEmpty || filter.Mode == ModeIsNotEmpty || isExistsMode(filter.Mode) {
+			continue
+		}
+		value, err := transformValue(fn, filter)
+		if err != nil {
+			return Root{}, &InvalidValueError{Field: filter.Field, Value: filter.Value, DataType: filter.DataType, Err: err}
+		}
+		if !copied {
+			transformed.FieldFilters = append([]FieldFilter{}, root.FieldFilters...)
+			copied = true
+		}
+		transformed.FieldFilters[i].Value = value
+	}
+	if len(root.Groups) > 0 {
+		transformed.Groups = make([]Root, len(root.Groups))
+		for i, group := range root.Groups {
+			transformedGroup, err := f.transformValues(group)
+			if err != nil {
+				return Root{}, err
+			}
+			transformed.Groups[i] = transformedGroup
+		}
+	}
+	return transformed, nil
+}
+
+// transformValue applies fn to the value of filter, or to each Range bound or list item
+func transformValue(fn func(any) (any, error), filter FieldFilter) (any, error) {
+	apply := func(value any) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return fn(value)
+	}
+	switch filter.Mode {
+	case ModeRange:
+		rng, err := toRange(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		if rng.From, err = apply(rng.From); err != nil {
+			return nil, err
+		}
+		if rng.To, err = apply(rng.To); err != nil {
+			return nil, err
+		}
+		return rng, nil
+	case ModeIn, ModeNotIn:
+		list, err := parseList(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]any, len(list))
+		for i, item := range list {
+			if items[i], err = apply(item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return apply(filter.Value)
+}
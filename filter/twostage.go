@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// DataGormThenQuery filters in two stages, for conditions SQL cannot express: gormRoot is applied in SQL
+// as DataGormNoPage does, then memRoot in memory as DataQuery does, and the final set is paginated with
+// its TotalSize. The relations memRoot's nested fields read are preloaded along with gormRoot.Preload, so
+// nested getters see populated relations; list those a RegisterField getter reads in gormRoot.Preload.
+// Without memRoot.SortFields the final set is sorted by gormRoot.SortFields; the SQL stage is capped at
+// GolangFilteringConfig.MaxExportRows like DataGormNoPage (see Root.TruncateExport on gormRoot).
+// The FetchHooks only run on the returned page.
+//
+// Example usage:
+//
+//	handler.RegisterField("customer_risk", func(o *Order) any { return riskScore(o.Customer) }, filter.DataTypeNumber)
+//	gormRoot := filter.Root{
+//	    Logic:        filter.LogicAnd,
+//	    FieldFilters: []filter.FieldFilter{{Field: "status", Value: "open", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+//	    Preload:      []string{"Customer"}, // Read by the customer_risk getter
+//	}
+//	memRoot := filter.Root{
+//	    Logic:        filter.LogicAnd,
+//	    FieldFilters: []filter.FieldFilter{{Field: "customer_risk", Value: 70, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}},
+//	}
+//	result, err := handler.DataGormThenQuery(db, gormRoot, memRoot, pageIndex, pageSize)
+func (f *Handler[T]) DataGormThenQuery(
+	db *gorm.DB,
+	gormRoot Root,
+	memRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return f.DataGormThenQueryCtx(context.Background(), db, gormRoot, memRoot, pageIndex, pageSize)
+}
+
+// DataGormThenQueryCtx is DataGormThenQuery with cancellation support.
+// The context is passed to GORM for the fetch and to the in-memory workers.
+func (f *Handler[T]) DataGormThenQueryCtx(
+	ctx context.Context,
+	db *gorm.DB,
+	gormRoot Root,
+	memRoot Root,
+	pageIndex int,
+	pageSize int,
+) (*PaginationResult[T], error) {
+	return observe(f, ctx, "DataGormThenQuery", memRoot, "", func(report *QueryResultInfo) (*PaginationResult[T], error) {
+		// Report invalid in-memory filters before fetching anything
+		if _, err := f.restrictMemoryRoot(memRoot); err != nil {
+			return nil, err
+		}
+		db := db.WithContext(ctx)
+
+		fetchRoot := gormRoot
+		fetchRoot.Preload = append(append([]string{}, gormRoot.Preload...), f.relationPreloads(db, memRoot)...)
+		data, err := f.fetchGormNoPage(ctx, db, fetchRoot, report)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(memRoot.SortFields) == 0 {
+			memRoot.SortFields = gormRoot.SortFields
+		}
+		result, err := f.dataQuery(ctx, data, memRoot, pageIndex, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		report.Scanned = len(data)
+		report.TotalSize = result.TotalSize
+		return result, nil
+	})
+}
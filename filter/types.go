@@ -1,6 +1,11 @@
 package filter
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
 
 // Mode defines the type of comparison operation to perform
 type Mode string
@@ -22,6 +27,10 @@ const (
 	ModeRange       Mode = "range"       // Between two values
 	ModeBefore      Mode = "before"      // Before (date/time)
 	ModeAfter       Mode = "after"       // After (date/time)
+	ModeIn          Mode = "in"          // Matches any value in a list
+	ModeNotIn       Mode = "notIn"       // Matches no value in a list
+	ModeExists      Mode = "exists"      // Relation has at least one related record
+	ModeNotExists   Mode = "notExists"   // Relation has no related records
 )
 
 // DataType defines the data type being filtered
@@ -29,14 +38,17 @@ type DataType string
 
 // data type constants define the type of data being filtered
 const (
-	DataTypeNumber DataType = "number" // Numeric values
-	DataTypeText   DataType = "text"   // Text/string values
-	DataTypeBool   DataType = "bool"   // Boolean values
-	DataTypeDate   DataType = "date"   // Date values
-	DataTypeTime   DataType = "time"   // Time values
+	DataTypeNumber   DataType = "number"   // Numeric values
+	DataTypeText     DataType = "text"     // Text/string values
+	DataTypeBool     DataType = "bool"     // Boolean values
+	DataTypeDate     DataType = "date"     // Date values
+	DataTypeTime     DataType = "time"     // Time-of-day values, compared by wall clock whatever their zone
+	DataTypeUUID     DataType = "uuid"     // UUID values (uuid.UUID, [16]byte, or canonical strings)
+	DataTypeDuration DataType = "duration" // Durations ("1h30m" or numbers) on number fields, see Handler.DurationUnit
 )
 
-// Logic defines how multiple filters are combined
+// Logic defines how multiple filters are combined. Only LogicAnd combines them with AND: an empty
+// Logic combines them with OR, like LogicOr, in every query, in ParseRootFromJSON and in MergeRoots.
 type Logic string
 
 // logic constants define how to combine multiple filters
@@ -54,51 +66,322 @@ const (
 	SortOrderDesc SortOrder = "desc" // Descending order
 )
 
+// NullsOrder places NULL values (nil pointers, nil parents, zero times) before or after the other values
+type NullsOrder string
+
+// Nulls order constants define where NULL values sort regardless of the sort direction
+const (
+	NullsDefault NullsOrder = ""      // The database's placement (SQLite and MySQL: first ascending, last descending)
+	NullsFirst   NullsOrder = "first" // NULL values come first
+	NullsLast    NullsOrder = "last"  // NULL values come last
+)
+
+// TextMatchStrategy chooses the SQL case-insensitive pattern filters compile to
+type TextMatchStrategy string
+
+// Text match strategy constants define how ModeContains, ModeNotContains, ModeStartsWith and ModeEndsWith
+// ignore case in SQL
+const (
+	TextMatchAuto  TextMatchStrategy = ""      // ILIKE on PostgreSQL, LOWER(col) LIKE LOWER(?) elsewhere
+	TextMatchLower TextMatchStrategy = "lower" // LOWER(col) LIKE LOWER(?) on every database
+	TextMatchILike TextMatchStrategy = "ilike" // col ILIKE ? on every database, for PostgreSQL-compatible dialects
+)
+
+// Strategy identifies whether Hybrid filtered in memory or in the database
+type Strategy string
+
+// strategy constants define the paths Hybrid can take
+const (
+	StrategyMemory   Strategy = "memory"   // Fetch rows and filter with DataQuery
+	StrategyDatabase Strategy = "database" // Filter in SQL with DataGorm
+)
+
+// HybridOptions customizes how Hybrid chooses a strategy
+type HybridOptions struct {
+	// StrategyFunc chooses the strategy from the estimated table size and the filter.
+	// When nil, in-memory filtering is used if estimatedRows <= threshold.
+	StrategyFunc func(estimatedRows int64, root Root) Strategy
+	// Estimator replaces Handler.EstimateRows as the source of estimatedRows, e.g. to stub the
+	// database statistics in tests. An error makes Hybrid use the database strategy.
+	Estimator func(db *gorm.DB) (int64, error)
+	// FallbackToGormOnError retries the Root through DataGorm when the in-memory path fails after the
+	// rows are loaded, e.g. on a value the in-memory parsers reject or a nested field without a getter
+	// (beyond GolangFilteringConfig.MaxDepth), which Hybrid otherwise sends straight to the database
+	// strategy. The in-memory error is reported to the Observer as QueryResultInfo.FallbackErr and the result has Fallback set. Canceled
+	// contexts and failed fetches are returned as is.
+	FallbackToGormOnError bool
+}
+
+// CSVOptions configures the ...WithOptions CSV exports and the streaming CSV exports.
+// Use DefaultCSVOptions for the output produced by GormNoPaginationCSV and DataQueryNoPageCSV.
+type CSVOptions struct {
+	Delimiter   rune     // Field delimiter (',' when zero)
+	OmitHeaders bool     // Leaves out the header row (written when false, as in DefaultCSVOptions)
+	Columns     []string // Columns to write, in this order (all columns sorted alphabetically when empty); unknown names are an error
+	NullAs      string   // Text written for nil values (nil pointers); nested values under a nil relation are left empty
+	// ExcludeFields leaves these fields out of the header and rows, along with the columns nested under them
+	// ("team" also drops "team.name"), even when Columns lists them; the order of the other columns is kept.
+	// Aliases of a field ("tax_id", "taxid") are all dropped, and unknown names are ignored.
+	ExcludeFields []string
+	// TimeFormat is the layout used for time.Time values, including pointers and types embedding or
+	// converting to time.Time (time.RFC3339 when empty). Zero times are written as an empty string.
+	TimeFormat string
+	// Location converts time values to this zone before formatting (each value's own zone when nil)
+	Location *time.Location
+	// BatchSize is the number of rows fetched (GORM) or written (in-memory) between flushes by the streaming exports.
+	// Defaults to DefaultStreamBatchSize when <= 0.
+	BatchSize int
+	// EscapeFormulas prefixes cells starting with =, +, -, @, a tab or a carriage return with a single quote,
+	// so spreadsheets show user-supplied values such as "=HYPERLINK(...)" as text instead of running them
+	// (CSV injection). Numbers such as -5 are left alone. On in DefaultCSVOptions.
+	EscapeFormulas bool
+}
+
+// JSONOptions configures the JSON exports (GormNoPaginationJSONWithOptions and friends)
+type JSONOptions struct {
+	// ExcludeFields leaves these fields out of every record, wherever they are nested ("team.salary"
+	// drops the salary member of the team object, or of every element of a team array). Fields are
+	// named like filter fields (json name or lowercase Go name) and unknown names are ignored.
+	ExcludeFields []string
+}
+
+// SQLOptions configures BuildSQL
+type SQLOptions struct {
+	// Dialect is the database the SQL is written for, named like GORM's dialectors: "sqlite" (when empty),
+	// "mysql", "postgres" or "sqlserver". It picks the identifier quoting (backticks on SQLite and MySQL,
+	// double quotes otherwise) and dialect-specific expressions such as ILIKE on PostgreSQL.
+	Dialect string
+	// NamingStrategy maps T's Go names to its table and columns, as the GORM config migrating T does
+	// (GORM's default snake_case naming when nil)
+	NamingStrategy schema.Namer
+}
+
+// DefaultStreamBatchSize is the number of rows fetched per batch by the streaming exports
+// (GormCSVStream, GormNDJSONStream and their in-memory counterparts) when no batch size is set
+const DefaultStreamBatchSize = 1000
+
 // represents a single filter condition
 type FieldFilter struct {
 	Field    string   `json:"field"`    // Field name to filter on
 	Value    any      `json:"value"`    // Value to compare against
 	Mode     Mode     `json:"mode"`     // Comparison mode
 	DataType DataType `json:"dataType"` // Data type of the field
+	// CaseSensitive overrides GolangFilteringConfig.CaseSensitive for this text filter: true compares
+	// raw values, false folds case (nil: the handler's setting, case-insensitive by default)
+	CaseSensitive *bool `json:"caseSensitive,omitempty"`
+	// CompareField compares Field to another field of the same record instead of to Value,
+	// e.g. check_out < check_in. It takes precedence over Value and supports number, date and time
+	// fields with ModeEqual, ModeNotEqual, ModeGT, ModeGTE, ModeLT, ModeLTE, ModeBefore and ModeAfter.
+	CompareField string `json:"compareField,omitempty"`
+	// Group ANDs together the filters of a Root that share the same non-zero number, and combines
+	// that conjunction with the other filters and groups using the Root's Logic, so "age >= 18 AND
+	// age <= 65" can sit next to "vip = true" under LogicOr without a nested group
+	Group int `json:"group,omitempty"`
+}
+
+// SearchFilter matches Value against several text fields at once, for a single search box.
+// The fields are combined with OR and the result is ANDed with the rest of the Root.
+type SearchFilter struct {
+	Fields []string `json:"fields,omitempty"` // Fields to search (all allowed string fields of T when empty)
+	Value  string   `json:"value"`            // Search term (the search is skipped when empty)
+	Mode   Mode     `json:"mode,omitempty"`   // Text mode used for every field (ModeContains when empty)
 }
 
 // SortField represents a field to sort by
 type SortField struct {
-	Field string    `json:"field"` // Field name to sort by
-	Order SortOrder `json:"order"` // Sort direction
+	Field string     `json:"field"`           // Field name to sort by
+	Order SortOrder  `json:"order"`           // Sort direction
+	Nulls NullsOrder `json:"nulls,omitempty"` // Placement of NULL values (NullsDefault when empty)
+	// Language is a BCP 47 tag (e.g. "de", "sv") whose collation orders the strings of this field in
+	// memory, overriding GolangFilteringConfig.Collator. SQL keeps the collation of the column.
+	Language string `json:"language,omitempty"`
+	// DataType is the type of a JSON path or map key field (e.g. "metadata->seats"). With DataTypeNumber
+	// SQL casts its values to numbers, so they sort numerically like in memory instead of as text.
+	DataType DataType `json:"dataType,omitempty"`
+
+	search     *relevanceSearch // Search a RelevanceField sort scores against, set by restrictRoot
+	tiebreaker bool             // Appended by withPrimaryKey, so it sorts by the raw key (see foldsCase)
 }
 
 // Root represents the root filter configuration
 type Root struct {
-	FieldFilters []FieldFilter `json:"filters"`    // List of filter conditions
-	SortFields   []SortField   `json:"sortFields"` // List of sort fields
-	Logic        Logic         `json:"logic"`      // How to combine filters (AND/OR)
-	Preload      []string      `json:"preload"`    // List of related entities to preload (only applicable for GORM)
+	FieldFilters     []FieldFilter `json:"filters"`                // List of filter conditions
+	SortFields       []SortField   `json:"sortFields"`             // List of sort fields
+	Logic            Logic         `json:"logic"`                  // How to combine filters (AND/OR)
+	Preload          []string      `json:"preload"`                // List of related entities to preload (only applicable for GORM; see Preloads for conditions)
+	Search           *SearchFilter `json:"search,omitempty"`       // Search term matched against several fields, ANDed with the filters
+	TimeZone         string        `json:"timeZone,omitempty"`     // IANA zone for date values without an offset (GolangFilteringConfig.Location when empty)
+	Groups           []Root        `json:"groups,omitempty"`       // Nested filter groups combined with FieldFilters using Logic (only FieldFilters, Logic and Groups are used)
+	SelectFields     []string      `json:"selectFields,omitempty"` // Fields to fetch in DataGorm/DataGormNoPage (all when empty; ignored in-memory)
+	StrictValidation *bool         `json:"-"`                      // Overrides GolangFilteringConfig.StrictValidation for this query (server-side only, never decoded from JSON)
+	IncludeDeleted   bool          `json:"-"`                      // Includes soft-deleted rows (DeletedAt set) in every path (server-side only, never decoded from JSON)
+	MaxDepth         int           `json:"-"`                      // Fails the query on fields with more dotted segments than this, e.g. 2 rejects "a.b.c" (no limit when <= 0; server-side only, never decoded from JSON)
+	TruncateExport   bool          `json:"-"`                      // Returns the first GolangFilteringConfig.MaxExportRows records instead of failing when more match (server-side only, never decoded from JSON)
+	Aggregations     []Aggregation `json:"aggregations,omitempty"` // Aggregates of numeric fields over every matching record, returned in PaginationResult.Aggregates
+	Limit            int           `json:"limit,omitempty"`        // Caps the records of DataGormNoPage, DataQueryNoPage, Hybrid's NoPage paths, the exports and ForEach (no cap when <= 0; paged queries ignore it)
+	// ChildCounts match records by how many of their related records match a condition, ANDed with
+	// the rest of the Root (ignored in nested groups)
+	ChildCounts []ChildCountFilter `json:"childCounts,omitempty"`
+	// Preloads load relations with the records of the GORM paths like Preload, optionally restricted by
+	// GORM conditions (server-side only, never decoded from JSON, as Conditions are SQL)
+	Preloads []Preload `json:"-"`
+
+	compileKey string // Key the compiled filters are cached under, set by restrictRoot ("" when not cached)
+}
+
+// Preload loads a relation, or a nested relation such as "Orders.Items", with the records of the GORM
+// paths, passing Conditions to GORM's Preload for the last relation of the path:
+//
+//	{Relation: "Orders.Items", Conditions: []any{"quantity > ?", 1}}
+//
+// Segments are Go field names, JSON names or column names of the relations, as in nested fields.
+type Preload struct {
+	Relation   string
+	Conditions []any
+}
+
+// ChildCountFilter matches records by the number of elements of a has-many or many2many relation
+// matching Where, such as customers with at least 3 open orders:
+//
+//	{Relation: "orders", Where: filter.Root{FieldFilters: []filter.FieldFilter{{Field: "status", Value: "open", Mode: filter.ModeEqual, DataType: filter.DataTypeText}}}, Mode: filter.ModeGTE, Value: 3}
+type ChildCountFilter struct {
+	Relation string `json:"relation"` // Slice field of T holding the related records
+	// Where selects the related records to count, with fields relative to them ("status", not "orders.status").
+	// Only its FieldFilters, Logic and Groups are used; every related record is counted when it has no filters.
+	// Like other fields of slice elements, its fields need MaxDepth > 1.
+	Where Root `json:"where"`
+	Mode  Mode `json:"mode"`  // ModeEqual, ModeNotEqual, ModeGT, ModeGTE, ModeLT, ModeLTE or ModeRange
+	Value any  `json:"value"` // Count to compare with, or a Range of counts
+}
+
+// AggregateFunc is a function computed by an Aggregation
+type AggregateFunc string
+
+const (
+	AggregateSum   AggregateFunc = "sum"
+	AggregateAvg   AggregateFunc = "avg"
+	AggregateMin   AggregateFunc = "min"
+	AggregateMax   AggregateFunc = "max"
+	AggregateCount AggregateFunc = "count" // Records with a non-NULL value
+)
+
+// Aggregation requests an aggregate of a numeric field over every record matching the filters
+type Aggregation struct {
+	Field string        `json:"field"` // Numeric field to aggregate
+	Func  AggregateFunc `json:"func"`  // Aggregate function
 }
 
-// Range represents a range of values for filtering
+// Range represents a range of values for filtering.
+// Both bounds are inclusive unless FromExclusive or ToExclusive is set, so adjacent
+// buckets such as [100, 200) and [200, 300) count a boundary value exactly once.
 type Range struct {
-	From any `json:"from"` // Start of range
-	To   any `json:"to"`   // End of range
+	From          any  `json:"from"`                    // Start of range
+	To            any  `json:"to"`                      // End of range
+	FromExclusive bool `json:"fromExclusive,omitempty"` // Exclude values equal to From
+	ToExclusive   bool `json:"toExclusive,omitempty"`   // Exclude values equal to To
 }
 
 // PaginationResult contains filtered and paginated results
 type PaginationResult[T any] struct {
 	Data      []*T `json:"data"`      // Current page data
 	TotalSize int  `json:"totalSize"` // Total matching records
-	TotalPage int  `json:"totalPage"` // Total number of pages
+	TotalPage int  `json:"totalPage"` // Total number of pages, at least 1 (an empty result is page 1 of 1)
 	PageIndex int  `json:"pageIndex"` // Current page index (0-based)
 	PageSize  int  `json:"pageSize"`  // Records per page
+	HasNext   bool `json:"hasNext"`   // Whether a page exists after this one
+	HasPrev   bool `json:"hasPrev"`   // Whether a page exists before this one
+	// PageSizeClamped reports that the requested page size exceeded GolangFilteringConfig.MaxPageSize,
+	// which PageSize holds instead
+	PageSizeClamped bool `json:"pageSizeClamped,omitempty"`
+	// OutOfRange reports a PageIndex past the last page (TotalPage-1), for which Data is empty
+	OutOfRange bool `json:"outOfRange,omitempty"`
+	// AppliedFilters echoes the filters, search and sort fields of the query as given,
+	// without those on unknown or disallowed fields
+	AppliedFilters *Root `json:"appliedFilters,omitempty"`
+	// IgnoredFields lists the unknown or disallowed filter, search and sort fields that were dropped
+	IgnoredFields []string `json:"ignoredFields,omitempty"`
+	// IgnoredSorts lists the sort fields that were dropped: those on unknown or disallowed fields,
+	// and those repeating an earlier sort field (the first occurrence is kept)
+	IgnoredSorts []string `json:"ignoredSorts,omitempty"`
+	// Aggregates holds the results of Root.Aggregations by Aggregation.Key (e.g. "sum_amount"), computed over
+	// every matching record rather than the page. Avg, min and max are left out when no record has a value.
+	Aggregates map[string]float64 `json:"aggregates,omitempty"`
+	// Strategy is the path Hybrid used to produce this result (empty for non-hybrid calls, never serialized)
+	Strategy Strategy `json:"-"`
+	// Fallback reports that the in-memory path of Hybrid failed and DataGorm produced this result
+	// instead (see HybridOptions.FallbackToGormOnError, never serialized)
+	Fallback bool `json:"-"`
+}
+
+// PaginationCursorResult contains filtered results for keyset (cursor) pagination
+type PaginationCursorResult[T any] struct {
+	Data       []*T   `json:"data"`       // Current page data
+	NextCursor string `json:"nextCursor"` // Opaque cursor for the next page (empty when there are no more records)
+	HasMore    bool   `json:"hasMore"`    // Whether more records exist after this page
+	PageSize   int    `json:"pageSize"`   // Records per page
+	// PageSizeClamped reports that the requested page size exceeded GolangFilteringConfig.MaxPageSize,
+	// which PageSize holds instead
+	PageSizeClamped bool `json:"pageSizeClamped,omitempty"`
 }
 
 // RangeNumber represents a numeric range
 type RangeNumber struct {
-	From float64 // Start of numeric range
-	To   float64 // End of numeric range
+	From          float64 // Start of numeric range
+	To            float64 // End of numeric range
+	FromExclusive bool    // Exclude From itself
+	ToExclusive   bool    // Exclude To itself
+}
+
+// RangeText represents a text range, compared lexicographically
+type RangeText struct {
+	From          string // Start of text range
+	To            string // End of text range
+	FromExclusive bool   // Exclude From itself
+	ToExclusive   bool   // Exclude To itself
 }
 
 // RangeDate represents a date range
 type RangeDate struct {
-	From time.Time // Start date
-	To   time.Time // End date
+	From          time.Time // Start date
+	To            time.Time // End date
+	FromExclusive bool      // Exclude From itself (the whole From day for date-only bounds)
+	ToExclusive   bool      // Exclude To itself (the whole To day for date-only bounds)
+}
+
+// FieldDescriptor describes a filterable field of T, as returned by Handler.Fields
+type FieldDescriptor struct {
+	Name     string   `json:"name"`               // Field key (json tag or Go name), the last segment of Path
+	Path     string   `json:"path"`               // Key to use in FieldFilter.Field and SortField.Field (e.g. "department.name")
+	GoType   string   `json:"goType"`             // Go type of the field (e.g. "*time.Time")
+	DataType DataType `json:"dataType"`           // Suggested FieldFilter.DataType
+	Nested   bool     `json:"nested"`             // Whether the field belongs to a related struct or slice
+	Nullable bool     `json:"nullable"`           // Whether the value can be nil: a pointer field, or reached through a pointer or slice
+	Values   []string `json:"values,omitempty"`   // Values set by Handler.RestrictValues, sorted
+	Computed bool     `json:"computed,omitempty"` // Whether the field was added with Handler.RegisterField (GoType is empty)
+}
+
+// Analysis describes how the queries would apply a Root, as returned by Handler.Analyze
+type Analysis struct {
+	Filters []FilterAnalysis `json:"filters"` // The filters of the Root and its nested groups, in order
+	Sorts   []SortAnalysis   `json:"sorts"`   // The sort fields of the Root, in order
+}
+
+// FilterAnalysis describes one FieldFilter of a Root
+type FilterAnalysis struct {
+	Field          string   `json:"field"`
+	Mode           Mode     `json:"mode"`
+	DataType       DataType `json:"dataType"`
+	Resolved       bool     `json:"resolved"`                 // Whether the queries apply the filter (false when they ignore it or fail on it)
+	ResolvedColumn string   `json:"resolvedColumn,omitempty"` // Column or SQL expression the GORM path reads, unquoted
+	ParsedValue    any      `json:"parsedValue,omitempty"`    // Value after transforms and relative dates, parsed as DataType
+	Warnings       []string `json:"warnings,omitempty"`       // Why the filter is ignored or may not do what it seems to
+}
+
+// SortAnalysis describes one SortField of a Root
+type SortAnalysis struct {
+	Field          string    `json:"field"`
+	Order          SortOrder `json:"order"`
+	Resolved       bool      `json:"resolved"`                 // Whether the queries sort by the field
+	ResolvedColumn string    `json:"resolvedColumn,omitempty"` // Column or SQL expression the GORM path sorts by, unquoted
+	Warnings       []string  `json:"warnings,omitempty"`
 }
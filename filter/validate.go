@@ -0,0 +1,190 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ValidationError is one problem ValidateRoot found in a Root
+type ValidationError struct {
+	Field string // Filter, search, sort or aggregation field the problem is on ("" for the Root itself)
+	Err   error  // Why the field is invalid
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateRoot checks every filter, search, sort field, aggregation and preload relation of filterRoot,
+// including nested groups, without querying anything: unknown or disallowed fields, unknown relations,
+// modes a data type does not support (e.g. ModeRange on a bool), values that cannot be parsed, inverted
+// ranges, values outside RestrictValues, and unknown Logic, SortOrder and NullsOrder values. Values are transformed and relative dates resolved
+// first, as the queries do. It returns nil for a valid Root, or an errors.Join of one *ValidationError per
+// problem so an API can reject a request with every problem at once. With StrictValidation, the queries
+// run the same checks before anything else, reporting unknown and disallowed fields only when
+// RejectUnknownFields and RejectDisallowedFields are set.
+//
+// Example usage:
+//
+//	if err := handler.ValidateRoot(filterRoot); err != nil {
+//		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+//	}
+func (f *Handler[T]) ValidateRoot(filterRoot Root) error {
+	return f.validateRoot(filterRoot, true, false)
+}
+
+// validateRoot implements ValidateRoot. Unknown and disallowed fields are reported when rejectFields is set,
+// or as RejectUnknownFields and RejectDisallowedFields say otherwise; filters on fields that are not reported
+// are skipped like the queries skip them. With memory, fields without a getter are unknown (see knownField).
+func (f *Handler[T]) validateRoot(filterRoot Root, rejectFields, memory bool) error {
+	var errs []error
+	report := func(field string, err error) {
+		errs = append(errs, &ValidationError{Field: field, Err: err})
+	}
+	// checkField reports an unknown or disallowed field and whether it may be used
+	checkField := func(field string) bool {
+		if !f.knownField(field, memory) {
+			if rejectFields || f.rejectUnknown {
+				report(field, &UnknownFieldError{Field: field})
+			}
+			return false
+		}
+		if !f.isFieldAllowed(field) {
+			if rejectFields || f.rejectDisallowed {
+				report(field, &FieldNotAllowedError{Field: field})
+			}
+			return false
+		}
+		return true
+	}
+
+	loc := f.location
+	if filterRoot.TimeZone != "" {
+		zone, err := time.LoadLocation(filterRoot.TimeZone)
+		if err != nil {
+			report("", &InvalidFilterError{Err: fmt.Errorf("unknown time zone %q", filterRoot.TimeZone)})
+		} else {
+			loc = zone
+		}
+	}
+	f.validateGroup(filterRoot, f.now().In(loc), checkField, report)
+
+	if search := filterRoot.Search; search != nil {
+		for _, field := range search.Fields {
+			checkField(field)
+		}
+		if search.Mode != "" {
+			probe := FieldFilter{Field: "search", Value: search.Value, Mode: search.Mode, DataType: DataTypeText}
+			if _, err := compileText(probe, false); err != nil {
+				report("", &UnsupportedModeError{Field: "search", Mode: search.Mode, DataType: DataTypeText,
+					reason: fmt.Sprintf("search mode %s is not supported", search.Mode)})
+			}
+		}
+	}
+
+	sorted := make(map[string]bool, len(filterRoot.SortFields))
+	for _, sortField := range filterRoot.SortFields {
+		if sortField.Field != RelevanceField && checkField(sortField.Field) {
+			if id := f.fieldID(sortField.Field); sorted[id] {
+				report(sortField.Field, errors.New("duplicate sort field"))
+			} else {
+				sorted[id] = true
+			}
+		}
+		switch sortField.Order {
+		case "", SortOrderAsc, SortOrderDesc:
+		default:
+			report(sortField.Field, &InvalidFilterError{Err: fmt.Errorf("unknown sort order '%s'", sortField.Order)})
+		}
+		switch sortField.Nulls {
+		case NullsDefault, NullsFirst, NullsLast:
+		default:
+			report(sortField.Field, &InvalidFilterError{Err: fmt.Errorf("unknown nulls order '%s'", sortField.Nulls)})
+		}
+		if err := checkLanguage(sortField.Language); err != nil {
+			report(sortField.Field, &InvalidFilterError{Err: fmt.Errorf("unknown language '%s'", sortField.Language)})
+		}
+	}
+
+	for _, aggregation := range filterRoot.Aggregations {
+		if !f.isFieldAllowed(aggregation.Field) {
+			checkField(aggregation.Field)
+			continue
+		}
+		if err := f.checkAggregation(aggregation); err != nil {
+			report(aggregation.Field, err)
+		}
+	}
+
+	for _, count := range filterRoot.ChildCounts {
+		if _, err := f.buildChildCount(count, filterRoot.TimeZone); err != nil {
+			report(count.Relation, err)
+		}
+	}
+
+	// Preload relations resolve under GORM's default naming strategy, as no database is at hand
+	if _, unknown, err := f.preloads(sqlDialect{}, filterRoot); err == nil {
+		for _, relation := range unknown {
+			report(relation, &UnknownFieldError{Field: relation, reason: "unknown preload relation"})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateGroup reports the problems of the filters of group and its nested groups
+func (f *Handler[T]) validateGroup(group Root, now time.Time, checkField func(field string) bool, report func(field string, err error)) {
+	switch group.Logic {
+	case "", LogicAnd, LogicOr:
+	default:
+		report("", &InvalidFilterError{Err: fmt.Errorf("unknown logic '%s'", group.Logic)})
+	}
+	for _, filter := range group.FieldFilters {
+		if filter.Field == "" {
+			report("", &InvalidFilterError{Err: errors.New("filter field cannot be empty")})
+			continue
+		}
+		if !checkField(filter.Field) {
+			continue
+		}
+		if err := f.validateFilter(filter, now); err != nil {
+			report(filter.Field, err)
+		}
+	}
+	for _, child := range group.Groups {
+		f.validateGroup(child, now, checkField, report)
+	}
+}
+
+// validateFilter returns the first problem of filter: its value is transformed and resolved
+// and then compiled as DataQuery does, so every query path rejects the same filters
+func (f *Handler[T]) validateFilter(filter FieldFilter, now time.Time) error {
+	if filter.CompareField != "" {
+		return f.checkCompareFilter(filter)
+	}
+	if fn, exists := f.transforms[f.fieldID(filter.Field)]; exists && filter.Mode != ModeIsEmpty && filter.Mode != ModeIsNotEmpty && !isExistsMode(filter.Mode) {
+		value, err := transformValue(fn, filter)
+		if err != nil {
+			return filterError(filter, err)
+		}
+		filter.Value = value
+	}
+	if filter.DataType == DataTypeDate {
+		value, _, err := f.resolveDateValue(filter.Value, now)
+		if err != nil {
+			return filterError(filter, err)
+		}
+		filter.Value = value
+	}
+	if _, err := f.compileFilter(filter); err != nil {
+		return err
+	}
+	return f.checkValue(filter)
+}
@@ -0,0 +1,269 @@
+package filter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormNoPaginationXLSX performs database-level filtering like GormNoPaginationCSV and returns
+// the results as an Excel workbook (.xlsx) with a single sheet and a header row.
+// Columns follow the same deterministic order as GormNoPaginationCSV (field names sorted alphabetically).
+// Cells are typed from the Go values: numbers as numbers, time.Time as date cells, bools as booleans,
+// and everything else as text (so leading zeros are kept). nil values and zero times are left empty.
+// The relations of the nested columns are preloaded.
+func (f *Handler[T]) GormNoPaginationXLSX(
+	db *gorm.DB,
+	filterRoot Root,
+) ([]byte, error) {
+	filterRoot = f.withColumnPreloads(db, filterRoot, f.defaultColumns())
+	return f.gormExport("GormNoPaginationXLSX", db, filterRoot, func(items []*T) ([]byte, error) {
+		return f.xlsxBytes(items)
+	})
+}
+
+// DataQueryNoPageXLSX performs in-memory filtering like DataQueryNoPageCSV and returns
+// the results as an Excel workbook (.xlsx). See GormNoPaginationXLSX for the cell types.
+func (f *Handler[T]) DataQueryNoPageXLSX(
+	data []*T,
+	filterRoot Root,
+) ([]byte, error) {
+	return f.dataQueryExport("DataQueryNoPageXLSX", data, filterRoot, func(items []*T) ([]byte, error) {
+		return f.xlsxBytes(items)
+	})
+}
+
+// GormNoPaginationXLSXCustom is GormNoPaginationXLSX with headers and values defined by customGetter,
+// mirroring GormNoPaginationCSVCustom. Headers are the customGetter map keys of the first record,
+// sorted alphabetically; with no matching records the sheet is empty.
+//
+// Example usage:
+//
+//	xlsxData, err := handler.GormNoPaginationXLSXCustom(db, filterRoot, func(user *User) map[string]any {
+//	    return map[string]any{
+//	        "Full Name": user.FirstName + " " + user.LastName,
+//	        "Joined":    user.CreatedAt,
+//	    }
+//	})
+func (f *Handler[T]) GormNoPaginationXLSXCustom(
+	db *gorm.DB,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+) ([]byte, error) {
+	return f.gormExport("GormNoPaginationXLSXCustom", db, filterRoot, func(items []*T) ([]byte, error) {
+		return xlsxBytesCustom(items, customGetter)
+	})
+}
+
+// DataQueryNoPageXLSXCustom is DataQueryNoPageXLSX with headers and values defined by customGetter.
+// See GormNoPaginationXLSXCustom.
+func (f *Handler[T]) DataQueryNoPageXLSXCustom(
+	data []*T,
+	filterRoot Root,
+	customGetter func(*T) map[string]any,
+) ([]byte, error) {
+	return f.dataQueryExport("DataQueryNoPageXLSXCustom", data, filterRoot, func(items []*T) ([]byte, error) {
+		return xlsxBytesCustom(items, customGetter)
+	})
+}
+
+// xlsxBytes writes items as a workbook using the getters
+func (f *Handler[T]) xlsxBytes(items []*T) ([]byte, error) {
+	fieldNames := f.defaultColumns()
+	getters := f.columnGetters(fieldNames)
+	return writeXLSX(fieldNames, len(items), func(row int) []any {
+		values := make([]any, len(fieldNames))
+		for i, getter := range getters {
+			values[i] = getter(items[row])
+		}
+		return values
+	})
+}
+
+// xlsxBytesCustom writes items as a workbook using customGetter
+func xlsxBytesCustom[T any](items []*T, customGetter func(*T) map[string]any) ([]byte, error) {
+	if len(items) == 0 {
+		return writeXLSX(nil, 0, nil)
+	}
+
+	firstItemFields := customGetter(items[0])
+	fieldNames := make([]string, 0, len(firstItemFields))
+	for fieldName := range firstItemFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	return writeXLSX(fieldNames, len(items), func(row int) []any {
+		itemFields := customGetter(items[row])
+		values := make([]any, len(fieldNames))
+		for i, fieldName := range fieldNames {
+			values[i] = itemFields[fieldName]
+		}
+		return values
+	})
+}
+
+// xlsxStatic holds the workbook parts that do not depend on the data.
+// Style 1 formats date cells (built-in number format 22, "m/d/yy h:mm").
+var xlsxStatic = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// writeXLSX builds a single-sheet workbook with a header row (omitted when headers is empty)
+// followed by rowCount rows produced by values
+func writeXLSX(headers []string, rowCount int, values func(row int) []any) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	for _, part := range xlsxStatic {
+		w, err := zipWriter.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write XLSX part %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX part %s: %w", part.name, err)
+		}
+	}
+
+	w, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write XLSX sheet: %w", err)
+	}
+	var sheet bytes.Buffer
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if len(headers) > 0 {
+		row := make([]any, len(headers))
+		for i, header := range headers {
+			row[i] = header
+		}
+		writeXLSXRow(&sheet, 1, row)
+		for i := range rowCount {
+			writeXLSXRow(&sheet, i+2, values(i))
+		}
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if _, err := w.Write(sheet.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX sheet: %w", err)
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXLSXRow appends a <row> element with one typed cell per non-empty value
+func writeXLSXRow(sheet *bytes.Buffer, rowNumber int, values []any) {
+	fmt.Fprintf(sheet, `<row r="%d">`, rowNumber)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(rowNumber)
+		switch v := xlsxCellValue(value).(type) {
+		case nil:
+			// Leave the cell empty
+		case bool:
+			cell := "0"
+			if v {
+				cell = "1"
+			}
+			fmt.Fprintf(sheet, `<c r="%s" t="b"><v>%s</v></c>`, ref, cell)
+		case float64:
+			fmt.Fprintf(sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+		case time.Time:
+			fmt.Fprintf(sheet, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(xlsxDateSerial(v), 'f', -1, 64))
+		case string:
+			fmt.Fprintf(sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			_ = xml.EscapeText(sheet, []byte(v))
+			sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	sheet.WriteString(`</row>`)
+}
+
+// xlsxCellValue normalizes value to nil, bool, float64, time.Time, or string.
+// Pointers are dereferenced; nil, missing values and zero times become nil.
+func xlsxCellValue(value any) any {
+	if isNilValue(value) || isMissing(value) {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return nil
+		}
+		return t
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) || math.IsInf(rv.Float(), 0) {
+			return fmt.Sprintf("%v", rv.Interface())
+		}
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	}
+	return fmt.Sprintf("%v", rv.Interface())
+}
+
+// xlsxDateSerial converts t to an Excel serial date (days since 1899-12-30) using its wall clock
+func xlsxDateSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return (float64(wall.Unix())+float64(wall.Nanosecond())/1e9)/86400 + 25569
+}
+
+// xlsxColumnName returns the column letters for a 0-based index (0 -> A, 26 -> AA)
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -4,6 +4,7 @@ go 1.25.4
 
 require (
 	github.com/kennygrant/sanitize v1.2.4
+	golang.org/x/text v0.31.0
 	gorm.io/driver/sqlite v1.6.0
 	gorm.io/gorm v1.31.1
 )
@@ -14,5 +15,4 @@ require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/mattn/go-sqlite3 v1.14.32 // indirect
-	golang.org/x/text v0.31.0 // indirect
 )
@@ -0,0 +1,249 @@
+package test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// AggCustomer is the customer an AggInvoice belongs to
+type AggCustomer struct {
+	ID     uint   `gorm:"primaryKey" json:"id"`
+	Region string `json:"region"`
+	Credit int    `json:"credit"`
+}
+
+// AggInvoice has a nullable discount, so count, avg, min and max skip invoices without one
+type AggInvoice struct {
+	ID         uint        `gorm:"primaryKey" json:"id"`
+	Status     string      `json:"status"`
+	Amount     float64     `json:"amount"`
+	Discount   *float64    `json:"discount"`
+	CustomerID uint        `json:"customer_id"`
+	Customer   AggCustomer `json:"customer"`
+}
+
+func generateAggInvoices() []*AggInvoice {
+	discount := func(d float64) *float64 { return &d }
+	north := AggCustomer{ID: 1, Region: "north", Credit: 100}
+	south := AggCustomer{ID: 2, Region: "south", Credit: 40}
+	return []*AggInvoice{
+		{ID: 1, Status: "paid", Amount: 120, Discount: discount(10), CustomerID: 1, Customer: north},
+		{ID: 2, Status: "paid", Amount: 80.5, CustomerID: 2, Customer: south},
+		{ID: 3, Status: "open", Amount: 45, Discount: discount(5), CustomerID: 1, Customer: north},
+		{ID: 4, Status: "paid", Amount: 300, Discount: discount(30), CustomerID: 2, Customer: south},
+		{ID: 5, Status: "void", Amount: 15, CustomerID: 1, Customer: north},
+	}
+}
+
+func setupAggDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&AggCustomer{}, &AggInvoice{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, invoice := range generateAggInvoices() {
+		if err := db.Create(invoice).Error; err != nil {
+			t.Fatalf("Failed to create invoice: %v", err)
+		}
+	}
+	return db
+}
+
+// allAggregations requests every function on field
+func allAggregations(field string) []filter.Aggregation {
+	return []filter.Aggregation{
+		{Field: field, Func: filter.AggregateSum},
+		{Field: field, Func: filter.AggregateAvg},
+		{Field: field, Func: filter.AggregateMin},
+		{Field: field, Func: filter.AggregateMax},
+		{Field: field, Func: filter.AggregateCount},
+	}
+}
+
+func equalAggregates(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok || math.Abs(value-other) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAggregations_BothPaths tests that DataQuery and DataGorm (directly and through Hybrid) aggregate
+// every matching record, not only the page, to the same values
+func TestAggregations_BothPaths(t *testing.T) {
+	db := setupAggDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[AggInvoice](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	invoices := generateAggInvoices()
+	paid := []filter.FieldFilter{{Field: "status", Value: "paid", Mode: filter.ModeEqual, DataType: filter.DataTypeText}}
+
+	tests := []struct {
+		name         string
+		filters      []filter.FieldFilter
+		aggregations []filter.Aggregation
+		expected     map[string]float64
+	}{
+		{"Amount", paid, allAggregations("amount"), map[string]float64{
+			"sum_amount": 500.5, "avg_amount": 500.5 / 3, "min_amount": 80.5, "max_amount": 300, "count_amount": 3,
+		}},
+		// Invoice 2 has no discount
+		{"NullableDiscount", paid, allAggregations("discount"), map[string]float64{
+			"sum_discount": 40, "avg_discount": 20, "min_discount": 10, "max_discount": 30, "count_discount": 2,
+		}},
+		{"NoMatches", []filter.FieldFilter{{Field: "status", Value: "draft", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+			allAggregations("amount"), map[string]float64{"sum_amount": 0, "count_amount": 0}},
+		{"NestedField", paid, []filter.Aggregation{{Field: "customer.credit", Func: filter.AggregateSum}}, map[string]float64{
+			"sum_customer.credit": 180,
+		}},
+		{"NoFilters", nil, []filter.Aggregation{{Field: "amount", Func: filter.AggregateSum}}, map[string]float64{
+			"sum_amount": 560.5,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Hybrid filters in memory only on relations it preloads
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: tt.filters, Aggregations: tt.aggregations, Preload: []string{"Customer"}}
+
+			// A page of one record still aggregates every match
+			result, err := handler.DataQuery(invoices, root, 0, 1)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if !equalAggregates(result.Aggregates, tt.expected) {
+				t.Errorf("Expected DataQuery aggregates %v, got %v", tt.expected, result.Aggregates)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 1)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if !equalAggregates(page.Aggregates, tt.expected) {
+				t.Errorf("Expected DataGorm aggregates %v, got %v", tt.expected, page.Aggregates)
+			}
+			if page.TotalSize != result.TotalSize || len(page.Data) != len(result.Data) {
+				t.Errorf("Expected the same page from both paths, got %d/%d and %d/%d",
+					page.TotalSize, len(page.Data), result.TotalSize, len(result.Data))
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, root, 0, 1)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if !equalAggregates(hybrid.Aggregates, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) aggregates %v, got %v", hybrid.Strategy, tt.expected, hybrid.Aggregates)
+				}
+			}
+		})
+	}
+}
+
+// TestAggregations_ToManyJoin tests that a filter joining a to-many relation does not repeat records in aggregates
+func TestAggregations_ToManyJoin(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+		},
+		Aggregations: []filter.Aggregation{
+			{Field: "total", Func: filter.AggregateSum},
+			{Field: "total", Func: filter.AggregateCount},
+		},
+	}
+	// Order 1 has two WIDGET items but counts once
+	expected := map[string]float64{"sum_total": 160, "count_total": 2}
+
+	result, err := handler.DataQuery(generateHasManyOrders(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if !equalAggregates(result.Aggregates, expected) {
+		t.Errorf("Expected DataQuery aggregates %v, got %v", expected, result.Aggregates)
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if !equalAggregates(page.Aggregates, expected) {
+		t.Errorf("Expected DataGorm aggregates %v, got %v", expected, page.Aggregates)
+	}
+}
+
+// TestAggregations_Errors tests that non-numeric, unknown and to-many fields and unknown functions fail on both paths
+func TestAggregations_Errors(t *testing.T) {
+	db := setupAggDB(t)
+	handler := filter.NewFilter[AggInvoice](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name        string
+		aggregation filter.Aggregation
+		errMsg      string
+	}{
+		{"TextField", filter.Aggregation{Field: "status", Func: filter.AggregateSum}, "cannot aggregate text field status"},
+		{"UnknownField", filter.Aggregation{Field: "total", Func: filter.AggregateSum}, "unknown aggregation field total"},
+		{"UnknownFunc", filter.Aggregation{Field: "amount", Func: "median"}, `unknown aggregate function "median"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, Aggregations: []filter.Aggregation{tt.aggregation}}
+			if _, err := handler.DataQuery(generateAggInvoices(), root, 0, 10); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected DataQuery error %q, got %v", tt.errMsg, err)
+			}
+			if _, err := handler.DataGorm(db, root, 0, 10); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected DataGorm error %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+
+	maxDepth := 2
+	orders := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{Logic: filter.LogicAnd, Aggregations: []filter.Aggregation{{Field: "items.quantity", Func: filter.AggregateSum}}}
+	if _, err := orders.DataQuery(generateHasManyOrders(), root, 0, 10); err == nil || !strings.Contains(err.Error(), "to-many") {
+		t.Errorf("Expected a to-many error, got %v", err)
+	}
+}
+
+// TestAggregations_DeniedAndJSON tests that aggregations follow DeniedFields and are parsed from JSON
+func TestAggregations_DeniedAndJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"aggregations":[{"field":"amount","func":"SUM"},{"field":"discount","func":"max"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.Aggregations[0].Func != filter.AggregateSum {
+		t.Errorf("Expected the function to be normalized to sum, got %q", root.Aggregations[0].Func)
+	}
+	if _, err := filter.ParseRootFromJSON([]byte(`{"aggregations":[{"field":"amount","func":"median"}]}`)); err == nil {
+		t.Error("Expected an error for an unknown aggregate function")
+	}
+
+	handler := filter.NewFilter[AggInvoice](filter.GolangFilteringConfig{DeniedFields: []string{"discount"}})
+	result, err := handler.DataQuery(generateAggInvoices(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if _, ok := result.Aggregates["max_discount"]; ok || result.Aggregates["sum_amount"] != 560.5 {
+		t.Errorf("Expected only sum_amount, got %v", result.Aggregates)
+	}
+
+	rejecting := filter.NewFilter[AggInvoice](filter.GolangFilteringConfig{DeniedFields: []string{"discount"}, RejectDisallowedFields: true})
+	if _, err := rejecting.DataQuery(generateAggInvoices(), root, 0, 10); err == nil || !strings.Contains(err.Error(), "discount") {
+		t.Errorf("Expected the denied field to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestAnalyze tests that Analyze resolves fields to their columns, parses values as their data type and
+// warns about filters and sorts the queries ignore
+func TestAnalyze(t *testing.T) {
+	now := time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC)
+	maxDepth := 2
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{
+		MaxDepth: &maxDepth,
+		Now:      func() time.Time { return now },
+	})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "value", Value: filter.Range{From: "1", To: 20}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "nope", Value: "x", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "value", Value: "abc", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+			{Field: "name", Value: "", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+		Groups: []filter.Root{{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: "today", Mode: filter.ModeEqual, DataType: filter.DataTypeDate},
+			{Field: "currency.currency_code", Value: []string{"USD"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		}}},
+		SortFields: []filter.SortField{
+			{Field: "name"},
+			{Field: "name", Order: filter.SortOrderDesc},
+			{Field: "missing", Order: filter.SortOrderAsc},
+			{Field: filter.RelevanceField, Order: filter.SortOrderDesc},
+		},
+	}
+
+	analysis, err := handler.Analyze(root)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(analysis.Filters) != 6 {
+		t.Fatalf("Expected 6 analyzed filters, got %d", len(analysis.Filters))
+	}
+
+	filters := analysis.Filters
+	if !filters[0].Resolved || filters[0].ResolvedColumn != "value" {
+		t.Errorf("Expected value to resolve to its column, got %+v", filters[0])
+	}
+	if got, ok := filters[0].ParsedValue.(filter.RangeNumber); !ok || got.From != 1 || got.To != 20 {
+		t.Errorf("Expected a parsed number range from 1 to 20, got %#v", filters[0].ParsedValue)
+	}
+	if filters[1].Resolved || len(filters[1].Warnings) != 1 || !strings.Contains(filters[1].Warnings[0], "unknown field nope") {
+		t.Errorf("Expected an unresolved unknown field with a warning, got %+v", filters[1])
+	}
+	if filters[2].Resolved || len(filters[2].Warnings) != 1 {
+		t.Errorf("Expected an unresolved unparsable value with a warning, got %+v", filters[2])
+	}
+	if !filters[3].Resolved || len(filters[3].Warnings) != 1 || !strings.Contains(filters[3].Warnings[0], "matches every record") {
+		t.Errorf("Expected an empty contains value to warn it matches every record, got %+v", filters[3])
+	}
+	today := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	if got, ok := filters[4].ParsedValue.(time.Time); !filters[4].Resolved || !ok || !got.Equal(today) {
+		t.Errorf("Expected today to resolve to midnight, got %+v", filters[4])
+	}
+	if !filters[5].Resolved || filters[5].ResolvedColumn != "Currency.currency_code" {
+		t.Errorf("Expected the nested field to resolve to its joined column, got %+v", filters[5])
+	}
+	if !reflect.DeepEqual(filters[5].ParsedValue, []any{"USD"}) {
+		t.Errorf("Expected a parsed list, got %#v", filters[5].ParsedValue)
+	}
+
+	sorts := analysis.Sorts
+	if len(sorts) != 4 {
+		t.Fatalf("Expected 4 analyzed sorts, got %d", len(sorts))
+	}
+	if !sorts[0].Resolved || sorts[0].Order != filter.SortOrderAsc || sorts[0].ResolvedColumn != "name" {
+		t.Errorf("Expected name to sort ascending by default, got %+v", sorts[0])
+	}
+	for i, warning := range []string{"already sorted", "unknown field missing", "needs a search"} {
+		if sort := sorts[i+1]; sort.Resolved || len(sort.Warnings) != 1 || !strings.Contains(sort.Warnings[0], warning) {
+			t.Errorf("Expected sort %s to be ignored with %q, got %+v", sort.Field, warning, sort)
+		}
+	}
+}
+
+// TestAnalyze_TimeZone tests that Analyze resolves relative dates in the time zone of the root and rejects
+// an unknown one
+func TestAnalyze_TimeZone(t *testing.T) {
+	now := time.Date(2025, 3, 31, 2, 0, 0, 0, time.UTC)
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{
+		Now: func() time.Time { return now },
+	})
+	root := filter.Root{Logic: filter.LogicAnd, TimeZone: "America/New_York", FieldFilters: []filter.FieldFilter{
+		{Field: "created_at", Value: "today", Mode: filter.ModeGTE, DataType: filter.DataTypeDate},
+	}}
+
+	analysis, err := handler.Analyze(root)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	// 2:00 UTC on Mar 31 is still Mar 30 in New York
+	if got, ok := analysis.Filters[0].ParsedValue.(time.Time); !ok || got.Day() != 30 {
+		t.Errorf("Expected today in New York to be Mar 30, got %#v", analysis.Filters[0].ParsedValue)
+	}
+
+	root.TimeZone = "Mars/Olympus"
+	if _, err := handler.Analyze(root); err == nil {
+		t.Error("Expected an error for an unknown time zone")
+	}
+}
@@ -0,0 +1,176 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// benchmarkRows is the dataset size used by the in-memory filtering benchmarks
+const benchmarkRows = 1_000_000
+
+// generateBenchmarkUsers creates n users with varied ages, roles and creation dates
+func generateBenchmarkUsers(n int) []*TestUser {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	roles := []string{"admin", "user", "moderator"}
+	users := make([]*TestUser, n)
+	for i := range users {
+		users[i] = &TestUser{
+			ID:        uint(i + 1),
+			Name:      fmt.Sprintf("User %d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Age:       18 + i%60,
+			IsActive:  i%3 != 0,
+			Role:      roles[i%len(roles)],
+			CreatedAt: baseTime.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return users
+}
+
+func benchmarkDataQueryNoPage(b *testing.B, root filter.Root) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateBenchmarkUsers(benchmarkRows)
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := handler.DataQueryNoPage(users, root); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDataQueryDateRange(b *testing.B) {
+	benchmarkDataQueryNoPage(b, filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: filter.Range{From: "2024-02-01", To: "2024-06-30"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+			{Field: "created_at", Value: "2024-03-15T12:00:00Z", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+		},
+	})
+}
+
+func BenchmarkDataQueryNumber(b *testing.B) {
+	benchmarkDataQueryNoPage(b, filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: "30", Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			{Field: "age", Value: []any{30, 40, 50, "60"}, Mode: filter.ModeNotIn, DataType: filter.DataTypeNumber},
+		},
+	})
+}
+
+func BenchmarkDataQueryText(b *testing.B) {
+	benchmarkDataQueryNoPage(b, filter.Root{
+		Logic: filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: []string{"Admin", "MODERATOR"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			{Field: "email", Value: "USER1", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+		},
+	})
+}
+
+// BenchmarkDataGormSimpleFilter measures the per-call overhead of DataGorm with one filter against sqlite,
+// where the parsed model schema is reused across calls; run with -benchmem to compare allocations
+func BenchmarkDataGormSimpleFilter(b *testing.B) {
+	db := setupTestDB(b)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: 30, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := handler.DataGorm(db, root, 0, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDataQuerySmall measures DataQuery on a slice small enough to be filtered without workers
+func BenchmarkDataQuerySmall(b *testing.B) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateBenchmarkUsers(500)
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := handler.DataQuery(users, root, 0, 20); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkPooledRows is the dataset size of the buffer pooling benchmarks
+const benchmarkPooledRows = 100_000
+
+// benchmarkDataQueryPooling measures DataQuery with a two-filter query over 100k rows, with and without
+// GolangFilteringConfig.PoolBuffers; compare allocs/op and B/op of the two benchmarks
+func benchmarkDataQueryPooling(b *testing.B, pooled bool) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{PoolBuffers: pooled})
+	users := generateBenchmarkUsers(benchmarkPooledRows)
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: 30, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := handler.DataQuery(users, root, 0, 50); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDataQueryUnpooled(b *testing.B) {
+	benchmarkDataQueryPooling(b, false)
+}
+
+func BenchmarkDataQueryPooled(b *testing.B) {
+	benchmarkDataQueryPooling(b, true)
+}
+
+// benchmarkDataQueryCompileCache measures repeated DataQuery calls with the same five-filter query over
+// 100k rows, with and without GolangFilteringConfig.CompileCacheSize
+func benchmarkDataQueryCompileCache(b *testing.B, cacheSize int) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{CompileCacheSize: cacheSize})
+	users := generateBenchmarkUsers(benchmarkPooledRows)
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: filter.Range{From: 25, To: 60}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "role", Value: []any{"admin", "moderator"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			{Field: "email", Value: "@example.com", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			{Field: "created_at", Value: filter.Range{From: "2024-01-10", To: "2024-03-31"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := handler.DataQuery(users, root, 0, 50); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDataQueryUncompiled(b *testing.B) {
+	benchmarkDataQueryCompileCache(b, 0)
+}
+
+func BenchmarkDataQueryCompileCache(b *testing.B) {
+	benchmarkDataQueryCompileCache(b, 64)
+}
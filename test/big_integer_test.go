@@ -0,0 +1,233 @@
+package test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SnowflakeEvent references external records by IDs above 2^53, which float64 cannot hold exactly
+type SnowflakeEvent struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	ExternalID int64  `json:"external_id"`
+	Snowflake  uint64 `json:"snowflake"`
+}
+
+// generateSnowflakeEvents returns events whose IDs 3 and 4 round to the same float64 (2^63), with 4 below 3
+func generateSnowflakeEvents() []*SnowflakeEvent {
+	const above53 = 1<<53 + 1
+	return []*SnowflakeEvent{
+		{ID: 1, ExternalID: above53, Snowflake: above53},
+		{ID: 2, ExternalID: above53 + 1, Snowflake: above53 + 1},
+		{ID: 3, ExternalID: math.MaxInt64, Snowflake: math.MaxInt64},
+		{ID: 4, ExternalID: math.MaxInt64 - 1, Snowflake: math.MaxInt64 - 1},
+	}
+}
+
+func snowflakeEventIDs(events []*SnowflakeEvent) []uint {
+	ids := make([]uint, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func setupSnowflakeDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&SnowflakeEvent{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateSnowflakeEvents()).Error; err != nil {
+		t.Fatalf("Failed to create events: %v", err)
+	}
+	return db
+}
+
+// TestBigIntegers tests that integer filters and sorts on int64 and uint64 fields above 2^53 compare
+// exactly, matching the same records in DataQuery and DataGorm
+func TestBigIntegers(t *testing.T) {
+	db := setupSnowflakeDB(t)
+	handler := filter.NewFilter[SnowflakeEvent](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"EqualString", filter.ModeEqual, "9223372036854775807", []uint{3}},
+		{"EqualInt64", filter.ModeEqual, int64(math.MaxInt64 - 1), []uint{4}},
+		{"EqualUint64", filter.ModeEqual, uint64(math.MaxInt64), []uint{3}},
+		{"NotEqual", filter.ModeNotEqual, "9223372036854775807", []uint{1, 2, 4}},
+		{"GT", filter.ModeGT, "9223372036854775806", []uint{3}},
+		{"LTE", filter.ModeLTE, 1<<53 + 1, []uint{1}},
+		{"Range", filter.ModeRange, filter.Range{From: "9007199254740994", To: "9223372036854775806"}, []uint{2, 4}},
+		{"ExclusiveRange", filter.ModeRange, filter.Range{From: 1<<53 + 1, To: "9223372036854775807", FromExclusive: true, ToExclusive: true}, []uint{2, 4}},
+		{"In", filter.ModeIn, []any{"9007199254740993", int64(math.MaxInt64)}, []uint{1, 3}},
+		{"NotIn", filter.ModeNotIn, []string{"9223372036854775806"}, []uint{1, 2, 3}},
+		// Float values still compare as float64
+		{"FloatValue", filter.ModeGT, 9.2e18, []uint{3, 4}},
+	}
+
+	for _, field := range []string{"external_id", "snowflake"} {
+		for _, tt := range tests {
+			t.Run(field+"/"+tt.name, func(t *testing.T) {
+				root := filter.Root{
+					Logic:        filter.LogicAnd,
+					FieldFilters: []filter.FieldFilter{{Field: field, Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeNumber}},
+					SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+				}
+				result, err := handler.DataQuery(generateSnowflakeEvents(), root, 0, 10)
+				if err != nil {
+					t.Fatalf("DataQuery failed: %v", err)
+				}
+				if got := snowflakeEventIDs(result.Data); !equalIDs(got, tt.expected) {
+					t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+				}
+
+				page, err := handler.DataGorm(db, root, 0, 10)
+				if err != nil {
+					t.Fatalf("DataGorm failed: %v", err)
+				}
+				if got := snowflakeEventIDs(page.Data); !equalIDs(got, tt.expected) {
+					t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+				}
+			})
+		}
+
+		// Events 3 and 4 would tie as float64 and fall back to the primary key tiebreaker
+		for order, expected := range map[filter.SortOrder][]uint{
+			filter.SortOrderAsc:  {1, 2, 4, 3},
+			filter.SortOrderDesc: {3, 4, 2, 1},
+		} {
+			root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: field, Order: order}}}
+			result, err := handler.DataQuery(generateSnowflakeEvents(), root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := snowflakeEventIDs(result.Data); !equalIDs(got, expected) {
+				t.Errorf("Expected DataQuery sorting %s %s to give %v, got %v", field, order, expected, got)
+			}
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := snowflakeEventIDs(page.Data); !equalIDs(got, expected) {
+				t.Errorf("Expected DataGorm sorting %s %s to give %v, got %v", field, order, expected, got)
+			}
+		}
+	}
+}
+
+// TestBigIntegers_Uint64Range tests that uint64 values above the int64 range filter and sort exactly in memory
+func TestBigIntegers_Uint64Range(t *testing.T) {
+	events := []*SnowflakeEvent{
+		{ID: 1, Snowflake: math.MaxUint64},
+		{ID: 2, Snowflake: math.MaxUint64 - 1},
+		{ID: 3, Snowflake: 1 << 63},
+	}
+	handler := filter.NewFilter[SnowflakeEvent](filter.GolangFilteringConfig{})
+
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "snowflake", Value: "18446744073709551614", Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		{Field: "snowflake", Value: uint64(math.MaxUint64), Mode: filter.ModeNotEqual, DataType: filter.DataTypeNumber},
+	}}
+	result, err := handler.DataQuery(events, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := snowflakeEventIDs(result.Data); !equalIDs(got, []uint{2}) {
+		t.Errorf("Expected only event 2, got %v", got)
+	}
+
+	root = filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "snowflake", Order: filter.SortOrderAsc}}}
+	result, err = handler.DataQuery(events, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := snowflakeEventIDs(result.Data); !equalIDs(got, []uint{3, 2, 1}) {
+		t.Errorf("Expected events sorted by snowflake as 3, 2, 1, got %v", got)
+	}
+}
+
+// TestBigIntegers_FromJSON tests that integers above 2^53 in a JSON filter keep every digit, so they
+// match exactly in DataQuery and DataGorm
+func TestBigIntegers_FromJSON(t *testing.T) {
+	db := setupSnowflakeDB(t)
+	handler := filter.NewFilter[SnowflakeEvent](filter.GolangFilteringConfig{StrictValidation: true})
+
+	// 9007199254740993 is 2^53 + 1, which float64 rounds to 2^53
+	root, err := filter.ParseRootFromJSON([]byte(`{"logic":"and","filters":[
+		{"field":"external_id","value":9007199254740993,"mode":"equal","dataType":"number"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	result, err := handler.DataQuery(generateSnowflakeEvents(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := snowflakeEventIDs(result.Data); !equalIDs(got, []uint{1}) {
+		t.Errorf("Expected DataQuery IDs [1], got %v", got)
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got := snowflakeEventIDs(page.Data); !equalIDs(got, []uint{1}) {
+		t.Errorf("Expected DataGorm IDs [1], got %v", got)
+	}
+
+	if _, err := filter.ParseRootFromJSON([]byte(`{"logic":"and"} {}`)); !errors.Is(err, filter.ErrInvalidFilter) {
+		t.Errorf("Expected an invalid filter error for trailing data, got %v", err)
+	}
+}
+
+// TestBigIntegers_Uint64AboveInt64InSQL tests that DataGorm compares with uint64 values above the int64
+// range, which database/sql cannot bind
+func TestBigIntegers_Uint64AboveInt64InSQL(t *testing.T) {
+	db := setupSnowflakeDB(t)
+	handler := filter.NewFilter[SnowflakeEvent](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"GT", filter.ModeGT, "9223372036854775808", []uint{}},
+		{"LT", filter.ModeLT, uint64(math.MaxUint64), []uint{1, 2, 3, 4}},
+		{"NotEqual", filter.ModeNotEqual, "18446744073709551615", []uint{1, 2, 3, 4}},
+		{"In", filter.ModeIn, []any{"9007199254740993", uint64(math.MaxUint64)}, []uint{1}},
+		{"Range", filter.ModeRange, filter.Range{From: "9223372036854775806", To: uint64(math.MaxUint64)}, []uint{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: "snowflake", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeNumber}},
+				SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+			}
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := snowflakeEventIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+			result, err := handler.DataQuery(generateSnowflakeEvents(), root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := snowflakeEventIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestBoolCoercion tests that string and numeric boolean representations agree on both paths
+func TestBoolCoercion(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	// 7 active and 3 inactive users
+	tests := []struct {
+		value    any
+		expected int
+	}{
+		{true, 7},
+		{"true", 7},
+		{"TRUE", 7},
+		{" False ", 3},
+		{"1", 7},
+		{"0", 3},
+		{"yes", 7},
+		{"No", 3},
+		{1, 7},
+		{0.0, 3},
+		{json.Number("1"), 7},
+	}
+
+	for _, tt := range tests {
+		filterRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "is_active", Value: tt.value, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+		}
+
+		result, err := handler.DataQuery(users, filterRoot, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery(%v) failed: %v", tt.value, err)
+		}
+		gormResult, err := handler.DataGorm(db, filterRoot, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGorm(%v) failed: %v", tt.value, err)
+		}
+		if result.TotalSize != tt.expected || gormResult.TotalSize != tt.expected {
+			t.Errorf("Value %#v: expected %d, got DataQuery=%d DataGorm=%d", tt.value, tt.expected, result.TotalSize, gormResult.TotalSize)
+		}
+	}
+}
+
+// TestBoolCoercion_Invalid tests that invalid boolean values return a field-specific error
+func TestBoolCoercion_Invalid(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+
+	for _, value := range []any{"maybe", 2, "10"} {
+		filterRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "is_active", Value: value, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+		}
+
+		_, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 10)
+		if err == nil || !strings.Contains(err.Error(), "is_active") {
+			t.Errorf("DataQuery(%v): expected error naming is_active, got %v", value, err)
+		}
+		_, err = handler.DataGorm(db, filterRoot, 0, 10)
+		if err == nil || !strings.Contains(err.Error(), "is_active") {
+			t.Errorf("DataGorm(%v): expected error naming is_active, got %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestPoolBuffers_ResultsAreNotShared tests that with PoolBuffers, pages match the unpooled handler and
+// stay intact when later calls reuse the pooled buffers
+func TestPoolBuffers_ResultsAreNotShared(t *testing.T) {
+	users := generateBenchmarkUsers(5000)
+	pooled := filter.NewFilter[TestUser](filter.GolangFilteringConfig{PoolBuffers: true})
+	plain := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	adults := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "age", Value: 40, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}},
+		SortFields:   []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+	admins := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+	}
+
+	first, err := pooled.DataQuery(users, adults, 2, 25)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	ids := make([]uint, len(first.Data))
+	for i, user := range first.Data {
+		ids[i] = user.ID
+	}
+	expected, err := plain.DataQuery(users, adults, 2, 25)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if first.TotalSize != expected.TotalSize || len(first.Data) != len(expected.Data) {
+		t.Fatalf("Expected %d of %d records, got %d of %d", len(expected.Data), expected.TotalSize, len(first.Data), first.TotalSize)
+	}
+	for i := range expected.Data {
+		if first.Data[i] != expected.Data[i] {
+			t.Fatalf("Expected the pooled page to match at %d", i)
+		}
+	}
+
+	// Reuse the pooled buffers for other queries, then check the first page is unchanged
+	for range 10 {
+		if _, err := pooled.DataQuery(users, admins, 0, 100); err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if _, err := pooled.DataQueryNoPage(users, admins); err != nil {
+			t.Fatalf("DataQueryNoPage failed: %v", err)
+		}
+	}
+	for i, user := range first.Data {
+		if user == nil || user.ID != ids[i] {
+			t.Fatalf("Expected page item %d to stay user %d after reusing the pool", i, ids[i])
+		}
+	}
+}
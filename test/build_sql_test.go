@@ -0,0 +1,166 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestBuildSQL_MatchesGorm tests that BuildSQL returns the WHERE conditions, ORDER BY and arguments of the
+// page query DataGorm builds, byte for byte, on each dialect
+func TestBuildSQL_MatchesGorm(t *testing.T) {
+	depth := 2
+	bills := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{MaxDepth: &depth})
+	tickets := filter.NewFilter[SoftDeleteTicket](filter.GolangFilteringConfig{})
+	dialects := []struct {
+		name      string
+		dialector gorm.Dialector
+		quote     string
+	}{
+		{"sqlite", sqlite.Open(":memory:"), "`"},
+		{"mysql", mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}, "`"},
+		{"postgres", mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}, `"`},
+	}
+	roots := map[string]filter.Root{
+		"NoFilters": {Logic: filter.LogicAnd},
+		"Modes": {Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "value", Value: filter.Range{From: 1, To: 20}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "name", Value: "Peso", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			{Field: "name", Value: "x", Mode: filter.ModeNotContains, DataType: filter.DataTypeText},
+			{Field: "created_at", Value: "2024-01-02", Mode: filter.ModeEqual, DataType: filter.DataTypeDate},
+			{Field: "created_at", Value: "08:00", Mode: filter.ModeAfter, DataType: filter.DataTypeTime},
+			{Field: "value", Value: []int{1, 5, 20}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber},
+		}, SortFields: []filter.SortField{{Field: "value", Order: filter.SortOrderDesc, Nulls: filter.NullsLast}}},
+		"NestedOr": {Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "currency.currency_code", Value: []string{"USD", "PHP"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			{Field: "name", Value: "Coin", Mode: filter.ModeEndsWith, DataType: filter.DataTypeText},
+		}, Groups: []filter.Root{{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "value", Value: 5, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			{Field: "id", CompareField: "currency_id", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		}}}, SortFields: []filter.SortField{{Field: "currency.name", Order: filter.SortOrderAsc}}},
+		"Relevance": {Logic: filter.LogicAnd, Search: &filter.SearchFilter{Value: "peso"},
+			SortFields: []filter.SortField{{Field: filter.RelevanceField, Order: filter.SortOrderDesc}}},
+	}
+
+	for _, dialect := range dialects {
+		db, err := gorm.Open(dialect.dialector, &gorm.Config{DryRun: true})
+		if err != nil {
+			t.Fatalf("Failed to connect to database: %v", err)
+		}
+		for name, root := range roots {
+			t.Run(dialect.name+"/"+name, func(t *testing.T) {
+				sql, vars, err := bills.ExplainGorm(db, root, 0, 10)
+				if err != nil {
+					t.Fatalf("ExplainGorm failed: %v", err)
+				}
+				where, args, orderBy, err := bills.BuildSQL(root, filter.SQLOptions{Dialect: dialect.name})
+				if err != nil {
+					t.Fatalf("BuildSQL failed: %v", err)
+				}
+				expectBuiltSQL(t, sql, vars, where, args, orderBy)
+			})
+		}
+
+		// GORM adds the soft-delete check after the filters
+		t.Run(dialect.name+"/SoftDelete", func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+				{Field: "status", Value: "open", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			}}
+			sql, vars, err := tickets.ExplainGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("ExplainGorm failed: %v", err)
+			}
+			where, args, orderBy, err := tickets.BuildSQL(root, filter.SQLOptions{Dialect: dialect.name})
+			if err != nil {
+				t.Fatalf("BuildSQL failed: %v", err)
+			}
+			if !strings.HasSuffix(where, "deleted_at"+dialect.quote+" IS NULL") {
+				t.Errorf("Expected the soft-delete check last, got %q", where)
+			}
+			expectBuiltSQL(t, sql, vars, where, args, orderBy)
+		})
+	}
+}
+
+// expectBuiltSQL checks that the DataGorm page query sql with vars has the WHERE and ORDER BY of BuildSQL
+func expectBuiltSQL(t *testing.T, sql string, vars []any, where string, args []any, orderBy string) {
+	t.Helper()
+	expected := " ORDER BY " + orderBy + " LIMIT "
+	if where != "" {
+		expected = " WHERE " + where + expected
+	}
+	if !strings.Contains(sql, expected) || (where == "" && strings.Contains(sql, " WHERE ")) {
+		t.Errorf("Expected the GORM query to contain %q, got:\n%s", expected, sql)
+	}
+	// The LIMIT is bound after the arguments of BuildSQL
+	if len(vars) < len(args) || !reflect.DeepEqual(vars[:len(args)], args) {
+		t.Errorf("Expected arguments %v, got %v", args, vars)
+	}
+}
+
+// TestBuildSQL_DatabaseSQL tests that the output of BuildSQL runs through database/sql and matches DataGormNoPage
+func TestBuildSQL_DatabaseSQL(t *testing.T) {
+	db := setupSoftDeleteDB(t)
+	handler := filter.NewFilter[SoftDeleteTicket](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "status", Value: []string{"OPEN", "closed"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "status", Order: filter.SortOrderDesc}},
+	}
+	expected, err := handler.DataGormNoPage(db, root)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+
+	where, args, orderBy, err := handler.BuildSQL(root, filter.SQLOptions{})
+	if err != nil {
+		t.Fatalf("BuildSQL failed: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database/sql handle: %v", err)
+	}
+	rows, err := sqlDB.Query(fmt.Sprintf("SELECT id FROM soft_delete_tickets WHERE %s ORDER BY %s", where, orderBy), args...)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Rows failed: %v", err)
+	}
+	// Open tickets 1 and 4 (2 is soft-deleted), then closed ticket 3
+	if !slices.Equal(ids, ticketIDs(expected)) || !slices.Equal(ids, []uint{1, 4, 3}) {
+		t.Errorf("Expected ids %v, got %v", ticketIDs(expected), ids)
+	}
+}
+
+// TestBuildSQL_Errors tests that BuildSQL rejects unknown dialects and, like DataGorm, invalid strict filters
+func TestBuildSQL_Errors(t *testing.T) {
+	handler := filter.NewFilter[SoftDeleteTicket](filter.GolangFilteringConfig{StrictValidation: true})
+	root := filter.Root{Logic: filter.LogicAnd}
+	if _, _, _, err := handler.BuildSQL(root, filter.SQLOptions{Dialect: "oracle"}); err == nil || !strings.Contains(err.Error(), "oracle") {
+		t.Errorf("Expected an unsupported dialect error, got %v", err)
+	}
+
+	root.FieldFilters = []filter.FieldFilter{{Field: "id", Value: "many", Mode: filter.ModeGT, DataType: filter.DataTypeNumber}}
+	if _, _, _, err := handler.BuildSQL(root, filter.SQLOptions{}); !errors.Is(err, filter.ErrInvalidValue) {
+		t.Errorf("Expected an invalid value error, got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestRootBuilder_Filters tests that every builder method produces the FieldFilter written by hand
+func TestRootBuilder_Filters(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	caseSensitive, caseInsensitive := true, false
+
+	tests := []struct {
+		name     string
+		builder  *filter.RootBuilder
+		expected filter.FieldFilter
+	}{
+		{"TextEqual", filter.NewRoot().Text("name").Equal("john"), filter.FieldFilter{Field: "name", Value: "john", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		{"TextNotEqual", filter.NewRoot().Text("name").NotEqual("john"), filter.FieldFilter{Field: "name", Value: "john", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}},
+		{"TextContains", filter.NewRoot().Text("name").Contains("jo"), filter.FieldFilter{Field: "name", Value: "jo", Mode: filter.ModeContains, DataType: filter.DataTypeText}},
+		{"TextNotContains", filter.NewRoot().Text("name").NotContains("jo"), filter.FieldFilter{Field: "name", Value: "jo", Mode: filter.ModeNotContains, DataType: filter.DataTypeText}},
+		{"TextStartsWith", filter.NewRoot().Text("name").StartsWith("jo"), filter.FieldFilter{Field: "name", Value: "jo", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText}},
+		{"TextEndsWith", filter.NewRoot().Text("name").EndsWith("oe"), filter.FieldFilter{Field: "name", Value: "oe", Mode: filter.ModeEndsWith, DataType: filter.DataTypeText}},
+		{"TextIn", filter.NewRoot().Text("role").In("admin", "user"), filter.FieldFilter{Field: "role", Value: []string{"admin", "user"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}},
+		{"TextNotIn", filter.NewRoot().Text("role").NotIn("admin"), filter.FieldFilter{Field: "role", Value: []string{"admin"}, Mode: filter.ModeNotIn, DataType: filter.DataTypeText}},
+		{"TextBetween", filter.NewRoot().Text("code").Between("A000", "a999"), filter.FieldFilter{Field: "code", Value: filter.Range{From: "A000", To: "a999"}, Mode: filter.ModeRange, DataType: filter.DataTypeText}},
+		{"TextIsEmpty", filter.NewRoot().Text("name").IsEmpty(), filter.FieldFilter{Field: "name", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText}},
+		{"TextIsNotEmpty", filter.NewRoot().Text("name").IsNotEmpty(), filter.FieldFilter{Field: "name", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeText}},
+		{"TextCaseSensitive", filter.NewRoot().Text("name").CaseSensitive().Equal("John"), filter.FieldFilter{Field: "name", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText, CaseSensitive: &caseSensitive}},
+		{"TextCaseInsensitive", filter.NewRoot().Text("name").CaseInsensitive().Contains("jo"), filter.FieldFilter{Field: "name", Value: "jo", Mode: filter.ModeContains, DataType: filter.DataTypeText, CaseSensitive: &caseInsensitive}},
+		{"NumberEqual", filter.NewRoot().Number("age").Equal(30), filter.FieldFilter{Field: "age", Value: 30.0, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber}},
+		{"NumberNotEqual", filter.NewRoot().Number("age").NotEqual(30), filter.FieldFilter{Field: "age", Value: 30.0, Mode: filter.ModeNotEqual, DataType: filter.DataTypeNumber}},
+		{"NumberGT", filter.NewRoot().Number("age").GT(18), filter.FieldFilter{Field: "age", Value: 18.0, Mode: filter.ModeGT, DataType: filter.DataTypeNumber}},
+		{"NumberGTE", filter.NewRoot().Number("age").GTE(18), filter.FieldFilter{Field: "age", Value: 18.0, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}},
+		{"NumberLT", filter.NewRoot().Number("age").LT(65), filter.FieldFilter{Field: "age", Value: 65.0, Mode: filter.ModeLT, DataType: filter.DataTypeNumber}},
+		{"NumberLTE", filter.NewRoot().Number("age").LTE(65), filter.FieldFilter{Field: "age", Value: 65.0, Mode: filter.ModeLTE, DataType: filter.DataTypeNumber}},
+		{"NumberIn", filter.NewRoot().Number("age").In(25, 30), filter.FieldFilter{Field: "age", Value: []float64{25, 30}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber}},
+		{"NumberNotIn", filter.NewRoot().Number("age").NotIn(25), filter.FieldFilter{Field: "age", Value: []float64{25}, Mode: filter.ModeNotIn, DataType: filter.DataTypeNumber}},
+		{"NumberBetween", filter.NewRoot().Number("age").Between(18, 65), filter.FieldFilter{Field: "age", Value: filter.Range{From: 18.0, To: 65.0}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}},
+		{"NumberIsEmpty", filter.NewRoot().Number("age").IsEmpty(), filter.FieldFilter{Field: "age", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeNumber}},
+		{"NumberIsNotEmpty", filter.NewRoot().Number("age").IsNotEmpty(), filter.FieldFilter{Field: "age", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeNumber}},
+		{"DurationEqual", filter.NewRoot().Duration("minutes").Equal(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeEqual, DataType: filter.DataTypeDuration}},
+		{"DurationNotEqual", filter.NewRoot().Duration("minutes").NotEqual(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeNotEqual, DataType: filter.DataTypeDuration}},
+		{"DurationGT", filter.NewRoot().Duration("minutes").GT(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeGT, DataType: filter.DataTypeDuration}},
+		{"DurationGTE", filter.NewRoot().Duration("minutes").GTE(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeGTE, DataType: filter.DataTypeDuration}},
+		{"DurationLT", filter.NewRoot().Duration("minutes").LT(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeLT, DataType: filter.DataTypeDuration}},
+		{"DurationLTE", filter.NewRoot().Duration("minutes").LTE(time.Hour), filter.FieldFilter{Field: "minutes", Value: time.Hour, Mode: filter.ModeLTE, DataType: filter.DataTypeDuration}},
+		{"DurationIn", filter.NewRoot().Duration("minutes").In(time.Hour), filter.FieldFilter{Field: "minutes", Value: []time.Duration{time.Hour}, Mode: filter.ModeIn, DataType: filter.DataTypeDuration}},
+		{"DurationNotIn", filter.NewRoot().Duration("minutes").NotIn(time.Hour), filter.FieldFilter{Field: "minutes", Value: []time.Duration{time.Hour}, Mode: filter.ModeNotIn, DataType: filter.DataTypeDuration}},
+		{"DurationBetween", filter.NewRoot().Duration("minutes").Between(time.Hour, 2*time.Hour), filter.FieldFilter{Field: "minutes", Value: filter.Range{From: time.Hour, To: 2 * time.Hour}, Mode: filter.ModeRange, DataType: filter.DataTypeDuration}},
+		{"DurationIsEmpty", filter.NewRoot().Duration("minutes").IsEmpty(), filter.FieldFilter{Field: "minutes", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeDuration}},
+		{"DurationIsNotEmpty", filter.NewRoot().Duration("minutes").IsNotEmpty(), filter.FieldFilter{Field: "minutes", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeDuration}},
+		{"BoolEqual", filter.NewRoot().Bool("is_active").Equal(true), filter.FieldFilter{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}},
+		{"BoolNotEqual", filter.NewRoot().Bool("is_active").NotEqual(true), filter.FieldFilter{Field: "is_active", Value: true, Mode: filter.ModeNotEqual, DataType: filter.DataTypeBool}},
+		{"BoolIsEmpty", filter.NewRoot().Bool("is_active").IsEmpty(), filter.FieldFilter{Field: "is_active", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeBool}},
+		{"BoolIsNotEmpty", filter.NewRoot().Bool("is_active").IsNotEmpty(), filter.FieldFilter{Field: "is_active", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeBool}},
+		{"UUIDEqual", filter.NewRoot().UUID("id").Equal(id), filter.FieldFilter{Field: "id", Value: id, Mode: filter.ModeEqual, DataType: filter.DataTypeUUID}},
+		{"UUIDNotEqual", filter.NewRoot().UUID("id").NotEqual(id), filter.FieldFilter{Field: "id", Value: id, Mode: filter.ModeNotEqual, DataType: filter.DataTypeUUID}},
+		{"UUIDIn", filter.NewRoot().UUID("id").In(id), filter.FieldFilter{Field: "id", Value: []any{id}, Mode: filter.ModeIn, DataType: filter.DataTypeUUID}},
+		{"UUIDNotIn", filter.NewRoot().UUID("id").NotIn(id), filter.FieldFilter{Field: "id", Value: []any{id}, Mode: filter.ModeNotIn, DataType: filter.DataTypeUUID}},
+		{"UUIDIsEmpty", filter.NewRoot().UUID("id").IsEmpty(), filter.FieldFilter{Field: "id", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeUUID}},
+		{"UUIDIsNotEmpty", filter.NewRoot().UUID("id").IsNotEmpty(), filter.FieldFilter{Field: "id", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeUUID}},
+		{"DateEqual", filter.NewRoot().Date("created_at").Equal(day), filter.FieldFilter{Field: "created_at", Value: day, Mode: filter.ModeEqual, DataType: filter.DataTypeDate}},
+		{"DateNotEqual", filter.NewRoot().Date("created_at").NotEqual("2025-01-01"), filter.FieldFilter{Field: "created_at", Value: "2025-01-01", Mode: filter.ModeNotEqual, DataType: filter.DataTypeDate}},
+		{"DateBefore", filter.NewRoot().Date("created_at").Before("today"), filter.FieldFilter{Field: "created_at", Value: "today", Mode: filter.ModeBefore, DataType: filter.DataTypeDate}},
+		{"DateAfter", filter.NewRoot().Date("created_at").After("now-7d"), filter.FieldFilter{Field: "created_at", Value: "now-7d", Mode: filter.ModeAfter, DataType: filter.DataTypeDate}},
+		{"DateGTE", filter.NewRoot().Date("created_at").GTE(day), filter.FieldFilter{Field: "created_at", Value: day, Mode: filter.ModeGTE, DataType: filter.DataTypeDate}},
+		{"DateLTE", filter.NewRoot().Date("created_at").LTE(day), filter.FieldFilter{Field: "created_at", Value: day, Mode: filter.ModeLTE, DataType: filter.DataTypeDate}},
+		{"DateIn", filter.NewRoot().Date("created_at").In("2025-01-01", day), filter.FieldFilter{Field: "created_at", Value: []any{"2025-01-01", day}, Mode: filter.ModeIn, DataType: filter.DataTypeDate}},
+		{"DateNotIn", filter.NewRoot().Date("created_at").NotIn(day), filter.FieldFilter{Field: "created_at", Value: []any{day}, Mode: filter.ModeNotIn, DataType: filter.DataTypeDate}},
+		{"DateBetween", filter.NewRoot().Date("created_at").Between("2025-01-01", "2025-12-31"), filter.FieldFilter{Field: "created_at", Value: filter.Range{From: "2025-01-01", To: "2025-12-31"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate}},
+		{"DateIsEmpty", filter.NewRoot().Date("created_at").IsEmpty(), filter.FieldFilter{Field: "created_at", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeDate}},
+		{"DateIsNotEmpty", filter.NewRoot().Date("created_at").IsNotEmpty(), filter.FieldFilter{Field: "created_at", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeDate}},
+		{"TimeEqual", filter.NewRoot().Time("starts_at").Equal("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeEqual, DataType: filter.DataTypeTime}},
+		{"TimeNotEqual", filter.NewRoot().Time("starts_at").NotEqual("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeNotEqual, DataType: filter.DataTypeTime}},
+		{"TimeGT", filter.NewRoot().Time("starts_at").GT("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeGT, DataType: filter.DataTypeTime}},
+		{"TimeGTE", filter.NewRoot().Time("starts_at").GTE("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeGTE, DataType: filter.DataTypeTime}},
+		{"TimeLT", filter.NewRoot().Time("starts_at").LT("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeLT, DataType: filter.DataTypeTime}},
+		{"TimeLTE", filter.NewRoot().Time("starts_at").LTE("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeLTE, DataType: filter.DataTypeTime}},
+		{"TimeBefore", filter.NewRoot().Time("starts_at").Before("08:00"), filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeBefore, DataType: filter.DataTypeTime}},
+		{"TimeAfter", filter.NewRoot().Time("starts_at").After(day), filter.FieldFilter{Field: "starts_at", Value: day, Mode: filter.ModeAfter, DataType: filter.DataTypeTime}},
+		{"TimeBetween", filter.NewRoot().Time("starts_at").Between("08:00", "17:00"), filter.FieldFilter{Field: "starts_at", Value: filter.Range{From: "08:00", To: "17:00"}, Mode: filter.ModeRange, DataType: filter.DataTypeTime}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			expected := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.expected}, SortFields: []filter.SortField{}, Preload: []string{}}
+			root.SortFields, root.Preload = []filter.SortField{}, []string{}
+			if !reflect.DeepEqual(root, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, root)
+			}
+		})
+	}
+}
+
+// TestRootBuilder_Root tests that logic, groups, search, sorting and preloads build the Root written by hand
+func TestRootBuilder_Root(t *testing.T) {
+	root, err := filter.NewRoot().And().
+		Text("name").Contains("john").
+		Number("age").GTE(18).
+		Group(filter.NewRoot().Or().Text("role").Equal("admin").Bool("is_active").Equal(true)).
+		Search("doe", "name", "email").
+		SortRelevance().
+		SortDesc("created_at").
+		SortAsc("id").
+		Preload("Department").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	expected := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "john", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			{Field: "age", Value: 18.0, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+		Groups: []filter.Root{{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+		}},
+		Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "doe"},
+		SortFields: []filter.SortField{
+			{Field: filter.RelevanceField, Order: filter.SortOrderDesc},
+			{Field: "created_at", Order: filter.SortOrderDesc},
+			{Field: "id", Order: filter.SortOrderAsc},
+		},
+		Preload: []string{"Department"},
+	}
+	if !reflect.DeepEqual(root, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, root)
+	}
+}
+
+// TestRootBuilder_Query tests that a built Root filters like its hand-written equivalent
+func TestRootBuilder_Query(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	root, err := filter.NewRoot().
+		Text("email").EndsWith("@example.com").
+		Number("age").Between(25, 35).
+		Bool("is_active").Equal(true).
+		Date("created_at").Between("2024-01-01", "2024-12-31").
+		SortDesc("age").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	expected := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "email", Value: "@example.com", Mode: filter.ModeEndsWith, DataType: filter.DataTypeText},
+			{Field: "age", Value: filter.Range{From: 25, To: 35}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "created_at", Value: filter.Range{From: "2024-01-01", To: "2024-12-31"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	built, err := handler.DataQuery(generateTestUsers(), root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	literal, err := handler.DataQuery(generateTestUsers(), expected, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if len(built.Data) == 0 || !equalIDs(userIDs(built.Data), userIDs(literal.Data)) {
+		t.Errorf("Expected users %v, got %v", userIDs(literal.Data), userIDs(built.Data))
+	}
+}
+
+// TestRootBuilder_Errors tests that Build reports misuse instead of returning a Root
+func TestRootBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *filter.RootBuilder
+		message string
+	}{
+		{"EmptyField", filter.NewRoot().Text("").Equal("john"), "filter field cannot be empty"},
+		{"EmptySortField", filter.NewRoot().SortAsc(""), "sort field cannot be empty"},
+		{"EmptyPreload", filter.NewRoot().Preload(""), "preload relation cannot be empty"},
+		{"InvertedNumberRange", filter.NewRoot().Number("age").Between(65, 18), "range from number cannot be after to number"},
+		{"InvertedTextRange", filter.NewRoot().Text("code").Between("b", "A"), "range from text cannot be after to text"},
+		{"InvertedDateRange", filter.NewRoot().Date("created_at").Between("2025-12-31", "2025-01-01"), "range from date cannot be after to date"},
+		{"InvertedTimeRange", filter.NewRoot().Time("starts_at").Between("17:00", "08:00"), "range from time cannot be after to time"},
+		{"InvertedDurationRange", filter.NewRoot().Duration("minutes").Between(time.Hour, time.Minute), "range from duration cannot be after to duration"},
+		{"InvalidDate", filter.NewRoot().Date("created_at").Equal(42), "invalid time value"},
+		{"InvalidTime", filter.NewRoot().Time("starts_at").Equal("noon"), "field starts_at"},
+		{"InvalidUUID", filter.NewRoot().UUID("id").In("not-a-uuid"), "field id"},
+		{"InGroup", filter.NewRoot().Group(filter.NewRoot().Number("").GT(1)), "number filter field cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := tt.builder.Build()
+			if err == nil {
+				t.Fatalf("Expected Build to fail, got %+v", root)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
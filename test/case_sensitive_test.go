@@ -0,0 +1,58 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestCaseSensitiveFilter tests that "John" vs "john" differ only when CaseSensitive is set, per filter
+// or for the handler, in DataQuery and DataGorm alike
+func TestCaseSensitiveFilter(t *testing.T) {
+	db := setupTestDB(t)
+	users := generateTestUsers()
+	sensitive, insensitive := true, false
+
+	tests := []struct {
+		name     string
+		config   filter.GolangFilteringConfig
+		filter   filter.FieldFilter
+		expected int
+	}{
+		{"EqualInsensitive", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "name", Value: "john doe", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, 1},
+		{"EqualSensitiveMismatch", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "name", Value: "john doe", Mode: filter.ModeEqual, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 0},
+		{"EqualSensitiveMatch", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "name", Value: "John Doe", Mode: filter.ModeEqual, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 1},
+		{"InSensitive", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "role", Value: []string{"ADMIN", "user"}, Mode: filter.ModeIn, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 5},
+		{"NotEqualSensitive", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "role", Value: "Admin", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 10},
+		{"HandlerDefault", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "role", Value: "ADMIN", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, 0},
+		{"FilterOverridesHandler", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "role", Value: "ADMIN", Mode: filter.ModeEqual, DataType: filter.DataTypeText, CaseSensitive: &insensitive}, 3},
+		{"ContainsSensitiveMismatch", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "name", Value: "smith", Mode: filter.ModeContains, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 0},
+		{"ContainsSensitiveMatch", filter.GolangFilteringConfig{}, filter.FieldFilter{Field: "name", Value: "Smith", Mode: filter.ModeContains, DataType: filter.DataTypeText, CaseSensitive: &sensitive}, 2},
+		{"StartsWithSensitive", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "name", Value: "john", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText}, 0},
+		{"NotContainsSensitive", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "email", Value: "JOHN", Mode: filter.ModeNotContains, DataType: filter.DataTypeText}, 10},
+		{"EndsWithOverridesHandler", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "name", Value: "SMITH", Mode: filter.ModeEndsWith, DataType: filter.DataTypeText, CaseSensitive: &insensitive}, 2},
+		{"GlobWildcardLiteral", filter.GolangFilteringConfig{CaseSensitive: true}, filter.FieldFilter{Field: "name", Value: "J*", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := filter.NewFilter[TestUser](tt.config)
+			filterRoot := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+			}
+
+			result, err := handler.DataQuery(users, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			gormResult, err := handler.DataGorm(db, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if result.TotalSize != tt.expected || gormResult.TotalSize != tt.expected {
+				t.Errorf("Expected %d, got DataQuery=%d DataGorm=%d", tt.expected, result.TotalSize, gormResult.TotalSize)
+			}
+		})
+	}
+}
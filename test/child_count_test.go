@@ -0,0 +1,179 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestChildCount_HasMany tests that child counts match the same orders in DataQuery, DataGorm and CountGorm
+func TestChildCount_HasMany(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true})
+	widgets := filter.Root{FieldFilters: []filter.FieldFilter{
+		{Field: "sku", Value: "widget", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+	}}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"EveryItem", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Mode: filter.ModeGTE, Value: 2},
+		}}, []uint{1, 3}},
+		{"NoItems", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Mode: filter.ModeEqual, Value: 0},
+		}}, []uint{4}},
+		{"Where", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Where: widgets, Mode: filter.ModeEqual, Value: 2},
+		}}, []uint{1}},
+		{"WhereNotEqual", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Where: widgets, Mode: filter.ModeNotEqual, Value: 0},
+		}}, []uint{1, 2}},
+		// Both conditions must hold for the same item: order 1 has a GADGET and an item with 5, but not both
+		{"SameElement", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Where: filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+				{Field: "sku", Value: "GADGET", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "quantity", Value: 2, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			}}, Mode: filter.ModeGTE, Value: 1},
+		}}, []uint{}},
+		{"NestedGroup", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Where: filter.Root{Logic: filter.LogicAnd, Groups: []filter.Root{{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "sku", Value: "BOLT", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+					{Field: "quantity", Value: 5, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+				},
+			}}}, Mode: filter.ModeEqual, Value: 1},
+		}}, []uint{1, 3}},
+		{"Range", filter.Root{ChildCounts: []filter.ChildCountFilter{
+			{Relation: "items", Mode: filter.ModeRange, Value: filter.Range{From: 1, To: 3, ToExclusive: true}},
+		}}, []uint{2, 3}},
+		{"WithFilters", filter.Root{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "total", Value: 100, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+				{Field: "items.sku", Value: "NUT", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			ChildCounts: []filter.ChildCountFilter{{Relation: "items", Mode: filter.ModeLTE, Value: 2}},
+		}, []uint{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.root.SortFields = []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}
+			result, err := handler.DataQuery(generateHasManyOrders(), tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := orderIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := orderIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+
+			count, err := handler.CountGorm(db, tt.root)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) {
+				t.Errorf("Expected CountGorm %d, got %d", len(tt.expected), count)
+			}
+		})
+	}
+}
+
+// TestChildCount_Many2Many tests child counts on a many2many relation
+func TestChildCount_Many2Many(t *testing.T) {
+	db := setupManyMemberDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[ManyMember](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		ChildCounts: []filter.ChildCountFilter{{
+			Relation: "roles",
+			Where: filter.Root{FieldFilters: []filter.FieldFilter{
+				{Field: "name", Value: "viewer", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText},
+			}},
+			Mode:  filter.ModeGTE,
+			Value: 1,
+		}},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	expected := []uint{1, 3}
+
+	result, err := handler.DataQuery(generateManyMembers(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := memberIDs(result.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataQuery IDs %v, got %v", expected, got)
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got := memberIDs(page.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataGorm IDs %v, got %v", expected, got)
+	}
+}
+
+// TestChildCount_Invalid tests that child counts the queries cannot run are rejected by every path
+func TestChildCount_Invalid(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+
+	tests := []struct {
+		name  string
+		count filter.ChildCountFilter
+	}{
+		{"UnknownRelation", filter.ChildCountFilter{Relation: "lines", Mode: filter.ModeGT, Value: 1}},
+		{"NotARelation", filter.ChildCountFilter{Relation: "customer", Mode: filter.ModeGT, Value: 1}},
+		{"UnsupportedMode", filter.ChildCountFilter{Relation: "items", Mode: filter.ModeContains, Value: 1}},
+		{"InvalidValue", filter.ChildCountFilter{Relation: "items", Mode: filter.ModeGT, Value: "many"}},
+		{"UnknownWhereField", filter.ChildCountFilter{Relation: "items", Mode: filter.ModeGT, Value: 1, Where: filter.Root{
+			FieldFilters: []filter.FieldFilter{{Field: "color", Value: "red", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{ChildCounts: []filter.ChildCountFilter{tt.count}}
+			if err := handler.ValidateRoot(root); err == nil {
+				t.Error("Expected ValidateRoot to fail")
+			}
+			if _, err := handler.DataQuery(generateHasManyOrders(), root, 0, 10); err == nil {
+				t.Error("Expected DataQuery to fail")
+			}
+			if _, err := handler.DataGorm(db, root, 0, 10); err == nil {
+				t.Error("Expected DataGorm to fail")
+			}
+		})
+	}
+}
+
+// TestChildCount_ParseRoot tests that child counts are decoded and normalized from JSON
+func TestChildCount_ParseRoot(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"childCounts":[{"relation":"items","mode":"RANGE","value":{"from":2,"to":3},
+		"where":{"filters":[{"field":"quantity","value":10,"mode":"lt","dataType":"number"}]}}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	result, err := handler.DataQuery(generateHasManyOrders(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := orderIDs(result.Data); !equalIDs(got, []uint{1}) {
+		t.Errorf("Expected IDs [1], got %v", got)
+	}
+}
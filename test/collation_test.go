@@ -0,0 +1,159 @@
+package test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func collationProducts() []*Product {
+	return []*Product{
+		{ID: 1, Name: "Zebra"},
+		{ID: 2, Name: "Ärger"},
+		{ID: 3, Name: "apple"},
+		{ID: 4, Name: "Banane"},
+		{ID: 5, Name: "Apfel"},
+	}
+}
+
+func sortedNames(t *testing.T, handler *filter.Handler[Product], sortField filter.SortField) string {
+	t.Helper()
+	result, err := handler.DataQuery(collationProducts(), filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{sortField},
+	}, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	names := make([]string, len(result.Data))
+	for i, item := range result.Data {
+		names[i] = item.Name
+	}
+	return strings.Join(names, ",")
+}
+
+// TestCollation tests that in-memory string sorting follows the configured collator, a SortField language,
+// or case-insensitive order, and stays byte-wise when CaseSensitive is set
+func TestCollation(t *testing.T) {
+	byName := filter.SortField{Field: "name", Order: filter.SortOrderAsc}
+	tests := []struct {
+		name      string
+		config    filter.GolangFilteringConfig
+		sortField filter.SortField
+		expected  string
+	}{
+		{
+			name:      "CaseInsensitiveDefault",
+			sortField: byName,
+			expected:  "Apfel,apple,Banane,Zebra,Ärger",
+		},
+		{
+			name:      "CaseSensitive",
+			config:    filter.GolangFilteringConfig{CaseSensitive: true},
+			sortField: byName,
+			expected:  "Apfel,Banane,Zebra,apple,Ärger",
+		},
+		{
+			name:      "GermanCollator",
+			config:    filter.GolangFilteringConfig{Collator: collate.New(language.German)},
+			sortField: byName,
+			expected:  "Apfel,apple,Ärger,Banane,Zebra",
+		},
+		{
+			name:      "GermanCollatorDesc",
+			config:    filter.GolangFilteringConfig{Collator: collate.New(language.German)},
+			sortField: filter.SortField{Field: "name", Order: filter.SortOrderDesc},
+			expected:  "Zebra,Banane,Ärger,apple,Apfel",
+		},
+		{
+			name:      "SortFieldLanguage",
+			config:    filter.GolangFilteringConfig{CaseSensitive: true},
+			sortField: filter.SortField{Field: "name", Order: filter.SortOrderAsc, Language: "de"},
+			expected:  "Apfel,apple,Ärger,Banane,Zebra",
+		},
+		{
+			// Swedish sorts Ä after Z, overriding the German collator
+			name:      "SortFieldLanguageOverridesCollator",
+			config:    filter.GolangFilteringConfig{Collator: collate.New(language.German)},
+			sortField: filter.SortField{Field: "name", Order: filter.SortOrderAsc, Language: "sv"},
+			expected:  "Apfel,apple,Banane,Zebra,Ärger",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := filter.NewFilter[Product](tt.config)
+			if got := sortedNames(t, handler, tt.sortField); got != tt.expected {
+				t.Errorf("Expected order %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestCollation_Concurrent tests that concurrent queries can share the configured collator
+func TestCollation_Concurrent(t *testing.T) {
+	handler := filter.NewFilter[Product](filter.GolangFilteringConfig{Collator: collate.New(language.German)})
+	byName := filter.SortField{Field: "name", Order: filter.SortOrderAsc}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := sortedNames(t, handler, byName); got != "Apfel,apple,Ärger,Banane,Zebra" {
+				t.Errorf("Expected the German order, got %s", got)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestCollation_InvalidLanguage tests that a malformed SortField language is rejected
+func TestCollation_InvalidLanguage(t *testing.T) {
+	_, err := filter.ParseRootFromJSON([]byte(`{"logic":"and","sortFields":[{"field":"name","order":"asc","language":"not a tag"}]}`))
+	if err == nil || !strings.Contains(err.Error(), "unknown language") {
+		t.Errorf("Expected an unknown language error from ParseRootFromJSON, got %v", err)
+	}
+
+	handler := filter.NewFilter[Product](filter.GolangFilteringConfig{})
+	err = handler.ValidateRoot(filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderAsc, Language: "not a tag"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown language") {
+		t.Errorf("Expected an unknown language error from ValidateRoot, got %v", err)
+	}
+}
+
+// TestCollation_HybridStrategiesAgree tests that both Hybrid strategies sort text in the same order:
+// case-insensitively by default, with SQL sorting by the lowercase column, and byte-wise when CaseSensitive is set
+func TestCollation_HybridStrategiesAgree(t *testing.T) {
+	db := setupProductDB(t)
+	for _, tt := range []struct {
+		caseSensitive bool
+		expected      []uint
+	}{
+		{false, []uint{4, 5, 1, 3, 2}},
+		{true, []uint{4, 3, 2, 5, 1}},
+	} {
+		handler := filter.NewFilter[Product](filter.GolangFilteringConfig{CaseSensitive: tt.caseSensitive})
+		root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderAsc}}}
+		for _, threshold := range []int{1000, 1} {
+			result, err := handler.Hybrid(db, threshold, root, 0, 10)
+			if err != nil {
+				t.Fatalf("Hybrid failed: %v", err)
+			}
+			var ids []uint
+			for _, product := range result.Data {
+				ids = append(ids, product.ID)
+			}
+			if !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected %s order %v with CaseSensitive %v, got %v", result.Strategy, tt.expected, tt.caseSensitive, ids)
+			}
+		}
+	}
+}
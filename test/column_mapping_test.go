@@ -0,0 +1,97 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// LegacyInvoice maps struct fields onto legacy column names
+type LegacyInvoice struct {
+	ID          uint    `gorm:"primaryKey" json:"id"`
+	ReferenceNo string  `gorm:"column:ref_number" filter:"column:ref_number" json:"reference_no"`
+	Amount      float64 `gorm:"column:amt" json:"amount"`
+}
+
+func setupLegacyInvoiceDB(t *testing.T) (*gorm.DB, []*LegacyInvoice) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&LegacyInvoice{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	invoices := []*LegacyInvoice{
+		{ID: 1, ReferenceNo: "INV-001", Amount: 100},
+		{ID: 2, ReferenceNo: "INV-002", Amount: 250},
+		{ID: 3, ReferenceNo: "CRN-001", Amount: 75},
+	}
+	for _, invoice := range invoices {
+		if err := db.Create(invoice).Error; err != nil {
+			t.Fatalf("Failed to create invoice: %v", err)
+		}
+	}
+	return db, invoices
+}
+
+// TestColumnMapping_TagAndConfig tests that struct tags and config mappings are used in WHERE and ORDER BY
+func TestColumnMapping_TagAndConfig(t *testing.T) {
+	db, invoices := setupLegacyInvoiceDB(t)
+	handler := filter.NewFilter[LegacyInvoice](filter.GolangFilteringConfig{
+		ColumnMappings: map[string]string{"amount": "amt"},
+	})
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "reference_no", Value: "INV", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+			{Field: "amount", Value: 50, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "amount", Order: filter.SortOrderDesc}},
+	}
+
+	result, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 2 || result.Data[0].ID != 2 {
+		t.Errorf("Expected invoices 2 then 1, got total %d", result.TotalSize)
+	}
+
+	// In-memory path keeps using the struct fields, so Hybrid stays consistent
+	hybrid, err := handler.Hybrid(db, 0, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("Hybrid failed: %v", err)
+	}
+	memory, err := handler.DataQuery(invoices, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if hybrid.TotalSize != 2 || memory.TotalSize != 2 || memory.Data[0].ID != 2 {
+		t.Errorf("Expected 2 records from Hybrid and DataQuery, got %d and %d", hybrid.TotalSize, memory.TotalSize)
+	}
+}
+
+// TestColumnMapping_InvalidConfigPanics tests that bad mappings are rejected at NewFilter time
+func TestColumnMapping_InvalidConfigPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings map[string]string
+	}{
+		{"UnknownField", map[string]string{"missing_field": "col"}},
+		{"UnsafeColumn", map[string]string{"amount": "amt; DROP TABLE users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected NewFilter to panic, got nil")
+				}
+			}()
+			filter.NewFilter[LegacyInvoice](filter.GolangFilteringConfig{ColumnMappings: tt.mappings})
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// CompareStay is a hotel stay whose check-out can be missing or, through bad data, before the check-in
+type CompareStay struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	CheckIn    time.Time  `json:"check_in"`
+	CheckOut   *time.Time `json:"check_out"`
+	Nights     int        `json:"nights"`
+	PaidNights int        `json:"paid_nights"`
+	Guest      string     `json:"guest"`
+}
+
+func generateCompareStays() []*CompareStay {
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+	}
+	checkOut := func(day, hour int) *time.Time {
+		t := at(day, hour)
+		return &t
+	}
+	return []*CompareStay{
+		{ID: 1, CheckIn: at(1, 15), CheckOut: checkOut(3, 11), Nights: 2, PaidNights: 2, Guest: "Ann"},
+		{ID: 2, CheckIn: at(4, 15), CheckOut: checkOut(2, 11), Nights: 3, PaidNights: 1, Guest: "Ben"},
+		{ID: 3, CheckIn: at(5, 9), CheckOut: nil, Nights: 1, PaidNights: 2, Guest: "Cid"},
+		{ID: 4, CheckIn: at(6, 10), CheckOut: checkOut(6, 10), Nights: 0, PaidNights: 0, Guest: "Dee"},
+	}
+}
+
+func setupCompareDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&CompareStay{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, stay := range generateCompareStays() {
+		if err := db.Create(stay).Error; err != nil {
+			t.Fatalf("Failed to create stay: %v", err)
+		}
+	}
+
+	var queries []string
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return db, &queries
+}
+
+// TestCompareField_AllPaths tests column-to-column filters in DataQuery, DataGorm and both Hybrid strategies
+func TestCompareField_AllPaths(t *testing.T) {
+	db, _ := setupCompareDB(t)
+	handler := filter.NewFilter[CompareStay](filter.GolangFilteringConfig{})
+	stays := generateCompareStays()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"DateBefore", filter.FieldFilter{Field: "check_out", Mode: filter.ModeBefore, CompareField: "check_in", DataType: filter.DataTypeDate}, []uint{2}},
+		{"DateLTE", filter.FieldFilter{Field: "check_out", Mode: filter.ModeLTE, CompareField: "check_in", DataType: filter.DataTypeDate}, []uint{2, 4}},
+		{"DateAfter", filter.FieldFilter{Field: "check_out", Mode: filter.ModeAfter, CompareField: "check_in", DataType: filter.DataTypeDate}, []uint{1}},
+		{"DateEqual", filter.FieldFilter{Field: "check_out", Mode: filter.ModeEqual, CompareField: "check_in", DataType: filter.DataTypeDate}, []uint{4}},
+		// A missing check-out only matches the negative mode
+		{"DateNotEqual", filter.FieldFilter{Field: "check_out", Mode: filter.ModeNotEqual, CompareField: "check_in", DataType: filter.DataTypeDate}, []uint{1, 2, 3}},
+		{"NumberGT", filter.FieldFilter{Field: "nights", Mode: filter.ModeGT, CompareField: "paid_nights", DataType: filter.DataTypeNumber}, []uint{2}},
+		{"NumberEqual", filter.FieldFilter{Field: "nights", Mode: filter.ModeEqual, CompareField: "paid_nights", DataType: filter.DataTypeNumber}, []uint{1, 4}},
+		// CompareField takes precedence over Value
+		{"NumberLTIgnoresValue", filter.FieldFilter{Field: "nights", Value: 100, Mode: filter.ModeLT, CompareField: "paid_nights", DataType: filter.DataTypeNumber}, []uint{3}},
+		// Times compare the time of day only
+		{"TimeLT", filter.FieldFilter{Field: "check_out", Mode: filter.ModeLT, CompareField: "check_in", DataType: filter.DataTypeTime}, []uint{1, 2}},
+		{"TimeGTE", filter.FieldFilter{Field: "check_out", Mode: filter.ModeGTE, CompareField: "check_in", DataType: filter.DataTypeTime}, []uint{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			result, err := handler.DataQuery(stays, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			var ids []uint
+			for _, stay := range result.Data {
+				ids = append(ids, stay.ID)
+			}
+			if !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery stays %v, got %v", tt.expected, ids)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				page, err := handler.Hybrid(db, threshold, root, 0, 10)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				ids = ids[:0]
+				for _, stay := range page.Data {
+					ids = append(ids, stay.ID)
+				}
+				if !equalIDs(ids, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) stays %v, got %v", page.Strategy, tt.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+// TestCompareField_SQL tests that the comparison is between columns, with no bound value
+func TestCompareField_SQL(t *testing.T) {
+	db, queries := setupCompareDB(t)
+	handler := filter.NewFilter[CompareStay](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "nights", Value: 5, Mode: filter.ModeGT, CompareField: "paid_nights", DataType: filter.DataTypeNumber},
+			{Field: "check_out", Mode: filter.ModeNotEqual, CompareField: "check_in", DataType: filter.DataTypeDate},
+		},
+	}
+
+	if _, err := handler.DataGorm(db, root, 0, 10); err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	query := (*queries)[len(*queries)-1]
+	for _, expected := range []string{"nights > paid_nights", "(check_out IS NULL OR check_in IS NULL OR check_out != check_in)"} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("Expected %s in %s", expected, query)
+		}
+	}
+}
+
+// TestCompareField_Errors tests that unsupported compare filters fail in memory and in strict SQL
+func TestCompareField_Errors(t *testing.T) {
+	db, _ := setupCompareDB(t)
+	handler := filter.NewFilter[CompareStay](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name   string
+		filter filter.FieldFilter
+		errMsg string
+	}{
+		{"UnknownField", filter.FieldFilter{Field: "nights", Mode: filter.ModeGT, CompareField: "paid", DataType: filter.DataTypeNumber}, "unknown compare field paid"},
+		{"Mode", filter.FieldFilter{Field: "nights", Mode: filter.ModeRange, CompareField: "paid_nights", DataType: filter.DataTypeNumber}, "not supported with compare field"},
+		{"DataType", filter.FieldFilter{Field: "guest", Mode: filter.ModeEqual, CompareField: "guest", DataType: filter.DataTypeText}, "unsupported data type text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			if _, err := handler.DataQuery(generateCompareStays(), root, 0, 10); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected DataQuery error %q, got %v", tt.errMsg, err)
+			}
+			if _, err := handler.DataGorm(db, root, 0, 10); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected DataGorm error %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+// TestCompareField_DeniedField tests that a denied compare field drops the filter like a denied field
+func TestCompareField_DeniedField(t *testing.T) {
+	handler := filter.NewFilter[CompareStay](filter.GolangFilteringConfig{
+		DeniedFields:           []string{"paid_nights"},
+		RejectDisallowedFields: true,
+	})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "nights", Mode: filter.ModeGT, CompareField: "paid_nights", DataType: filter.DataTypeNumber},
+		},
+	}
+	if _, err := handler.DataQuery(generateCompareStays(), root, 0, 10); err == nil || !strings.Contains(err.Error(), "paid_nights") {
+		t.Errorf("Expected the denied compare field to be rejected, got %v", err)
+	}
+}
+
+// TestCompareField_ParseJSON tests that compareField is read from JSON
+func TestCompareField_ParseJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"check_out","mode":"before","compareField":"check_in","dataType":"date"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.FieldFilters[0].CompareField != "check_in" {
+		t.Errorf("Expected compare field check_in, got %q", root.FieldFilters[0].CompareField)
+	}
+}
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func compileCacheRoot(minAge int) filter.Root {
+	return filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: minAge, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			{Field: "role", Value: []any{"admin", "user"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+}
+
+// TestCompileCache_MatchesUncached tests that repeated in-memory and GORM queries return what an
+// uncached handler does, including after other Roots evict them from a one-entry cache
+func TestCompileCache_MatchesUncached(t *testing.T) {
+	users := generateBenchmarkUsers(3000)
+	db := setupTestDB(t)
+	cached := filter.NewFilter[TestUser](filter.GolangFilteringConfig{CompileCacheSize: 1})
+	plain := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	for round := range 3 {
+		for _, minAge := range []int{30, 50, 30} {
+			root := compileCacheRoot(minAge)
+			for _, page := range []int{0, 2} {
+				got, err := cached.DataQuery(users, root, page, 20)
+				if err != nil {
+					t.Fatalf("DataQuery failed: %v", err)
+				}
+				expected, err := plain.DataQuery(users, root, page, 20)
+				if err != nil {
+					t.Fatalf("DataQuery failed: %v", err)
+				}
+				if got.TotalSize != expected.TotalSize || len(got.Data) != len(expected.Data) {
+					t.Fatalf("Round %d, age %d, page %d: expected %d of %d records, got %d of %d",
+						round, minAge, page, len(expected.Data), expected.TotalSize, len(got.Data), got.TotalSize)
+				}
+				for i := range expected.Data {
+					if got.Data[i] != expected.Data[i] {
+						t.Fatalf("Round %d, age %d, page %d: expected the same record at %d", round, minAge, page, i)
+					}
+				}
+			}
+
+			got, err := cached.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			expected, err := plain.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got.TotalSize != expected.TotalSize || len(got.Data) != len(expected.Data) {
+				t.Fatalf("Round %d, age %d: expected %d of %d rows, got %d of %d",
+					round, minAge, len(expected.Data), expected.TotalSize, len(got.Data), got.TotalSize)
+			}
+			for i := range expected.Data {
+				if got.Data[i].ID != expected.Data[i].ID {
+					t.Fatalf("Round %d, age %d: expected row %d to be user %d, got %d", round, minAge, i, expected.Data[i].ID, got.Data[i].ID)
+				}
+			}
+		}
+	}
+}
+
+// TestCompileCache_RelativeDatesFollowTheClock tests that relative date values are resolved on every
+// query instead of being cached with the time of the first one
+func TestCompileCache_RelativeDatesFollowTheClock(t *testing.T) {
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	now := day
+	db, events := setupRelativeEvents(t, []time.Time{day, day.AddDate(0, 0, 1)})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{
+		CompileCacheSize: 8,
+		Now:              func() time.Time { return now },
+	})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "created_at", Value: "today", Mode: filter.ModeEqual, DataType: filter.DataTypeDate}},
+	}
+
+	for _, expected := range []uint{1, 2} {
+		result, err := handler.DataQueryNoPage(events, root)
+		if err != nil {
+			t.Fatalf("DataQueryNoPage failed: %v", err)
+		}
+		if ids := eventIDs(result); len(ids) != 1 || ids[0] != expected {
+			t.Errorf("Expected in-memory event %d for %s, got %v", expected, now.Format(time.DateOnly), ids)
+		}
+		rows, err := handler.DataGormNoPage(db, root)
+		if err != nil {
+			t.Fatalf("DataGormNoPage failed: %v", err)
+		}
+		if ids := eventIDs(rows); len(ids) != 1 || ids[0] != expected {
+			t.Errorf("Expected GORM event %d for %s, got %v", expected, now.Format(time.DateOnly), ids)
+		}
+		now = now.AddDate(0, 0, 1)
+	}
+}
+
+// TestCompileCache_ErrorsAreNotCached tests that an invalid Root fails on every query
+func TestCompileCache_ErrorsAreNotCached(t *testing.T) {
+	users := generateBenchmarkUsers(10)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{CompileCacheSize: 8, StrictValidation: true})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "age", Value: "old", Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}},
+	}
+	for range 2 {
+		if _, err := handler.DataQuery(users, root, 0, 10); err == nil {
+			t.Fatal("Expected an error for an invalid number")
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ComputedPerson has a full name and age bucket that only exist as computed fields
+type ComputedPerson struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Age       int    `json:"age"`
+}
+
+func generateComputedPeople() []*ComputedPerson {
+	return []*ComputedPerson{
+		{ID: 1, FirstName: "Ada", LastName: "Lovelace", Age: 36},
+		{ID: 2, FirstName: "Alan", LastName: "Turing", Age: 41},
+		{ID: 3, FirstName: "Grace", LastName: "Hopper", Age: 85},
+		{ID: 4, FirstName: "Ada", LastName: "Yonath", Age: 19},
+	}
+}
+
+func computedPersonIDs(people []*ComputedPerson) []uint {
+	ids := make([]uint, len(people))
+	for i, person := range people {
+		ids[i] = person.ID
+	}
+	return ids
+}
+
+func newComputedPersonFilter() *filter.Handler[ComputedPerson] {
+	return filter.NewFilter[ComputedPerson](filter.GolangFilteringConfig{StrictValidation: true}).
+		RegisterField("full_name", func(p *ComputedPerson) any { return p.FirstName + " " + p.LastName }, filter.DataTypeText).
+		RegisterFieldSQL("full_name", "first_name || ' ' || last_name").
+		RegisterField("age_bucket", func(p *ComputedPerson) any { return p.Age / 10 * 10 }, filter.DataTypeNumber)
+}
+
+// TestComputedField_AllPaths tests that a computed field with an SQL expression filters and sorts
+// identically in DataQuery and DataGorm
+func TestComputedField_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ComputedPerson{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateComputedPeople()).Error; err != nil {
+		t.Fatalf("Failed to create people: %v", err)
+	}
+	handler := newComputedPersonFilter()
+	people := generateComputedPeople()
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"Equal", filter.Root{
+			Logic:        filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{{Field: "full_name", Value: "ada lovelace", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		}, []uint{1}},
+		{"ContainsAcrossColumns", filter.Root{
+			Logic:        filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{{Field: "full_name", Value: "n t", Mode: filter.ModeContains, DataType: filter.DataTypeText}},
+		}, []uint{2}},
+		{"SortDesc", filter.Root{
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "full_name", Order: filter.SortOrderDesc}},
+		}, []uint{3, 2, 4, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataQuery(people, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := computedPersonIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := computedPersonIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestComputedField_WithoutSQL tests that a computed field without an SQL expression filters in memory,
+// and that the GORM path rejects it in strict mode and skips it otherwise
+func TestComputedField_WithoutSQL(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ComputedPerson{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateComputedPeople()).Error; err != nil {
+		t.Fatalf("Failed to create people: %v", err)
+	}
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "age_bucket", Value: 30, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber}},
+		SortFields:   []filter.SortField{{Field: "age_bucket", Order: filter.SortOrderDesc}},
+	}
+
+	handler := newComputedPersonFilter()
+	result, err := handler.DataQuery(generateComputedPeople(), root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := computedPersonIDs(result.Data); !equalIDs(got, []uint{1}) {
+		t.Errorf("Expected DataQuery IDs [1], got %v", got)
+	}
+
+	if _, err := handler.DataGorm(db, root, 0, 100); err == nil || !strings.Contains(err.Error(), "age_bucket") {
+		t.Errorf("Expected strict DataGorm to reject a computed field without SQL, got %v", err)
+	}
+
+	lenient := filter.NewFilter[ComputedPerson](filter.GolangFilteringConfig{}).
+		RegisterField("age_bucket", func(p *ComputedPerson) any { return p.Age / 10 * 10 }, filter.DataTypeNumber)
+	page, err := lenient.DataGorm(db, root, 0, 100)
+	if err != nil {
+		t.Fatalf("Expected lenient DataGorm to skip the computed field, got %v", err)
+	}
+	if page.TotalSize != 4 {
+		t.Errorf("Expected the computed filter to be skipped (4 records), got %d", page.TotalSize)
+	}
+}
+
+// TestComputedField_CSV tests that computed fields are exported as CSV columns on both paths
+func TestComputedField_CSV(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ComputedPerson{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateComputedPeople()).Error; err != nil {
+		t.Fatalf("Failed to create people: %v", err)
+	}
+	handler := newComputedPersonFilter()
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "full_name", Value: "hopper", Mode: filter.ModeEndsWith, DataType: filter.DataTypeText}},
+	}
+	opts := filter.DefaultCSVOptions()
+	opts.Columns = []string{"id", "full_name", "age_bucket"}
+
+	memoryCSV, err := handler.DataQueryNoPageCSVWithOptions(generateComputedPeople(), root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	gormCSV, err := handler.GormNoPaginationCSVWithOptions(db, root, opts)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+
+	expected := [][]string{{"id", "full_name", "age_bucket"}, {"3", "Grace Hopper", "80"}}
+	for name, data := range map[string][]byte{"memory": memoryCSV, "gorm": gormCSV} {
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read %s CSV: %v", name, err)
+		}
+		if len(records) != len(expected) {
+			t.Fatalf("Expected %d %s CSV rows, got %v", len(expected), name, records)
+		}
+		for i, record := range records {
+			if strings.Join(record, ",") != strings.Join(expected[i], ",") {
+				t.Errorf("Expected %s CSV row %v, got %v", name, expected[i], record)
+			}
+		}
+	}
+}
+
+// TestComputedField_Registration tests the Getter accessor, Fields and the panics on conflicting names
+func TestComputedField_Registration(t *testing.T) {
+	handler := newComputedPersonFilter()
+	get, ok := handler.Getter("full_name")
+	if !ok || get(&ComputedPerson{FirstName: "Ada", LastName: "Lovelace"}) != "Ada Lovelace" {
+		t.Errorf("Expected Getter to read the computed field")
+	}
+	if _, ok := handler.Getter("missing"); ok {
+		t.Error("Expected Getter to report an unknown field")
+	}
+
+	found := false
+	for _, field := range handler.Fields() {
+		if field.Path == "age_bucket" {
+			found = field.Computed && field.DataType == filter.DataTypeNumber
+		}
+	}
+	if !found {
+		t.Error("Expected Fields to describe the computed age_bucket field")
+	}
+
+	assertPanics := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected %s to panic", name)
+			}
+		}()
+		fn()
+	}
+	assertPanics("RegisterField on an existing field", func() {
+		newComputedPersonFilter().RegisterField("first_name", func(p *ComputedPerson) any { return "" }, filter.DataTypeText)
+	})
+	assertPanics("RegisterFieldSQL on a reflected field", func() {
+		newComputedPersonFilter().RegisterFieldSQL("age", "age * 2")
+	})
+}
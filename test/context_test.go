@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// ContextRow is a small model used for large in-memory cancellation tests
+type ContextRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestDataQueryCtx_Cancelled tests that cancelling mid-filter on a large slice stops the workers. The
+// context is cancelled by the getter of the filtered field on its first call, so the query is always
+// cancelled while it runs.
+func TestDataQueryCtx_Cancelled(t *testing.T) {
+	data := make([]*ContextRow, 1_000_000)
+	for i := range data {
+		data[i] = &ContextRow{ID: i, Name: fmt.Sprintf("row-%d", i)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int64
+	handler := filter.NewFilter[ContextRow](filter.GolangFilteringConfig{MaxWorkers: 4}).
+		RegisterField("cancelling_name", func(row *ContextRow) any {
+			if calls.Add(1) == 1 {
+				cancel()
+			}
+			return row.Name
+		}, filter.DataTypeText)
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "cancelling_name", Value: "999", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+	}
+
+	if _, err := handler.DataQueryCtx(ctx, data, filterRoot, 0, 10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if n := calls.Load(); n >= int64(len(data)) {
+		t.Errorf("Expected the workers to stop before reading every row, read %d", n)
+	}
+}
+
+// TestDataQueryCtx_AlreadyCancelled tests that an already-cancelled context returns ctx.Err()
+func TestDataQueryCtx_AlreadyCancelled(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+	if _, err := handler.DataQueryCtx(ctx, generateTestUsers(), filterRoot, 0, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("DataQueryCtx: expected context.Canceled, got %v", err)
+	}
+	if _, err := handler.DataQueryNoPageCtx(ctx, generateTestUsers(), filterRoot); !errors.Is(err, context.Canceled) {
+		t.Errorf("DataQueryNoPageCtx: expected context.Canceled, got %v", err)
+	}
+}
+
+// TestDataGormCtx_Cancelled tests that the context is passed through to GORM
+func TestDataGormCtx_Cancelled(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := handler.DataGormCtx(ctx, db, filterRoot, 0, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("DataGormCtx: expected context.Canceled, got %v", err)
+	}
+	if _, err := handler.HybridCtx(ctx, db, 10000, filterRoot, 0, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("HybridCtx: expected context.Canceled, got %v", err)
+	}
+
+	// A live context behaves like the non-context variant
+	result, err := handler.DataGormCtx(context.Background(), db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGormCtx failed: %v", err)
+	}
+	if result.TotalSize != 10 {
+		t.Errorf("Expected 10 records, got %d", result.TotalSize)
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestCountGorm_MatchesDataGormTotalSize tests that CountGorm always equals DataGorm's TotalSize
+func TestCountGorm_MatchesDataGormTotalSize(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name       string
+		filterRoot filter.Root
+		expected   int
+	}{
+		{"NoFilters", filter.Root{Logic: filter.LogicAnd}, 10},
+		{"RoleEqual", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, 3},
+		{"OrLogicWithSort", filter.Root{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "moderator", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+		}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, tt.filterRoot, 0, 1)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			count, err := handler.CountGorm(db, tt.filterRoot)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			queryCount, err := handler.CountQuery(users, tt.filterRoot)
+			if err != nil {
+				t.Fatalf("CountQuery failed: %v", err)
+			}
+
+			if int(count) != page.TotalSize || count != int64(tt.expected) {
+				t.Errorf("Expected CountGorm=%d (DataGorm TotalSize=%d), got %d", tt.expected, page.TotalSize, count)
+			}
+			if queryCount != tt.expected {
+				t.Errorf("Expected CountQuery=%d, got %d", tt.expected, queryCount)
+			}
+		})
+	}
+}
+
+// TestCountGorm_NestedFieldJoins tests that nested-field filters still auto-join for counting
+func TestCountGorm_NestedFieldJoins(t *testing.T) {
+	db := setupNestedRelationsDB(t)
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{})
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "currency.currency_code", Value: "PHP", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	page, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	count, err := handler.CountGorm(db, filterRoot)
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	if count != 2 || int(count) != page.TotalSize {
+		t.Errorf("Expected 2 records (DataGorm TotalSize=%d), got %d", page.TotalSize, count)
+	}
+}
+
+// TestCountGorm_PresetConditions tests that existing WHERE conditions on db are preserved
+func TestCountGorm_PresetConditions(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	count, err := handler.CountGorm(db.Where("is_active = ?", true), filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+
+	var expected int64
+	db.Model(&TestUser{}).Where("is_active = ?", true).Count(&expected)
+	if count != expected {
+		t.Errorf("Expected %d active users, got %d", expected, count)
+	}
+}
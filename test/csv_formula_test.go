@@ -0,0 +1,106 @@
+package test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// FormulaContact has user-supplied text a spreadsheet could run as a formula
+type FormulaContact struct {
+	ID      uint    `gorm:"primaryKey" json:"id"`
+	Name    string  `json:"name"`
+	Balance float64 `json:"balance"`
+}
+
+func generateFormulaContacts() []*FormulaContact {
+	return []*FormulaContact{
+		{ID: 1, Name: `=HYPERLINK("http://evil.example","Click")`, Balance: -5},
+		{ID: 2, Name: "+1 555 0100", Balance: 10},
+		{ID: 3, Name: "@SUM(A1:A2)", Balance: 0},
+		{ID: 4, Name: "Plain Name", Balance: 2.5},
+	}
+}
+
+// TestCSVEscapeFormulas tests that every CSV export neutralizes formula cells by default
+// and that numbers, including negative ones, are left alone
+func TestCSVEscapeFormulas(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&FormulaContact{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateFormulaContacts()).Error; err != nil {
+		t.Fatalf("Failed to create contacts: %v", err)
+	}
+	handler := filter.NewFilter[FormulaContact](filter.GolangFilteringConfig{})
+	root := filter.Root{SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}}
+	custom := func(contact *FormulaContact) map[string]any {
+		return map[string]any{"balance": contact.Balance, "id": contact.ID, "name": contact.Name}
+	}
+
+	exports := map[string]func() ([]byte, error){
+		"GormNoPaginationCSV": func() ([]byte, error) { return handler.GormNoPaginationCSV(db, root) },
+		"DataQueryNoPageCSV": func() ([]byte, error) {
+			return handler.DataQueryNoPageCSV(generateFormulaContacts(), root)
+		},
+		"GormNoPaginationCSVCustom": func() ([]byte, error) {
+			return handler.GormNoPaginationCSVCustom(db, root, custom)
+		},
+		"DataQueryNoPageCSVCustom": func() ([]byte, error) {
+			return handler.DataQueryNoPageCSVCustom(generateFormulaContacts(), root, custom)
+		},
+	}
+	expected := [][]string{
+		{"balance", "id", "name"},
+		{"-5", "1", `'=HYPERLINK("http://evil.example","Click")`},
+		{"10", "2", "'+1 555 0100"},
+		{"0", "3", "'@SUM(A1:A2)"},
+		{"2.5", "4", "Plain Name"},
+	}
+
+	for name, export := range exports {
+		t.Run(name, func(t *testing.T) {
+			csvData, err := export()
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			records, err := csv.NewReader(strings.NewReader(string(csvData))).ReadAll()
+			if err != nil {
+				t.Fatalf("Failed to parse CSV: %v", err)
+			}
+			if len(records) != len(expected) {
+				t.Fatalf("Expected %d records, got %d: %q", len(expected), len(records), csvData)
+			}
+			for i := range expected {
+				if strings.Join(records[i], "|") != strings.Join(expected[i], "|") {
+					t.Errorf("Record %d: expected %q, got %q", i, expected[i], records[i])
+				}
+			}
+		})
+	}
+}
+
+// TestCSVEscapeFormulasDisabled tests that cells are written as is without EscapeFormulas
+func TestCSVEscapeFormulasDisabled(t *testing.T) {
+	handler := filter.NewFilter[FormulaContact](filter.GolangFilteringConfig{})
+	root := filter.Root{SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}}
+	opts := filter.DefaultCSVOptions()
+	opts.EscapeFormulas = false
+	opts.Columns = []string{"name"}
+
+	csvData, err := handler.DataQueryNoPageCSVWithOptions(generateFormulaContacts()[:1], root, opts)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	expected := "name\n\"=HYPERLINK(\"\"http://evil.example\"\",\"\"Click\"\")\"\n"
+	if string(csvData) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(csvData))
+	}
+}
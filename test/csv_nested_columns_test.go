@@ -0,0 +1,120 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ExportWorkShift is the relation exported as nested CSV columns
+type ExportWorkShift struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// ExportAttendance belongs to an optional work shift
+type ExportAttendance struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	Employee    string           `json:"employee"`
+	WorkShiftID *uint            `json:"work_shift_id"`
+	WorkShift   *ExportWorkShift `gorm:"foreignKey:WorkShiftID" json:"work_shift"`
+}
+
+func generateExportAttendance() []*ExportAttendance {
+	shiftID := uint(1)
+	shift := &ExportWorkShift{ID: 1, Name: "Morning", StartTime: time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)}
+	return []*ExportAttendance{
+		{ID: 1, Employee: "Ana", WorkShiftID: &shiftID, WorkShift: shift},
+		{ID: 2, Employee: "Ben"},
+	}
+}
+
+// TestCSVNestedColumns tests that the default CSV exports write relations as their nested columns
+// rather than struct dumps, on both paths, preloading them on the GORM path and writing empty cells
+// under a nil relation
+func TestCSVNestedColumns(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ExportWorkShift{}, &ExportAttendance{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateExportAttendance()).Error; err != nil {
+		t.Fatalf("Failed to create attendance: %v", err)
+	}
+
+	maxDepth := 2
+	handler := filter.NewFilter[ExportAttendance](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	opts := filter.DefaultCSVOptions()
+
+	memoryCSV, err := handler.DataQueryNoPageCSVWithOptions(generateExportAttendance(), root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	gormCSV, err := handler.GormNoPaginationCSVWithOptions(db, root, opts)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+	var streamCSV bytes.Buffer
+	if err := handler.GormCSVStream(db, root, &streamCSV, opts); err != nil {
+		t.Fatalf("GormCSVStream failed: %v", err)
+	}
+
+	for name, data := range map[string][]byte{"memory": memoryCSV, "gorm": gormCSV, "stream": streamCSV.Bytes()} {
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read %s CSV: %v", name, err)
+		}
+		if len(records) != 3 {
+			t.Fatalf("Expected a header and 2 rows in %s CSV, got %v", name, records)
+		}
+		header := records[0]
+		row := func(record []string) map[string]string {
+			values := make(map[string]string, len(header))
+			for i, column := range header {
+				values[column] = record[i]
+			}
+			return values
+		}
+		for _, column := range header {
+			if column == "work_shift" || column == "workshift" {
+				t.Errorf("Expected the %s CSV to leave out the relation column %q", name, column)
+			}
+		}
+		ana, ben := row(records[1]), row(records[2])
+		if ana["work_shift.name"] != "Morning" || ana["work_shift.start_time"] != "2024-03-04T08:00:00Z" {
+			t.Errorf("Expected the %s CSV to export the nested shift columns, got %v", name, ana)
+		}
+		if ben["work_shift.name"] != "" || ben["work_shift.start_time"] != "" {
+			t.Errorf("Expected empty nested cells under a nil shift in the %s CSV, got %v", name, ben)
+		}
+		if strings.Contains(string(data), "&{") || strings.Contains(string(data), "0xc") {
+			t.Errorf("Expected no struct dumps or pointers in the %s CSV, got %s", name, data)
+		}
+	}
+
+	// An explicitly chosen relation column is written as JSON
+	opts.Columns = []string{"employee", "work_shift"}
+	chosen, err := handler.DataQueryNoPageCSVWithOptions(generateExportAttendance(), root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	expected := "employee,work_shift\n" +
+		`Ana,"{""id"":1,""name"":""Morning"",""start_time"":""2024-03-04T08:00:00Z""}"` + "\n" +
+		"Ben,<nil>\n"
+	if string(chosen) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(chosen))
+	}
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func TestCSVWithOptions(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+
+	exports := map[string]func(opts filter.CSVOptions) ([]byte, error){
+		"gorm": func(opts filter.CSVOptions) ([]byte, error) {
+			return handler.GormNoPaginationCSVWithOptions(db, root, opts)
+		},
+		"memory": func(opts filter.CSVOptions) ([]byte, error) {
+			return handler.DataQueryNoPageCSVWithOptions(users, root, opts)
+		},
+	}
+
+	for name, export := range exports {
+		t.Run(name+" columns and delimiter", func(t *testing.T) {
+			csvData, err := export(filter.CSVOptions{
+				Delimiter: ';',
+				Columns:   []string{"id", "name", "age"},
+			})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			expected := "id;name;age\n1;John Doe;25\n5;Charlie Wilson;42\n10;Grace Lee;31\n"
+			if string(csvData) != expected {
+				t.Errorf("Expected %q, got %q", expected, string(csvData))
+			}
+		})
+
+		t.Run(name+" without headers", func(t *testing.T) {
+			csvData, err := export(filter.CSVOptions{OmitHeaders: true, Columns: []string{"email"}})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			expected := "john@example.com\ncharlie@example.com\ngrace@example.com\n"
+			if string(csvData) != expected {
+				t.Errorf("Expected %q, got %q", expected, string(csvData))
+			}
+		})
+
+		t.Run(name+" zero options write headers", func(t *testing.T) {
+			csvData, err := export(filter.CSVOptions{Columns: []string{"id"}})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if expected := "id\n1\n5\n10\n"; string(csvData) != expected {
+				t.Errorf("Expected %q, got %q", expected, string(csvData))
+			}
+		})
+
+		t.Run(name+" unknown column", func(t *testing.T) {
+			_, err := export(filter.CSVOptions{Columns: []string{"id", "salary"}})
+			if err == nil || !strings.Contains(err.Error(), `"salary"`) {
+				t.Errorf("Expected unknown column error naming salary, got %v", err)
+			}
+		})
+
+		t.Run(name+" defaults match", func(t *testing.T) {
+			csvData, err := export(filter.DefaultCSVOptions())
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			var expected []byte
+			if name == "gorm" {
+				expected, err = handler.GormNoPaginationCSV(db, root)
+			} else {
+				expected, err = handler.DataQueryNoPageCSV(users, root)
+			}
+			if err != nil {
+				t.Fatalf("Export without options failed: %v", err)
+			}
+			if string(csvData) != string(expected) {
+				t.Errorf("Expected default options to match:\n%s\ngot:\n%s", expected, csvData)
+			}
+		})
+	}
+}
+
+func TestCSVCustomWithOptions(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	customGetter := func(user *TestUser) map[string]any {
+		return map[string]any{
+			"User ID":   user.ID,
+			"Full Name": user.Name,
+			"Role":      user.Role,
+		}
+	}
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "moderator", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	noMatch := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "nobody", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	exports := map[string]func(root filter.Root, opts filter.CSVOptions) ([]byte, error){
+		"gorm": func(root filter.Root, opts filter.CSVOptions) ([]byte, error) {
+			return handler.GormNoPaginationCSVCustomWithOptions(db, root, customGetter, opts)
+		},
+		"memory": func(root filter.Root, opts filter.CSVOptions) ([]byte, error) {
+			return handler.DataQueryNoPageCSVCustomWithOptions(users, root, customGetter, opts)
+		},
+	}
+
+	for name, export := range exports {
+		t.Run(name+" columns", func(t *testing.T) {
+			csvData, err := export(root, filter.CSVOptions{
+				Delimiter: '\t',
+				Columns:   []string{"User ID", "Full Name"},
+			})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			expected := "User ID\tFull Name\n4\tAlice Brown\n8\tEve Adams\n"
+			if string(csvData) != expected {
+				t.Errorf("Expected %q, got %q", expected, string(csvData))
+			}
+		})
+
+		t.Run(name+" unknown column", func(t *testing.T) {
+			if _, err := export(root, filter.CSVOptions{Columns: []string{"Salary"}}); err == nil {
+				t.Error("Expected unknown column error")
+			}
+		})
+
+		t.Run(name+" no data with columns writes headers", func(t *testing.T) {
+			csvData, err := export(noMatch, filter.CSVOptions{Columns: []string{"User ID", "Role"}})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if string(csvData) != "User ID,Role\n" {
+				t.Errorf("Expected header only, got %q", string(csvData))
+			}
+		})
+
+		t.Run(name+" no data without columns is empty", func(t *testing.T) {
+			csvData, err := export(noMatch, filter.DefaultCSVOptions())
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if len(csvData) != 0 {
+				t.Errorf("Expected empty output, got %q", string(csvData))
+			}
+		})
+	}
+}
+
+func TestCSVWithOptionsNullAs(t *testing.T) {
+	db := setupNilParentDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		Preload:    []string{"Department"},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	opts := filter.CSVOptions{
+		Columns: []string{"name", "department_id", "department.name"},
+		NullAs:  "N/A",
+	}
+	expected := "name,department_id,department.name\nAlice,1,Engineering\nBob,N/A,\nCarol,N/A,\n"
+
+	csvData, err := handler.GormNoPaginationCSVWithOptions(db, root, opts)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+	if string(csvData) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(csvData))
+	}
+
+	csvData, err = handler.DataQueryNoPageCSVWithOptions(generateNilParentStaff(), root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	if string(csvData) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(csvData))
+	}
+}
+
+func TestCSVStreamWithOptions(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	opts := filter.CSVOptions{OmitHeaders: true, Delimiter: '|', Columns: []string{"id", "role"}, BatchSize: 4}
+	var buf strings.Builder
+	if err := handler.DataQueryCSVStream(users, filter.Root{Logic: filter.LogicAnd}, &buf, opts); err != nil {
+		t.Fatalf("DataQueryCSVStream failed: %v", err)
+	}
+	expected, err := handler.DataQueryNoPageCSVWithOptions(users, filter.Root{Logic: filter.LogicAnd}, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	if buf.String() != string(expected) {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "1|admin\n2|user\n") {
+		t.Errorf("Unexpected output %q", buf.String())
+	}
+}
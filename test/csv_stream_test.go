@@ -0,0 +1,215 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// failingWriter accepts limit bytes and then fails every write
+type failingWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+var errWriterFull = errors.New("writer full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errWriterFull
+	}
+	return w.buf.Write(p)
+}
+
+// streamOptions returns the default CSV options with the given batch size
+func streamOptions(batchSize int) filter.CSVOptions {
+	opts := filter.DefaultCSVOptions()
+	opts.BatchSize = batchSize
+	return opts
+}
+
+func TestGormCSVStream(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	roots := map[string]filter.Root{
+		"no filters": {Logic: filter.LogicAnd},
+		"filtered": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+		},
+		"sorted": {
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+		},
+		"sorted with ties": {
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "role", Order: filter.SortOrderAsc}},
+		},
+	}
+
+	for name, root := range roots {
+		for _, batchSize := range []int{0, 1, 3, 10, 100} {
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := handler.GormCSVStream(db, root, &buf, streamOptions(batchSize)); err != nil {
+					t.Fatalf("GormCSVStream failed: %v", err)
+				}
+
+				if root.SortFields != nil && root.SortFields[0].Field == "role" {
+					// Ties are unordered in GormNoPaginationCSV; the stream breaks them by id
+					records, err := csv.NewReader(&buf).ReadAll()
+					if err != nil {
+						t.Fatalf("Failed to parse CSV: %v", err)
+					}
+					idColumn := slices.Index(records[0], "id")
+					var ids []string
+					for _, record := range records[1:] {
+						ids = append(ids, record[idColumn])
+					}
+					expected := []string{"1", "5", "10", "4", "8", "2", "3", "6", "7", "9"}
+					if !slices.Equal(ids, expected) {
+						t.Errorf("Expected ids %v, got %v", expected, ids)
+					}
+					return
+				}
+
+				expected, err := handler.GormNoPaginationCSV(db, root)
+				if err != nil {
+					t.Fatalf("GormNoPaginationCSV failed: %v", err)
+				}
+				if buf.String() != string(expected) {
+					t.Errorf("Stream output differs (batch size %d):\n%s\nexpected:\n%s", batchSize, buf.String(), expected)
+				}
+			})
+		}
+	}
+}
+
+func TestGormCSVStreamEmpty(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "nobody", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := handler.GormCSVStream(db, root, &buf, filter.DefaultCSVOptions()); err != nil {
+		t.Fatalf("GormCSVStream failed: %v", err)
+	}
+	expected, err := handler.GormNoPaginationCSV(db, root)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSV failed: %v", err)
+	}
+	if buf.String() != string(expected) {
+		t.Errorf("Expected header-only output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestGormCSVStreamWriterError(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	full, err := handler.GormNoPaginationCSV(db, filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSV failed: %v", err)
+	}
+	lines := strings.SplitAfter(string(full), "\n")
+	// Room for the header and the first batch of two rows only
+	prefix := strings.Join(lines[:3], "")
+
+	w := &failingWriter{limit: len(prefix)}
+	err = handler.GormCSVStream(db, filter.Root{Logic: filter.LogicAnd}, w, streamOptions(2))
+	if !errors.Is(err, errWriterFull) {
+		t.Fatalf("Expected writer error, got %v", err)
+	}
+	if w.buf.String() != prefix {
+		t.Errorf("Expected flushed prefix %q, got %q", prefix, w.buf.String())
+	}
+}
+
+func TestGormCSVStreamStrictError(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := handler.GormCSVStream(db, root, &buf, filter.DefaultCSVOptions()); err == nil {
+		t.Fatal("Expected error for invalid filter value")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", buf.String())
+	}
+}
+
+func TestDataQueryCSVStream(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	roots := map[string]filter.Root{
+		"no filters": {Logic: filter.LogicAnd},
+		"filtered and sorted": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "user", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+		},
+	}
+
+	for name, root := range roots {
+		t.Run(name, func(t *testing.T) {
+			expected, err := handler.DataQueryNoPageCSV(users, root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+			}
+			for _, batchSize := range []int{0, 1, 4, 10} {
+				var buf bytes.Buffer
+				if err := handler.DataQueryCSVStream(users, root, &buf, streamOptions(batchSize)); err != nil {
+					t.Fatalf("DataQueryCSVStream failed: %v", err)
+				}
+				if buf.String() != string(expected) {
+					t.Errorf("Stream output differs (batch size %d):\n%s\nexpected:\n%s", batchSize, buf.String(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestDataQueryCSVStreamWriterError(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	full, err := handler.DataQueryNoPageCSV(users, filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+	}
+	lines := strings.SplitAfter(string(full), "\n")
+	prefix := strings.Join(lines[:4], "")
+
+	w := &failingWriter{limit: len(prefix)}
+	err = handler.DataQueryCSVStream(users, filter.Root{Logic: filter.LogicAnd}, w, streamOptions(3))
+	if !errors.Is(err, errWriterFull) {
+		t.Fatalf("Expected writer error, got %v", err)
+	}
+	if w.buf.String() != prefix {
+		t.Errorf("Expected flushed prefix %q, got %q", prefix, w.buf.String())
+	}
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// CSVEvent has a required and a nullable timestamp
+type CSVEvent struct {
+	ID       uint       `gorm:"primaryKey" json:"id"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// StampTime embeds time.Time, CivilDay converts to it
+type StampTime struct{ time.Time }
+type CivilDay time.Time
+
+func generateCSVEvents() []*CSVEvent {
+	start := time.Date(2025, 11, 3, 14, 30, 45, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	return []*CSVEvent{
+		{ID: 1, StartsAt: start, EndsAt: &end},
+		{ID: 2, StartsAt: time.Time{}, EndsAt: nil},
+	}
+}
+
+// TestCSVTimeFormat tests that times are exported as RFC 3339 by default, with the configured
+// format and location, and zero times as empty cells, in GORM and in memory
+func TestCSVTimeFormat(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&CSVEvent{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	events := generateCSVEvents()
+	if err := db.Create(events).Error; err != nil {
+		t.Fatalf("Failed to create events: %v", err)
+	}
+	handler := filter.NewFilter[CSVEvent](filter.GolangFilteringConfig{})
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	defaults := filter.DefaultCSVOptions()
+	defaults.Columns = []string{"ends_at", "id", "starts_at"}
+	manila := time.FixedZone("PHT", 8*60*60)
+	tests := []struct {
+		name     string
+		opts     filter.CSVOptions
+		expected string
+	}{
+		{
+			name: "Default",
+			opts: defaults,
+			expected: "ends_at,id,starts_at\n" +
+				"2025-11-03T16:00:45Z,1,2025-11-03T14:30:45Z\n" +
+				"<nil>,2,\n",
+		},
+		{
+			name:     "EmptyFormat",
+			opts:     filter.CSVOptions{OmitHeaders: true, Columns: []string{"starts_at"}},
+			expected: "2025-11-03T14:30:45Z\n\n",
+		},
+		{
+			name: "FormatAndLocation",
+			opts: filter.CSVOptions{
+				OmitHeaders: true,
+				Columns:     []string{"id", "starts_at", "ends_at"},
+				NullAs:      "-",
+				TimeFormat:  "2006-01-02 15:04",
+				Location:    manila,
+			},
+			expected: "1,2025-11-03 22:30,2025-11-04 00:00\n2,,-\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory, err := handler.DataQueryNoPageCSVWithOptions(events, root, tt.opts)
+			if err != nil {
+				t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+			}
+			if string(memory) != tt.expected {
+				t.Errorf("Expected in-memory CSV %q, got %q", tt.expected, string(memory))
+			}
+
+			gormData, err := handler.GormNoPaginationCSVWithOptions(db, root, tt.opts)
+			if err != nil {
+				t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+			}
+			if string(gormData) != tt.expected {
+				t.Errorf("Expected GORM CSV %q, got %q", tt.expected, string(gormData))
+			}
+		})
+	}
+
+	defaultCSV, err := handler.GormNoPaginationCSV(db, root)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSV failed: %v", err)
+	}
+	expected := "ends_at,endsat,id,starts_at,startsat\n" +
+		"2025-11-03T16:00:45Z,2025-11-03T16:00:45Z,1,2025-11-03T14:30:45Z,2025-11-03T14:30:45Z\n" +
+		"<nil>,<nil>,2,,\n"
+	if string(defaultCSV) != expected {
+		t.Errorf("Expected GormNoPaginationCSV %q, got %q", expected, string(defaultCSV))
+	}
+}
+
+// TestCSVTimeFormat_Custom tests that the Custom variants format the times returned by the mapper,
+// including types embedding or converting to time.Time and sql.NullTime
+func TestCSVTimeFormat_Custom(t *testing.T) {
+	handler := filter.NewFilter[CSVEvent](filter.GolangFilteringConfig{})
+	events := generateCSVEvents()
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	mapper := func(event *CSVEvent) map[string]any {
+		return map[string]any{
+			"a_stamp":    StampTime{event.StartsAt},
+			"b_day":      CivilDay(event.StartsAt),
+			"c_null":     sql.NullTime{Time: event.StartsAt, Valid: event.EndsAt != nil},
+			"d_end":      event.EndsAt,
+			"e_start_ts": &event.StartsAt,
+		}
+	}
+
+	csvData, err := handler.DataQueryNoPageCSVCustomWithOptions(events, root, mapper, filter.CSVOptions{
+		OmitHeaders: true,
+		TimeFormat:  time.DateOnly,
+		NullAs:      "null",
+	})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVCustomWithOptions failed: %v", err)
+	}
+	expected := "2025-11-03,2025-11-03,2025-11-03,2025-11-03,2025-11-03\n" +
+		",,null,null,\n"
+	if string(csvData) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(csvData))
+	}
+
+	defaultCSV, err := handler.DataQueryNoPageCSVCustom(events[:1], root, mapper)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVCustom failed: %v", err)
+	}
+	expected = "a_stamp,b_day,c_null,d_end,e_start_ts\n" +
+		"2025-11-03T14:30:45Z,2025-11-03T14:30:45Z,2025-11-03T14:30:45Z,2025-11-03T16:00:45Z,2025-11-03T14:30:45Z\n"
+	if string(defaultCSV) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(defaultCSV))
+	}
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// collectCursorPages walks every page using the provided fetch function and returns the IDs in order
+func collectCursorPages(t *testing.T, fetch func(cursor string) (*filter.PaginationCursorResult[TestUser], error)) []uint {
+	t.Helper()
+	var ids []uint
+	cursor := ""
+	for page := 0; page < 20; page++ {
+		result, err := fetch(cursor)
+		if err != nil {
+			t.Fatalf("Cursor page %d failed: %v", page, err)
+		}
+		for _, user := range result.Data {
+			ids = append(ids, user.ID)
+		}
+		if !result.HasMore {
+			if result.NextCursor != "" {
+				t.Errorf("Expected empty NextCursor on last page, got %q", result.NextCursor)
+			}
+			return ids
+		}
+		cursor = result.NextCursor
+	}
+	t.Fatal("Cursor pagination did not terminate")
+	return nil
+}
+
+// TestCursorPagination_MatchesOffsetOrder tests that walking all cursor pages yields the same order as a full sort
+func TestCursorPagination_MatchesOffsetOrder(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name       string
+		sortFields []filter.SortField
+	}{
+		{"DefaultIDOrder", nil},
+		{"AgeDesc", []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}}},
+		{"RoleAscCreatedDesc", []filter.SortField{
+			{Field: "role", Order: filter.SortOrderAsc},
+			{Field: "created_at", Order: filter.SortOrderDesc},
+		}},
+		{"IsActiveDescName", []filter.SortField{
+			{Field: "is_active", Order: filter.SortOrderDesc},
+			{Field: "name", Order: filter.SortOrderAsc},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterRoot := filter.Root{
+				Logic:      filter.LogicAnd,
+				SortFields: tt.sortFields,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "age", Value: 26, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+				},
+			}
+
+			// Reference order: full sort with id as final tie-breaker
+			referenceRoot := filterRoot
+			referenceRoot.SortFields = append(append([]filter.SortField{}, tt.sortFields...), filter.SortField{Field: "id", Order: filter.SortOrderAsc})
+			reference, err := handler.DataGormNoPage(db, referenceRoot)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+
+			gormIDs := collectCursorPages(t, func(cursor string) (*filter.PaginationCursorResult[TestUser], error) {
+				return handler.DataGormCursor(db, filterRoot, cursor, 3)
+			})
+			queryIDs := collectCursorPages(t, func(cursor string) (*filter.PaginationCursorResult[TestUser], error) {
+				return handler.DataQueryCursor(users, filterRoot, cursor, 3)
+			})
+
+			if len(gormIDs) != len(reference) || len(queryIDs) != len(reference) {
+				t.Fatalf("Expected %d records, got DataGormCursor=%d DataQueryCursor=%d", len(reference), len(gormIDs), len(queryIDs))
+			}
+			for i, user := range reference {
+				if gormIDs[i] != user.ID {
+					t.Errorf("DataGormCursor position %d: expected ID %d, got %d", i, user.ID, gormIDs[i])
+				}
+				if queryIDs[i] != user.ID {
+					t.Errorf("DataQueryCursor position %d: expected ID %d, got %d", i, user.ID, queryIDs[i])
+				}
+			}
+		})
+	}
+}
+
+// TestCursorPagination_DeleteBetweenPages tests that rows removed before the cursor do not cause skipped records
+func TestCursorPagination_DeleteBetweenPages(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+
+	first, err := handler.DataGormCursor(db, filterRoot, "", 5)
+	if err != nil {
+		t.Fatalf("First page failed: %v", err)
+	}
+	if !first.HasMore || len(first.Data) != 5 {
+		t.Fatalf("Expected 5 records with more pages, got %d (hasMore=%v)", len(first.Data), first.HasMore)
+	}
+
+	// Offset pagination would now skip ID 6 because every later row shifts up by one
+	if err := db.Delete(&TestUser{}, 2).Error; err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	second, err := handler.DataGormCursor(db, filterRoot, first.NextCursor, 5)
+	if err != nil {
+		t.Fatalf("Second page failed: %v", err)
+	}
+	if len(second.Data) != 5 || second.Data[0].ID != 6 || second.HasMore {
+		t.Errorf("Expected IDs 6..10 on the last page, got %d records (hasMore=%v)", len(second.Data), second.HasMore)
+	}
+}
+
+// TestCursorPagination_InvalidCursor tests that malformed cursors return errors
+func TestCursorPagination_InvalidCursor(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+
+	if _, err := handler.DataGormCursor(db, filterRoot, "!!!", 5); err == nil {
+		t.Error("Expected error for malformed cursor, got nil")
+	}
+
+	// A cursor from a different sort configuration is rejected
+	page, err := handler.DataQueryCursor(generateTestUsers(), filterRoot, "", 2)
+	if err != nil {
+		t.Fatalf("DataQueryCursor failed: %v", err)
+	}
+	filterRoot.SortFields = []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}}
+	if _, err := handler.DataQueryCursor(generateTestUsers(), filterRoot, page.NextCursor, 2); err == nil {
+		t.Error("Expected error for cursor with mismatched sort fields, got nil")
+	}
+}
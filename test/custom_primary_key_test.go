@@ -0,0 +1,224 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// KeyedRegion is the region a KeyedAccount belongs to
+type KeyedRegion struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+// KeyedTag labels a KeyedAccount
+type KeyedTag struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	AccountCode string `json:"account_code"`
+	Label       string `json:"label"`
+}
+
+// KeyedAccount has a string primary key that is not named ID, a belongs-to and a has-many relation
+type KeyedAccount struct {
+	Code     string      `gorm:"primaryKey" json:"code"`
+	Name     string      `json:"name"`
+	Balance  float64     `json:"balance"`
+	RegionID uint        `json:"region_id"`
+	Region   KeyedRegion `json:"region"`
+	Tags     []KeyedTag  `gorm:"foreignKey:AccountCode" json:"tags"`
+}
+
+func setupKeyedAccountDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&KeyedRegion{}, &KeyedAccount{}, &KeyedTag{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	regions := []*KeyedRegion{{ID: 1, Name: "north"}, {ID: 2, Name: "south"}}
+	accounts := []*KeyedAccount{
+		{Code: "A", Name: "Acme", Balance: 100, RegionID: 2, Tags: []KeyedTag{{Label: "vip"}, {Label: "late"}}},
+		{Code: "B", Name: "Bolt", Balance: 50, RegionID: 1, Tags: []KeyedTag{{Label: "vip"}}},
+		{Code: "C", Name: "Cog", Balance: 25, RegionID: 1},
+	}
+	if err := db.Create(regions).Error; err != nil {
+		t.Fatalf("Failed to create regions: %v", err)
+	}
+	if err := db.Create(accounts).Error; err != nil {
+		t.Fatalf("Failed to create accounts: %v", err)
+	}
+	return db
+}
+
+// TestCustomPrimaryKey_ToManyJoins tests that grouping, distinct counts, aggregates and facets of queries
+// joining a to-many relation use the primary key GORM detects rather than an id column
+func TestCustomPrimaryKey_ToManyJoins(t *testing.T) {
+	db := setupKeyedAccountDB(t)
+	handler := filter.NewFilter[KeyedAccount](filter.GolangFilteringConfig{})
+	vip := filter.FieldFilter{Field: "tags.label", Value: "vip", Mode: filter.ModeEqual, DataType: filter.DataTypeText}
+
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{vip},
+		SortFields:   []filter.SortField{{Field: "tags.label", Order: filter.SortOrderAsc}, {Field: "region.name", Order: filter.SortOrderAsc}},
+		Aggregations: []filter.Aggregation{{Field: "balance", Func: filter.AggregateSum}},
+	}
+	result, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 2 || len(result.Data) != 2 || result.Data[0].Code != "A" || result.Data[1].Code != "B" {
+		t.Errorf("Expected accounts A and B, got %d records of %d", len(result.Data), result.TotalSize)
+	}
+	if sum := result.Aggregates["sum_balance"]; sum != 150 {
+		t.Errorf("Expected a balance sum of 150, got %v", sum)
+	}
+
+	count, err := handler.CountGorm(db, filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{vip}})
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected a count of 2, got %d", count)
+	}
+
+	facets, err := handler.FacetGorm(db, filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{vip}}, "region.name")
+	if err != nil {
+		t.Fatalf("FacetGorm failed: %v", err)
+	}
+	if facets["north"] != 1 || facets["south"] != 1 {
+		t.Errorf("Expected one account per region, got %v", facets)
+	}
+}
+
+// TestCustomPrimaryKey_GroupedBelongsToSort tests that a query grouped by the primary key also groups by
+// the key of a joined belongs-to relation, as PostgreSQL rejects its selected and sorted columns otherwise
+func TestCustomPrimaryKey_GroupedBelongsToSort(t *testing.T) {
+	postgres := mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}
+	handler := filter.NewFilter[KeyedAccount](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "tags.label", Order: filter.SortOrderAsc}, {Field: "region.name", Order: filter.SortOrderDesc}},
+	}
+	sql := captureDryRunSQL(t, postgres, func(db *gorm.DB) error {
+		_, err := handler.DataGorm(db, root, 0, 10)
+		return err
+	})
+	for _, expected := range []string{`GROUP BY "keyed_accounts"."code","Region"."id"`, `MIN("Tags"."label") ASC`, `"Region"."name" DESC`} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("Expected SQL to contain %s, got:\n%s", expected, sql)
+		}
+	}
+}
+
+// TestCustomPrimaryKey_Cursor tests that cursor pagination breaks sort ties on the primary key GORM detects,
+// in SQL and in memory, with or without the sort tiebreaker
+func TestCustomPrimaryKey_Cursor(t *testing.T) {
+	db := setupKeyedAccountDB(t)
+	accounts := []*KeyedAccount{
+		{Code: "C", Name: "Cog", Balance: 25, RegionID: 1},
+		{Code: "A", Name: "Acme", Balance: 100, RegionID: 2},
+		{Code: "B", Name: "Bolt", Balance: 50, RegionID: 1},
+	}
+	root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "region_id", Order: filter.SortOrderAsc}}}
+
+	for _, disable := range []bool{false, true} {
+		handler := filter.NewFilter[KeyedAccount](filter.GolangFilteringConfig{DisableSortTiebreaker: disable})
+		for name, fetch := range map[string]func(cursor string) (*filter.PaginationCursorResult[KeyedAccount], error){
+			"DataGormCursor": func(cursor string) (*filter.PaginationCursorResult[KeyedAccount], error) {
+				return handler.DataGormCursor(db, root, cursor, 1)
+			},
+			"DataQueryCursor": func(cursor string) (*filter.PaginationCursorResult[KeyedAccount], error) {
+				return handler.DataQueryCursor(accounts, root, cursor, 1)
+			},
+		} {
+			var codes []string
+			cursor := ""
+			for range 5 {
+				page, err := fetch(cursor)
+				if err != nil {
+					t.Fatalf("%s failed: %v", name, err)
+				}
+				for _, account := range page.Data {
+					codes = append(codes, account.Code)
+				}
+				if !page.HasMore {
+					break
+				}
+				cursor = page.NextCursor
+			}
+			if got := strings.Join(codes, ","); got != "B,C,A" {
+				t.Errorf("Expected %s (DisableSortTiebreaker %v) to page B,C,A, got %s", name, disable, got)
+			}
+		}
+	}
+}
+
+// TestCustomPrimaryKey_BuildSQL tests that the default ORDER BY of BuildSQL, without sort fields or with
+// DisableSortTiebreaker, is the primary key GORM detects, and that the query runs through database/sql
+func TestCustomPrimaryKey_BuildSQL(t *testing.T) {
+	db := setupKeyedAccountDB(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database/sql handle: %v", err)
+	}
+	for _, disable := range []bool{false, true} {
+		handler := filter.NewFilter[KeyedAccount](filter.GolangFilteringConfig{DisableSortTiebreaker: disable})
+		_, _, orderBy, err := handler.BuildSQL(filter.Root{Logic: filter.LogicAnd}, filter.SQLOptions{})
+		if err != nil {
+			t.Fatalf("BuildSQL failed: %v", err)
+		}
+		if orderBy != "code ASC" {
+			t.Errorf("Expected ORDER BY the code column (DisableSortTiebreaker %v), got %s", disable, orderBy)
+		}
+		rows, err := sqlDB.Query("SELECT code FROM keyed_accounts ORDER BY " + orderBy)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		var codes []string
+		for rows.Next() {
+			var code string
+			if err := rows.Scan(&code); err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+			codes = append(codes, code)
+		}
+		rows.Close()
+		if got := strings.Join(codes, ","); got != "A,B,C" {
+			t.Errorf("Expected codes A,B,C, got %s", got)
+		}
+	}
+}
+
+// TestCustomPrimaryKey_SelectFields tests that SelectFields always fetches the primary key GORM detects,
+// so the records stay identifiable and preloadable
+func TestCustomPrimaryKey_SelectFields(t *testing.T) {
+	db := setupKeyedAccountDB(t)
+	handler := filter.NewFilter[KeyedAccount](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		SortFields:   []filter.SortField{{Field: "name", Order: filter.SortOrderAsc}},
+		SelectFields: []string{"name"},
+		Preload:      []string{"Tags"},
+	}
+
+	result, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	var codes []string
+	for _, account := range result.Data {
+		codes = append(codes, account.Code)
+	}
+	if got := strings.Join(codes, ","); got != "A,B,C" {
+		t.Errorf("Expected codes A,B,C, got %s", got)
+	}
+	if len(result.Data) == 3 && (len(result.Data[0].Tags) != 2 || result.Data[0].Balance != 0) {
+		t.Errorf("Expected Acme with its 2 tags and no balance, got %+v", result.Data[0])
+	}
+}
@@ -0,0 +1,191 @@
+package test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Money is a fixed-point amount in cents that, like decimal.Decimal, is stored through driver.Valuer
+// as a decimal string and implements fmt.Stringer
+type Money struct {
+	cents int64
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100)
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(src any) error {
+	var amount float64
+	switch v := src.(type) {
+	case float64:
+		amount = v
+	case int64:
+		amount = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		amount = parsed
+	case []byte:
+		parsed, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return err
+		}
+		amount = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	m.cents = int64(math.Round(amount * 100))
+	return nil
+}
+
+func (Money) GormDataType() string {
+	return "decimal"
+}
+
+// TicketStatus is a string-kind enum
+type TicketStatus string
+
+// TicketPriority is an int-kind enum with names for display
+type TicketPriority int
+
+func (p TicketPriority) String() string {
+	return [...]string{"low", "normal", "high"}[p]
+}
+
+type CustomTicket struct {
+	ID       uint           `gorm:"primaryKey" json:"id"`
+	Status   TicketStatus   `json:"status"`
+	Priority TicketPriority `json:"priority"`
+	Price    Money          `json:"price"`
+}
+
+func generateCustomTickets() []*CustomTicket {
+	return []*CustomTicket{
+		{ID: 1, Status: "open", Priority: 2, Price: Money{cents: 1250}},
+		{ID: 2, Status: "closed", Priority: 0, Price: Money{cents: 999}},
+		{ID: 3, Status: "open", Priority: 1, Price: Money{cents: 30000}},
+		{ID: 4, Status: "pending", Priority: 2, Price: Money{cents: 1000}},
+	}
+}
+
+func customTicketIDs(tickets []*CustomTicket) []uint {
+	ids := make([]uint, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+	}
+	return ids
+}
+
+func setupCustomTicketsDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&CustomTicket{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateCustomTickets()).Error; err != nil {
+		t.Fatalf("Failed to create tickets: %v", err)
+	}
+	return db
+}
+
+// TestCustomTypes_Filters tests that named string and int types and a Valuer/Stringer decimal
+// filter in memory as they do in SQL
+func TestCustomTypes_Filters(t *testing.T) {
+	db := setupCustomTicketsDB(t)
+	handler := filter.NewFilter[CustomTicket](filter.GolangFilteringConfig{StrictValidation: true})
+	tickets := generateCustomTickets()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"EnumEqual", filter.FieldFilter{Field: "status", Value: "open", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{1, 3}},
+		{"EnumIn", filter.FieldFilter{Field: "status", Value: []string{"closed", "pending"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}, []uint{2, 4}},
+		{"EnumContains", filter.FieldFilter{Field: "status", Value: "EN", Mode: filter.ModeContains, DataType: filter.DataTypeText}, []uint{1, 3, 4}},
+		{"IntEnumGTE", filter.FieldFilter{Field: "priority", Value: 1, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}, []uint{1, 3, 4}},
+		{"IntEnumIn", filter.FieldFilter{Field: "priority", Value: []int{0, 2}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber}, []uint{1, 2, 4}},
+		{"DecimalGT", filter.FieldFilter{Field: "price", Value: 10, Mode: filter.ModeGT, DataType: filter.DataTypeNumber}, []uint{1, 3}},
+		{"DecimalEqual", filter.FieldFilter{Field: "price", Value: "12.5", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber}, []uint{1}},
+		{"DecimalRange", filter.FieldFilter{Field: "price", Value: filter.Range{From: 9.99, To: 12.5}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, []uint{1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			result, err := handler.DataQuery(tickets, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := customTicketIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := customTicketIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestCustomTypes_Sort tests that custom types sort by value in memory as they do in SQL:
+// the decimal numerically rather than by its text, the int enum by number rather than by name
+func TestCustomTypes_Sort(t *testing.T) {
+	db := setupCustomTicketsDB(t)
+	handler := filter.NewFilter[CustomTicket](filter.GolangFilteringConfig{})
+	tickets := generateCustomTickets()
+
+	tests := []struct {
+		field    string
+		order    filter.SortOrder
+		expected []uint
+	}{
+		{"price", filter.SortOrderAsc, []uint{2, 4, 1, 3}},
+		{"price", filter.SortOrderDesc, []uint{3, 1, 4, 2}},
+		{"priority", filter.SortOrderDesc, []uint{1, 4, 3, 2}},
+		{"status", filter.SortOrderAsc, []uint{2, 1, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"_"+string(tt.order), func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: tt.field, Order: tt.order}}}
+
+			result, err := handler.DataQuery(tickets, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := customTicketIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery order %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := customTicketIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm order %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// PrecisionEvent is stamped with sub-second precision, as timestamp columns usually are
+type PrecisionEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func generatePrecisionEvents() []*PrecisionEvent {
+	at := func(sec, nsec int) time.Time { return time.Date(2025, 11, 5, 14, 30, sec, nsec, time.UTC) }
+	return []*PrecisionEvent{
+		{ID: 1, OccurredAt: at(0, 0)},
+		{ID: 2, OccurredAt: at(0, 123000)},
+		{ID: 3, OccurredAt: at(0, 999000000)},
+		{ID: 4, OccurredAt: at(1, 0)},
+		{ID: 5, OccurredAt: at(2, 500000000)},
+	}
+}
+
+func precisionEventIDs(events []*PrecisionEvent) []uint {
+	ids := make([]uint, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+// TestDatePrecision_AllPaths tests that DatePrecision compares timestamps by whole second in DataQuery and DataGorm
+func TestDatePrecision_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&PrecisionEvent{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generatePrecisionEvents()).Error; err != nil {
+		t.Fatalf("Failed to create events: %v", err)
+	}
+	second := time.Date(2025, 11, 5, 14, 30, 0, 0, time.UTC)
+	next := second.Add(time.Second)
+
+	tests := []struct {
+		name      string
+		precision time.Duration
+		filter    filter.FieldFilter
+		expected  []uint
+	}{
+		{"Equal", time.Second, filter.FieldFilter{Field: "occurred_at", Value: second, Mode: filter.ModeEqual, DataType: filter.DataTypeDate}, []uint{1, 2, 3}},
+		{"EqualExact", 0, filter.FieldFilter{Field: "occurred_at", Value: second, Mode: filter.ModeEqual, DataType: filter.DataTypeDate}, []uint{1}},
+		{"NotEqual", time.Second, filter.FieldFilter{Field: "occurred_at", Value: second, Mode: filter.ModeNotEqual, DataType: filter.DataTypeDate}, []uint{4, 5}},
+		{"EqualString", time.Second, filter.FieldFilter{Field: "occurred_at", Value: "2025-11-05T14:30:02Z", Mode: filter.ModeEqual, DataType: filter.DataTypeDate}, []uint{5}},
+		{"LTE", time.Second, filter.FieldFilter{Field: "occurred_at", Value: second, Mode: filter.ModeLTE, DataType: filter.DataTypeDate}, []uint{1, 2, 3}},
+		{"After", time.Second, filter.FieldFilter{Field: "occurred_at", Value: second, Mode: filter.ModeAfter, DataType: filter.DataTypeDate}, []uint{4, 5}},
+		{"Before", time.Second, filter.FieldFilter{Field: "occurred_at", Value: next, Mode: filter.ModeBefore, DataType: filter.DataTypeDate}, []uint{1, 2, 3}},
+		{"Range", time.Second, filter.FieldFilter{Field: "occurred_at", Value: filter.Range{From: second, To: next}, Mode: filter.ModeRange, DataType: filter.DataTypeDate}, []uint{1, 2, 3, 4}},
+		{"RangeExclusive", time.Second, filter.FieldFilter{Field: "occurred_at", Value: filter.Range{From: second, To: next.Add(time.Second), FromExclusive: true}, Mode: filter.ModeRange, DataType: filter.DataTypeDate}, []uint{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := filter.NewFilter[PrecisionEvent](filter.GolangFilteringConfig{DatePrecision: tt.precision})
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+				SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+			}
+			result, err := handler.DataQuery(generatePrecisionEvents(), root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := precisionEventIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := precisionEventIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// mockDialector runs on SQLite but reports another dialect name and quote character
+type mockDialector struct {
+	gorm.Dialector
+	name  string
+	quote byte
+}
+
+func (d mockDialector) Name() string {
+	return d.name
+}
+
+func (d mockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte(d.quote)
+	writer.WriteString(str)
+	writer.WriteByte(d.quote)
+}
+
+// setupDialectDB opens the nested relations fixture through dialector and records executed queries
+func setupDialectDB(t *testing.T, dialector gorm.Dialector) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&TestCurrency{}, &TestBillAndCoin{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	currencies := []TestCurrency{
+		{ID: 1, Name: "US Dollar", CurrencyCode: "USD"},
+		{ID: 2, Name: "Euro", CurrencyCode: "EUR"},
+	}
+	for _, currency := range currencies {
+		if err := db.Create(&currency).Error; err != nil {
+			t.Fatalf("Failed to create currency: %v", err)
+		}
+	}
+	items := []TestBillAndCoin{
+		{ID: 1, CurrencyID: 1, Name: "One Dollar Bill", Value: 1},
+		{ID: 2, CurrencyID: 2, Name: "Euro Coin", Value: 1},
+		{ID: 3, CurrencyID: 1, Name: "Five Dollar Bill", Value: 5},
+	}
+	for _, item := range items {
+		if err := db.Create(&item).Error; err != nil {
+			t.Fatalf("Failed to create bill/coin: %v", err)
+		}
+	}
+
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return db, &queries
+}
+
+// TestDialectQuoting tests that nested and disambiguated identifiers use the dialect's quote character
+func TestDialectQuoting(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialector gorm.Dialector
+		expected  []string
+	}{
+		{"SQLite", sqlite.Open(":memory:"), []string{"`Currency`.`currency_code`", "`Currency`.`name`", "`test_bill_and_coins`.`value`"}},
+		{"MySQL", mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}, []string{"`Currency`.`currency_code`", "`Currency`.`name`", "`test_bill_and_coins`.`value`"}},
+		{"Postgres", mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}, []string{`"Currency"."currency_code"`, `"Currency"."name"`, `"test_bill_and_coins"."value"`}},
+	}
+
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "currency.currency_code", Value: "USD", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "value", Value: 0, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{
+			{Field: "currency.name", Order: filter.SortOrderAsc},
+			{Field: "value", Order: filter.SortOrderDesc},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, queries := setupDialectDB(t, tt.dialector)
+
+			result, err := handler.DataGorm(db, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if result.TotalSize != 2 || result.Data[0].ID != 3 {
+				t.Errorf("Expected 2 USD records with ID 3 first, got %d", result.TotalSize)
+			}
+			if _, err := handler.DataGormNoPage(db, filterRoot); err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			csvData, err := handler.GormNoPaginationCSVCustom(db, filterRoot, func(item *TestBillAndCoin) map[string]any {
+				return map[string]any{"name": item.Name}
+			})
+			if err != nil {
+				t.Fatalf("GormNoPaginationCSVCustom failed: %v", err)
+			}
+			if !strings.HasPrefix(string(csvData), "name\nFive Dollar Bill\n") {
+				t.Errorf("Expected CSV sorted by nested field, got %q", csvData)
+			}
+
+			sql := strings.Join(*queries, "\n")
+			for _, identifier := range tt.expected {
+				if !strings.Contains(sql, identifier) {
+					t.Errorf("Expected SQL to contain %s, got:\n%s", identifier, sql)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// DurationCall stores its length as integer minutes and its hold time as a time.Duration
+type DurationCall struct {
+	ID       uint          `gorm:"primaryKey" json:"id"`
+	Minutes  int           `json:"minutes"`
+	HoldTime time.Duration `json:"hold_time"`
+}
+
+func generateDurationCalls() []*DurationCall {
+	return []*DurationCall{
+		{ID: 1, Minutes: 15, HoldTime: 30 * time.Second},
+		{ID: 2, Minutes: 60, HoldTime: 2 * time.Minute},
+		{ID: 3, Minutes: 90, HoldTime: 5 * time.Minute},
+		{ID: 4, Minutes: 150, HoldTime: 0},
+	}
+}
+
+func durationCallIDs(calls []*DurationCall) []uint {
+	ids := make([]uint, len(calls))
+	for i, call := range calls {
+		ids[i] = call.ID
+	}
+	return ids
+}
+
+// TestDuration_AllPaths tests that duration filters match the same rows in DataQuery and DataGorm
+func TestDuration_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&DurationCall{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateDurationCalls()).Error; err != nil {
+		t.Fatalf("Failed to create calls: %v", err)
+	}
+	handler := filter.NewFilter[DurationCall](filter.GolangFilteringConfig{StrictValidation: true}).
+		DurationUnit("minutes", time.Minute)
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"String", filter.FieldFilter{Field: "minutes", Value: "1h30m", Mode: filter.ModeEqual, DataType: filter.DataTypeDuration}, []uint{3}},
+		{"Number", filter.FieldFilter{Field: "minutes", Value: 60, Mode: filter.ModeGT, DataType: filter.DataTypeDuration}, []uint{3, 4}},
+		{"NumericString", filter.FieldFilter{Field: "minutes", Value: "15", Mode: filter.ModeEqual, DataType: filter.DataTypeDuration}, []uint{1}},
+		{"Duration", filter.FieldFilter{Field: "minutes", Value: 2 * time.Hour, Mode: filter.ModeGTE, DataType: filter.DataTypeDuration}, []uint{4}},
+		{"Range", filter.FieldFilter{Field: "minutes", Value: filter.Range{From: "1h", To: "2h"}, Mode: filter.ModeRange, DataType: filter.DataTypeDuration}, []uint{2, 3}},
+		{"JSONRange", filter.FieldFilter{Field: "minutes", Value: map[string]any{"from": "10m", "to": 60.0, "toExclusive": true}, Mode: filter.ModeRange, DataType: filter.DataTypeDuration}, []uint{1}},
+		{"In", filter.FieldFilter{Field: "minutes", Value: []any{"15m", "2h30m"}, Mode: filter.ModeIn, DataType: filter.DataTypeDuration}, []uint{1, 4}},
+		{"Nanoseconds", filter.FieldFilter{Field: "hold_time", Value: "1m", Mode: filter.ModeGTE, DataType: filter.DataTypeDuration}, []uint{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+				SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+			}
+			result, err := handler.DataQuery(generateDurationCalls(), root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := durationCallIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := durationCallIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestDuration_InvalidValue tests that a value that is neither a duration nor a number is rejected
+func TestDuration_InvalidValue(t *testing.T) {
+	handler := filter.NewFilter[DurationCall](filter.GolangFilteringConfig{StrictValidation: true}).
+		DurationUnit("minutes", time.Minute)
+	root := filter.Root{
+		FieldFilters: []filter.FieldFilter{
+			{Field: "minutes", Value: "an hour", Mode: filter.ModeEqual, DataType: filter.DataTypeDuration},
+		},
+	}
+	if err := handler.ValidateRoot(root); err == nil {
+		t.Error("Expected ValidateRoot to reject the value")
+	}
+	if _, err := handler.DataQuery(generateDurationCalls(), root, 0, 10); err == nil {
+		t.Error("Expected DataQuery to reject the value")
+	}
+}
+
+// TestDuration_ParseRoot tests that "duration" is accepted as a data type in JSON payloads
+func TestDuration_ParseRoot(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"minutes","value":"1h","mode":"gte","dataType":"duration"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	handler := filter.NewFilter[DurationCall](filter.GolangFilteringConfig{}).DurationUnit("minutes", time.Minute)
+	result, err := handler.DataQuery(generateDurationCalls(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := durationCallIDs(result.Data); !equalIDs(got, []uint{2, 3, 4}) {
+		t.Errorf("Expected IDs [2 3 4], got %v", got)
+	}
+}
+
+// TestDuration_UnknownField tests that DurationUnit panics on an unknown field
+func TestDuration_UnknownField(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected DurationUnit to panic")
+		}
+	}()
+	filter.NewFilter[DurationCall](filter.GolangFilteringConfig{}).DurationUnit("length", time.Minute)
+}
@@ -0,0 +1,167 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// EmbeddedAudit is embedded by value, like a shared Auditable struct
+type EmbeddedAudit struct {
+	CreatedBy string `json:"created_by"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// EmbeddedReview is embedded by pointer and may be nil
+type EmbeddedReview struct {
+	ReviewedBy string `json:"reviewed_by"`
+	Title      string `json:"review_title"` // Hidden by EmbeddedDocument.Title
+}
+
+// EmbeddedDocument embeds gorm.Model (no json tags), a value struct and a pointer struct
+type EmbeddedDocument struct {
+	gorm.Model
+	EmbeddedAudit
+	*EmbeddedReview
+	Title string `json:"title"`
+}
+
+func generateEmbeddedDocuments() []*EmbeddedDocument {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newDocument := func(id uint, days int, createdBy, updatedBy, title string, review *EmbeddedReview) *EmbeddedDocument {
+		return &EmbeddedDocument{
+			Model:          gorm.Model{ID: id, CreatedAt: base.AddDate(0, 0, days), UpdatedAt: base.AddDate(0, 0, days)},
+			EmbeddedAudit:  EmbeddedAudit{CreatedBy: createdBy, UpdatedBy: updatedBy},
+			EmbeddedReview: review,
+			Title:          title,
+		}
+	}
+	return []*EmbeddedDocument{
+		newDocument(1, 0, "alice", "carol", "Budget", &EmbeddedReview{ReviewedBy: "dave", Title: "Approved"}),
+		newDocument(2, 10, "bob", "alice", "Roadmap", nil),
+		newDocument(3, 20, "alice", "bob", "Hiring", &EmbeddedReview{ReviewedBy: "erin", Title: "Rejected"}),
+		newDocument(4, 30, "carol", "", "Retro", nil),
+	}
+}
+
+func setupEmbeddedDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&EmbeddedDocument{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, document := range generateEmbeddedDocuments() {
+		if err := db.Create(document).Error; err != nil {
+			t.Fatalf("Failed to create document: %v", err)
+		}
+	}
+	return db
+}
+
+func documentIDs(documents []*EmbeddedDocument) []uint {
+	ids := make([]uint, len(documents))
+	for i, document := range documents {
+		ids[i] = document.ID
+	}
+	return ids
+}
+
+// TestEmbedded_PromotedFields tests that fields of embedded structs are filtered and sorted as top-level
+// fields in DataQuery and DataGorm alike
+func TestEmbedded_PromotedFields(t *testing.T) {
+	db := setupEmbeddedDB(t)
+	handler := filter.NewFilter[EmbeddedDocument](filter.GolangFilteringConfig{})
+	documents := generateEmbeddedDocuments()
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		// gorm.Model has no json tags, so its fields use GORM's column names
+		{"GormModelCreatedAt", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "created_at", Value: filter.Range{From: "2025-01-05", To: "2025-01-25"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+			},
+		}, []uint{2, 3}},
+		{"GormModelID", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "id", Value: 3, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			},
+		}, []uint{3, 4}},
+		{"ValueEmbedJSONTag", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "created_by", Value: "alice", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, []uint{1, 3}},
+		{"PointerEmbed", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "reviewed_by", Value: "erin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, []uint{3}},
+		// A nil embedded pointer behaves like NULL columns
+		{"NilPointerEmbedIsEmpty", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "reviewed_by", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText},
+			},
+		}, []uint{2, 4}},
+		// The outer Title hides EmbeddedReview.Title
+		{"OuterFieldWins", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "title", Value: "Roadmap", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, []uint{2}},
+		{"SortByPromotedField", filter.Root{
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "created_by", Order: filter.SortOrderDesc}, {Field: "created_at", Order: filter.SortOrderDesc}},
+		}, []uint{4, 2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataQuery(documents, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := documentIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery documents %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := documentIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm documents %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestEmbedded_AllowedFields tests that promoted fields can be named in AllowedFields
+func TestEmbedded_AllowedFields(t *testing.T) {
+	handler := filter.NewFilter[EmbeddedDocument](filter.GolangFilteringConfig{
+		AllowedFields:          []string{"created_at", "created_by"},
+		RejectDisallowedFields: true,
+	})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "reviewed_by", Value: "dave", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	if _, err := handler.DataQuery(generateEmbeddedDocuments(), root, 0, 10); err == nil {
+		t.Error("Expected an error for a promoted field outside AllowedFields")
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+)
+
+// TestEstimateRows_CountFallback tests that without statistics the estimate is an exact count,
+// ignoring WHERE conditions on db
+func TestEstimateRows_CountFallback(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	rows, err := handler.EstimateRows(db.Where("role = ?", "admin"))
+	if err != nil {
+		t.Fatalf("EstimateRows failed: %v", err)
+	}
+	if rows != 10 {
+		t.Errorf("Expected 10 rows, got %d", rows)
+	}
+}
+
+// TestEstimateRows_SQLiteStatistics tests that after ANALYZE the estimate comes from sqlite_stat1,
+// so rows added since are not counted until the next ANALYZE
+func TestEstimateRows_SQLiteStatistics(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	if err := db.Exec("ANALYZE").Error; err != nil {
+		t.Fatalf("ANALYZE failed: %v", err)
+	}
+	for id := 11; id <= 15; id++ {
+		if err := db.Create(&TestUser{ID: uint(id), Name: "New User", Role: "user"}).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	rows, err := handler.EstimateRows(db)
+	if err != nil {
+		t.Fatalf("EstimateRows failed: %v", err)
+	}
+	if rows != 10 {
+		t.Errorf("Expected the analyzed estimate of 10 rows, got %d", rows)
+	}
+
+	if err := db.Exec("ANALYZE").Error; err != nil {
+		t.Fatalf("ANALYZE failed: %v", err)
+	}
+	if rows, err = handler.EstimateRows(db); err != nil || rows != 15 {
+		t.Errorf("Expected 15 rows after a new ANALYZE, got %d (%v)", rows, err)
+	}
+}
+
+// TestEstimateRows_HybridEstimator tests that HybridOptions.Estimator replaces EstimateRows in Hybrid
+func TestEstimateRows_HybridEstimator(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+
+	tests := []struct {
+		name      string
+		estimator func(*gorm.DB) (int64, error)
+		expected  filter.Strategy
+	}{
+		{"Small", func(*gorm.DB) (int64, error) { return 5, nil }, filter.StrategyMemory},
+		{"Large", func(*gorm.DB) (int64, error) { return 5_000_000, nil }, filter.StrategyDatabase},
+		// A failed estimate falls back to the database
+		{"Error", func(*gorm.DB) (int64, error) { return 0, errors.New("no statistics") }, filter.StrategyDatabase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			opts := filter.HybridOptions{Estimator: func(db *gorm.DB) (int64, error) {
+				calls++
+				return tt.estimator(db)
+			}}
+			result, err := handler.HybridWithOptions(context.Background(), db, 1000, filterRoot, 0, 10, opts)
+			if err != nil {
+				t.Fatalf("HybridWithOptions failed: %v", err)
+			}
+			if calls != 1 {
+				t.Errorf("Expected the estimator to be called once, got %d", calls)
+			}
+			if result.Strategy != tt.expected {
+				t.Errorf("Expected strategy %q, got %q", tt.expected, result.Strategy)
+			}
+			if result.TotalSize != 10 {
+				t.Errorf("Expected 10 users, got %d", result.TotalSize)
+			}
+		})
+	}
+}
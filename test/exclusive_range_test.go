@@ -0,0 +1,142 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestExclusiveRange_NumberBuckets tests that half-open age buckets count each user exactly once
+func TestExclusiveRange_NumberBuckets(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	// Ages 30 and 35 sit on bucket boundaries and belong to the bucket they start
+	buckets := []struct {
+		from, to float64
+		expected []uint
+	}{
+		{25, 30, []uint{1, 4, 7, 8}},
+		{30, 35, []uint{2, 6, 10}},
+		{35, 45, []uint{3, 5, 9}},
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		root := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "age", Value: filter.Range{From: bucket.from, To: bucket.to, ToExclusive: true}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			},
+		}
+
+		result, err := handler.DataQuery(users, root, 0, 20)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if ids := userIDs(result.Data); !equalIDs(ids, bucket.expected) {
+			t.Errorf("Expected DataQuery users %v in [%v, %v), got %v", bucket.expected, bucket.from, bucket.to, ids)
+		}
+
+		page, err := handler.DataGorm(db, root, 0, 20)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if ids := userIDs(page.Data); !equalIDs(ids, bucket.expected) {
+			t.Errorf("Expected DataGorm users %v in [%v, %v), got %v", bucket.expected, bucket.from, bucket.to, ids)
+		}
+		total += page.TotalSize
+	}
+	if total != len(users) {
+		t.Errorf("Expected the buckets to count %d users in total, got %d", len(users), total)
+	}
+}
+
+// TestExclusiveRange_Bounds tests each combination of inclusive and exclusive bounds
+func TestExclusiveRange_Bounds(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name     string
+		value    any
+		expected []uint
+	}{
+		{"Inclusive", filter.Range{From: 30, To: 35}, []uint{2, 3, 6, 10}},
+		{"FromExclusive", filter.Range{From: 30, To: 35, FromExclusive: true}, []uint{3, 6, 10}},
+		{"ToExclusive", filter.Range{From: 30, To: 35, ToExclusive: true}, []uint{2, 6, 10}},
+		{"BothExclusive", filter.Range{From: 30, To: 35, FromExclusive: true, ToExclusive: true}, []uint{6, 10}},
+		{"JSONObject", map[string]any{"from": 30, "to": 35, "fromExclusive": true}, []uint{3, 6, 10}},
+		{"EmptyWhenEqualAndExclusive", filter.Range{From: 30, To: 30, ToExclusive: true}, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "age", Value: tt.value, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+				},
+			}
+
+			result, err := handler.DataQuery(users, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := userIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery users %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := userIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm users %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestExclusiveRange_DateBuckets tests that monthly buckets count an event at midnight on the 1st exactly once,
+// for timestamp and date-only bounds
+func TestExclusiveRange_DateBuckets(t *testing.T) {
+	db, events := setupRelativeEvents(t, []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),   // 1
+		time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC), // 2
+		time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),   // 3
+		time.Date(2025, 2, 14, 9, 0, 0, 0, time.UTC),  // 4
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),   // 5
+	})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{})
+
+	runRelativeDateCases(t, handler, db, events, []relativeDateCase{
+		{"January", filter.ModeRange, filter.Range{From: "2025-01-01", To: "2025-02-01", ToExclusive: true}, []uint{1, 2}},
+		{"February", filter.ModeRange, filter.Range{From: "2025-02-01", To: "2025-03-01", ToExclusive: true}, []uint{3, 4}},
+		{"March", filter.ModeRange, filter.Range{From: "2025-03-01", To: "2025-04-01", ToExclusive: true}, []uint{5}},
+		{"JanuaryTimestamps", filter.ModeRange, filter.Range{From: "2025-01-01T00:00:00Z", To: "2025-01-31T23:00:00Z", ToExclusive: true}, []uint{1}},
+		{"FebruaryTimestamps", filter.ModeRange, filter.Range{From: "2025-01-31T23:00:00Z", To: "2025-02-14T09:00:00Z", FromExclusive: true}, []uint{3, 4}},
+		// An exclusive date-only From leaves out its whole day
+		{"AfterFirstOfFebruary", filter.ModeRange, filter.Range{From: "2025-02-01", To: "2025-03-01", FromExclusive: true}, []uint{4, 5}},
+		{"JSONObject", filter.ModeRange, map[string]any{"from": "2025-01-01", "to": "2025-02-01", "toExclusive": true}, []uint{1, 2}},
+	})
+}
+
+// TestExclusiveRange_ParseFromJSON tests that exclusive flags are decoded and must be booleans
+func TestExclusiveRange_ParseFromJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"age","value":{"from":100,"to":200,"toExclusive":true},"mode":"range","dataType":"number"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	rng, ok := root.FieldFilters[0].Value.(filter.Range)
+	if !ok || rng.FromExclusive || !rng.ToExclusive {
+		t.Errorf("Expected a Range with only ToExclusive set, got %#v", root.FieldFilters[0].Value)
+	}
+
+	if _, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"age","value":{"from":100,"to":200,"toExclusive":"yes"},"mode":"range","dataType":"number"}]}`)); err == nil {
+		t.Error("Expected an error for a non-boolean toExclusive")
+	}
+}
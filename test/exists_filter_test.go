@@ -0,0 +1,185 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestExistsFilter_HasMany tests that exists filters on a has-many relation match the same orders in DataQuery,
+// DataGorm and both strategies of Hybrid
+func TestExistsFilter_HasMany(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true})
+	memory := func(int64, filter.Root) filter.Strategy { return filter.StrategyMemory }
+	database := func(int64, filter.Root) filter.Strategy { return filter.StrategyDatabase }
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"Exists", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "items", Mode: filter.ModeExists},
+		}}, []uint{1, 2, 3}},
+		{"NotExists", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "items", Mode: filter.ModeNotExists},
+		}}, []uint{4}},
+		{"OrWithValue", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "items", Mode: filter.ModeNotExists},
+			{Field: "total", Value: 100, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		}}, []uint{1, 4}},
+		{"WithNestedField", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "items", Mode: filter.ModeExists},
+			{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText},
+		}}, []uint{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.root.SortFields = []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}
+			result, err := handler.DataQuery(generateHasManyOrders(), tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := orderIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := orderIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+
+			for strategy, strategyFunc := range map[filter.Strategy]func(int64, filter.Root) filter.Strategy{
+				filter.StrategyMemory:   memory,
+				filter.StrategyDatabase: database,
+			} {
+				opts := filter.HybridOptions{StrategyFunc: strategyFunc}
+				hybrid, err := handler.HybridWithOptions(t.Context(), db, 1000, tt.root, 0, 10, opts)
+				if err != nil {
+					t.Fatalf("Hybrid (%s) failed: %v", strategy, err)
+				}
+				if hybrid.Strategy != strategy {
+					t.Errorf("Expected the %s strategy, got %s", strategy, hybrid.Strategy)
+				}
+				if got := orderIDs(hybrid.Data); !equalIDs(got, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) IDs %v, got %v", strategy, tt.expected, got)
+				}
+			}
+		})
+	}
+
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "items", Mode: filter.ModeExists}}}
+	sql, _, err := handler.ExplainGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("ExplainGorm failed: %v", err)
+	}
+	if !strings.Contains(sql, "EXISTS (SELECT 1 FROM `has_many_order_items` `Items` WHERE `has_many_orders`.`id` = `Items`.`order_id`)") {
+		t.Errorf("Expected a correlated EXISTS subquery, got:\n%s", sql)
+	}
+}
+
+// TestExistsFilter_BelongsTo tests that exists filters on a pointer belongs-to relation check its foreign key
+// in SQL and the pointer or, when it was not loaded, the foreign key in memory
+func TestExistsFilter_BelongsTo(t *testing.T) {
+	db := setupNilParentDB(t)
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{StrictValidation: true})
+
+	for mode, expected := range map[filter.Mode][]uint{filter.ModeExists: {1}, filter.ModeNotExists: {2, 3}} {
+		root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "department", Mode: mode}}}
+		result, err := handler.DataQuery(generateNilParentStaff(), root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if got := staffIDs(result.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected DataQuery IDs %v for %s, got %v", expected, mode, got)
+		}
+
+		page, err := handler.DataGorm(db, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if got := staffIDs(page.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected DataGorm IDs %v for %s, got %v", expected, mode, got)
+		}
+
+		memory := filter.HybridOptions{StrategyFunc: func(int64, filter.Root) filter.Strategy { return filter.StrategyMemory }}
+		hybrid, err := handler.HybridWithOptions(t.Context(), db, 1000, root, 0, 10, memory)
+		if err != nil {
+			t.Fatalf("Hybrid failed: %v", err)
+		}
+		if got := staffIDs(hybrid.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected Hybrid IDs %v for %s, got %v", expected, mode, got)
+		}
+	}
+
+	deptID := uint(1)
+	unloaded := []*NilParentStaff{{ID: 4, Name: "Dan", DepartmentID: &deptID}, {ID: 5, Name: "Eve"}}
+	for mode, expected := range map[filter.Mode][]uint{filter.ModeExists: {4}, filter.ModeNotExists: {5}} {
+		root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "department", Mode: mode}}}
+		result, err := handler.DataQuery(unloaded, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if got := staffIDs(result.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected DataQuery IDs %v for %s without a preload, got %v", expected, mode, got)
+		}
+	}
+
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "department", Mode: filter.ModeNotExists}}}
+	sql, _, err := handler.ExplainGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("ExplainGorm failed: %v", err)
+	}
+	if !strings.Contains(sql, "WHERE `department_id` IS NULL") || strings.Contains(sql, "EXISTS") {
+		t.Errorf("Expected a foreign key check, got:\n%s", sql)
+	}
+}
+
+func staffIDs(staff []*NilParentStaff) []uint {
+	ids := make([]uint, len(staff))
+	for i, s := range staff {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+// TestExistsFilter_Errors tests that exists filters parse without a data type, are rejected on fields
+// that are not relations and fail on a to-many relation that was not loaded
+func TestExistsFilter_Errors(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"logic":"and","filters":[{"field":"items","mode":"notexists"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.FieldFilters[0].Mode != filter.ModeNotExists {
+		t.Errorf("Expected ModeNotExists, got %s", root.FieldFilters[0].Mode)
+	}
+
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{StrictValidation: true})
+	root = filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "customer", Mode: filter.ModeExists}}}
+	if _, err := handler.DataQuery(generateHasManyOrders(), root, 0, 10); !errors.Is(err, filter.ErrUnsupportedMode) {
+		t.Errorf("Expected an unsupported mode error from DataQuery, got %v", err)
+	}
+	if _, err := handler.DataGorm(db, root, 0, 10); !errors.Is(err, filter.ErrUnsupportedMode) {
+		t.Errorf("Expected an unsupported mode error from DataGorm, got %v", err)
+	}
+	if err := handler.ValidateRoot(root); !errors.Is(err, filter.ErrUnsupportedMode) {
+		t.Errorf("Expected an unsupported mode error from ValidateRoot, got %v", err)
+	}
+
+	unloaded := []*HasManyOrder{{ID: 1, Customer: "Alice"}}
+	for _, mode := range []filter.Mode{filter.ModeExists, filter.ModeNotExists} {
+		root = filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{{Field: "items", Mode: mode}}}
+		if _, err := handler.DataQuery(unloaded, root, 0, 10); err == nil || !strings.Contains(err.Error(), "not loaded") {
+			t.Errorf("Expected an error for an unloaded relation with %s, got %v", mode, err)
+		}
+	}
+}
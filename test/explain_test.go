@@ -0,0 +1,154 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestExplainGorm_MatchesDataGorm tests that the SQL returned by ExplainGorm selects the same page as
+// DataGorm when run, without ExplainGorm executing anything itself
+func TestExplainGorm_MatchesDataGorm(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "age", Value: 26, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+	preset := db.Where("role <> ?", "moderator")
+
+	// Nothing is executed, so a database without the table explains the same SQL
+	empty, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	emptySQL, _, err := handler.ExplainGorm(empty.Where("role <> ?", "moderator"), root, 1, 2)
+	if err != nil {
+		t.Fatalf("ExplainGorm without a table failed: %v", err)
+	}
+
+	sql, args, err := handler.ExplainGorm(preset, root, 1, 2)
+	if err != nil {
+		t.Fatalf("ExplainGorm failed: %v", err)
+	}
+	if sql != emptySQL {
+		t.Errorf("Expected the same SQL without a table, got %s and %s", sql, emptySQL)
+	}
+	for _, part := range []string{"role <> ?", "is_active = ?", "age >= ?", "ORDER BY age DESC", "LIMIT 2", "OFFSET 2"} {
+		if !strings.Contains(sql, part) {
+			t.Errorf("Expected SQL to contain %q, got %s", part, sql)
+		}
+	}
+	if len(args) != 3 {
+		t.Errorf("Expected 3 arguments, got %v", args)
+	}
+
+	var explained []*TestUser
+	if err := db.Raw(sql, args...).Scan(&explained).Error; err != nil {
+		t.Fatalf("Running the explained SQL failed: %v", err)
+	}
+	page, err := handler.DataGorm(preset, root, 1, 2)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got, expected := userIDs(explained), userIDs(page.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected the explained SQL to return %v, got %v", expected, got)
+	}
+}
+
+// TestExplainGorm_JoinsAndErrors tests that ExplainGorm includes the joins of nested fields and
+// returns the errors DataGorm would
+func TestExplainGorm_JoinsAndErrors(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "items.quantity", Order: filter.SortOrderDesc}},
+		Preload:    []string{"Items"},
+	}
+
+	sql, _, err := handler.ExplainGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("ExplainGorm failed: %v", err)
+	}
+	for _, part := range []string{"EXISTS", "LEFT JOIN", "GROUP BY", "LIMIT 10"} {
+		if !strings.Contains(sql, part) {
+			t.Errorf("Expected SQL to contain %q, got %s", part, sql)
+		}
+	}
+
+	root.FieldFilters[0] = filter.FieldFilter{Field: "total", Value: "lots", Mode: filter.ModeGT, DataType: filter.DataTypeNumber}
+	if _, _, err := handler.ExplainGorm(db, root, 0, 10); err == nil {
+		t.Error("Expected ExplainGorm error for an invalid value in strict mode, got nil")
+	}
+}
+
+// TestExplainQuery tests the description of the in-memory evaluation of a Root
+func TestExplainQuery(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email"}})
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected string
+	}{
+		{
+			name:     "NoFilters",
+			root:     filter.Root{Logic: filter.LogicAnd},
+			expected: "all records\nsort: id asc\n",
+		},
+		{
+			name: "GroupsAndSort",
+			root: filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "age", Value: filter.Range{From: 18, To: 65, ToExclusive: true}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+					{Field: "email", Value: "x", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+					{Field: "nickname", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText},
+				},
+				Groups: []filter.Root{{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+					{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+					{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+				}}},
+				SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc, Nulls: filter.NullsLast}},
+			},
+			expected: "and\n" +
+				"  age range [18, 65) (number)\n" +
+				"  nickname isEmpty (text) ignored: unknown field\n" +
+				"  or\n" +
+				"    role equal \"admin\" (text)\n" +
+				"    is_active equal true (bool)\n" +
+				"sort: age desc nulls last, id asc\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handler.ExplainQuery(tt.root)
+			if err != nil {
+				t.Fatalf("ExplainQuery failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	invalid := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "age", Value: "old", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+	}}
+	if _, err := handler.ExplainQuery(invalid); err == nil {
+		t.Error("Expected ExplainQuery error for an invalid number, got nil")
+	}
+}
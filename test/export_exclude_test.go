@@ -0,0 +1,174 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// ExcludeTeam is the team of an ExcludeEmployee
+type ExcludeTeam struct {
+	Name   string  `json:"name"`
+	Budget float64 `json:"budget"`
+}
+
+// ExcludeEmployee has fields that must stay out of some exports
+type ExcludeEmployee struct {
+	ID     uint         `json:"id"`
+	Name   string       `json:"name"`
+	Salary float64      `json:"salary"`
+	TaxID  string       `json:"tax_id"`
+	Team   *ExcludeTeam `json:"team"`
+	Teams  []ExcludeTeam
+}
+
+func excludeEmployees() []*ExcludeEmployee {
+	return []*ExcludeEmployee{
+		{ID: 1, Name: "Ann", Salary: 9000, TaxID: "T-1", Team: &ExcludeTeam{Name: "Core", Budget: 50}, Teams: []ExcludeTeam{{Name: "Ops", Budget: 5}}},
+		{ID: 2, Name: "Ben", Salary: 7000, TaxID: "T-2"},
+	}
+}
+
+func csvHeader(t *testing.T, csvData []byte) string {
+	t.Helper()
+	header, _, _ := strings.Cut(string(csvData), "\n")
+	return header
+}
+
+// TestCSVExcludeFields tests that ExcludeFields drops columns, their aliases and nested columns,
+// composes with Columns and ignores unknown names
+func TestCSVExcludeFields(t *testing.T) {
+	maxDepth := 2
+	handler := filter.NewFilter[ExcludeEmployee](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	employees := excludeEmployees()
+	root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}}
+
+	tests := []struct {
+		name     string
+		opts     filter.CSVOptions
+		expected string
+	}{
+		{
+			name:     "default columns",
+			opts:     filter.CSVOptions{ExcludeFields: []string{"salary", "tax_id", "team", "teams", "missing"}},
+			expected: "id,name",
+		},
+		{
+			name:     "nested column",
+			opts:     filter.CSVOptions{ExcludeFields: []string{"team.budget", "teams", "salary", "taxid"}},
+			expected: "id,name,team.name",
+		},
+		{
+			name:     "with columns",
+			opts:     filter.CSVOptions{Columns: []string{"name", "salary", "team.budget", "id"}, ExcludeFields: []string{"Salary", "team"}},
+			expected: "name,id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvData, err := handler.DataQueryNoPageCSVWithOptions(employees, root, tt.opts)
+			if err != nil {
+				t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+			}
+			if header := csvHeader(t, csvData); header != tt.expected {
+				t.Errorf("Expected header %q, got %q", tt.expected, header)
+			}
+			lines := strings.Split(strings.TrimSpace(string(csvData)), "\n")
+			if len(lines) != 3 {
+				t.Fatalf("Expected a header and 2 rows, got %d lines", len(lines))
+			}
+			for _, line := range lines[1:] {
+				if cells := strings.Count(line, ",") + 1; cells != strings.Count(tt.expected, ",")+1 {
+					t.Errorf("Expected row %q to have as many cells as the header", line)
+				}
+				if strings.Contains(line, "9000") || strings.Contains(line, "T-1") {
+					t.Errorf("Expected no excluded values in row %q", line)
+				}
+			}
+		})
+	}
+
+	t.Run("unknown column still fails", func(t *testing.T) {
+		_, err := handler.DataQueryNoPageCSVWithOptions(employees, root, filter.CSVOptions{Columns: []string{"name", "nope"}, ExcludeFields: []string{"nope"}})
+		if err == nil {
+			t.Error("Expected an error for an unknown column")
+		}
+	})
+
+	t.Run("custom getter", func(t *testing.T) {
+		csvData, err := handler.DataQueryNoPageCSVCustomWithOptions(employees, root, func(employee *ExcludeEmployee) map[string]any {
+			return map[string]any{"name": employee.Name, "salary": employee.Salary, "pay.bonus": 1}
+		}, filter.CSVOptions{ExcludeFields: []string{"salary", "pay"}})
+		if err != nil {
+			t.Fatalf("DataQueryNoPageCSVCustomWithOptions failed: %v", err)
+		}
+		if header := csvHeader(t, csvData); header != "name" {
+			t.Errorf("Expected header %q, got %q", "name", header)
+		}
+	})
+}
+
+// TestCSVExcludeFields_Gorm tests ExcludeFields on the database export
+func TestCSVExcludeFields_Gorm(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	csvData, err := handler.GormNoPaginationCSVWithOptions(db, filter.Root{Logic: filter.LogicAnd}, filter.CSVOptions{
+		ExcludeFields: []string{"email", "created_at", "isactive"},
+	})
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+	if header := csvHeader(t, csvData); header != "age,id,name,role" {
+		t.Errorf("Expected header %q, got %q", "age,id,name,role", header)
+	}
+	if strings.Contains(string(csvData), "@example.com") {
+		t.Error("Expected no email addresses in the export")
+	}
+}
+
+// TestJSONExcludeFields tests that JSON exports drop excluded members, nested ones included,
+// and keep the order of the others
+func TestJSONExcludeFields(t *testing.T) {
+	handler := filter.NewFilter[ExcludeEmployee](filter.GolangFilteringConfig{})
+	employees := excludeEmployees()
+	root := filter.Root{Logic: filter.LogicAnd}
+	opts := filter.JSONOptions{ExcludeFields: []string{"salary", "taxid", "team.budget", "teams.budget", "missing.field"}}
+
+	jsonData, err := handler.DataQueryNoPageJSONWithOptions(employees, root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageJSONWithOptions failed: %v", err)
+	}
+	expected := `[{"id":1,"name":"Ann","team":{"name":"Core"},"Teams":[{"name":"Ops"}]},{"id":2,"name":"Ben","team":null,"Teams":null}]`
+	if string(jsonData) != expected {
+		t.Errorf("Expected %s, got %s", expected, jsonData)
+	}
+
+	var buf bytes.Buffer
+	if err := handler.DataQueryNDJSONStreamWithOptions(employees, root, &buf, opts); err != nil {
+		t.Fatalf("DataQueryNDJSONStreamWithOptions failed: %v", err)
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(jsonData, &records); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(records) {
+		t.Fatalf("Expected %d NDJSON lines, got %d", len(records), len(lines))
+	}
+	for i, line := range lines {
+		if line != string(records[i]) {
+			t.Errorf("Expected NDJSON line %d to be %s, got %s", i, records[i], line)
+		}
+	}
+
+	plain, err := handler.DataQueryNoPageJSON(employees, root)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageJSON failed: %v", err)
+	}
+	if !strings.Contains(string(plain), `"salary":9000`) {
+		t.Errorf("Expected the plain export to keep salary, got %s", plain)
+	}
+}
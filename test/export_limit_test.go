@@ -0,0 +1,223 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// truncationObserver records QueryResultInfo.Truncated of the last query
+type truncationObserver struct {
+	truncated bool
+}
+
+func (o *truncationObserver) OnQueryStart(filter.QueryInfo) func(filter.QueryResultInfo) {
+	return func(info filter.QueryResultInfo) { o.truncated = info.Truncated }
+}
+
+// TestMaxExportRows_Exceeded tests that every no-page query and export fails once more records match than MaxExportRows
+func TestMaxExportRows_Exceeded(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxExportRows: 10})
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	check := func(name string, err error) {
+		t.Helper()
+		var limitErr *filter.ExportLimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != 10 {
+			t.Fatalf("%s: expected an ExportLimitError of 10 rows, got %v", name, err)
+		}
+		if !strings.Contains(err.Error(), "result exceeds export limit of 10 rows") {
+			t.Errorf("%s: unexpected error message %q", name, err.Error())
+		}
+	}
+
+	_, err := handler.DataGormNoPage(db, root)
+	check("DataGormNoPage", err)
+	_, err = handler.DataQueryNoPage(items, root)
+	check("DataQueryNoPage", err)
+	_, err = handler.GormNoPaginationCSV(db, root)
+	check("GormNoPaginationCSV", err)
+	_, err = handler.DataQueryNoPageCSV(items, root)
+	check("DataQueryNoPageCSV", err)
+	_, err = handler.GormNoPaginationCSVCustom(db, root, func(item *LimitedItem) map[string]any {
+		return map[string]any{"name": item.Name}
+	})
+	check("GormNoPaginationCSVCustom", err)
+	_, err = handler.GormNoPaginationJSON(db, root)
+	check("GormNoPaginationJSON", err)
+	_, err = handler.DataQueryNoPageJSON(items, root)
+	check("DataQueryNoPageJSON", err)
+	_, err = handler.GormNoPaginationXLSX(db, root)
+	check("GormNoPaginationXLSX", err)
+	check("GormCSVStream", handler.GormCSVStream(db, root, &bytes.Buffer{}, filter.DefaultCSVOptions()))
+	check("DataQueryCSVStream", handler.DataQueryCSVStream(items, root, &bytes.Buffer{}, filter.DefaultCSVOptions()))
+	check("GormNDJSONStream", handler.GormNDJSONStream(db, root, &bytes.Buffer{}))
+	check("DataQueryNDJSONStream", handler.DataQueryNDJSONStream(items, root, &bytes.Buffer{}))
+	_, err = handler.DataHybridNoPage(db, 1000, root)
+	check("DataHybridNoPage", err)
+
+	// Filters bringing the matches down to the limit succeed
+	root.FieldFilters = []filter.FieldFilter{{Field: "id", Value: 10, Mode: filter.ModeLTE, DataType: filter.DataTypeNumber}}
+	data, err := handler.DataGormNoPage(db, root)
+	if err != nil || len(data) != 10 {
+		t.Fatalf("DataGormNoPage: expected 10 records, got %d (%v)", len(data), err)
+	}
+}
+
+// TestMaxExportRows_StreamWritesNothing tests that a stream past MaxExportRows fails before writing
+// any row, even when its batches are smaller than the limit
+func TestMaxExportRows_StreamWritesNothing(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxExportRows: 10})
+	opts := filter.DefaultCSVOptions()
+	opts.BatchSize = 4
+
+	for _, root := range []filter.Root{
+		{Logic: filter.LogicAnd},
+		{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderDesc}}},
+	} {
+		var csvOutput, ndjsonOutput bytes.Buffer
+		var limitErr *filter.ExportLimitError
+		if err := handler.GormCSVStream(db, root, &csvOutput, opts); !errors.As(err, &limitErr) {
+			t.Fatalf("GormCSVStream: expected an ExportLimitError, got %v", err)
+		}
+		if csvOutput.Len() != 0 {
+			t.Errorf("GormCSVStream: expected no output, got %q", csvOutput.String())
+		}
+		if err := handler.GormNDJSONStream(db, root, &ndjsonOutput); !errors.As(err, &limitErr) {
+			t.Fatalf("GormNDJSONStream: expected an ExportLimitError, got %v", err)
+		}
+		if ndjsonOutput.Len() != 0 {
+			t.Errorf("GormNDJSONStream: expected no output, got %q", ndjsonOutput.String())
+		}
+	}
+}
+
+// TestMaxExportRows_Equal tests that exactly MaxExportRows matches are returned in full
+func TestMaxExportRows_Equal(t *testing.T) {
+	items := generateLimitedItems(10)
+	db := setupLimitedItemsDB(t, items)
+	observer := &truncationObserver{}
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxExportRows: 10, Observer: observer})
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	data, err := handler.DataGormNoPage(db, root)
+	if err != nil || len(data) != 10 || observer.truncated {
+		t.Fatalf("DataGormNoPage: expected 10 records, not truncated, got %d (%v, truncated=%v)", len(data), err, observer.truncated)
+	}
+	data, err = handler.DataQueryNoPage(items, root)
+	if err != nil || len(data) != 10 {
+		t.Fatalf("DataQueryNoPage: expected 10 records, got %d (%v)", len(data), err)
+	}
+	var buf bytes.Buffer
+	if err := handler.GormCSVStream(db, root, &buf, filter.CSVOptions{BatchSize: 3}); err != nil {
+		t.Fatalf("GormCSVStream failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 11 {
+		t.Fatalf("GormCSVStream: expected a header and 10 rows, got %d (%v)", len(rows), err)
+	}
+
+	// A Root.Limit at or under MaxExportRows is never an error
+	items = generateLimitedItems(25)
+	root.Limit = 10
+	data, err = handler.DataQueryNoPage(items, root)
+	if err != nil || len(data) != 10 {
+		t.Fatalf("DataQueryNoPage with Limit: expected 10 records, got %d (%v)", len(data), err)
+	}
+}
+
+// TestMaxExportRows_Truncate tests that Root.TruncateExport returns the first MaxExportRows records in sort order and reports it
+func TestMaxExportRows_Truncate(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	observer := &truncationObserver{}
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxExportRows: 10, Observer: observer})
+	root := filter.Root{
+		Logic:          filter.LogicAnd,
+		SortFields:     []filter.SortField{{Field: "id", Order: filter.SortOrderDesc}},
+		TruncateExport: true,
+	}
+	expected := []uint{25, 24, 23, 22, 21, 20, 19, 18, 17, 16}
+
+	check := func(name string, ids []uint, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if len(ids) != len(expected) {
+			t.Fatalf("%s: expected IDs %v, got %v", name, expected, ids)
+		}
+		for i := range ids {
+			if ids[i] != expected[i] {
+				t.Fatalf("%s: expected IDs %v, got %v", name, expected, ids)
+			}
+		}
+		if !observer.truncated {
+			t.Errorf("%s: expected Truncated to be reported", name)
+		}
+		observer.truncated = false
+	}
+
+	data, err := handler.DataGormNoPage(db, root)
+	check("DataGormNoPage", limitedItemIDs(data), err)
+	data, err = handler.DataQueryNoPage(items, root)
+	check("DataQueryNoPage", limitedItemIDs(data), err)
+
+	var buf bytes.Buffer
+	err = handler.GormCSVStream(db, root, &buf, filter.CSVOptions{OmitHeaders: true, Columns: []string{"id"}, BatchSize: 4})
+	check("GormCSVStream", csvIDs(t, buf.String()), err)
+	csvData, err := handler.DataQueryNoPageCSVWithOptions(items, root, filter.CSVOptions{OmitHeaders: true, Columns: []string{"id"}})
+	check("DataQueryNoPageCSV", csvIDs(t, string(csvData)), err)
+}
+
+// TestMaxExportRows_OptOut tests that a negative MaxExportRows returns every match and zero applies DefaultMaxExportRows
+func TestMaxExportRows_OptOut(t *testing.T) {
+	items := generateLimitedItems(filter.DefaultMaxExportRows + 1)
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	unlimited := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxExportRows: -1})
+	data, err := unlimited.DataQueryNoPage(items, root)
+	if err != nil || len(data) != len(items) {
+		t.Fatalf("MaxExportRows -1: expected %d records, got %d (%v)", len(items), len(data), err)
+	}
+
+	defaulted := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{})
+	_, err = defaulted.DataQueryNoPage(items, root)
+	var limitErr *filter.ExportLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != filter.DefaultMaxExportRows {
+		t.Fatalf("MaxExportRows 0: expected an ExportLimitError of %d rows, got %v", filter.DefaultMaxExportRows, err)
+	}
+
+	// ForEach is never capped
+	count := 0
+	if err := defaulted.QueryForEach(items, root, func(*LimitedItem) error { count++; return nil }); err != nil || count != len(items) {
+		t.Fatalf("QueryForEach: expected %d items, got %d (%v)", len(items), count, err)
+	}
+}
+
+// csvIDs returns the single-column rows of a headerless CSV export as IDs
+func csvIDs(t *testing.T, data string) []uint {
+	t.Helper()
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		id, err := strconv.ParseUint(row[0], 10, 64)
+		if err != nil {
+			t.Fatalf("Failed to parse ID %q: %v", row[0], err)
+		}
+		ids[i] = uint(id)
+	}
+	return ids
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestFacet_BothPaths tests that FacetGorm and FacetQuery count the same values,
+// leaving out the filters on the facet field itself
+func TestFacet_BothPaths(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	active := filter.FieldFilter{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}
+	admins := filter.FieldFilter{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		field    string
+		expected map[string]int64
+	}{
+		{
+			name:     "Text",
+			root:     filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{active, admins}},
+			field:    "role",
+			expected: map[string]int64{"admin": 3, "user": 2, "moderator": 2},
+		},
+		{
+			name:     "Bool",
+			root:     filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{active, admins}},
+			field:    "is_active",
+			expected: map[string]int64{"true": 3},
+		},
+		{
+			name:     "Number",
+			root:     filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{admins}},
+			field:    "age",
+			expected: map[string]int64{"25": 1, "42": 1, "31": 1},
+		},
+		{
+			name:     "NoFilters",
+			root:     filter.Root{Logic: filter.LogicAnd},
+			field:    "is_active",
+			expected: map[string]int64{"true": 7, "false": 3},
+		},
+		{
+			name: "FacetFieldInNestedGroup",
+			root: filter.Root{Logic: filter.LogicAnd, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{admins, active}},
+			}},
+			field:    "Role",
+			expected: map[string]int64{"admin": 3, "user": 2, "moderator": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, err := handler.FacetGorm(db, tt.root, tt.field)
+			if err != nil {
+				t.Fatalf("FacetGorm failed: %v", err)
+			}
+			if !maps.Equal(counts, tt.expected) {
+				t.Errorf("Expected FacetGorm counts %v, got %v", tt.expected, counts)
+			}
+
+			counts, err = handler.FacetQuery(users, tt.root, tt.field)
+			if err != nil {
+				t.Fatalf("FacetQuery failed: %v", err)
+			}
+			if !maps.Equal(counts, tt.expected) {
+				t.Errorf("Expected FacetQuery counts %v, got %v", tt.expected, counts)
+			}
+		})
+	}
+}
+
+// TestFacet_NestedField tests faceting on a field of a belongs-to relation
+func TestFacet_NestedField(t *testing.T) {
+	db := setupAggDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[AggInvoice](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "status", Value: "paid", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "customer.region", Value: "north", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	expected := map[string]int64{"north": 1, "south": 2}
+
+	counts, err := handler.FacetGorm(db, root, "customer.region")
+	if err != nil {
+		t.Fatalf("FacetGorm failed: %v", err)
+	}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("Expected FacetGorm counts %v, got %v", expected, counts)
+	}
+
+	counts, err = handler.FacetQuery(generateAggInvoices(), root, "customer.region")
+	if err != nil {
+		t.Fatalf("FacetQuery failed: %v", err)
+	}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("Expected FacetQuery counts %v, got %v", expected, counts)
+	}
+}
+
+// TestFacet_ToManyJoin tests that a filter joining a to-many relation counts each record once
+func TestFacet_ToManyJoin(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			// Order 1 has two matching items
+			{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+	}
+	expected := map[string]int64{"Alice": 1, "Bob": 1}
+
+	counts, err := handler.FacetGorm(db, root, "customer")
+	if err != nil {
+		t.Fatalf("FacetGorm failed: %v", err)
+	}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("Expected FacetGorm counts %v, got %v", expected, counts)
+	}
+
+	counts, err = handler.FacetQuery(generateHasManyOrders(), root, "customer")
+	if err != nil {
+		t.Fatalf("FacetQuery failed: %v", err)
+	}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("Expected FacetQuery counts %v, got %v", expected, counts)
+	}
+}
+
+// TestFacet_Errors tests that unknown, denied and to-many facet fields are rejected on both paths
+func TestFacet_Errors(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{
+		MaxDepth:     &maxDepth,
+		DeniedFields: []string{"total"},
+	})
+	orders := generateHasManyOrders()
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{"nonexistent", "unknown facet field nonexistent"},
+		{"total", "facet field not allowed: total"},
+		{"items.sku", "cannot facet to-many field items.sku"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if _, err := handler.FacetGorm(db, root, tt.field); err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected FacetGorm error %q, got %v", tt.expected, err)
+			}
+			if _, err := handler.FacetQuery(orders, root, tt.field); err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected FacetQuery error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
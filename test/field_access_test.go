@@ -0,0 +1,127 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestFieldAccess_DeniedFieldsIgnored tests that denied fields are dropped from filters and sorts on both paths
+func TestFieldAccess_DeniedFieldsIgnored(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"age"}})
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "age", Value: 30, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		},
+		// "Age" resolves to the same field as "age" and is denied too
+		SortFields: []filter.SortField{{Field: "Age", Order: filter.SortOrderDesc}},
+	}
+
+	memory, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	gormResult, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+
+	// Only the role filter applies, in default id order
+	for name, result := range map[string]*filter.PaginationResult[TestUser]{"DataQuery": memory, "DataGorm": gormResult} {
+		if result.TotalSize != 3 {
+			t.Errorf("%s: expected 3 admins, got %d", name, result.TotalSize)
+			continue
+		}
+		if result.Data[0].ID != 1 {
+			t.Errorf("%s: expected denied sort to be ignored, first ID %d", name, result.Data[0].ID)
+		}
+	}
+
+	// The caller's root is left untouched
+	if len(filterRoot.FieldFilters) != 2 || len(filterRoot.SortFields) != 1 {
+		t.Error("Expected restrictions not to modify the caller's filterRoot")
+	}
+}
+
+// TestFieldAccess_AllowedFieldsWithNestedGroups tests that only allowed fields apply, including inside groups
+func TestFieldAccess_AllowedFieldsWithNestedGroups(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{AllowedFields: []string{"name", "role"}})
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		Groups: []filter.Root{
+			{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "role", Value: "user", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+					{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+				},
+			},
+		},
+	}
+
+	count, err := handler.CountGorm(db, filterRoot)
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	queryCount, err := handler.CountQuery(generateTestUsers(), filterRoot)
+	if err != nil {
+		t.Fatalf("CountQuery failed: %v", err)
+	}
+	if count != 5 || queryCount != 5 {
+		t.Errorf("Expected 5 users with is_active ignored, got CountGorm=%d CountQuery=%d", count, queryCount)
+	}
+}
+
+// TestFieldAccess_RejectDisallowedFields tests that disallowed fields return an error listing them
+func TestFieldAccess_RejectDisallowedFields(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{
+		DeniedFields:           []string{"email", "age"},
+		RejectDisallowedFields: true,
+	})
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "email", Value: "john", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			{Field: "name", Value: "john", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}},
+	}
+
+	_, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err == nil || !strings.Contains(err.Error(), "email") || !strings.Contains(err.Error(), "age") {
+		t.Errorf("DataGorm: expected error listing email and age, got %v", err)
+	}
+	if _, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 10); err == nil {
+		t.Error("DataQuery: expected error for disallowed fields, got nil")
+	}
+
+	// Allowed fields still work
+	filterRoot.FieldFilters = filterRoot.FieldFilters[1:]
+	filterRoot.SortFields = nil
+	result, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 3 {
+		t.Errorf("Expected 3 names containing john, got %d", result.TotalSize)
+	}
+}
+
+// TestFieldAccess_UnknownFieldPanics tests that typos in field lists are caught at NewFilter time
+func TestFieldAccess_UnknownFieldPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewFilter to panic for unknown denied field, got nil")
+		}
+	}()
+	filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"salry"}})
+}
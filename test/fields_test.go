@@ -0,0 +1,127 @@
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func descriptorPaths(fields []filter.FieldDescriptor) []string {
+	paths := make([]string, len(fields))
+	for i, field := range fields {
+		paths[i] = field.Path
+	}
+	return paths
+}
+
+// TestFields_TopLevel tests that every field of a flat struct is described with its suggested data type
+func TestFields_TopLevel(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	expected := []filter.FieldDescriptor{
+		{Name: "id", Path: "id", GoType: "uint", DataType: filter.DataTypeNumber},
+		{Name: "name", Path: "name", GoType: "string", DataType: filter.DataTypeText},
+		{Name: "email", Path: "email", GoType: "string", DataType: filter.DataTypeText},
+		{Name: "age", Path: "age", GoType: "int", DataType: filter.DataTypeNumber},
+		{Name: "is_active", Path: "is_active", GoType: "bool", DataType: filter.DataTypeBool},
+		{Name: "role", Path: "role", GoType: "string", DataType: filter.DataTypeText},
+		{Name: "created_at", Path: "created_at", GoType: "time.Time", DataType: filter.DataTypeDate},
+	}
+	if fields := handler.Fields(); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, fields)
+	}
+}
+
+// TestFields_NestedAndNullable tests that nested fields are listed down to MaxDepth and that fields
+// behind a nil-able pointer are nullable
+func TestFields_NestedAndNullable(t *testing.T) {
+	shallow := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{})
+	if paths := descriptorPaths(shallow.Fields()); !reflect.DeepEqual(paths, []string{"id", "name", "department_id"}) {
+		t.Errorf("Expected only top-level fields without MaxDepth, got %v", paths)
+	}
+
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	fields := handler.Fields()
+	byPath := make(map[string]filter.FieldDescriptor, len(fields))
+	for _, field := range fields {
+		byPath[field.Path] = field
+	}
+
+	expected := map[string]filter.FieldDescriptor{
+		"name":                  {Name: "name", Path: "name", GoType: "string", DataType: filter.DataTypeText},
+		"department_id":         {Name: "department_id", Path: "department_id", GoType: "*uint", DataType: filter.DataTypeNumber, Nullable: true},
+		"department.name":       {Name: "name", Path: "department.name", GoType: "string", DataType: filter.DataTypeText, Nested: true, Nullable: true},
+		"department.founded_at": {Name: "founded_at", Path: "department.founded_at", GoType: "time.Time", DataType: filter.DataTypeDate, Nested: true, Nullable: true},
+	}
+	for path, want := range expected {
+		if got, ok := byPath[path]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %s to be described as %+v, got %+v", path, want, got)
+		}
+	}
+	// The struct field itself is not filterable
+	if _, ok := byPath["department"]; ok {
+		t.Error("Expected the department struct not to be listed")
+	}
+}
+
+// TestFields_EmbeddedPointerIsNullable tests that promoted fields of an embedded pointer are nullable
+func TestFields_EmbeddedPointerIsNullable(t *testing.T) {
+	handler := filter.NewFilter[EmbeddedDocument](filter.GolangFilteringConfig{})
+	byPath := make(map[string]filter.FieldDescriptor)
+	for _, field := range handler.Fields() {
+		byPath[field.Path] = field
+	}
+
+	if field := byPath["reviewed_by"]; !field.Nullable || field.Nested {
+		t.Errorf("Expected reviewed_by to be a nullable top-level field, got %+v", field)
+	}
+	if field := byPath["created_by"]; field.Nullable {
+		t.Errorf("Expected created_by not to be nullable, got %+v", field)
+	}
+	// gorm.Model.DeletedAt is a struct, so it is not listed
+	if _, ok := byPath["DeletedAt"]; ok {
+		t.Error("Expected DeletedAt not to be listed")
+	}
+}
+
+// TestFields_AllowedDeniedAndValues tests that Fields respects AllowedFields, DeniedFields and RestrictValues
+func TestFields_AllowedDeniedAndValues(t *testing.T) {
+	allowed := filter.NewFilter[TestUser](filter.GolangFilteringConfig{AllowedFields: []string{"name", "role", "age"}})
+	if paths := descriptorPaths(allowed.Fields()); !reflect.DeepEqual(paths, []string{"name", "age", "role"}) {
+		t.Errorf("Expected only allowed fields in declaration order, got %v", paths)
+	}
+
+	denied := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email", "IsActive"}}).
+		RestrictValues("role", []string{"user", "admin"})
+	fields := denied.Fields()
+	if paths := descriptorPaths(fields); !reflect.DeepEqual(paths, []string{"id", "name", "age", "role", "created_at"}) {
+		t.Errorf("Expected denied fields to be left out, got %v", paths)
+	}
+	for _, field := range fields {
+		if field.Path == "role" && !reflect.DeepEqual(field.Values, []string{"admin", "user"}) {
+			t.Errorf("Expected the restricted values sorted, got %v", field.Values)
+		}
+		if field.Path != "role" && field.Values != nil {
+			t.Errorf("Expected no values for %s, got %v", field.Path, field.Values)
+		}
+	}
+}
+
+// TestFields_JSON tests the JSON encoding a frontend receives
+func TestFields_JSON(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{AllowedFields: []string{"role"}}).
+		RestrictValues("role", []string{"admin"})
+
+	encoded, err := json.Marshal(handler.Fields())
+	if err != nil {
+		t.Fatalf("Failed to encode fields: %v", err)
+	}
+	expected := `[{"name":"role","path":"role","goType":"string","dataType":"text","nested":false,"nullable":false,"values":["admin"]}]`
+	if got := strings.TrimSpace(string(encoded)); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestFilterGroup_BothPaths tests that filters sharing a FieldFilter.Group are ANDed together and combined
+// with the other filters using the Root's Logic, on DataQuery and DataGorm
+func TestFilterGroup_BothPaths(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	age := func(mode filter.Mode, value int, group int) filter.FieldFilter {
+		return filter.FieldFilter{Field: "age", Value: value, Mode: mode, DataType: filter.DataTypeNumber, Group: group}
+	}
+	text := func(field, value string, mode filter.Mode, group int) filter.FieldFilter {
+		return filter.FieldFilter{Field: field, Value: value, Mode: mode, DataType: filter.DataTypeText, Group: group}
+	}
+	inactive := filter.FieldFilter{Field: "is_active", Value: false, Mode: filter.ModeEqual, DataType: filter.DataTypeBool, Group: 2}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{
+			// (age >= 30 AND age <= 35) OR role = moderator
+			name: "SameFieldRangeUnderOr",
+			root: filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+				age(filter.ModeGTE, 30, 1), age(filter.ModeLTE, 35, 1), text("role", "moderator", filter.ModeEqual, 0),
+			}},
+			expected: []uint{2, 3, 4, 6, 8, 10},
+		},
+		{
+			// age >= 40 OR (role = user AND is_active = false)
+			name: "CrossFieldGroupsUnderOr",
+			root: filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+				age(filter.ModeGTE, 40, 1), text("role", "user", filter.ModeEqual, 2), inactive,
+			}},
+			expected: []uint{3, 5, 6, 9},
+		},
+		{
+			// Group members need not be adjacent: (age >= 40 AND age <= 45) OR role = user
+			name: "InterleavedGroup",
+			root: filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+				age(filter.ModeGTE, 40, 7), text("role", "user", filter.ModeEqual, 0), age(filter.ModeLTE, 45, 7),
+			}},
+			expected: []uint{2, 3, 5, 6, 7, 9},
+		},
+		{
+			// (age >= 26 AND age <= 30) AND name contains john
+			name: "GroupUnderAnd",
+			root: filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+				age(filter.ModeGTE, 26, 1), age(filter.ModeLTE, 30, 1), text("name", "john", filter.ModeContains, 0),
+			}},
+			expected: []uint{7},
+		},
+		{
+			// is_active = true AND ((age >= 25 AND age <= 26) OR role = admin)
+			name: "GroupInNestedGroup",
+			root: filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+				},
+				Groups: []filter.Root{{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+					age(filter.ModeGTE, 25, 1), age(filter.ModeLTE, 26, 1), text("role", "admin", filter.ModeEqual, 0),
+				}}},
+			},
+			expected: []uint{1, 5, 8, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataQuery(users, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := userIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := userIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestFilterGroup_JSON tests that the group number is decoded from JSON and echoed in AppliedFilters
+func TestFilterGroup_JSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{
+		"logic": "or",
+		"filters": [
+			{"field": "age", "value": 30, "mode": "gte", "dataType": "number", "group": 1},
+			{"field": "age", "value": 35, "mode": "lte", "dataType": "number", "group": 1},
+			{"field": "role", "value": "moderator", "mode": "equal", "dataType": "text"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.FieldFilters[0].Group != 1 || root.FieldFilters[1].Group != 1 || root.FieldFilters[2].Group != 0 {
+		t.Fatalf("Expected groups 1, 1 and 0, got %+v", root.FieldFilters)
+	}
+
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	result, err := handler.DataQuery(generateTestUsers(), root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if expected, got := []uint{2, 3, 4, 6, 8, 10}, userIDs(result.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected IDs %v, got %v", expected, got)
+	}
+	if applied := result.AppliedFilters; len(applied.FieldFilters) != 3 || applied.FieldFilters[1].Group != 1 {
+		t.Errorf("Expected AppliedFilters to keep the filters as written, got %+v", applied.FieldFilters)
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestInMemoryInvalidFilterAlwaysErrors verifies that invalid filter values are rejected before
+// any row is evaluated, so OR short-circuiting, data ordering, and chunking cannot hide them
+func TestInMemoryInvalidFilterAlwaysErrors(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	matchesEveryone := filter.FieldFilter{Field: "age", Value: 0, Mode: filter.ModeGT, DataType: filter.DataTypeNumber}
+
+	tests := []struct {
+		name string
+		root filter.Root
+	}{
+		{
+			name: "invalid date after a matching filter with OR logic",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					matchesEveryone,
+					{Field: "created_at", Value: "not-a-date", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+				},
+			},
+		},
+		{
+			name: "invalid number after a failing filter with AND logic",
+			root: filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "age", Value: 1000, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+					{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+				},
+			},
+		},
+		{
+			name: "invalid list item",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					matchesEveryone,
+					{Field: "age", Value: []any{30, "abc"}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber},
+				},
+			},
+		},
+		{
+			name: "invalid value in nested group",
+			root: filter.Root{
+				Logic:        filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{matchesEveryone},
+				Groups: []filter.Root{{
+					Logic: filter.LogicAnd,
+					FieldFilters: []filter.FieldFilter{
+						{Field: "is_active", Value: "maybe", Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+					},
+				}},
+			},
+		},
+		{
+			name: "unsupported mode",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					matchesEveryone,
+					{Field: "name", Value: "a", Mode: filter.ModeGT, DataType: filter.DataTypeText},
+				},
+			},
+		},
+		{
+			name: "unsupported data type",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					matchesEveryone,
+					{Field: "name", Value: "a", Mode: filter.ModeEqual, DataType: "color"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			datasets := map[string][]*TestUser{
+				"all users": users,
+				"one user":  users[:1],
+				"no users":  {},
+				"reversed":  {users[9], users[8], users[7], users[6], users[5], users[4], users[3], users[2], users[1], users[0]},
+			}
+			for name, data := range datasets {
+				if _, err := handler.DataQuery(data, tt.root, 0, 10); err == nil {
+					t.Errorf("DataQuery with %s: expected error", name)
+				}
+				if _, err := handler.DataQueryNoPage(data, tt.root); err == nil {
+					t.Errorf("DataQueryNoPage with %s: expected error", name)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryInvalidFilterOnNilParent(t *testing.T) {
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+
+	// Bob and Carol have no department, so the nested value is missing for them
+	staff := generateNilParentStaff()[1:]
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "department.budget", Value: "lots", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		},
+	}
+	if _, err := handler.DataQueryNoPage(staff, root); err == nil {
+		t.Error("Expected error for invalid value even when every parent is nil")
+	}
+}
+
+func TestInMemoryPreParsedValuesMatch(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: []string{"2024-01-01", "2024-05-01", "2024-10-01"}, Mode: filter.ModeIn, DataType: filter.DataTypeDate},
+			{Field: "name", Value: []string{"JOHN DOE", "grace lee"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		},
+	}
+	result, err := handler.DataQueryNoPage(users, root)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	if len(result) != 2 || result[0].Name != "John Doe" || result[1].Name != "Grace Lee" {
+		t.Errorf("Expected John Doe and Grace Lee, got %v", result)
+	}
+}
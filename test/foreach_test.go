@@ -0,0 +1,195 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestForEach_SortedBatches tests that GormForEach and QueryForEach visit every match in the order
+// of DataGormNoPage and DataQueryNoPage, in batches of the requested size
+func TestForEach_SortedBatches(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: 26, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	expected, err := handler.DataGormNoPage(db, filterRoot)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	var ids []uint
+	var batchSizes []int
+	err = handler.GormForEach(db, filterRoot, 3, func(batch []*TestUser) error {
+		batchSizes = append(batchSizes, len(batch))
+		for _, user := range batch {
+			ids = append(ids, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GormForEach failed: %v", err)
+	}
+	if got := userIDs(expected); !equalIDs(ids, got) {
+		t.Errorf("Expected GormForEach IDs %v, got %v", got, ids)
+	}
+	for i, size := range batchSizes {
+		if size > 3 || (i < len(batchSizes)-1 && size != 3) {
+			t.Errorf("Expected full batches of 3 rows, got sizes %v", batchSizes)
+			break
+		}
+	}
+
+	users := generateTestUsers()
+	expected, err = handler.DataQueryNoPage(users, filterRoot)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	ids = nil
+	err = handler.QueryForEach(users, filterRoot, func(user *TestUser) error {
+		ids = append(ids, user.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("QueryForEach failed: %v", err)
+	}
+	if got := userIDs(expected); !equalIDs(ids, got) {
+		t.Errorf("Expected QueryForEach IDs %v, got %v", got, ids)
+	}
+}
+
+// TestForEach_RowsLeavingTheFilter tests that GormForEach doesn't skip matches when fn changes the
+// rows of each batch so they no longer match, as a batch job that processes them would
+func TestForEach_RowsLeavingTheFilter(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		},
+		SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderAsc}},
+	}
+
+	expected, err := handler.DataGormNoPage(db, filterRoot)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	var ids []uint
+	err = handler.GormForEach(db, filterRoot, 2, func(batch []*TestUser) error {
+		for _, user := range batch {
+			ids = append(ids, user.ID)
+			if err := db.Model(&TestUser{}).Where("id = ?", user.ID).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GormForEach failed: %v", err)
+	}
+	if got := userIDs(expected); !equalIDs(ids, got) {
+		t.Errorf("Expected GormForEach IDs %v, got %v", got, ids)
+	}
+}
+
+// TestForEach_StopsOnError tests that an error from fn stops the iteration and is returned unchanged
+func TestForEach_StopsOnError(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	errStop := errors.New("stop")
+
+	calls := 0
+	err := handler.GormForEach(db, filter.Root{Logic: filter.LogicAnd}, 2, func(batch []*TestUser) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Expected GormForEach to stop after the first batch with errStop, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = handler.QueryForEach(generateTestUsers(), filter.Root{Logic: filter.LogicAnd}, func(user *TestUser) error {
+		calls++
+		if calls == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || calls != 3 {
+		t.Errorf("Expected QueryForEach to stop at the third record with errStop, got %v after %d calls", err, calls)
+	}
+}
+
+// TestForEach_Cancelled tests that a cancelled context stops both iterations
+func TestForEach_Cancelled(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := handler.GormForEachCtx(ctx, db, filter.Root{Logic: filter.LogicAnd}, 2, func(batch []*TestUser) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected GormForEachCtx to return context.Canceled, got %v", err)
+	}
+
+	calls := 0
+	err = handler.QueryForEachCtx(ctx, generateTestUsers(), filter.Root{Logic: filter.LogicAnd}, func(user *TestUser) error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("Expected QueryForEachCtx to return context.Canceled without calls, got %v after %d calls", err, calls)
+	}
+}
+
+// TestForEach_SelectFieldsWithoutSortField tests that keyset batches still visit every match when
+// SelectFields leaves out the sort field, whose value each batch continues after
+func TestForEach_SelectFieldsWithoutSortField(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic:        filter.LogicAnd,
+		SortFields:   []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+		SelectFields: []string{"name"},
+	}
+
+	expected, err := handler.DataGormNoPage(db, filterRoot)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	var ids []uint
+	err = handler.GormForEach(db, filterRoot, 2, func(batch []*TestUser) error {
+		for _, user := range batch {
+			ids = append(ids, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GormForEach failed: %v", err)
+	}
+	if got := userIDs(expected); len(got) < 3 || !equalIDs(ids, got) {
+		t.Errorf("Expected GormForEach IDs %v, got %v", got, ids)
+	}
+
+	var buf bytes.Buffer
+	opts := filter.DefaultCSVOptions()
+	opts.BatchSize = 2
+	if err := handler.GormCSVStream(db, filterRoot, &buf, opts); err != nil {
+		t.Fatalf("GormCSVStream failed: %v", err)
+	}
+	if rows := strings.Count(buf.String(), "\n") - 1; rows != len(expected) {
+		t.Errorf("Expected GormCSVStream to write %d rows, got %d", len(expected), rows)
+	}
+}
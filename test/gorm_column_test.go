@@ -0,0 +1,150 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ColumnTagTeam has a gorm column tag that differs from its json name
+type ColumnTagTeam struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Label string `gorm:"column:team_label_txt" json:"label"`
+}
+
+// ColumnTagMember has gorm column tags on its own fields and on a belongs-to relation's fields
+type ColumnTagMember struct {
+	ID       uint           `gorm:"primaryKey" json:"id"`
+	FullName string         `gorm:"column:full_name_txt" json:"full_name"`
+	Score    int            `gorm:"column:score_num" json:"score"`
+	TeamID   uint           `json:"team_id"`
+	Team     *ColumnTagTeam `gorm:"foreignKey:TeamID" json:"team"`
+}
+
+func generateColumnTagMembers() []*ColumnTagMember {
+	red := &ColumnTagTeam{ID: 1, Label: "Red"}
+	blue := &ColumnTagTeam{ID: 2, Label: "Blue"}
+	return []*ColumnTagMember{
+		{ID: 1, FullName: "Ada Lovelace", Score: 90, TeamID: 1, Team: red},
+		{ID: 2, FullName: "Alan Turing", Score: 75, TeamID: 2, Team: blue},
+		{ID: 3, FullName: "Grace Hopper", Score: 82, TeamID: 1, Team: red},
+		{ID: 4, FullName: "Edsger Dijkstra", Score: 60, TeamID: 2, Team: blue},
+	}
+}
+
+func setupColumnTagDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ColumnTagTeam{}, &ColumnTagMember{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, team := range []*ColumnTagTeam{{ID: 1, Label: "Red"}, {ID: 2, Label: "Blue"}} {
+		if err := db.Create(team).Error; err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+	}
+	for _, member := range generateColumnTagMembers() {
+		member.Team = nil
+		if err := db.Create(member).Error; err != nil {
+			t.Fatalf("Failed to create member: %v", err)
+		}
+	}
+	return db
+}
+
+func columnTagMemberIDs(members []*ColumnTagMember) []uint {
+	ids := make([]uint, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	return ids
+}
+
+// TestGormColumnTags tests that gorm column tags are used in WHERE and ORDER BY, while DataQuery
+// keeps matching by json name, so DataGorm, DataQuery and Hybrid agree
+func TestGormColumnTags(t *testing.T) {
+	db := setupColumnTagDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[ColumnTagMember](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	members := generateColumnTagMembers()
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"TextFilter", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "full_name", Value: "a", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+			},
+		}, []uint{1, 2}},
+		{"NumberFilterAndSort", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "score", Value: 70, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			},
+			SortFields: []filter.SortField{{Field: "score", Order: filter.SortOrderDesc}},
+		}, []uint{1, 3, 2}},
+		{"SortByTaggedText", filter.Root{
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "full_name", Order: filter.SortOrderAsc}},
+		}, []uint{1, 2, 4, 3}},
+		{"NestedRelationColumn", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "team.label", Value: "Blue", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "score", Order: filter.SortOrderAsc}},
+		}, []uint{4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := columnTagMemberIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm members %v, got %v", tt.expected, ids)
+			}
+
+			result, err := handler.DataQuery(members, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := columnTagMemberIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery members %v, got %v", tt.expected, ids)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db.Preload("Team"), threshold, tt.root, 0, 10)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if ids := columnTagMemberIDs(hybrid.Data); !equalIDs(ids, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) members %v, got %v", hybrid.Strategy, tt.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+// TestGormColumnTags_SelectFields tests that selected fields load through their gorm column
+func TestGormColumnTags_SelectFields(t *testing.T) {
+	db := setupColumnTagDB(t)
+	handler := filter.NewFilter[ColumnTagMember](filter.GolangFilteringConfig{})
+	root := filter.Root{Logic: filter.LogicAnd, SelectFields: []string{"full_name"}}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if len(page.Data) != 4 || page.Data[0].FullName != "Ada Lovelace" || page.Data[0].Score != 0 {
+		t.Errorf("Expected only full_name to be loaded, got %+v", page.Data[0])
+	}
+}
@@ -0,0 +1,241 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// HasManyOrderItem is a line of a HasManyOrder
+type HasManyOrderItem struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	OrderID  uint   `json:"order_id"`
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// HasManyOrder has many items, so joining its items repeats the order once per item
+type HasManyOrder struct {
+	ID       uint               `gorm:"primaryKey" json:"id"`
+	Customer string             `json:"customer"`
+	Total    float64            `json:"total"`
+	Items    []HasManyOrderItem `gorm:"foreignKey:OrderID" json:"items"`
+}
+
+func generateHasManyOrders() []*HasManyOrder {
+	return []*HasManyOrder{
+		{ID: 1, Customer: "Alice", Total: 120, Items: []HasManyOrderItem{
+			{SKU: "WIDGET", Quantity: 2}, {SKU: "GADGET", Quantity: 1}, {SKU: "WIDGET-XL", Quantity: 5},
+		}},
+		{ID: 2, Customer: "Bob", Total: 40, Items: []HasManyOrderItem{
+			{SKU: "WIDGET", Quantity: 1},
+		}},
+		{ID: 3, Customer: "Carol", Total: 75, Items: []HasManyOrderItem{
+			{SKU: "BOLT", Quantity: 10}, {SKU: "NUT", Quantity: 10},
+		}},
+		{ID: 4, Customer: "Dave", Total: 10, Items: []HasManyOrderItem{}}, // No items
+	}
+}
+
+func setupHasManyDB(t *testing.T) *gorm.DB {
+	return setupHasManyDBWithConfig(t, &gorm.Config{})
+}
+
+func setupHasManyDBWithConfig(t *testing.T, config *gorm.Config) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), config)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&HasManyOrder{}, &HasManyOrderItem{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, order := range generateHasManyOrders() {
+		if err := db.Create(order).Error; err != nil {
+			t.Fatalf("Failed to create order: %v", err)
+		}
+	}
+	return db
+}
+
+func orderIDs(orders []*HasManyOrder) []uint {
+	ids := make([]uint, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestHasMany_CountsDistinctRecords tests that filtering on a has-many relation counts and returns
+// each order once, even though the join repeats an order for every matching item
+func TestHasMany_CountsDistinctRecords(t *testing.T) {
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		// Order 1 has two WIDGET items, so a plain JOIN would count it twice
+		{"ContainsWidget", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.sku", Value: "widget", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			},
+		}, []uint{1, 2}},
+		{"QuantityAtLeastTwo", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.quantity", Value: 2, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			},
+		}, []uint{1, 3}},
+		{"CombinedWithParentField", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.quantity", Value: 10, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+				{Field: "total", Value: 50, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+			},
+		}, []uint{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if page.TotalSize != len(tt.expected) || page.TotalPage != 1 {
+				t.Errorf("Expected TotalSize=%d TotalPage=1, got %d and %d", len(tt.expected), page.TotalSize, page.TotalPage)
+			}
+			if ids := orderIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected orders %v, got %v", tt.expected, ids)
+			}
+
+			count, err := handler.CountGorm(db, tt.root)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) {
+				t.Errorf("Expected CountGorm=%d, got %d", len(tt.expected), count)
+			}
+
+			all, err := handler.DataGormNoPage(db, tt.root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if ids := orderIDs(all); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGormNoPage orders %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestHasMany_PaginationDoesNotRepeatRecords tests that pages hold whole orders rather than joined rows
+func TestHasMany_PaginationDoesNotRepeatRecords(t *testing.T) {
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.quantity", Value: 1, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	var seen []uint
+	for pageIndex := 0; pageIndex < 3; pageIndex++ {
+		page, err := handler.DataGorm(db, root, pageIndex, 2)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if page.TotalSize != 3 || page.TotalPage != 2 {
+			t.Fatalf("Expected TotalSize=3 TotalPage=2, got %d and %d", page.TotalSize, page.TotalPage)
+		}
+		seen = append(seen, orderIDs(page.Data)...)
+	}
+	if !equalIDs(seen, []uint{1, 2, 3}) {
+		t.Errorf("Expected orders [1 2 3] across pages, got %v", seen)
+	}
+}
+
+// TestHasMany_SortByRelatedField tests that sorting on a has-many field orders each record
+// by its smallest value ascending and by its largest value descending
+func TestHasMany_SortByRelatedField(t *testing.T) {
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		order    filter.SortOrder
+		expected []uint
+	}{
+		// Smallest quantities: Dave none (NULL first in SQLite), Alice 1, Bob 1, Carol 10
+		{"Asc", filter.SortOrderAsc, []uint{4, 1, 2, 3}},
+		// Largest quantities: Carol 10, Alice 5, Bob 1, Dave none
+		{"Desc", filter.SortOrderDesc, []uint{3, 1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:      filter.LogicAnd,
+				SortFields: []filter.SortField{{Field: "items.quantity", Order: tt.order}, {Field: "id", Order: filter.SortOrderAsc}},
+			}
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if page.TotalSize != 4 {
+				t.Errorf("Expected TotalSize=4, got %d", page.TotalSize)
+			}
+			if ids := orderIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected orders %v, got %v", tt.expected, ids)
+			}
+
+			all, err := handler.DataGormNoPage(db, root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if ids := orderIDs(all); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGormNoPage orders %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestHasMany_PreloadStillLoadsAllItems tests that filtering on items does not trim the preloaded items
+func TestHasMany_PreloadStillLoadsAllItems(t *testing.T) {
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.sku", Value: "GADGET", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		Preload: []string{"Items"},
+	}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if len(page.Data) != 1 || page.Data[0].ID != 1 {
+		t.Fatalf("Expected only order 1, got %v", orderIDs(page.Data))
+	}
+	if len(page.Data[0].Items) != 3 {
+		t.Errorf("Expected 3 preloaded items, got %d", len(page.Data[0].Items))
+	}
+}
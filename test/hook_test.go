@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// HookMember belongs to an organization and has an email only admins may see
+type HookMember struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	OrgID uint   `json:"org_id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func generateHookMembers() []*HookMember {
+	return []*HookMember{
+		{ID: 1, OrgID: 1, Name: "Ana", Email: "ana@one.test"},
+		{ID: 2, OrgID: 2, Name: "Ben", Email: "ben@two.test"},
+		{ID: 3, OrgID: 1, Name: "Cy", Email: "cy@one.test"},
+		{ID: 4, OrgID: 2, Name: "Di", Email: "di@two.test"},
+	}
+}
+
+// tenantHook scopes every query to one organization, in SQL and in memory
+type tenantHook struct {
+	orgID uint
+}
+
+func (h tenantHook) BeforeGorm(db *gorm.DB, _ filter.Root) *gorm.DB {
+	return db.Where("org_id = ?", h.orgID)
+}
+
+func (h tenantHook) BeforeQuery(data []*HookMember, _ filter.Root) []*HookMember {
+	scoped := make([]*HookMember, 0, len(data))
+	for _, member := range data {
+		if member.OrgID == h.orgID {
+			scoped = append(scoped, member)
+		}
+	}
+	return scoped
+}
+
+// redactHook blanks the email of copies of the fetched members
+type redactHook struct{}
+
+func (redactHook) AfterFetch(items []*HookMember) []*HookMember {
+	redacted := make([]*HookMember, len(items))
+	for i, item := range items {
+		clone := *item
+		clone.Email = ""
+		redacted[i] = &clone
+	}
+	return redacted
+}
+
+func setupHookDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&HookMember{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateHookMembers()).Error; err != nil {
+		t.Fatalf("Failed to create members: %v", err)
+	}
+	return db
+}
+
+func checkHookMembers(t *testing.T, name string, members []*HookMember) {
+	t.Helper()
+	if len(members) != 2 {
+		t.Fatalf("%s: expected the 2 members of org 1, got %d", name, len(members))
+	}
+	for _, member := range members {
+		if member.OrgID != 1 || member.Email != "" {
+			t.Errorf("%s: expected org 1 members with redacted emails, got %+v", name, member)
+		}
+	}
+}
+
+// TestHooks_AllPaths tests that a tenancy hook scopes and a redaction hook post-processes every path
+func TestHooks_AllPaths(t *testing.T) {
+	db := setupHookDB(t)
+	members := generateHookMembers()
+	handler := filter.NewFilter[HookMember](filter.GolangFilteringConfig{}).
+		Use(tenantHook{orgID: 1}).
+		Use(redactHook{})
+	root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if page.TotalSize != 2 {
+		t.Errorf("DataGorm: expected TotalSize 2, got %d", page.TotalSize)
+	}
+	checkHookMembers(t, "DataGorm", page.Data)
+
+	all, err := handler.DataGormNoPage(db, root)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	checkHookMembers(t, "DataGormNoPage", all)
+
+	for _, threshold := range []int{1, 1000} {
+		page, err := handler.Hybrid(db, threshold, root, 0, 10)
+		if err != nil {
+			t.Fatalf("Hybrid failed: %v", err)
+		}
+		checkHookMembers(t, "Hybrid "+string(page.Strategy), page.Data)
+	}
+
+	page, err = handler.DataQuery(members, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	checkHookMembers(t, "DataQuery", page.Data)
+	if members[0].Email == "" {
+		t.Error("Expected the caller's records to keep their emails")
+	}
+
+	count, err := handler.CountGorm(db, root)
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountGorm: expected 2, got %d", count)
+	}
+
+	expected := [][]string{{"id", "name", "email"}, {"1", "Ana", ""}, {"3", "Cy", ""}}
+	opts := filter.DefaultCSVOptions()
+	opts.Columns = []string{"id", "name", "email"}
+	gormCSV, err := handler.GormNoPaginationCSVWithOptions(db, root, opts)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+	memoryCSV, err := handler.DataQueryNoPageCSVWithOptions(members, root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	for name, data := range map[string][]byte{"gorm": gormCSV, "memory": memoryCSV} {
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read %s CSV: %v", name, err)
+		}
+		if len(records) != len(expected) {
+			t.Fatalf("Expected %d %s CSV rows, got %v", len(expected), name, records)
+		}
+		for i := range expected {
+			for j := range expected[i] {
+				if records[i][j] != expected[i][j] {
+					t.Errorf("Expected %s CSV row %v, got %v", name, expected[i], records[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+// TestHooks_UsePanics tests that Use rejects values implementing no hook interface
+func TestHooks_UsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Use to panic")
+		}
+	}()
+	filter.NewFilter[HookMember](filter.GolangFilteringConfig{}).Use(struct{}{})
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestHybrid_FallbackToGormOnError tests that Hybrid retries through DataGorm a Root the in-memory path
+// cannot evaluate, here a nested field without a getter, reporting the in-memory error to the Observer
+func TestHybrid_FallbackToGormOnError(t *testing.T) {
+	db := setupNestedRelationsDB(t)
+	depth := 0 // No nested getters, so currency.currency_code only exists in the database
+	var reports []filter.QueryResultInfo
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{
+		MaxDepth: &depth,
+		Observer: filter.ObserverFunc(func(filter.QueryInfo) func(filter.QueryResultInfo) {
+			return func(info filter.QueryResultInfo) { reports = append(reports, info) }
+		}),
+	})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "currency.currency_code", Value: "PHP", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	memory := func(int64, filter.Root) filter.Strategy { return filter.StrategyMemory }
+
+	t.Run("Paginated", func(t *testing.T) {
+		reports = nil
+		opts := filter.HybridOptions{StrategyFunc: memory, FallbackToGormOnError: true}
+		result, err := handler.HybridWithOptions(t.Context(), db, 1000, root, 0, 10, opts)
+		if err != nil {
+			t.Fatalf("HybridWithOptions failed: %v", err)
+		}
+		if result.TotalSize != 2 || result.Data[0].ID != 4 || result.Data[1].ID != 5 {
+			t.Errorf("Expected the 2 peso items, got %d", result.TotalSize)
+		}
+		if !result.Fallback || result.Strategy != filter.StrategyDatabase {
+			t.Errorf("Expected a fallback to the database strategy, got %v (%s)", result.Fallback, result.Strategy)
+		}
+		if len(reports) != 1 || reports[0].Strategy != filter.StrategyDatabase || !errors.Is(reports[0].FallbackErr, filter.ErrUnknownField) {
+			t.Errorf("Expected the observer to report the in-memory error, got %+v", reports)
+		}
+	})
+
+	t.Run("NoPage", func(t *testing.T) {
+		reports = nil
+		opts := filter.HybridOptions{StrategyFunc: memory, FallbackToGormOnError: true}
+		data, strategy, err := handler.DataHybridNoPageWithOptions(t.Context(), db, 1000, root, opts)
+		if err != nil {
+			t.Fatalf("DataHybridNoPageWithOptions failed: %v", err)
+		}
+		if len(data) != 2 || strategy != filter.StrategyDatabase {
+			t.Errorf("Expected the 2 peso items from the database strategy, got %d (%s)", len(data), strategy)
+		}
+		if len(reports) != 1 || reports[0].FallbackErr == nil {
+			t.Errorf("Expected the observer to report the in-memory error, got %+v", reports)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		reports = nil
+		// Without the option the database strategy is picked outright rather than skipping the filter in memory
+		opts := filter.HybridOptions{StrategyFunc: memory}
+		result, err := handler.HybridWithOptions(t.Context(), db, 1000, root, 0, 10, opts)
+		if err != nil {
+			t.Fatalf("HybridWithOptions failed: %v", err)
+		}
+		if result.Fallback || result.Strategy != filter.StrategyDatabase || result.TotalSize != 2 {
+			t.Errorf("Expected the 2 peso items from the database strategy, got %d (%s)", result.TotalSize, result.Strategy)
+		}
+		if len(reports) != 1 || reports[0].FallbackErr != nil {
+			t.Errorf("Expected no fallback error, got %+v", reports)
+		}
+
+		data, strategy, err := handler.DataHybridNoPageWithOptions(t.Context(), db, 1000, root, opts)
+		if err != nil {
+			t.Fatalf("DataHybridNoPageWithOptions failed: %v", err)
+		}
+		if len(data) != 2 || strategy != filter.StrategyDatabase {
+			t.Errorf("Expected the 2 peso items from the database strategy, got %d (%s)", len(data), strategy)
+		}
+	})
+
+	t.Run("DatabaseFails", func(t *testing.T) {
+		// The fallback error is the database one when DataGorm fails too
+		strict := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{MaxDepth: &depth, StrictValidation: true})
+		invalid := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "value", Value: "lots", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+			},
+		}
+		opts := filter.HybridOptions{StrategyFunc: memory, FallbackToGormOnError: true}
+		if _, err := strict.HybridWithOptions(t.Context(), db, 1000, invalid, 0, 10, opts); !errors.Is(err, filter.ErrInvalidValue) {
+			t.Errorf("Expected an invalid value error, got %v", err)
+		}
+	})
+}
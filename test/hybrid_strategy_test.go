@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestHybridStrategy_DefaultThreshold tests that the default decision is reported on the result
+func TestHybridStrategy_DefaultThreshold(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{Logic: filter.LogicAnd}
+
+	memory, err := handler.Hybrid(db, 10000, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("Hybrid failed: %v", err)
+	}
+	if memory.Strategy != filter.StrategyMemory {
+		t.Errorf("Expected memory strategy for a small table, got %q", memory.Strategy)
+	}
+
+	database, err := handler.Hybrid(db, 1, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("Hybrid failed: %v", err)
+	}
+	if database.Strategy != filter.StrategyDatabase {
+		t.Errorf("Expected database strategy above the threshold, got %q", database.Strategy)
+	}
+	if memory.TotalSize != database.TotalSize {
+		t.Errorf("Expected identical totals, got memory=%d database=%d", memory.TotalSize, database.TotalSize)
+	}
+
+	// Non-hybrid calls leave Strategy empty
+	direct, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if direct.Strategy != "" {
+		t.Errorf("Expected empty strategy for DataGorm, got %q", direct.Strategy)
+	}
+}
+
+// TestHybridStrategy_StrategyFunc tests that a custom decision overrides the threshold
+func TestHybridStrategy_StrategyFunc(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	var seenRows int64
+	opts := filter.HybridOptions{
+		StrategyFunc: func(estimatedRows int64, root filter.Root) filter.Strategy {
+			seenRows = estimatedRows
+			// Always use the database when any filter is present
+			if len(root.FieldFilters) > 0 {
+				return filter.StrategyDatabase
+			}
+			return filter.StrategyMemory
+		},
+	}
+
+	result, err := handler.HybridWithOptions(context.Background(), db, 10000, filterRoot, 0, 10, opts)
+	if err != nil {
+		t.Fatalf("HybridWithOptions failed: %v", err)
+	}
+	if result.Strategy != filter.StrategyDatabase || result.TotalSize != 3 {
+		t.Errorf("Expected database strategy with 3 admins, got %q with %d", result.Strategy, result.TotalSize)
+	}
+	if seenRows != 10 {
+		t.Errorf("Expected StrategyFunc to receive 10 estimated rows, got %d", seenRows)
+	}
+
+	data, strategy, err := handler.DataHybridNoPageWithOptions(context.Background(), db, 0, filter.Root{Logic: filter.LogicAnd}, opts)
+	if err != nil {
+		t.Fatalf("DataHybridNoPageWithOptions failed: %v", err)
+	}
+	if strategy != filter.StrategyMemory || len(data) != 10 {
+		t.Errorf("Expected memory strategy with 10 records, got %q with %d", strategy, len(data))
+	}
+
+	// Unknown strategies are rejected
+	opts.StrategyFunc = func(int64, filter.Root) filter.Strategy { return "cache" }
+	if _, err := handler.HybridWithOptions(context.Background(), db, 10000, filterRoot, 0, 10, opts); err == nil {
+		t.Error("Expected error for unknown strategy, got nil")
+	}
+}
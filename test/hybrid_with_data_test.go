@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+)
+
+// countQueries registers a callback counting the SELECT queries run on db
+func countQueries(t *testing.T, db *gorm.DB) *int {
+	queries := 0
+	if err := db.Callback().Query().Before("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("test:count_raw", func(*gorm.DB) {
+		queries++
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return &queries
+}
+
+// TestHybridWithData_MemoryPathSkipsFetch tests that the in-memory path filters the given slice
+// without querying the database, and leaves the slice unchanged
+func TestHybridWithData_MemoryPathSkipsFetch(t *testing.T) {
+	db := setupTestDB(t)
+	queries := countQueries(t, db)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+	before := append([]*TestUser(nil), users...)
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	result, err := handler.HybridWithData(db, users, 1000, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("HybridWithData failed: %v", err)
+	}
+	if result.Strategy != filter.StrategyMemory {
+		t.Errorf("Expected the memory strategy, got %q", result.Strategy)
+	}
+	if *queries != 0 {
+		t.Errorf("Expected no queries on the memory path, got %d", *queries)
+	}
+
+	expected, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != expected.TotalSize || len(result.Data) != len(expected.Data) {
+		t.Fatalf("Expected %d users, got %d", expected.TotalSize, result.TotalSize)
+	}
+	for i := range result.Data {
+		if result.Data[i].ID != expected.Data[i].ID {
+			t.Errorf("Expected user %d at %d, got %d", expected.Data[i].ID, i, result.Data[i].ID)
+		}
+	}
+
+	for i := range users {
+		if users[i] != before[i] {
+			t.Fatalf("Expected the data slice to be unchanged, position %d changed", i)
+		}
+	}
+}
+
+// TestHybridWithData_DatabasePath tests that a slice above the threshold falls back to DataGorm
+func TestHybridWithData_DatabasePath(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		},
+	}
+
+	// A stale cached slice is not used on the database path
+	stale := generateTestUsers()[:2]
+	result, err := handler.HybridWithData(db, stale, 1, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("HybridWithData failed: %v", err)
+	}
+	if result.Strategy != filter.StrategyDatabase {
+		t.Errorf("Expected the database strategy, got %q", result.Strategy)
+	}
+	expected, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != expected.TotalSize {
+		t.Errorf("Expected TotalSize=%d from the database, got %d", expected.TotalSize, result.TotalSize)
+	}
+}
+
+// TestHybridWithData_StrategyFuncAndErrors tests that StrategyFunc sees len(data) and that invalid
+// filters are rejected before any query
+func TestHybridWithData_StrategyFuncAndErrors(t *testing.T) {
+	db := setupTestDB(t)
+	queries := countQueries(t, db)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{}).
+		RestrictValues("role", []string{"admin", "user"})
+	users := generateTestUsers()
+
+	var seenRows int64
+	opts := filter.HybridOptions{
+		StrategyFunc: func(estimatedRows int64, root filter.Root) filter.Strategy {
+			seenRows = estimatedRows
+			return filter.StrategyMemory
+		},
+	}
+	if _, err := handler.HybridWithDataCtx(context.Background(), db, users, 0, filter.Root{Logic: filter.LogicAnd}, 0, 5, opts); err != nil {
+		t.Fatalf("HybridWithDataCtx failed: %v", err)
+	}
+	if seenRows != int64(len(users)) {
+		t.Errorf("Expected StrategyFunc to see %d rows, got %d", len(users), seenRows)
+	}
+
+	invalid := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "owner", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	if _, err := handler.HybridWithData(db, users, 0, invalid, 0, 5); err == nil {
+		t.Error("Expected an error for a restricted value")
+	}
+	if *queries != 0 {
+		t.Errorf("Expected no queries, got %d", *queries)
+	}
+}
@@ -0,0 +1,174 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestInMode_Text tests ModeIn and ModeNotIn on text fields for both paths
+func TestInMode_Text(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name     string
+		mode     filter.Mode
+		value    any
+		expected int
+	}{
+		{"in []string", filter.ModeIn, []string{"admin", "moderator"}, 5},
+		{"in []any (JSON)", filter.ModeIn, []any{"Admin", "MODERATOR"}, 5},
+		{"not in", filter.ModeNotIn, []string{"admin", "moderator"}, 5},
+		{"in empty list", filter.ModeIn, []string{}, 0},
+		{"not in empty list", filter.ModeNotIn, []string{}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterRoot := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "role", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeText},
+				},
+			}
+
+			queryResult, err := handler.DataQuery(users, filterRoot, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			gormResult, err := handler.DataGorm(db, filterRoot, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if queryResult.TotalSize != tt.expected {
+				t.Errorf("DataQuery: expected %d, got %d", tt.expected, queryResult.TotalSize)
+			}
+			if gormResult.TotalSize != tt.expected {
+				t.Errorf("DataGorm: expected %d, got %d", tt.expected, gormResult.TotalSize)
+			}
+		})
+	}
+}
+
+// TestInMode_Number tests ModeIn and ModeNotIn on number fields combined with AND logic
+func TestInMode_Number(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "id", Value: []any{float64(1), float64(5), float64(9)}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		},
+	}
+
+	queryResult, err := handler.DataQuery(users, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	gormResult, err := handler.DataGorm(db, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	// IDs 1 and 5 are active, 9 is inactive
+	if queryResult.TotalSize != 2 {
+		t.Errorf("DataQuery: expected 2, got %d", queryResult.TotalSize)
+	}
+	if gormResult.TotalSize != 2 {
+		t.Errorf("DataGorm: expected 2, got %d", gormResult.TotalSize)
+	}
+
+	filterRoot.FieldFilters = []filter.FieldFilter{
+		{Field: "age", Value: []int{25, 30, 35}, Mode: filter.ModeNotIn, DataType: filter.DataTypeNumber},
+	}
+	queryResult, err = handler.DataQuery(users, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	gormResult, err = handler.DataGorm(db, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if queryResult.TotalSize != 7 || gormResult.TotalSize != 7 {
+		t.Errorf("Expected 7 on both paths, got DataQuery=%d DataGorm=%d", queryResult.TotalSize, gormResult.TotalSize)
+	}
+}
+
+// TestInMode_Date tests ModeIn on date fields using whole-day matching
+func TestInMode_Date(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{
+				Field:    "created_at",
+				Value:    []any{"2024-01-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+				Mode:     filter.ModeIn,
+				DataType: filter.DataTypeDate,
+			},
+		},
+	}
+
+	queryResult, err := handler.DataQuery(users, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	gormResult, err := handler.DataGorm(db, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if queryResult.TotalSize != 2 || gormResult.TotalSize != 2 {
+		t.Errorf("Expected 2 on both paths, got DataQuery=%d DataGorm=%d", queryResult.TotalSize, gormResult.TotalSize)
+	}
+
+	filterRoot.FieldFilters[0].Mode = filter.ModeNotIn
+	queryResult, err = handler.DataQuery(users, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	gormResult, err = handler.DataGorm(db, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if queryResult.TotalSize != 8 || gormResult.TotalSize != 8 {
+		t.Errorf("Expected 8 on both paths, got DataQuery=%d DataGorm=%d", queryResult.TotalSize, gormResult.TotalSize)
+	}
+}
+
+// TestInMode_BoolUnsupported tests that ModeIn returns an error for boolean fields
+func TestInMode_BoolUnsupported(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: []any{true}, Mode: filter.ModeIn, DataType: filter.DataTypeBool},
+		},
+	}
+
+	if _, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 100); err == nil {
+		t.Error("Expected error for ModeIn on boolean field, got nil")
+	}
+}
+
+// TestInMode_InvalidList tests that a non-list value returns an error
+func TestInMode_InvalidList(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		},
+	}
+
+	if _, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 100); err == nil {
+		t.Error("Expected error for non-list ModeIn value, got nil")
+	}
+}
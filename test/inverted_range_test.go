@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestInvertedRange_BothPathsFail tests that an inverted date or time range is an error in every path,
+// even without StrictValidation, instead of DataGorm silently dropping the condition and returning everything
+func TestInvertedRange_BothPathsFail(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name    string
+		filter  filter.FieldFilter
+		message string
+	}{
+		{"Date", filter.FieldFilter{
+			Field:    "created_at",
+			Value:    filter.Range{From: "2024-06-01", To: "2024-01-01"},
+			Mode:     filter.ModeRange,
+			DataType: filter.DataTypeDate,
+		}, "range from date cannot be after to date"},
+		{"DateFromJSONRange", filter.FieldFilter{
+			Field:    "created_at",
+			Value:    map[string]any{"from": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), "to": "2024-01-01"},
+			Mode:     filter.ModeRange,
+			DataType: filter.DataTypeDate,
+		}, "range from date cannot be after to date"},
+		{"Time", filter.FieldFilter{
+			Field:    "created_at",
+			Value:    filter.Range{From: "18:00:00", To: "09:00:00"},
+			Mode:     filter.ModeRange,
+			DataType: filter.DataTypeTime,
+		}, "range from time cannot be after to time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			orRoot := filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+					tt.filter,
+				},
+			}
+			groupRoot := filter.Root{Logic: filter.LogicAnd, Groups: []filter.Root{root}}
+
+			for rootName, r := range map[string]filter.Root{"And": root, "Or": orRoot, "Group": groupRoot} {
+				if _, err := handler.DataQuery(users, r, 0, 10); err == nil || !strings.Contains(err.Error(), tt.message) {
+					t.Errorf("%s: expected DataQuery error %q, got %v", rootName, tt.message, err)
+				}
+				if _, err := handler.DataGorm(db, r, 0, 10); err == nil || !strings.Contains(err.Error(), tt.message) {
+					t.Errorf("%s: expected DataGorm error %q, got %v", rootName, tt.message, err)
+				}
+				if _, err := handler.CountGorm(db, r); err == nil || !strings.Contains(err.Error(), tt.message) {
+					t.Errorf("%s: expected CountGorm error %q, got %v", rootName, tt.message, err)
+				}
+			}
+
+			// Hybrid fails the same way whichever strategy it picks
+			for _, strategy := range []filter.Strategy{filter.StrategyMemory, filter.StrategyDatabase} {
+				opts := filter.HybridOptions{
+					StrategyFunc: func(int64, filter.Root) filter.Strategy { return strategy },
+				}
+				if _, err := handler.HybridWithOptions(context.Background(), db, 100, root, 0, 10, opts); err == nil || !strings.Contains(err.Error(), tt.message) {
+					t.Errorf("Expected Hybrid (%s) error %q, got %v", strategy, tt.message, err)
+				}
+			}
+		})
+	}
+}
+
+// TestInvertedRange_OtherInvalidValuesStayLenient tests that other unparseable values are still skipped
+// by DataGorm without StrictValidation
+func TestInvertedRange_OtherInvalidValuesStayLenient(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: filter.Range{From: "not a date", To: "2024-01-01"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+		},
+	}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if page.TotalSize != 10 {
+		t.Errorf("Expected the unparseable filter to be skipped (10 users), got %d", page.TotalSize)
+	}
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NullableEmployee represents a model with nullable number, bool, and date columns
+type NullableEmployee struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Name            string     `json:"name"`
+	Commission      *float64   `json:"commission"`
+	IsRemote        *bool      `json:"is_remote"`
+	TerminationDate *time.Time `json:"termination_date"`
+}
+
+func setupNullableEmployees(t *testing.T) (*gorm.DB, []*NullableEmployee) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&NullableEmployee{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	commission := 150.0
+	remote := true
+	terminated := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	employees := []*NullableEmployee{
+		{ID: 1, Name: "Alice", Commission: &commission, IsRemote: &remote},
+		{ID: 2, Name: "Bob", TerminationDate: &terminated},
+		{ID: 3, Name: "Carol", Commission: &commission},
+		{ID: 4, Name: "Dave", IsRemote: &remote, TerminationDate: &terminated},
+		{ID: 5, Name: "Eve"},
+	}
+	if err := db.Create(&employees).Error; err != nil {
+		t.Fatalf("Failed to create employees: %v", err)
+	}
+	return db, employees
+}
+
+// TestIsEmpty_NullableFields tests ModeIsEmpty/ModeIsNotEmpty on nullable number, bool, and date fields
+func TestIsEmpty_NullableFields(t *testing.T) {
+	db, employees := setupNullableEmployees(t)
+	handler := filter.NewFilter[NullableEmployee](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		field    string
+		dataType filter.DataType
+		mode     filter.Mode
+		expected int
+	}{
+		{"DateIsEmpty", "termination_date", filter.DataTypeDate, filter.ModeIsEmpty, 3},
+		{"DateIsNotEmpty", "termination_date", filter.DataTypeDate, filter.ModeIsNotEmpty, 2},
+		{"NumberIsEmpty", "commission", filter.DataTypeNumber, filter.ModeIsEmpty, 3},
+		{"NumberIsNotEmpty", "commission", filter.DataTypeNumber, filter.ModeIsNotEmpty, 2},
+		{"BoolIsEmpty", "is_remote", filter.DataTypeBool, filter.ModeIsEmpty, 3},
+		{"BoolIsNotEmpty", "is_remote", filter.DataTypeBool, filter.ModeIsNotEmpty, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterRoot := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: tt.field, Mode: tt.mode, DataType: tt.dataType},
+				},
+			}
+
+			queryResult, err := handler.DataQuery(employees, filterRoot, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			gormResult, err := handler.DataGorm(db, filterRoot, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if queryResult.TotalSize != tt.expected {
+				t.Errorf("DataQuery: expected %d, got %d", tt.expected, queryResult.TotalSize)
+			}
+			if gormResult.TotalSize != tt.expected {
+				t.Errorf("DataGorm: expected %d, got %d", tt.expected, gormResult.TotalSize)
+			}
+		})
+	}
+}
+
+// TestIsEmpty_ZeroTime tests that a zero time.Time is treated as empty
+func TestIsEmpty_ZeroTime(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+	users[0].CreatedAt = time.Time{}
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeDate},
+		},
+	}
+
+	result, err := handler.DataQuery(users, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if result.TotalSize != 1 {
+		t.Errorf("Expected 1 user with zero created_at, got %d", result.TotalSize)
+	}
+}
@@ -0,0 +1,214 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func TestJSONExport(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	exports := map[string]func(root filter.Root) ([]byte, error){
+		"gorm":   func(root filter.Root) ([]byte, error) { return handler.GormNoPaginationJSON(db, root) },
+		"memory": func(root filter.Root) ([]byte, error) { return handler.DataQueryNoPageJSON(users, root) },
+	}
+
+	for name, export := range exports {
+		t.Run(name, func(t *testing.T) {
+			data, err := export(root)
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			var records []map[string]any
+			if err := json.Unmarshal(data, &records); err != nil {
+				t.Fatalf("Output is not a JSON array: %v", err)
+			}
+			if len(records) != 3 {
+				t.Fatalf("Expected 3 records, got %d", len(records))
+			}
+			// json tags are used as keys, order follows the sort
+			if records[0]["name"] != "Charlie Wilson" || records[0]["is_active"] != true {
+				t.Errorf("Unexpected first record %v", records[0])
+			}
+			if _, exists := records[0]["IsActive"]; exists {
+				t.Error("Expected json tag names, found Go field name")
+			}
+
+			noMatch := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "name", Value: "nobody", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				},
+			}
+			data, err = export(noMatch)
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if string(data) != "[]" {
+				t.Errorf("Expected [] for no matches, got %s", data)
+			}
+		})
+	}
+}
+
+func TestJSONExportCustom(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	customGetter := func(user *TestUser) map[string]any {
+		return map[string]any{"userId": user.ID, "label": strings.ToUpper(user.Name)}
+	}
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "moderator", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	expected := `[{"label":"ALICE BROWN","userId":4},{"label":"EVE ADAMS","userId":8}]`
+
+	data, err := handler.GormNoPaginationJSONCustom(db, root, customGetter)
+	if err != nil {
+		t.Fatalf("GormNoPaginationJSONCustom failed: %v", err)
+	}
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	data, err = handler.DataQueryNoPageJSONCustom(users, root, customGetter)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageJSONCustom failed: %v", err)
+	}
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestNDJSONStream(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	roots := map[string]filter.Root{
+		"unsorted": {Logic: filter.LogicAnd},
+		"sorted": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+			SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}},
+		},
+	}
+
+	for name, root := range roots {
+		t.Run(name, func(t *testing.T) {
+			array, err := handler.GormNoPaginationJSON(db, root)
+			if err != nil {
+				t.Fatalf("GormNoPaginationJSON failed: %v", err)
+			}
+			var expected []json.RawMessage
+			if err := json.Unmarshal(array, &expected); err != nil {
+				t.Fatalf("Failed to parse JSON array: %v", err)
+			}
+
+			streams := map[string]func(w *bytes.Buffer) error{
+				"gorm":   func(w *bytes.Buffer) error { return handler.GormNDJSONStream(db, root, w) },
+				"memory": func(w *bytes.Buffer) error { return handler.DataQueryNDJSONStream(users, root, w) },
+			}
+			for streamName, stream := range streams {
+				var buf bytes.Buffer
+				if err := stream(&buf); err != nil {
+					t.Fatalf("%s stream failed: %v", streamName, err)
+				}
+				lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+				if len(lines) != len(expected) {
+					t.Fatalf("%s: expected %d lines, got %d", streamName, len(expected), len(lines))
+				}
+				for i, line := range lines {
+					var got, want map[string]any
+					if err := json.Unmarshal([]byte(line), &got); err != nil {
+						t.Fatalf("%s: line %d is not JSON: %v", streamName, i, err)
+					}
+					_ = json.Unmarshal(expected[i], &want)
+					if got["id"] != want["id"] {
+						t.Errorf("%s: line %d has id %v, expected %v", streamName, i, got["id"], want["id"])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONStreamCustom(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	customGetter := func(user *TestUser) map[string]any {
+		return map[string]any{"id": user.ID, "role": user.Role}
+	}
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderDesc}},
+	}
+
+	var gormBuf, memoryBuf bytes.Buffer
+	if err := handler.GormNDJSONStreamCustom(db, root, &gormBuf, customGetter); err != nil {
+		t.Fatalf("GormNDJSONStreamCustom failed: %v", err)
+	}
+	if err := handler.DataQueryNDJSONStreamCustom(users, root, &memoryBuf, customGetter); err != nil {
+		t.Fatalf("DataQueryNDJSONStreamCustom failed: %v", err)
+	}
+
+	if gormBuf.String() != memoryBuf.String() {
+		t.Errorf("Expected GORM and in-memory streams to match:\n%s\n%s", gormBuf.String(), memoryBuf.String())
+	}
+	if !strings.HasPrefix(memoryBuf.String(), `{"id":10,"role":"admin"}`+"\n") {
+		t.Errorf("Unexpected output %q", memoryBuf.String())
+	}
+	if strings.Count(memoryBuf.String(), "\n") != 10 {
+		t.Errorf("Expected 10 lines, got %q", memoryBuf.String())
+	}
+}
+
+func TestNDJSONStreamErrors(t *testing.T) {
+	db := setupTestDB(t)
+	strictHandler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+
+	invalid := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+		},
+	}
+	var buf bytes.Buffer
+	if err := strictHandler.GormNDJSONStream(db, invalid, &buf); err == nil {
+		t.Error("Expected error for invalid filter value")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", buf.String())
+	}
+
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	w := &failingWriter{limit: 10}
+	err := handler.DataQueryNDJSONStream(generateTestUsers(), filter.Root{Logic: filter.LogicAnd}, w)
+	if !errors.Is(err, errWriterFull) {
+		t.Errorf("Expected writer error, got %v", err)
+	}
+}
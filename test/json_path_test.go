@@ -0,0 +1,182 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// JSONAccount stores free-form metadata as a JSON text column
+type JSONAccount struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `json:"name"`
+	Metadata string `json:"metadata"`
+}
+
+func generateJSONAccounts() []*JSONAccount {
+	return []*JSONAccount{
+		{ID: 1, Name: "Acme", Metadata: `{"plan":"pro","seats":25,"trial":false,"billing":{"country":"DE"}}`},
+		{ID: 2, Name: "Globex", Metadata: `{"plan":"free","seats":3,"trial":true,"billing":{"country":"US"}}`},
+		{ID: 3, Name: "Initech", Metadata: `{"plan":"Pro Plus","seats":120,"trial":false}`},
+		{ID: 4, Name: "Hooli", Metadata: `{}`},
+	}
+}
+
+func jsonAccountIDs(accounts []*JSONAccount) []uint {
+	ids := make([]uint, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.ID
+	}
+	return ids
+}
+
+// TestJSONPath_AllPaths tests that filters on JSON paths match the same rows in DataQuery and DataGorm
+func TestJSONPath_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&JSONAccount{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateJSONAccounts()).Error; err != nil {
+		t.Fatalf("Failed to create accounts: %v", err)
+	}
+	handler := filter.NewFilter[JSONAccount](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"Equal", filter.FieldFilter{Field: "metadata->plan", Value: "pro", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{1}},
+		{"NotEqual", filter.FieldFilter{Field: "metadata->plan", Value: "pro", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, []uint{2, 3, 4}},
+		{"Contains", filter.FieldFilter{Field: "metadata->plan", Value: "pro", Mode: filter.ModeContains, DataType: filter.DataTypeText}, []uint{1, 3}},
+		{"IsEmpty", filter.FieldFilter{Field: "metadata->plan", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText}, []uint{4}},
+		{"Nested", filter.FieldFilter{Field: "metadata->billing->country", Value: "us", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{2}},
+		{"Number", filter.FieldFilter{Field: "metadata->seats", Value: 10, Mode: filter.ModeGT, DataType: filter.DataTypeNumber}, []uint{1, 3}},
+		{"Bool", filter.FieldFilter{Field: "metadata->trial", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}, []uint{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+				SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+			}
+			result, err := handler.DataQuery(generateJSONAccounts(), root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := jsonAccountIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := jsonAccountIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestJSONPath_MapField tests JSON paths into a map field in memory, and that invalid paths stay unknown
+func TestJSONPath_MapField(t *testing.T) {
+	type Event struct {
+		ID    uint           `json:"id"`
+		Attrs map[string]any `json:"attrs"`
+	}
+	events := []*Event{
+		{ID: 1, Attrs: map[string]any{"source": "web", "retries": 2}},
+		{ID: 2, Attrs: map[string]any{"source": "mobile"}},
+		{ID: 3},
+	}
+	handler := filter.NewFilter[Event](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "attrs->source", Value: "web", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+	}
+	result, err := handler.DataQuery(events, root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != 1 {
+		t.Errorf("Expected only event 1, got %d events", len(result.Data))
+	}
+
+	root.FieldFilters = []filter.FieldFilter{{Field: "attrs->retries", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeNumber}}
+	result, err = handler.DataQuery(events, root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if len(result.Data) != 2 {
+		t.Errorf("Expected 2 events without retries, got %d", len(result.Data))
+	}
+
+	for _, field := range []string{"attrs->", "attrs->a'b", "missing->source"} {
+		if err := handler.ValidateRoot(filter.Root{
+			FieldFilters: []filter.FieldFilter{{Field: field, Value: "x", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		}); err == nil {
+			t.Errorf("Expected ValidateRoot to reject JSON path %q", field)
+		}
+	}
+}
+
+// TestJSONPath_NumberSort tests that a JSON path sorted with DataTypeNumber sorts numerically on
+// every path, casting the key on PostgreSQL and MySQL where it is read as text
+func TestJSONPath_NumberSort(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&JSONAccount{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateJSONAccounts()[:3]).Error; err != nil {
+		t.Fatalf("Failed to create accounts: %v", err)
+	}
+	handler := filter.NewFilter[JSONAccount](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "metadata->seats", Order: filter.SortOrderAsc, DataType: filter.DataTypeNumber}},
+	}
+	expected := []uint{2, 1, 3}
+
+	result, err := handler.DataQuery(generateJSONAccounts()[:3], root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := jsonAccountIDs(result.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataQuery IDs %v, got %v", expected, got)
+	}
+	page, err := handler.DataGorm(db, root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got := jsonAccountIDs(page.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataGorm IDs %v, got %v", expected, got)
+	}
+
+	for _, tt := range []struct {
+		dialector mockDialector
+		expected  string
+	}{
+		{mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}, `ORDER BY CAST((CAST(metadata AS jsonb) #>> '{seats}') AS numeric) ASC`},
+		{mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}, "ORDER BY CAST(JSON_UNQUOTE(JSON_EXTRACT(metadata, '$.seats')) AS DECIMAL(65,30)) ASC"},
+	} {
+		sql := captureDryRunSQL(t, tt.dialector, func(db *gorm.DB) error {
+			_, err := handler.DataGorm(db, root, 0, 10)
+			return err
+		})
+		if !strings.Contains(sql, tt.expected) {
+			t.Errorf("Expected %s SQL to contain %s, got:\n%s", tt.dialector.name, tt.expected, sql)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func lazyGetterEmployees() []*Employee {
+	acme := &Company{ID: 1, Name: "Acme"}
+	globex := &Company{ID: 2, Name: "Globex"}
+	engineering := &Department{ID: 1, Name: "Engineering", CompanyID: 1, Company: acme}
+	sales := &Department{ID: 2, Name: "Sales", CompanyID: 2, Company: globex}
+	orphan := &Department{ID: 3, Name: "Orphan"}
+	teams := []*Team{
+		{ID: 1, Name: "Backend", DepartmentID: 1, Department: engineering},
+		{ID: 2, Name: "Frontend", DepartmentID: 1, Department: engineering},
+		{ID: 3, Name: "Field", DepartmentID: 2, Department: sales},
+		{ID: 4, Name: "Lost", DepartmentID: 3, Department: orphan},
+		{ID: 5, Name: "Unassigned"},
+	}
+	employees := make([]*Employee, 0, 12)
+	for i := range 12 {
+		employee := &Employee{ID: uint(i + 1), Name: string(rune('A' + i))}
+		if i%6 != 5 {
+			employee.Team = teams[i%len(teams)]
+			employee.TeamID = employee.Team.ID
+		}
+		employees = append(employees, employee)
+	}
+	return employees
+}
+
+// TestLazyGetters_MatchWarmUp tests that nested getters generated on first use filter, sort and describe
+// a 4-level model (Employee -> Team -> Department -> Company) like getters generated up front by WarmUp
+func TestLazyGetters_MatchWarmUp(t *testing.T) {
+	maxDepth := 3
+	employees := lazyGetterEmployees()
+	roots := []filter.Root{
+		{
+			Logic:        filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{{Field: "team.department.company_id", Value: 1, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber}},
+			SortFields:   []filter.SortField{{Field: "team.name", Order: filter.SortOrderDesc}},
+		},
+		{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "Team.Department.Name", Value: "sal", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+				{Field: "team.department.name", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "team.department.company_id", Order: filter.SortOrderAsc}},
+		},
+		{
+			Logic:        filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{{Field: "team.department.id", Value: 3, Mode: filter.ModeLT, DataType: filter.DataTypeNumber}},
+		},
+	}
+
+	for i, root := range roots {
+		lazy := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true})
+		eager := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true}).WarmUp()
+
+		got, err := lazy.DataQueryNoPage(employees, root)
+		if err != nil {
+			t.Fatalf("Root %d: lazy DataQueryNoPage failed: %v", i, err)
+		}
+		expected, err := eager.DataQueryNoPage(employees, root)
+		if err != nil {
+			t.Fatalf("Root %d: eager DataQueryNoPage failed: %v", i, err)
+		}
+		if len(expected) == 0 {
+			t.Fatalf("Root %d: expected some employees to match", i)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Root %d: expected lazy getters to return %v, got %v", i, employeeIDs(expected), employeeIDs(got))
+		}
+	}
+
+	lazy := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	eager := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth}).WarmUp()
+	if !reflect.DeepEqual(lazy.Fields(), eager.Fields()) {
+		t.Errorf("Expected the same fields, got %v and %v", lazy.Fields(), eager.Fields())
+	}
+	get, ok := lazy.Getter("team.department.name")
+	if !ok {
+		t.Fatal("Expected a getter for team.department.name")
+	}
+	if name := get(employees[0]); name != "Engineering" {
+		t.Errorf("Expected Engineering, got %v", name)
+	}
+	// Like eager getters, lazy ones stop three segments deep
+	for _, field := range []string{"team.department.missing", "team.department.company.name"} {
+		if _, ok := lazy.Getter(field); ok {
+			t.Errorf("Expected no getter for %s", field)
+		}
+	}
+}
+
+// TestLazyGetters_CSVExportsNestedColumns tests that the default export columns include nested
+// fields whose getters were never used
+func TestLazyGetters_CSVExportsNestedColumns(t *testing.T) {
+	maxDepth := 3
+	handler := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	csv, err := handler.DataQueryNoPageCSV(lazyGetterEmployees()[:1], filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+	}
+	header := strings.SplitN(string(csv), "\n", 2)[0]
+	if !strings.Contains(header, "team.department.name") {
+		t.Errorf("Expected a team.department.name column, got header %q", header)
+	}
+}
+
+// TestRootMaxDepth_RejectsDeeperFields tests that Root.MaxDepth fails queries naming fields with more
+// dotted segments, and leaves shallower ones alone
+func TestRootMaxDepth_RejectsDeeperFields(t *testing.T) {
+	maxDepth := 3
+	handler := filter.NewFilter[Employee](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	employees := lazyGetterEmployees()
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		MaxDepth:     2,
+		FieldFilters: []filter.FieldFilter{{Field: "team.department.name", Value: "Engineering", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		SortFields:   []filter.SortField{{Field: "team.department.company_id", Order: filter.SortOrderAsc}},
+	}
+	_, err := handler.DataQuery(employees, root, 0, 10)
+	if err == nil {
+		t.Fatal("Expected an error for fields deeper than MaxDepth 2")
+	}
+	for _, field := range []string{"team.department.name", "team.department.company_id"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected the error to name %s, got %v", field, err)
+		}
+	}
+
+	root.MaxDepth = 3
+	result, err := handler.DataQuery(employees, root, 0, 10)
+	if err != nil {
+		t.Fatalf("Expected no error with MaxDepth 3, got %v", err)
+	}
+	if result.TotalSize == 0 {
+		t.Error("Expected employees in Engineering")
+	}
+}
+
+func employeeIDs(employees []*Employee) []uint {
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+	return ids
+}
@@ -0,0 +1,171 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// MapProduct keeps free-form attributes in JSON columns, read by key as "attributes.color"
+type MapProduct struct {
+	ID         uint              `gorm:"primaryKey" json:"id"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `gorm:"serializer:json" json:"attributes"`
+	Specs      map[string]any    `gorm:"serializer:json" json:"specs"`
+}
+
+func generateMapProducts() []*MapProduct {
+	return []*MapProduct{
+		{ID: 1, Name: "Shirt", Attributes: map[string]string{"color": "red", "size": "M"}, Specs: map[string]any{"weight": 0.2, "fragile": false}},
+		{ID: 2, Name: "Vase", Attributes: map[string]string{"color": "blue"}, Specs: map[string]any{"weight": 2.5, "fragile": true}},
+		{ID: 3, Name: "Lamp", Attributes: map[string]string{"color": "Red", "size": "L"}, Specs: map[string]any{"weight": 4}},
+		{ID: 4, Name: "Gift card"}, // nil maps
+	}
+}
+
+func setupMapProductsDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&MapProduct{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, product := range generateMapProducts() {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatalf("Failed to create product: %v", err)
+		}
+	}
+	return db
+}
+
+func mapProductIDs(products []*MapProduct) []uint {
+	ids := make([]uint, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+// TestMapFields tests that keys of map fields filter as text, numbers and bools in DataQuery and, through
+// their JSON column, in DataGorm, with missing keys and nil maps matching like nil parents
+func TestMapFields(t *testing.T) {
+	db := setupMapProductsDB(t)
+	handler := filter.NewFilter[MapProduct](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"TextEqual", filter.FieldFilter{Field: "attributes.color", Value: "red", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{1, 3}},
+		{"TextIn", filter.FieldFilter{Field: "attributes.size", Value: []string{"M", "XL"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}, []uint{1}},
+		{"NumberGTE", filter.FieldFilter{Field: "specs.weight", Value: 2, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}, []uint{2, 3}},
+		{"BoolEqual", filter.FieldFilter{Field: "specs.fragile", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}, []uint{2}},
+		// Product 2 has no size and product 4 no attributes at all
+		{"MissingKeyIsEmpty", filter.FieldFilter{Field: "attributes.size", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText}, []uint{2, 4}},
+		{"MissingKeyNotEqual", filter.FieldFilter{Field: "attributes.size", Value: "M", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, []uint{2, 3, 4}},
+		{"MissingKeyIsNotEmpty", filter.FieldFilter{Field: "specs.fragile", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeBool}, []uint{1, 2}},
+		{"UnknownKey", filter.FieldFilter{Field: "attributes.material", Value: "wood", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+				SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+			}
+			result, err := handler.DataQuery(generateMapProducts(), root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := mapProductIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := mapProductIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestMapFields_Sort tests that records sort by a map key, missing keys sorting like nil values
+func TestMapFields_Sort(t *testing.T) {
+	db := setupMapProductsDB(t)
+	handler := filter.NewFilter[MapProduct](filter.GolangFilteringConfig{})
+	root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{
+		{Field: "specs.weight", Order: filter.SortOrderDesc, Nulls: filter.NullsLast},
+	}}
+	expected := []uint{3, 2, 1, 4}
+
+	result, err := handler.DataQuery(generateMapProducts(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := mapProductIDs(result.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataQuery IDs %v, got %v", expected, got)
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got := mapProductIDs(page.Data); !equalIDs(got, expected) {
+		t.Errorf("Expected DataGorm IDs %v, got %v", expected, got)
+	}
+}
+
+// TestMapFields_CSV tests that map keys can be exported as CSV columns and filter CSV Custom exports
+func TestMapFields_CSV(t *testing.T) {
+	handler := filter.NewFilter[MapProduct](filter.GolangFilteringConfig{})
+	red := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "attributes.color", Value: "red", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+	}}
+
+	opts := filter.DefaultCSVOptions()
+	opts.Columns = []string{"name", "attributes.color", "attributes.size"}
+	opts.NullAs = ""
+	csvData, err := handler.DataQueryNoPageCSVWithOptions(generateMapProducts(), filter.Root{Logic: filter.LogicAnd}, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	for _, line := range []string{"name,attributes.color,attributes.size", "Shirt,red,M", "Vase,blue,"} {
+		if !strings.Contains(string(csvData), line+"\n") {
+			t.Errorf("Expected CSV line %q, got:\n%s", line, csvData)
+		}
+	}
+
+	csvData, err = handler.DataQueryNoPageCSVCustom(generateMapProducts(), red, func(p *MapProduct) map[string]any {
+		return map[string]any{"product": fmt.Sprintf("%s (%s)", p.Name, p.Attributes["color"])}
+	})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVCustom failed: %v", err)
+	}
+	if got := string(csvData); got != "product\nShirt (red)\nLamp (Red)\n" {
+		t.Errorf("Expected the two red products, got:\n%s", got)
+	}
+}
+
+// TestMapFields_InvalidKeyInSQL tests that DataGorm rejects a map key it cannot inline into the JSON path
+func TestMapFields_InvalidKeyInSQL(t *testing.T) {
+	db := setupMapProductsDB(t)
+	handler := filter.NewFilter[MapProduct](filter.GolangFilteringConfig{StrictValidation: true})
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "attributes.gift-wrap", Value: "yes", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+	}}
+	if _, err := handler.DataQuery(generateMapProducts(), root, 0, 10); err != nil {
+		t.Errorf("Expected DataQuery to read any map key, got %v", err)
+	}
+	if _, err := handler.DataGorm(db, root, 0, 10); err == nil || !strings.Contains(err.Error(), "identifier") {
+		t.Errorf("Expected an error for a map key that is not an identifier, got %v", err)
+	}
+}
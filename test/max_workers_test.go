@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestMaxWorkers_SameResults tests that every worker count, and the sequential path for small slices,
+// returns the same matches in the same order from DataQuery, DataQueryNoPage and the CSV export
+func TestMaxWorkers_SameResults(t *testing.T) {
+	root := filter.Root{
+		Logic: filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "age", Value: 70, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+	}
+	sorted := root
+	sorted.SortFields = []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}}
+
+	for _, size := range []int{10, 999, 1000, 5003} {
+		// Shuffled, so the IDs don't ascend and the order of data is the only order to keep
+		users := generateBenchmarkUsers(size)
+		rand.New(rand.NewSource(int64(size))).Shuffle(len(users), func(i, j int) {
+			users[i], users[j] = users[j], users[i]
+		})
+		position := make(map[*TestUser]int, len(users))
+		for i, user := range users {
+			position[user] = i
+		}
+		// Without the sort tiebreaker, ties and unsorted matches keep the order of data
+		baseline := filter.NewFilter[TestUser](filter.GolangFilteringConfig{MaxWorkers: 1, DisableSortTiebreaker: true})
+		expected, err := baseline.DataQueryNoPage(users, root)
+		if err != nil {
+			t.Fatalf("DataQueryNoPage failed: %v", err)
+		}
+		// Without sort fields the matches keep their order in data
+		for i := 1; i < len(expected); i++ {
+			if position[expected[i-1]] >= position[expected[i]] {
+				t.Fatalf("Expected data order with %d users, got %d before %d", size, expected[i-1].ID, expected[i].ID)
+			}
+		}
+		expectedPage, err := baseline.DataQuery(users, sorted, 1, 25)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		expectedCSV, err := baseline.DataQueryNoPageCSV(users, sorted)
+		if err != nil {
+			t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+		}
+
+		for _, workers := range []int{0, 2, 3, 8, 64} {
+			handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{MaxWorkers: workers, DisableSortTiebreaker: true})
+
+			result, err := handler.DataQueryNoPage(users, root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPage failed: %v", err)
+			}
+			if len(result) != len(expected) {
+				t.Fatalf("Expected %d matches with %d users and %d workers, got %d", len(expected), size, workers, len(result))
+			}
+			for i := range result {
+				if result[i] != expected[i] {
+					t.Fatalf("Expected user %d at %d with %d users and %d workers, got %d", expected[i].ID, i, size, workers, result[i].ID)
+				}
+			}
+
+			page, err := handler.DataQuery(users, sorted, 1, 25)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if page.TotalSize != expectedPage.TotalSize || len(page.Data) != len(expectedPage.Data) {
+				t.Fatalf("Expected %d users with %d workers, got %d", expectedPage.TotalSize, workers, page.TotalSize)
+			}
+			for i := range page.Data {
+				if page.Data[i] != expectedPage.Data[i] {
+					t.Errorf("Expected DataQuery user %d at %d with %d workers, got %d", expectedPage.Data[i].ID, i, workers, page.Data[i].ID)
+				}
+			}
+
+			csvData, err := handler.DataQueryNoPageCSV(users, sorted)
+			if err != nil {
+				t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+			}
+			if string(csvData) != string(expectedCSV) {
+				t.Errorf("Expected the same CSV with %d users and %d workers", size, workers)
+			}
+		}
+	}
+}
+
+// TestMaxWorkers_Cancelled tests that a cancelled context stops both the sequential and the parallel path
+func TestMaxWorkers_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, size := range []int{10, 5000} {
+		users := generateBenchmarkUsers(size)
+		for _, workers := range []int{1, 4} {
+			handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{MaxWorkers: workers})
+			if _, err := handler.DataQueryNoPageCtx(ctx, users, filter.Root{Logic: filter.LogicAnd}); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled with %d users and %d workers, got %v", size, workers, err)
+			}
+		}
+	}
+}
+
+// TestMaxWorkers_InputOrder tests that without sort fields DataQuery pages keep the order of data,
+// across repeated runs and with 8 workers or the sequential path alike
+func TestMaxWorkers_InputOrder(t *testing.T) {
+	users := generateBenchmarkUsers(5003)
+	rand.New(rand.NewSource(42)).Shuffle(len(users), func(i, j int) {
+		users[i], users[j] = users[j], users[i]
+	})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	var matches []*TestUser
+	for _, user := range users {
+		if user.Role == "admin" {
+			matches = append(matches, user)
+		}
+	}
+	const pageIndex, pageSize = 3, 50
+
+	for _, workers := range []int{8, 1} {
+		handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{MaxWorkers: workers, DisableSortTiebreaker: true})
+		for run := range 5 {
+			page, err := handler.DataQuery(users, root, pageIndex, pageSize)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if page.TotalSize != len(matches) || len(page.Data) != pageSize {
+				t.Fatalf("Expected %d of %d users with %d workers, got %d of %d", pageSize, len(matches), workers, len(page.Data), page.TotalSize)
+			}
+			for i, user := range page.Data {
+				if expected := matches[pageIndex*pageSize+i]; user != expected {
+					t.Fatalf("Run %d with %d workers: expected user %d at %d, got %d", run, workers, expected.ID, i, user.ID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// MergeTicket is a support ticket filtered by a saved filter merged with ad-hoc refinements
+type MergeTicket struct {
+	ID       uint   `json:"id"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee"`
+}
+
+func generateMergeTickets() []*MergeTicket {
+	return []*MergeTicket{
+		{ID: 1, Status: "open", Priority: 3, Assignee: "ana"},
+		{ID: 2, Status: "pending", Priority: 1, Assignee: "ben"},
+		{ID: 3, Status: "closed", Priority: 3, Assignee: "ana"},
+		{ID: 4, Status: "open", Priority: 2, Assignee: "ben"},
+		{ID: 5, Status: "pending", Priority: 3, Assignee: "ben"},
+	}
+}
+
+func mergeTicketIDs(tickets []*MergeTicket) []uint {
+	ids := make([]uint, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+	}
+	return ids
+}
+
+func mergeTextFilter(field, value string) filter.FieldFilter {
+	return filter.FieldFilter{Field: field, Value: value, Mode: filter.ModeEqual, DataType: filter.DataTypeText}
+}
+
+// TestMergeRoots_Filters tests that AND roots are inlined and OR roots wrapped as groups keeping their Logic
+func TestMergeRoots_Filters(t *testing.T) {
+	open, pending := mergeTextFilter("status", "open"), mergeTextFilter("status", "pending")
+	ana, ben := mergeTextFilter("assignee", "ana"), mergeTextFilter("assignee", "ben")
+
+	tests := []struct {
+		name          string
+		base, overlay filter.Root
+		expected      filter.Root
+	}{
+		{"BothAnd",
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{open}},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{ana}},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{open, ana}}},
+		{"OrWrapped",
+			filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{open, pending}},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{ben}},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{ben}, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{open, pending}},
+			}}},
+		{"BothOrWrapped",
+			filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{open, pending}},
+			filter.Root{FieldFilters: []filter.FieldFilter{ana, ben}},
+			filter.Root{Logic: filter.LogicAnd, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{open, pending}},
+				{FieldFilters: []filter.FieldFilter{ana, ben}},
+			}}},
+		{"SingleConditionInlined",
+			filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{open}},
+			filter.Root{Logic: filter.LogicOr, Groups: []filter.Root{{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{ana, ben}}}},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{open}, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{ana, ben}},
+			}}},
+		{"AndWithGroups",
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{open}, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{ana, ben}},
+			}},
+			filter.Root{},
+			filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{open}, Groups: []filter.Root{
+				{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{ana, ben}},
+			}}},
+		{"BothEmpty", filter.Root{Logic: filter.LogicOr}, filter.Root{}, filter.Root{Logic: filter.LogicAnd}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := filter.MergeRoots(tt.base, tt.overlay, filter.MergeOptions{})
+			if err != nil {
+				t.Fatalf("MergeRoots failed: %v", err)
+			}
+			if !reflect.DeepEqual(merged.FieldFilters, tt.expected.FieldFilters) || !reflect.DeepEqual(merged.Groups, tt.expected.Groups) ||
+				merged.Logic != tt.expected.Logic {
+				t.Errorf("Expected %+v, got %+v", tt.expected, merged)
+			}
+		})
+	}
+}
+
+// TestMergeRoots_SortFields tests that overlay's sort fields come first and replace base's for the same field
+func TestMergeRoots_SortFields(t *testing.T) {
+	base := filter.Root{SortFields: []filter.SortField{
+		{Field: "priority", Order: filter.SortOrderAsc},
+		{Field: "id", Order: filter.SortOrderAsc},
+	}}
+	overlay := filter.Root{SortFields: []filter.SortField{
+		{Field: "assignee", Order: filter.SortOrderAsc},
+		{Field: "Priority", Order: filter.SortOrderDesc},
+	}}
+
+	merged, err := filter.MergeRoots(base, overlay, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	expected := []filter.SortField{
+		{Field: "assignee", Order: filter.SortOrderAsc},
+		{Field: "Priority", Order: filter.SortOrderDesc},
+		{Field: "id", Order: filter.SortOrderAsc},
+	}
+	if !reflect.DeepEqual(merged.SortFields, expected) {
+		t.Errorf("Expected sort fields %+v, got %+v", expected, merged.SortFields)
+	}
+
+	merged, err = filter.MergeRoots(base, overlay, filter.MergeOptions{ReplaceSortFields: true})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	if !reflect.DeepEqual(merged.SortFields, overlay.SortFields) {
+		t.Errorf("Expected ReplaceSortFields to keep overlay's sort fields only, got %+v", merged.SortFields)
+	}
+
+	merged, err = filter.MergeRoots(base, filter.Root{}, filter.MergeOptions{ReplaceSortFields: true})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	if !reflect.DeepEqual(merged.SortFields, base.SortFields) {
+		t.Errorf("Expected base's sort fields without overlay sort fields, got %+v", merged.SortFields)
+	}
+}
+
+// TestMergeRoots_Preload tests that Preload is unioned and Preloads override base's for the same relation
+func TestMergeRoots_Preload(t *testing.T) {
+	base := filter.Root{
+		Preload:  []string{"Orders", "Profile"},
+		Preloads: []filter.Preload{{Relation: "Orders.Items", Conditions: []any{"quantity > ?", 1}}, {Relation: "Notes"}},
+	}
+	overlay := filter.Root{
+		Preload:  []string{"orders", "Address"},
+		Preloads: []filter.Preload{{Relation: "orders.items", Conditions: []any{"sku = ?", "A"}}},
+	}
+
+	merged, err := filter.MergeRoots(base, overlay, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	if expected := []string{"Orders", "Profile", "Address"}; !reflect.DeepEqual(merged.Preload, expected) {
+		t.Errorf("Expected Preload %v, got %v", expected, merged.Preload)
+	}
+	expected := []filter.Preload{{Relation: "Notes"}, {Relation: "orders.items", Conditions: []any{"sku = ?", "A"}}}
+	if !reflect.DeepEqual(merged.Preloads, expected) {
+		t.Errorf("Expected Preloads %+v, got %+v", expected, merged.Preloads)
+	}
+}
+
+// TestMergeRoots_Settings tests how the search, selected fields, aggregations and server-side settings combine
+func TestMergeRoots_Settings(t *testing.T) {
+	strict, lenient := true, false
+	base := filter.Root{
+		Search:           &filter.SearchFilter{Value: "printer"},
+		SelectFields:     []string{"id", "status"},
+		Aggregations:     []filter.Aggregation{{Field: "priority", Func: filter.AggregateSum}},
+		StrictValidation: &strict,
+		IncludeDeleted:   true,
+		MaxDepth:         3,
+		Limit:            100,
+	}
+	overlay := filter.Root{
+		Aggregations:     []filter.Aggregation{{Field: "Priority", Func: filter.AggregateSum}, {Field: "priority", Func: filter.AggregateMax}},
+		StrictValidation: &lenient,
+		Limit:            20,
+	}
+
+	merged, err := filter.MergeRoots(base, overlay, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	if merged.Search != base.Search || !reflect.DeepEqual(merged.SelectFields, base.SelectFields) {
+		t.Errorf("Expected base's search and selected fields without overlay ones, got %+v and %v", merged.Search, merged.SelectFields)
+	}
+	expected := []filter.Aggregation{{Field: "priority", Func: filter.AggregateSum}, {Field: "priority", Func: filter.AggregateMax}}
+	if !reflect.DeepEqual(merged.Aggregations, expected) {
+		t.Errorf("Expected aggregations %+v, got %+v", expected, merged.Aggregations)
+	}
+	if merged.StrictValidation != &lenient || !merged.IncludeDeleted || merged.TruncateExport {
+		t.Errorf("Expected overlay's StrictValidation and base's IncludeDeleted, got %+v", merged)
+	}
+	if merged.MaxDepth != 3 || merged.Limit != 20 {
+		t.Errorf("Expected MaxDepth 3 and Limit 20, got %d and %d", merged.MaxDepth, merged.Limit)
+	}
+
+	base.Search = &filter.SearchFilter{} // An empty term searches nothing
+	overlay.Search = &filter.SearchFilter{Value: "scanner"}
+	overlay.SelectFields = []string{"id"}
+	merged, err = filter.MergeRoots(base, overlay, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	if merged.Search != overlay.Search || !reflect.DeepEqual(merged.SelectFields, overlay.SelectFields) {
+		t.Errorf("Expected overlay's search and selected fields, got %+v and %v", merged.Search, merged.SelectFields)
+	}
+}
+
+// TestMergeRoots_Errors tests that an unknown Logic, two different searches and different time zones
+// cannot be merged
+func TestMergeRoots_Errors(t *testing.T) {
+	printer := filter.Root{Search: &filter.SearchFilter{Value: "printer"}}
+	scanner := filter.Root{Search: &filter.SearchFilter{Value: "scanner"}}
+	if _, err := filter.MergeRoots(printer, scanner, filter.MergeOptions{}); !errors.Is(err, filter.ErrInvalidFilter) {
+		t.Errorf("Expected an invalid filter error merging two searches, got %v", err)
+	}
+	if merged, err := filter.MergeRoots(printer, printer, filter.MergeOptions{}); err != nil || merged.Search.Value != "printer" {
+		t.Errorf("Expected the same search twice to merge, got %+v (%v)", merged.Search, err)
+	}
+	if _, err := filter.MergeRoots(filter.Root{Logic: "xor"}, filter.Root{}, filter.MergeOptions{}); err == nil ||
+		!strings.Contains(err.Error(), "unknown logic 'xor'") {
+		t.Errorf("Expected an unknown logic error, got %v", err)
+	}
+	if _, err := filter.MergeRoots(filter.Root{TimeZone: "Asia/Manila"}, filter.Root{TimeZone: "UTC"}, filter.MergeOptions{}); err == nil {
+		t.Error("Expected an error merging roots in different time zones")
+	}
+	merged, err := filter.MergeRoots(filter.Root{}, filter.Root{TimeZone: "Asia/Manila"}, filter.MergeOptions{})
+	if err != nil || merged.TimeZone != "Asia/Manila" {
+		t.Errorf("Expected the time zone of overlay, got %q (%v)", merged.TimeZone, err)
+	}
+}
+
+// TestMergeRoots_DataQuery tests that a merged Root matches the records matching both roots
+func TestMergeRoots_DataQuery(t *testing.T) {
+	handler := filter.NewFilter[MergeTicket](filter.GolangFilteringConfig{StrictValidation: true})
+	saved := filter.Root{
+		Logic:        filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{mergeTextFilter("status", "open"), mergeTextFilter("status", "pending")},
+		SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderDesc}},
+	}
+	adHoc := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			mergeTextFilter("assignee", "ben"),
+			{Field: "priority", Value: 2, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "priority", Order: filter.SortOrderDesc}},
+	}
+
+	merged, err := filter.MergeRoots(saved, adHoc, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+	result, err := handler.DataQuery(generateMergeTickets(), merged, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if got := mergeTicketIDs(result.Data); !equalIDs(got, []uint{5, 4}) {
+		t.Errorf("Expected tickets 5 and 4, got %v", got)
+	}
+	if len(saved.Groups) != 0 || len(adHoc.FieldFilters) != 2 {
+		t.Errorf("Expected the merged roots to be unchanged, got %+v and %+v", saved, adHoc)
+	}
+}
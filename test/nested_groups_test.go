@@ -0,0 +1,152 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestNestedGroups tests AND/OR filter trees on both DataQuery and DataGorm
+func TestNestedGroups(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&BillAndCoin{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	records := []*BillAndCoin{
+		{ID: 1, OrganizationID: 1, BranchID: 1, Amount: 100.0, Currency: "USD", Status: "active"},
+		{ID: 2, OrganizationID: 1, BranchID: 1, Amount: 200.0, Currency: "USD", Status: "pending"},
+		{ID: 3, OrganizationID: 1, BranchID: 1, Amount: 300.0, Currency: "EUR", Status: "active"},
+		{ID: 4, OrganizationID: 1, BranchID: 2, Amount: 150.0, Currency: "USD", Status: "active"},
+		{ID: 5, OrganizationID: 1, BranchID: 2, Amount: 250.0, Currency: "USD", Status: "inactive"},
+		{ID: 6, OrganizationID: 2, BranchID: 1, Amount: 400.0, Currency: "USD", Status: "active"},
+		{ID: 7, OrganizationID: 2, BranchID: 1, Amount: 500.0, Currency: "GBP", Status: "active"},
+		{ID: 8, OrganizationID: 2, BranchID: 2, Amount: 600.0, Currency: "USD", Status: "pending"},
+	}
+	if err := db.Create(&records).Error; err != nil {
+		t.Fatalf("Failed to create test records: %v", err)
+	}
+
+	handler := filter.NewFilter[BillAndCoin](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected int
+	}{
+		{
+			// (status = active AND amount > 300) OR (currency = EUR)
+			name: "OrOfTwoAndGroups",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				Groups: []filter.Root{
+					{
+						Logic: filter.LogicAnd,
+						FieldFilters: []filter.FieldFilter{
+							{Field: "status", Value: "active", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+							{Field: "amount", Value: 300, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+						},
+					},
+					{
+						Logic: filter.LogicAnd,
+						FieldFilters: []filter.FieldFilter{
+							{Field: "currency", Value: "EUR", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+						},
+					},
+				},
+			},
+			expected: 3, // IDs 3, 6, 7
+		},
+		{
+			// organization_id = 1 AND (status = pending OR currency = EUR)
+			name: "AndWithOrGroup",
+			root: filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "organization_id", Value: 1, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+				},
+				Groups: []filter.Root{
+					{
+						Logic: filter.LogicOr,
+						FieldFilters: []filter.FieldFilter{
+							{Field: "status", Value: "pending", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+							{Field: "currency", Value: "EUR", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+						},
+					},
+				},
+			},
+			expected: 2, // IDs 2, 3
+		},
+		{
+			// branch_id = 2 OR (organization_id = 2 AND (currency = GBP OR amount < 450))
+			name: "DeeplyNested",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "branch_id", Value: 2, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+				},
+				Groups: []filter.Root{
+					{
+						Logic: filter.LogicAnd,
+						FieldFilters: []filter.FieldFilter{
+							{Field: "organization_id", Value: 2, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+						},
+						Groups: []filter.Root{
+							{
+								Logic: filter.LogicOr,
+								FieldFilters: []filter.FieldFilter{
+									{Field: "currency", Value: "GBP", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+									{Field: "amount", Value: 450, Mode: filter.ModeLT, DataType: filter.DataTypeNumber},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: 5, // IDs 4, 5, 8 (branch 2) + 6, 7
+		},
+		{
+			// Groups with only unknown fields are ignored
+			name: "EmptyGroupIgnored",
+			root: filter.Root{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "status", Value: "inactive", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				},
+				Groups: []filter.Root{
+					{
+						Logic: filter.LogicAnd,
+						FieldFilters: []filter.FieldFilter{
+							{Field: "unknown_field", Value: "x", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+						},
+					},
+				},
+			},
+			expected: 1, // ID 5
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryResult, err := handler.DataQuery(records, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			gormResult, err := handler.DataGorm(db, tt.root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if queryResult.TotalSize != tt.expected {
+				t.Errorf("DataQuery: expected %d, got %d", tt.expected, queryResult.TotalSize)
+			}
+			if gormResult.TotalSize != tt.expected {
+				t.Errorf("DataGorm: expected %d, got %d", tt.expected, gormResult.TotalSize)
+			}
+		})
+	}
+}
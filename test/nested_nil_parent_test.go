@@ -0,0 +1,133 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NilParentDepartment is a related record that may be missing on NilParentStaff
+type NilParentDepartment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `json:"name"`
+	Budget    int       `json:"budget"`
+	Active    bool      `json:"active"`
+	FoundedAt time.Time `json:"founded_at"`
+}
+
+// NilParentStaff has an optional department (nil pointer when unassigned)
+type NilParentStaff struct {
+	ID           uint                 `gorm:"primaryKey" json:"id"`
+	Name         string               `json:"name"`
+	DepartmentID *uint                `json:"department_id"`
+	Department   *NilParentDepartment `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+}
+
+func generateNilParentStaff() []*NilParentStaff {
+	founded := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	deptID := uint(1)
+	department := &NilParentDepartment{ID: 1, Name: "Engineering", Budget: 100, Active: false, FoundedAt: founded}
+	return []*NilParentStaff{
+		{ID: 1, Name: "Alice", DepartmentID: &deptID, Department: department},
+		{ID: 2, Name: "Bob"}, // No department
+		{ID: 3, Name: "Carol"},
+	}
+}
+
+func setupNilParentDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&NilParentDepartment{}, &NilParentStaff{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, staff := range generateNilParentStaff() {
+		if err := db.Create(staff).Error; err != nil {
+			t.Fatalf("Failed to create staff: %v", err)
+		}
+	}
+	return db
+}
+
+// TestNestedNilParent_MatchesIsEmptyAndNegativeModes tests that rows with a nil parent only match ModeIsEmpty
+// and the negative modes on nested fields, for every data type, and that DataGorm's LEFT JOIN gives the same counts
+func TestNestedNilParent_MatchesIsEmptyAndNegativeModes(t *testing.T) {
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	data := generateNilParentStaff()
+	db := setupNilParentDB(t)
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected int
+	}{
+		// Previously nil parsed as 0 and matched LT 10
+		{"NumberLT", filter.FieldFilter{Field: "department.budget", Value: 10, Mode: filter.ModeLT, DataType: filter.DataTypeNumber}, 0},
+		{"NumberNotEqual", filter.FieldFilter{Field: "department.budget", Value: 5, Mode: filter.ModeNotEqual, DataType: filter.DataTypeNumber}, 3},
+		{"NumberIsNotEmpty", filter.FieldFilter{Field: "department.budget", Mode: filter.ModeIsNotEmpty, DataType: filter.DataTypeNumber}, 1},
+		{"NumberIsEmpty", filter.FieldFilter{Field: "department.budget", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeNumber}, 2},
+		{"TextNotEqual", filter.FieldFilter{Field: "department.name", Value: "Sales", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, 3},
+		{"TextNotContains", filter.FieldFilter{Field: "department.name", Value: "x", Mode: filter.ModeNotContains, DataType: filter.DataTypeText}, 3},
+		{"TextIsEmpty", filter.FieldFilter{Field: "department.name", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText}, 2},
+		{"BoolEqualFalse", filter.FieldFilter{Field: "department.active", Value: false, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}, 1},
+		{"DateBefore", filter.FieldFilter{Field: "department.founded_at", Value: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Mode: filter.ModeBefore, DataType: filter.DataTypeDate}, 1},
+		{"DateIsEmpty", filter.FieldFilter{Field: "department.founded_at", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeDate}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterRoot := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+			}
+
+			result, err := handler.DataQuery(data, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if result.TotalSize != tt.expected {
+				t.Errorf("DataQuery: expected %d records, got %d", tt.expected, result.TotalSize)
+			}
+
+			gormResult, err := handler.DataGorm(db, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if gormResult.TotalSize != tt.expected {
+				t.Errorf("DataGorm: expected %d records, got %d", tt.expected, gormResult.TotalSize)
+			}
+		})
+	}
+}
+
+// TestNestedNilParent_SortAndCSV tests that rows with a nil parent sort first and export as empty cells
+func TestNestedNilParent_SortAndCSV(t *testing.T) {
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+
+	filterRoot := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "department.name", Order: filter.SortOrderAsc}},
+	}
+	results, err := handler.DataQueryNoPage(generateNilParentStaff(), filterRoot)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	if len(results) != 3 || results[2].ID != 1 {
+		t.Errorf("Expected the staff member with a department to sort last, got %v", results)
+	}
+
+	csvData, err := handler.DataQueryNoPageCSV(generateNilParentStaff(), filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+	}
+	if !strings.Contains(string(csvData), "\n,,,,,,<nil>,<nil>,2,Bob\n") {
+		t.Errorf("Expected missing nested values exported as empty cells, got %s", csvData)
+	}
+}
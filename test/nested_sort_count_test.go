@@ -0,0 +1,77 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SortDepartment and SortEmployee both have an id column, which a nested sort joins together
+type SortDepartment struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+type SortEmployee struct {
+	ID           uint            `gorm:"primaryKey" json:"id"`
+	Name         string          `json:"name"`
+	DepartmentID uint            `json:"department_id"`
+	Department   *SortDepartment `gorm:"foreignKey:DepartmentID" json:"department"`
+}
+
+// TestNestedSort_CountWithoutFilters is a regression test: a nested sort without any filter joins the
+// relation, and the count must neither be ambiguous nor carry the ORDER BY
+func TestNestedSort_CountWithoutFilters(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&SortDepartment{}, &SortEmployee{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	departments := []*SortDepartment{{ID: 1, Name: "Sales"}, {ID: 2, Name: "Engineering"}}
+	if err := db.Create(departments).Error; err != nil {
+		t.Fatalf("Failed to create departments: %v", err)
+	}
+	employees := []*SortEmployee{
+		{ID: 1, Name: "Ana", DepartmentID: 1},
+		{ID: 2, Name: "Ben", DepartmentID: 2},
+		{ID: 3, Name: "Cy", DepartmentID: 1},
+	}
+	if err := db.Create(employees).Error; err != nil {
+		t.Fatalf("Failed to create employees: %v", err)
+	}
+
+	handler := filter.NewFilter[SortEmployee](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "department.name", Order: filter.SortOrderAsc}},
+	}
+
+	result, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 3 {
+		t.Errorf("Expected TotalSize 3, got %d", result.TotalSize)
+	}
+	if len(result.Data) != 3 || result.Data[0].DepartmentID != 2 {
+		t.Errorf("Expected the Engineering employee first, got %+v", result.Data)
+	}
+
+	// The caller's own ORDER BY and GROUP BY on an unqualified id must not break the count either
+	grouped := db.Order("id DESC").Group("sort_employees.id")
+	count, err := handler.CountGorm(grouped, filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "department.name", Value: "Sales", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		SortFields:   root.SortFields,
+	})
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected CountGorm 2, got %d", count)
+	}
+}
@@ -747,3 +747,51 @@ func TestHybridNestedTimeConsistency(t *testing.T) {
 		})
 	}
 }
+
+// TestNestedTime_HybridLoadsRelations tests that Hybrid loads the relations nested filter and sort fields
+// read on both strategies, so the memory strategy matches the database strategy without an explicit Preload
+func TestNestedTime_HybridLoadsRelations(t *testing.T) {
+	db := setupNestedTimeDB(t)
+	maxDepth := 3
+	handler := filter.NewFilter[EmployeeAttendance](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "work_shift.name", Value: "morning", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "work_shift.name", Order: filter.SortOrderDesc}},
+	}
+
+	var expected []uint
+	for _, threshold := range []int{0, 1000} {
+		result, err := handler.Hybrid(db, threshold, filterRoot, 0, 100)
+		if err != nil {
+			t.Fatalf("Hybrid (threshold %d) failed: %v", threshold, err)
+		}
+		if result.TotalSize == 0 {
+			t.Fatalf("Expected Hybrid (%s) to match the morning shifts, got none", result.Strategy)
+		}
+		ids := make([]uint, len(result.Data))
+		for i, attendance := range result.Data {
+			ids[i] = attendance.ID
+			if attendance.WorkShift == nil {
+				t.Errorf("Expected Hybrid (%s) to load WorkShift of attendance %d, got nil", result.Strategy, attendance.ID)
+			}
+		}
+		if expected == nil {
+			expected = ids
+		} else if !equalIDs(ids, expected) {
+			t.Errorf("Expected Hybrid (%s) IDs %v, got %v", result.Strategy, expected, ids)
+		}
+
+		records, err := handler.DataHybridNoPage(db, threshold, filterRoot)
+		if err != nil {
+			t.Fatalf("DataHybridNoPage (threshold %d) failed: %v", threshold, err)
+		}
+		for _, attendance := range records {
+			if attendance.WorkShift == nil {
+				t.Errorf("Expected DataHybridNoPage to load WorkShift of attendance %d, got nil", attendance.ID)
+			}
+		}
+	}
+}
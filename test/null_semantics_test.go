@@ -0,0 +1,151 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NullableOwner holds a column of every comparable data type
+type NullableOwner struct {
+	ID     uint      `gorm:"primaryKey" json:"id"`
+	Status string    `json:"status"`
+	Score  int       `json:"score"`
+	Flag   bool      `json:"flag"`
+	DueAt  time.Time `json:"due_at"`
+}
+
+// NullableRecord has an optional owner, so its owner columns are NULL when unassigned
+type NullableRecord struct {
+	ID      uint           `gorm:"primaryKey" json:"id"`
+	OwnerID *uint          `json:"owner_id"`
+	Owner   *NullableOwner `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+}
+
+func generateNullableRecords() []*NullableRecord {
+	owners := []*NullableOwner{
+		{ID: 1, Status: "active", Score: 10, Flag: true, DueAt: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, Status: "pending", Score: 20, Flag: false, DueAt: time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{ID: 4, Status: "", Score: 0, Flag: false, DueAt: time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	records := []*NullableRecord{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}} // Record 3 has no owner
+	for _, owner := range owners {
+		records[owner.ID-1].OwnerID = &owner.ID
+		records[owner.ID-1].Owner = owner
+	}
+	return records
+}
+
+func setupNullableDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&NullableOwner{}, &NullableRecord{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, record := range generateNullableRecords() {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	return db
+}
+
+func nullableIDs(records []*NullableRecord) []uint {
+	ids := make([]uint, len(records))
+	for i, record := range records {
+		ids[i] = record.ID
+	}
+	return ids
+}
+
+// TestNullSemantics_NegativeModesMatchNull tests that every negative mode matches a nil owner (NULL columns
+// behind the LEFT JOIN) in DataQuery, DataGorm, both Hybrid strategies and CountGorm, and that it selects exactly
+// the rows its positive mode does not
+func TestNullSemantics_NegativeModesMatchNull(t *testing.T) {
+	db := setupNullableDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[NullableRecord](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	records := generateNullableRecords()
+	allIDs := []uint{1, 2, 3, 4}
+
+	tests := []struct {
+		name     string
+		negative filter.FieldFilter
+		positive filter.Mode
+		expected []uint
+	}{
+		{"TextNotEqual", filter.FieldFilter{Field: "owner.status", Value: "active", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, filter.ModeEqual, []uint{2, 3, 4}},
+		{"TextNotEqualEmpty", filter.FieldFilter{Field: "owner.status", Value: "", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, filter.ModeEqual, []uint{1, 2, 3}},
+		{"TextNotContains", filter.FieldFilter{Field: "owner.status", Value: "ACT", Mode: filter.ModeNotContains, DataType: filter.DataTypeText}, filter.ModeContains, []uint{2, 3, 4}},
+		{"TextNotIn", filter.FieldFilter{Field: "owner.status", Value: []string{"active", "pending"}, Mode: filter.ModeNotIn, DataType: filter.DataTypeText}, filter.ModeIn, []uint{3, 4}},
+		{"NumberNotEqual", filter.FieldFilter{Field: "owner.score", Value: 10, Mode: filter.ModeNotEqual, DataType: filter.DataTypeNumber}, filter.ModeEqual, []uint{2, 3, 4}},
+		{"NumberNotEqualZero", filter.FieldFilter{Field: "owner.score", Value: 0, Mode: filter.ModeNotEqual, DataType: filter.DataTypeNumber}, filter.ModeEqual, []uint{1, 2, 3}},
+		{"NumberNotIn", filter.FieldFilter{Field: "owner.score", Value: []any{10, 20}, Mode: filter.ModeNotIn, DataType: filter.DataTypeNumber}, filter.ModeIn, []uint{3, 4}},
+		{"BoolNotEqual", filter.FieldFilter{Field: "owner.flag", Value: true, Mode: filter.ModeNotEqual, DataType: filter.DataTypeBool}, filter.ModeEqual, []uint{2, 3, 4}},
+		{"DateNotEqual", filter.FieldFilter{Field: "owner.due_at", Value: "2025-01-01", Mode: filter.ModeNotEqual, DataType: filter.DataTypeDate}, filter.ModeEqual, []uint{2, 3, 4}},
+		{"DateNotIn", filter.FieldFilter{Field: "owner.due_at", Value: []string{"2025-01-01", "2025-02-01"}, Mode: filter.ModeNotIn, DataType: filter.DataTypeDate}, filter.ModeIn, []uint{3, 4}},
+	}
+
+	run := func(t *testing.T, fieldFilter filter.FieldFilter) []uint {
+		t.Helper()
+		root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{fieldFilter}}
+
+		result, err := handler.DataQuery(records, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		ids := nullableIDs(result.Data)
+
+		page, err := handler.DataGorm(db, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if gormIDs := nullableIDs(page.Data); !equalIDs(gormIDs, ids) {
+			t.Errorf("Expected DataGorm %v to match DataQuery %v", gormIDs, ids)
+		}
+		for _, threshold := range []int{0, 1000} {
+			hybrid, err := handler.Hybrid(db.Preload("Owner"), threshold, root, 0, 10)
+			if err != nil {
+				t.Fatalf("Hybrid failed: %v", err)
+			}
+			if hybridIDs := nullableIDs(hybrid.Data); !equalIDs(hybridIDs, ids) {
+				t.Errorf("Expected Hybrid (%s) %v to match DataQuery %v", hybrid.Strategy, hybridIDs, ids)
+			}
+		}
+		count, err := handler.CountGorm(db, root)
+		if err != nil {
+			t.Fatalf("CountGorm failed: %v", err)
+		}
+		if count != int64(len(ids)) {
+			t.Errorf("Expected CountGorm=%d, got %d", len(ids), count)
+		}
+		return ids
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			negativeIDs := run(t, tt.negative)
+			if !equalIDs(negativeIDs, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, negativeIDs)
+			}
+
+			positive := tt.negative
+			positive.Mode = tt.positive
+			positiveIDs := run(t, positive)
+			union := map[uint]int{}
+			for _, id := range append(positiveIDs, negativeIDs...) {
+				union[id]++
+			}
+			for _, id := range allIDs {
+				if union[id] != 1 {
+					t.Errorf("Expected record %d to match exactly one of %s and %s, matched %d", id, tt.positive, tt.negative.Mode, union[id])
+				}
+			}
+		})
+	}
+}
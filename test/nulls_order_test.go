@@ -0,0 +1,207 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NullsEmployee has a nullable termination date (NULL while employed)
+type NullsEmployee struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Name            string     `json:"name"`
+	TerminationDate *time.Time `json:"termination_date"`
+}
+
+func generateNullsEmployees() []*NullsEmployee {
+	date := func(year int, month time.Month, day int) *time.Time {
+		t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	return []*NullsEmployee{
+		{ID: 1, Name: "Ann", TerminationDate: date(2024, 3, 1)},
+		{ID: 2, Name: "Ben"},
+		{ID: 3, Name: "Cid", TerminationDate: date(2024, 1, 15)},
+		{ID: 4, Name: "Dee"},
+		{ID: 5, Name: "Eve", TerminationDate: date(2024, 6, 30)},
+	}
+}
+
+func setupNullsEmployeeDB(t *testing.T, dialector gorm.Dialector) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&NullsEmployee{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, employee := range generateNullsEmployees() {
+		if err := db.Create(employee).Error; err != nil {
+			t.Fatalf("Failed to create employee: %v", err)
+		}
+	}
+
+	var queries []string
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return db, &queries
+}
+
+func nullsEmployeeIDs(employees []*NullsEmployee) []uint {
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+	return ids
+}
+
+var nullsOrderCases = []struct {
+	name     string
+	order    filter.SortOrder
+	nulls    filter.NullsOrder
+	expected []uint
+}{
+	{"DescNullsLast", filter.SortOrderDesc, filter.NullsLast, []uint{5, 1, 3, 2, 4}},
+	{"AscNullsLast", filter.SortOrderAsc, filter.NullsLast, []uint{3, 1, 5, 2, 4}},
+	{"DescNullsFirst", filter.SortOrderDesc, filter.NullsFirst, []uint{2, 4, 5, 1, 3}},
+	{"AscNullsFirst", filter.SortOrderAsc, filter.NullsFirst, []uint{2, 4, 3, 1, 5}},
+	// SQLite's own placement: first ascending, last descending
+	{"AscDefault", filter.SortOrderAsc, filter.NullsDefault, []uint{2, 4, 3, 1, 5}},
+	{"DescDefault", filter.SortOrderDesc, filter.NullsDefault, []uint{5, 1, 3, 2, 4}},
+}
+
+// TestNullsOrder_AllPaths tests that NULL placement is identical in DataQuery, DataGorm and both Hybrid strategies
+func TestNullsOrder_AllPaths(t *testing.T) {
+	db, _ := setupNullsEmployeeDB(t, sqlite.Open(":memory:"))
+	handler := filter.NewFilter[NullsEmployee](filter.GolangFilteringConfig{})
+	employees := generateNullsEmployees()
+
+	for _, tt := range nullsOrderCases {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				SortFields: []filter.SortField{
+					{Field: "termination_date", Order: tt.order, Nulls: tt.nulls},
+					{Field: "id", Order: filter.SortOrderAsc},
+				},
+			}
+
+			result, err := handler.DataQuery(employees, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := nullsEmployeeIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery order %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := nullsEmployeeIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm order %v, got %v", tt.expected, ids)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, root, 0, 10)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if ids := nullsEmployeeIDs(hybrid.Data); !equalIDs(ids, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) order %v, got %v", hybrid.Strategy, tt.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+// TestNullsOrder_SQL tests that PostgreSQL gets NULLS FIRST/LAST and other dialects an IS NULL sort key
+func TestNullsOrder_SQL(t *testing.T) {
+	handler := filter.NewFilter[NullsEmployee](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "termination_date", Order: filter.SortOrderDesc, Nulls: filter.NullsLast}},
+	}
+
+	tests := []struct {
+		name      string
+		dialector gorm.Dialector
+		expected  string
+	}{
+		{"SQLite", sqlite.Open(":memory:"), "ORDER BY CASE WHEN termination_date IS NULL THEN 1 ELSE 0 END, termination_date DESC"},
+		{"Postgres", mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}, "ORDER BY termination_date DESC NULLS LAST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, queries := setupNullsEmployeeDB(t, tt.dialector)
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := nullsEmployeeIDs(page.Data); !equalIDs(ids, []uint{5, 1, 3, 2, 4}) {
+				t.Errorf("Expected order [5 1 3 2 4], got %v", ids)
+			}
+			found := false
+			for _, query := range *queries {
+				found = found || strings.Contains(query, tt.expected)
+			}
+			if !found {
+				t.Errorf("Expected %s, got %v", tt.expected, *queries)
+			}
+		})
+	}
+}
+
+// TestNullsOrder_ZeroTimeIsNull tests that in memory a zero time.Time joins the NULL bucket
+func TestNullsOrder_ZeroTimeIsNull(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := []*TestUser{
+		{ID: 1, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2},
+		{ID: 3, CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "created_at", Order: filter.SortOrderAsc, Nulls: filter.NullsLast}},
+	}
+
+	result, err := handler.DataQuery(users, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	var ids []uint
+	for _, user := range result.Data {
+		ids = append(ids, user.ID)
+	}
+	if !equalIDs(ids, []uint{1, 3, 2}) {
+		t.Errorf("Expected the zero time last, got %v", ids)
+	}
+}
+
+// TestNullsOrder_ParseAndCursor tests JSON parsing of the nulls option and its rejection by cursor pagination
+func TestNullsOrder_ParseAndCursor(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"sortFields":[{"field":"termination_date","order":"desc","nulls":"LAST"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.SortFields[0].Nulls != filter.NullsLast {
+		t.Errorf("Expected NullsLast, got %q", root.SortFields[0].Nulls)
+	}
+
+	if _, err := filter.ParseRootFromJSON([]byte(`{"sortFields":[{"field":"termination_date","nulls":"middle"}]}`)); err == nil {
+		t.Error("Expected an error for an unknown nulls order")
+	}
+
+	handler := filter.NewFilter[NullsEmployee](filter.GolangFilteringConfig{})
+	if _, err := handler.DataQueryCursor(generateNullsEmployees(), root, "", 10); err == nil {
+		t.Error("Expected cursor pagination to reject a nulls order")
+	}
+}
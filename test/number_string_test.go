@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestNumberStringValues tests that numeric strings and json.Number work on both paths
+func TestNumberStringValues(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected int
+	}{
+		{"IntegerString", filter.FieldFilter{Field: "age", Value: "42", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber}, 1},
+		{"DecimalString", filter.FieldFilter{Field: "age", Value: "41.5", Mode: filter.ModeGT, DataType: filter.DataTypeNumber}, 1},
+		{"NegativeString", filter.FieldFilter{Field: "age", Value: "-3", Mode: filter.ModeGT, DataType: filter.DataTypeNumber}, 10},
+		{"PaddedString", filter.FieldFilter{Field: "age", Value: " 30 ", Mode: filter.ModeLTE, DataType: filter.DataTypeNumber}, 5},
+		{"JSONNumber", filter.FieldFilter{Field: "age", Value: json.Number("35"), Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}, 3},
+		{"RangeStringBounds", filter.FieldFilter{Field: "age", Value: filter.Range{From: "28", To: "33.0"}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, 5},
+		{"RangeMapStringBounds", filter.FieldFilter{Field: "age", Value: map[string]any{"from": "25", "to": json.Number("29")}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, 4},
+		{"InStringList", filter.FieldFilter{Field: "age", Value: []string{"25", "30"}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterRoot := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{tt.filter},
+			}
+
+			result, err := handler.DataQuery(users, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if result.TotalSize != tt.expected {
+				t.Errorf("DataQuery: expected %d records, got %d", tt.expected, result.TotalSize)
+			}
+
+			gormResult, err := handler.DataGorm(db, filterRoot, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if gormResult.TotalSize != tt.expected {
+				t.Errorf("DataGorm: expected %d records, got %d", tt.expected, gormResult.TotalSize)
+			}
+		})
+	}
+}
+
+// TestNumberStringValues_Invalid tests that non-numeric strings are rejected with an error
+func TestNumberStringValues_Invalid(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+
+	for _, value := range []any{"abc", "NaN", filter.Range{From: "20", To: "forty"}} {
+		mode := filter.ModeEqual
+		if _, isRange := value.(filter.Range); isRange {
+			mode = filter.ModeRange
+		}
+		filterRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "age", Value: value, Mode: mode, DataType: filter.DataTypeNumber},
+			},
+		}
+
+		if _, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 10); err == nil {
+			t.Errorf("DataQuery: expected error for %v, got nil", value)
+		}
+		if _, err := handler.DataGorm(db, filterRoot, 0, 10); err == nil {
+			t.Errorf("DataGorm: expected error for %v, got nil", value)
+		}
+	}
+}
+
+// TestNumberStringValues_TextSortUnchanged tests that numeric-looking text still sorts lexically
+func TestNumberStringValues_TextSortUnchanged(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := []*TestUser{
+		{ID: 1, Name: "9"},
+		{ID: 2, Name: "10"},
+	}
+
+	filterRoot := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderAsc}},
+	}
+	results, err := handler.DataQueryNoPage(users, filterRoot)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	if results[0].ID != 2 {
+		t.Errorf("Expected lexical order with \"10\" first, got ID %d", results[0].ID)
+	}
+}
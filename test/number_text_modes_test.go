@@ -0,0 +1,125 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RefInvoice has a numeric reference that users search by partial number
+type RefInvoice struct {
+	ID     uint  `gorm:"primaryKey" json:"id"`
+	Number int64 `json:"number"`
+}
+
+func generateRefInvoices() []*RefInvoice {
+	return []*RefInvoice{
+		{ID: 1, Number: 2025001},
+		{ID: 2, Number: 2025017},
+		{ID: 3, Number: 2024100},
+		{ID: 4, Number: 1202500},
+	}
+}
+
+func refInvoiceIDs(invoices []*RefInvoice) []uint {
+	ids := make([]uint, len(invoices))
+	for i, invoice := range invoices {
+		ids[i] = invoice.ID
+	}
+	return ids
+}
+
+// TestNumberTextModes_AllPaths tests that contains, starts with and ends with match the decimal text
+// of a number field identically in DataQuery, DataGorm and both Hybrid strategies
+func TestNumberTextModes_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&RefInvoice{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateRefInvoices()).Error; err != nil {
+		t.Fatalf("Failed to create invoices: %v", err)
+	}
+	handler := filter.NewFilter[RefInvoice](filter.GolangFilteringConfig{StrictValidation: true})
+	invoices := generateRefInvoices()
+
+	tests := []struct {
+		name     string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"ContainsText", filter.ModeContains, "2025", []uint{1, 2, 4}},
+		{"ContainsNumber", filter.ModeContains, 2025, []uint{1, 2, 4}},
+		{"ContainsFloatValue", filter.ModeContains, 2025.0, []uint{1, 2, 4}},
+		{"StartsWith", filter.ModeStartsWith, "2025", []uint{1, 2}},
+		{"EndsWithText", filter.ModeEndsWith, "00", []uint{3, 4}},
+		{"EndsWithNumber", filter.ModeEndsWith, 1, []uint{1}},
+		{"NoMatch", filter.ModeContains, "99", []uint{}},
+		// LIKE wildcards in the value match themselves, as the substring match of DataQuery does
+		{"ContainsPercent", filter.ModeContains, "%", []uint{}},
+		{"ContainsUnderscore", filter.ModeContains, "_", []uint{}},
+		{"StartsWithWildcards", filter.ModeStartsWith, "20_5%1", []uint{}},
+		{"EndsWithBackslash", filter.ModeEndsWith, `\`, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "number", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeNumber},
+				},
+			}
+
+			result, err := handler.DataQuery(invoices, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := refInvoiceIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := refInvoiceIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, root, 0, 100)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if got := refInvoiceIDs(hybrid.Data); !equalIDs(got, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) IDs %v, got %v", hybrid.Strategy, tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+// TestNumberTextModes_Invalid tests that a value that is neither text nor a number is an error on both paths
+func TestNumberTextModes_Invalid(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: []string{"2"}, Mode: filter.ModeContains, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	if _, err := handler.DataQuery(generateTestUsers(), root, 0, 100); err == nil {
+		t.Error("Expected DataQuery error for a list value, got nil")
+	}
+	if _, err := handler.DataGorm(db, root, 0, 100); err == nil {
+		t.Error("Expected DataGorm error for a list value, got nil")
+	}
+}
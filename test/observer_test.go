@@ -0,0 +1,229 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// observedQuery pairs the start and outcome of one observed call
+type observedQuery struct {
+	info   filter.QueryInfo
+	result filter.QueryResultInfo
+	done   bool
+}
+
+// recordingObserver records every observed call
+type recordingObserver struct {
+	mu      sync.Mutex
+	queries []*observedQuery
+}
+
+func (o *recordingObserver) OnQueryStart(info filter.QueryInfo) func(filter.QueryResultInfo) {
+	query := &observedQuery{info: info}
+	o.mu.Lock()
+	o.queries = append(o.queries, query)
+	o.mu.Unlock()
+	return func(result filter.QueryResultInfo) {
+		query.result, query.done = result, true
+	}
+}
+
+// take returns the recorded calls and clears them
+func (o *recordingObserver) take() []*observedQuery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	queries := o.queries
+	o.queries = nil
+	return queries
+}
+
+// TestObserver_Methods tests that each public method reports exactly one call, under its own name,
+// with the filter and sort counts, strategy, total size and rows scanned in memory
+func TestObserver_Methods(t *testing.T) {
+	db := setupTestDB(t)
+	observer := &recordingObserver{}
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{Observer: observer})
+	users := generateTestUsers()
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		Groups: []filter.Root{{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: 18, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		}}},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	tests := []struct {
+		method       string
+		run          func() error
+		infoStrategy filter.Strategy
+		strategy     filter.Strategy
+		totalSize    int
+		scanned      int
+	}{
+		{"DataQuery", func() error { _, err := handler.DataQuery(users, root, 0, 2); return err }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+		{"DataQueryNoPage", func() error { _, err := handler.DataQueryNoPage(users, root); return err }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+		{"DataGorm", func() error { _, err := handler.DataGorm(db, root, 0, 2); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataGorm", func() error {
+			_, err := handler.DataGormWithPreset(db, map[string]any{"is_active": true}, root, 0, 2)
+			return err
+		}, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataGormNoPage", func() error { _, err := handler.DataGormNoPage(db, root); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"Hybrid", func() error { _, err := handler.Hybrid(db, 1000, root, 0, 2); return err }, "", filter.StrategyMemory, 3, 10},
+		{"Hybrid", func() error { _, err := handler.Hybrid(db, 0, root, 0, 2); return err }, "", filter.StrategyDatabase, 3, 0},
+		{"DataHybridNoPage", func() error { _, err := handler.DataHybridNoPage(db, 1000, root); return err }, "", filter.StrategyMemory, 3, 10},
+		{"HybridCSV", func() error { _, err := handler.HybridCSV(db, 0, root); return err }, "", filter.StrategyDatabase, 3, 0},
+		{"HybridCSV", func() error { _, err := handler.HybridCSVWithPreset(db, nil, 1000, root); return err }, "", filter.StrategyMemory, 3, 10},
+		{"GormNoPaginationCSV", func() error { _, err := handler.GormNoPaginationCSV(db, root); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataQueryNoPageCSV", func() error { _, err := handler.DataQueryNoPageCSV(users, root); return err }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+		{"CountGorm", func() error { _, err := handler.CountGorm(db, root); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"CountQuery", func() error { _, err := handler.CountQuery(users, root); return err }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+		{"FacetGorm", func() error { _, err := handler.FacetGorm(db, root, "is_active"); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataQueryCursor", func() error { _, err := handler.DataQueryCursor(users, root, "", 2); return err }, filter.StrategyMemory, filter.StrategyMemory, 2, 10},
+		{"GormNoPaginationJSON", func() error { _, err := handler.GormNoPaginationJSON(db, root); return err }, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataQueryNoPageXLSX", func() error { _, err := handler.DataQueryNoPageXLSX(users, root); return err }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+		{"GormCSVStream", func() error {
+			return handler.GormCSVStream(db, root, io.Discard, filter.CSVOptions{BatchSize: 2})
+		}, filter.StrategyDatabase, filter.StrategyDatabase, 3, 0},
+		{"DataQueryNDJSONStream", func() error { return handler.DataQueryNDJSONStream(users, root, io.Discard) }, filter.StrategyMemory, filter.StrategyMemory, 3, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if err := tt.run(); err != nil {
+				t.Fatalf("%s failed: %v", tt.method, err)
+			}
+			queries := observer.take()
+			if len(queries) != 1 {
+				t.Fatalf("Expected 1 observed call, got %d", len(queries))
+			}
+			query := queries[0]
+			if !query.done {
+				t.Fatal("Expected the finish function to be called")
+			}
+			if query.info.Method != tt.method {
+				t.Errorf("Expected method %s, got %s", tt.method, query.info.Method)
+			}
+			if query.info.FilterCount != 2 || query.info.SortCount != 1 {
+				t.Errorf("Expected 2 filters and 1 sort, got %d and %d", query.info.FilterCount, query.info.SortCount)
+			}
+			if query.info.Strategy != tt.infoStrategy {
+				t.Errorf("Expected start strategy %q, got %q", tt.infoStrategy, query.info.Strategy)
+			}
+			if query.info.Context == nil {
+				t.Error("Expected a context")
+			}
+			result := query.result
+			if result.Strategy != tt.strategy || result.TotalSize != tt.totalSize || result.Scanned != tt.scanned {
+				t.Errorf("Expected strategy %s, total %d and scanned %d, got %s, %d and %d",
+					tt.strategy, tt.totalSize, tt.scanned, result.Strategy, result.TotalSize, result.Scanned)
+			}
+			if result.Err != nil || result.Duration < 0 {
+				t.Errorf("Expected no error and a duration, got %v and %v", result.Err, result.Duration)
+			}
+		})
+	}
+}
+
+// TestObserver_ErrorsAndContext tests that failures are reported with their error and that the
+// Ctx methods pass their context to the observer
+func TestObserver_ErrorsAndContext(t *testing.T) {
+	db := setupTestDB(t)
+	observer := &recordingObserver{}
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{Observer: observer, StrictValidation: true})
+	invalid := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "age", Value: "old", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+	}}
+
+	_, err := handler.DataGorm(db, invalid, 0, 10)
+	if err == nil {
+		t.Fatal("Expected DataGorm error for an invalid number, got nil")
+	}
+	queries := observer.take()
+	if len(queries) != 1 || !queries[0].done || queries[0].result.Err != err {
+		t.Fatalf("Expected one observed call with the returned error, got %+v", queries)
+	}
+
+	type requestKey struct{}
+	ctx := context.WithValue(context.Background(), requestKey{}, "req-42")
+	if _, err := handler.DataQueryCtx(ctx, generateTestUsers(), filter.Root{Logic: filter.LogicAnd}, 0, 10); err != nil {
+		t.Fatalf("DataQueryCtx failed: %v", err)
+	}
+	if _, err := handler.GormNoPaginationCSV(db.WithContext(ctx), filter.Root{Logic: filter.LogicAnd}); err != nil {
+		t.Fatalf("GormNoPaginationCSV failed: %v", err)
+	}
+	queries = observer.take()
+	if len(queries) != 2 {
+		t.Fatalf("Expected 2 observed calls, got %d", len(queries))
+	}
+	for _, query := range queries {
+		if got := query.info.Context.Value(requestKey{}); got != "req-42" {
+			t.Errorf("%s: expected the caller's context, got value %v", query.info.Method, got)
+		}
+	}
+}
+
+// TestObserver_Slog wires an ObserverFunc to log/slog, logging every query with its outcome
+func TestObserver_Slog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	observer := filter.ObserverFunc(func(info filter.QueryInfo) func(filter.QueryResultInfo) {
+		return func(result filter.QueryResultInfo) {
+			logger.LogAttrs(info.Context, slog.LevelInfo, "filter query",
+				slog.String("method", info.Method),
+				slog.Int("filters", info.FilterCount),
+				slog.String("strategy", string(result.Strategy)),
+				slog.Int("total", result.TotalSize),
+				slog.Duration("duration", result.Duration),
+				slog.Any("error", result.Err),
+			)
+		}
+	})
+
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{Observer: observer})
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+	}}
+	if _, err := handler.HybridCtx(context.Background(), db, 1000, root, 0, 5); err != nil {
+		t.Fatalf("HybridCtx failed: %v", err)
+	}
+
+	expected := `level=INFO msg="filter query" method=Hybrid filters=1 strategy=memory total=7 error=<nil>`
+	if got := strings.TrimSpace(buf.String()); got != expected {
+		t.Errorf("Expected log line %q, got %q", expected, got)
+	}
+}
+
+// TestObserver_NilFinish tests that an observer may return a nil finish function
+func TestObserver_NilFinish(t *testing.T) {
+	started := 0
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{
+		Observer: filter.ObserverFunc(func(filter.QueryInfo) func(filter.QueryResultInfo) {
+			started++
+			return nil
+		}),
+	})
+	if _, err := handler.DataQuery(generateTestUsers(), filter.Root{Logic: filter.LogicAnd}, 0, 10); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if started != 1 {
+		t.Errorf("Expected 1 observed call, got %d", started)
+	}
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// OrDepartment shares the name, is_active and budget columns with OrEmployee, so unqualified
+// columns are ambiguous once it is joined
+type OrDepartment struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"is_active"`
+	Budget   int    `json:"budget"`
+}
+
+// OrEmployee belongs to an OrDepartment
+type OrEmployee struct {
+	ID           uint          `gorm:"primaryKey" json:"id"`
+	Name         string        `json:"name"`
+	IsActive     bool          `json:"is_active"`
+	Budget       int           `json:"budget"`
+	DepartmentID uint          `json:"department_id"`
+	Department   *OrDepartment `gorm:"foreignKey:DepartmentID" json:"department"`
+}
+
+func generateOrEmployees() []*OrEmployee {
+	engineering := &OrDepartment{ID: 1, Name: "Engineering", IsActive: true, Budget: 500}
+	sales := &OrDepartment{ID: 2, Name: "Sales", IsActive: false, Budget: 100}
+	return []*OrEmployee{
+		{ID: 1, Name: "Alice", IsActive: false, Budget: 10, DepartmentID: 1, Department: engineering},
+		{ID: 2, Name: "Bob", IsActive: true, Budget: 20, DepartmentID: 2, Department: sales},
+		{ID: 3, Name: "Carol", IsActive: false, Budget: 30, DepartmentID: 2, Department: sales},
+		{ID: 4, Name: "Dave", IsActive: false, Budget: 600, DepartmentID: 2, Department: sales},
+	}
+}
+
+func setupOrEmployeesDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&OrDepartment{}, &OrEmployee{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, employee := range generateOrEmployees() {
+		if err := db.Create(employee).Error; err != nil {
+			t.Fatalf("Failed to create employee: %v", err)
+		}
+	}
+	return db
+}
+
+func orEmployeeIDs(employees []*OrEmployee) []uint {
+	ids := make([]uint, len(employees))
+	for i, employee := range employees {
+		ids[i] = employee.ID
+	}
+	return ids
+}
+
+// TestOrLogic_NestedFields tests that OR logic mixing a nested field with a plain field of the same
+// column name qualifies both through the join, for bool, number and text, and matches DataQuery
+func TestOrLogic_NestedFields(t *testing.T) {
+	db := setupOrEmployeesDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[OrEmployee](filter.GolangFilteringConfig{MaxDepth: &maxDepth, StrictValidation: true})
+	byID := []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"Bool", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "department.is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		}, SortFields: byID}, []uint{1, 2}},
+		{"Number", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "department.budget", Value: 200, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			{Field: "budget", Value: 500, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		}, SortFields: byID}, []uint{1, 4}},
+		{"Text", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "department.name", Value: "eng", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+			{Field: "name", Value: "carol", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		}, SortFields: byID}, []uint{1, 3}},
+		{"NestedInGroup", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+		}, Groups: []filter.Root{{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "department.is_active", Value: false, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "budget", Value: 100, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		}}}, SortFields: byID}, []uint{2, 4}},
+		// Only the sort is nested, but its join makes the filtered columns ambiguous
+		{"NestedSortOnly", filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "name", Value: "Alice", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		}, SortFields: []filter.SortField{{Field: "department.name", Order: filter.SortOrderDesc}}}, []uint{2, 1}},
+		{"NestedSortOnlyAnd", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "is_active", Value: false, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "budget", Value: 100, Mode: filter.ModeLT, DataType: filter.DataTypeNumber},
+		}, SortFields: []filter.SortField{{Field: "department.name", Order: filter.SortOrderAsc}}}, []uint{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataQuery(generateOrEmployees(), tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := orEmployeeIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := orEmployeeIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+			if page.TotalSize != len(tt.expected) {
+				t.Errorf("Expected a DataGorm total of %d, got %d", len(tt.expected), page.TotalSize)
+			}
+		})
+	}
+}
+
+// TestNestedBoolSort tests that sorting by a nested bool orders false before true in DataQuery and DataGorm
+func TestNestedBoolSort(t *testing.T) {
+	db := setupOrEmployeesDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[OrEmployee](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+
+	for order, expected := range map[filter.SortOrder][]uint{
+		filter.SortOrderAsc:  {2, 3, 4, 1},
+		filter.SortOrderDesc: {1, 2, 3, 4},
+	} {
+		root := filter.Root{Logic: filter.LogicOr, FieldFilters: []filter.FieldFilter{
+			{Field: "department.is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "is_active", Value: false, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			{Field: "budget", Value: 20, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+		}, SortFields: []filter.SortField{{Field: "department.is_active", Order: order}}}
+
+		result, err := handler.DataQuery(generateOrEmployees(), root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if got := orEmployeeIDs(result.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected DataQuery IDs %v sorting %s, got %v", expected, order, got)
+		}
+
+		page, err := handler.DataGorm(db, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if got := orEmployeeIDs(page.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected DataGorm IDs %v sorting %s, got %v", expected, order, got)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// LimitedItem is a plain record for the page size and limit tests
+type LimitedItem struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+func generateLimitedItems(n int) []*LimitedItem {
+	items := make([]*LimitedItem, n)
+	for i := range items {
+		items[i] = &LimitedItem{ID: uint(i + 1), Name: fmt.Sprintf("item-%02d", i+1), Score: (i * 7) % 10}
+	}
+	return items
+}
+
+func limitedItemIDs(items []*LimitedItem) []uint {
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func setupLimitedItemsDB(t *testing.T, items []*LimitedItem) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&LimitedItem{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(items).Error; err != nil {
+		t.Fatalf("Failed to create items: %v", err)
+	}
+	return db
+}
+
+// TestMaxPageSize_Clamps tests that every paged path caps the page size at MaxPageSize and reports it
+func TestMaxPageSize_Clamps(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{MaxPageSize: 10})
+	root := filter.Root{Logic: filter.LogicAnd}
+
+	check := func(name string, result *filter.PaginationResult[LimitedItem], err error, clamped bool) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		expectedSize := 5
+		if clamped {
+			expectedSize = 10
+		}
+		if result.PageSize != expectedSize || len(result.Data) != expectedSize || result.PageSizeClamped != clamped {
+			t.Errorf("%s: expected %d records with PageSizeClamped=%v, got PageSize %d, %d records, PageSizeClamped=%v",
+				name, expectedSize, clamped, result.PageSize, len(result.Data), result.PageSizeClamped)
+		}
+		if clamped && result.TotalPage != 3 {
+			t.Errorf("%s: expected 3 pages of the clamped size, got %d", name, result.TotalPage)
+		}
+	}
+
+	for _, clamped := range []bool{true, false} {
+		pageSize := 5
+		if clamped {
+			pageSize = 100000
+		}
+		result, err := handler.DataGorm(db, root, 0, pageSize)
+		check("DataGorm", result, err, clamped)
+		result, err = handler.DataQuery(items, root, 0, pageSize)
+		check("DataQuery", result, err, clamped)
+		result, err = handler.Hybrid(db, 1000, root, 0, pageSize)
+		check("Hybrid", result, err, clamped)
+	}
+
+	cursorResult, err := handler.DataQueryCursor(items, root, "", 100000)
+	if err != nil {
+		t.Fatalf("DataQueryCursor failed: %v", err)
+	}
+	if len(cursorResult.Data) != 10 || !cursorResult.PageSizeClamped || !cursorResult.HasMore {
+		t.Errorf("Expected a clamped cursor page of 10, got %d records (clamped %v)", len(cursorResult.Data), cursorResult.PageSizeClamped)
+	}
+}
+
+// TestRootLimit_NoPage tests that Root.Limit caps the NoPage queries after sorting, on both paths
+func TestRootLimit_NoPage(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "score", Value: 5, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}},
+		SortFields:   []filter.SortField{{Field: "score", Order: filter.SortOrderDesc}, {Field: "id", Order: filter.SortOrderAsc}},
+		Limit:        4,
+	}
+	expected := []uint{8, 18, 5, 15}
+
+	memory, err := handler.DataQueryNoPage(items, root)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	if got := limitedItemIDs(memory); !equalIDs(got, expected) {
+		t.Errorf("Expected DataQueryNoPage IDs %v, got %v", expected, got)
+	}
+	database, err := handler.DataGormNoPage(db, root)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	if got := limitedItemIDs(database); !equalIDs(got, expected) {
+		t.Errorf("Expected DataGormNoPage IDs %v, got %v", expected, got)
+	}
+
+	count, err := handler.CountQuery(items, root)
+	if err != nil {
+		t.Fatalf("CountQuery failed: %v", err)
+	}
+	if count != 12 {
+		t.Errorf("Expected CountQuery to ignore Limit and count 12, got %d", count)
+	}
+	page, err := handler.DataQuery(items, root, 0, 30)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if page.TotalSize != 12 || len(page.Data) != 12 {
+		t.Errorf("Expected DataQuery to ignore Limit, got %d of %d", len(page.Data), page.TotalSize)
+	}
+}
+
+// TestRootLimit_CSV tests that Root.Limit caps the CSV exports, including batched streaming
+func TestRootLimit_CSV(t *testing.T) {
+	items := generateLimitedItems(25)
+	db := setupLimitedItemsDB(t, items)
+	handler := filter.NewFilter[LimitedItem](filter.GolangFilteringConfig{})
+	sorted := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderDesc}},
+		Limit:      7,
+	}
+	unsorted := filter.Root{Logic: filter.LogicAnd, Limit: 7}
+	opts := filter.DefaultCSVOptions()
+	opts.BatchSize = 3
+
+	countRows := func(name string, data []byte) {
+		t.Helper()
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read %s CSV: %v", name, err)
+		}
+		if len(records) != 8 {
+			t.Errorf("Expected %s to export a header and 7 rows, got %d rows", name, len(records))
+		}
+	}
+
+	for name, root := range map[string]filter.Root{"sorted": sorted, "unsorted": unsorted} {
+		data, err := handler.GormNoPaginationCSV(db, root)
+		if err != nil {
+			t.Fatalf("GormNoPaginationCSV failed: %v", err)
+		}
+		countRows("GormNoPaginationCSV "+name, data)
+
+		var buf bytes.Buffer
+		if err := handler.GormCSVStream(db, root, &buf, opts); err != nil {
+			t.Fatalf("GormCSVStream failed: %v", err)
+		}
+		countRows("GormCSVStream "+name, buf.Bytes())
+
+		data, err = handler.DataQueryNoPageCSV(items, root)
+		if err != nil {
+			t.Fatalf("DataQueryNoPageCSV failed: %v", err)
+		}
+		countRows("DataQueryNoPageCSV "+name, data)
+
+		custom := func(item *LimitedItem) map[string]any { return map[string]any{"name": item.Name} }
+		data, err = handler.GormNoPaginationCSVCustom(db, root, custom)
+		if err != nil {
+			t.Fatalf("GormNoPaginationCSVCustom failed: %v", err)
+		}
+		countRows("GormNoPaginationCSVCustom "+name, data)
+	}
+}
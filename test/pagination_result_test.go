@@ -0,0 +1,152 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+)
+
+// paginationPaths runs the same query through every paginated path
+func paginationPaths(handler *filter.Handler[TestUser], db *gorm.DB) map[string]func(filter.Root, int, int) (*filter.PaginationResult[TestUser], error) {
+	users := generateTestUsers()
+	return map[string]func(filter.Root, int, int) (*filter.PaginationResult[TestUser], error){
+		"DataQuery": func(root filter.Root, pageIndex, pageSize int) (*filter.PaginationResult[TestUser], error) {
+			return handler.DataQuery(users, root, pageIndex, pageSize)
+		},
+		"DataGorm": func(root filter.Root, pageIndex, pageSize int) (*filter.PaginationResult[TestUser], error) {
+			return handler.DataGorm(db, root, pageIndex, pageSize)
+		},
+		"HybridMemory": func(root filter.Root, pageIndex, pageSize int) (*filter.PaginationResult[TestUser], error) {
+			return handler.Hybrid(db, 1000, root, pageIndex, pageSize)
+		},
+		"HybridDatabase": func(root filter.Root, pageIndex, pageSize int) (*filter.PaginationResult[TestUser], error) {
+			return handler.Hybrid(db, 0, root, pageIndex, pageSize)
+		},
+	}
+}
+
+// TestPaginationResult_HasNextHasPrev tests the page navigation flags on every path
+func TestPaginationResult_HasNextHasPrev(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	all := filter.Root{Logic: filter.LogicAnd}
+	none := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: 100, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		root      filter.Root
+		pageIndex int
+		hasNext   bool
+		hasPrev   bool
+	}{
+		// 10 users in pages of 3: pages 0 to 3
+		{"FirstPage", all, 0, true, false},
+		{"MiddlePage", all, 1, true, true},
+		{"LastPage", all, 3, false, true},
+		{"PastTheEnd", all, 5, false, true},
+		{"NoResults", none, 0, false, false},
+	}
+
+	for path, run := range paginationPaths(handler, db) {
+		for _, tt := range tests {
+			t.Run(path+"/"+tt.name, func(t *testing.T) {
+				result, err := run(tt.root, tt.pageIndex, 3)
+				if err != nil {
+					t.Fatalf("%s failed: %v", path, err)
+				}
+				if result.HasNext != tt.hasNext || result.HasPrev != tt.hasPrev {
+					t.Errorf("Expected HasNext=%v HasPrev=%v, got %v and %v", tt.hasNext, tt.hasPrev, result.HasNext, result.HasPrev)
+				}
+			})
+		}
+	}
+}
+
+// TestPaginationResult_IgnoredFields tests that dropped fields are reported and left out of AppliedFilters on every path
+func TestPaginationResult_IgnoredFields(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email"}})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "nickname", Value: "jd", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		Groups: []filter.Root{{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "email", Value: "example", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+				{Field: "age", Value: 20, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+			},
+		}},
+		Search:     &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "a"},
+		SortFields: []filter.SortField{{Field: "agee", Order: filter.SortOrderAsc}, {Field: "age", Order: filter.SortOrderDesc}},
+	}
+	expectedIgnored := []string{"nickname", "email", "agee"}
+
+	for path, run := range paginationPaths(handler, db) {
+		t.Run(path, func(t *testing.T) {
+			result, err := run(root, 0, 10)
+			if err != nil {
+				t.Fatalf("%s failed: %v", path, err)
+			}
+			if !reflect.DeepEqual(result.IgnoredFields, expectedIgnored) {
+				t.Errorf("Expected IgnoredFields %v, got %v", expectedIgnored, result.IgnoredFields)
+			}
+
+			applied := result.AppliedFilters
+			if applied == nil {
+				t.Fatal("Expected AppliedFilters")
+			}
+			if len(applied.FieldFilters) != 1 || applied.FieldFilters[0].Field != "role" {
+				t.Errorf("Expected only the role filter to be applied, got %+v", applied.FieldFilters)
+			}
+			if len(applied.Groups) != 1 || len(applied.Groups[0].FieldFilters) != 1 || applied.Groups[0].FieldFilters[0].Field != "age" {
+				t.Errorf("Expected only the age filter to be applied in the group, got %+v", applied.Groups)
+			}
+			if applied.Search == nil || !reflect.DeepEqual(applied.Search.Fields, []string{"name"}) {
+				t.Errorf("Expected only the name search field to be applied, got %+v", applied.Search)
+			}
+			if len(applied.SortFields) != 1 || applied.SortFields[0].Field != "age" {
+				t.Errorf("Expected only the age sort field to be applied, got %+v", applied.SortFields)
+			}
+		})
+	}
+
+	// The caller's Root is left unchanged
+	if len(root.FieldFilters) != 2 || len(root.Groups[0].FieldFilters) != 2 || len(root.Search.Fields) != 2 || len(root.SortFields) != 2 {
+		t.Errorf("Expected the caller's Root to be unchanged, got %+v", root)
+	}
+}
+
+// TestPaginationResult_NothingIgnored tests that IgnoredFields is empty when every field takes effect
+func TestPaginationResult_NothingIgnored(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	for path, run := range paginationPaths(handler, db) {
+		result, err := run(root, 0, 10)
+		if err != nil {
+			t.Fatalf("%s failed: %v", path, err)
+		}
+		if len(result.IgnoredFields) != 0 {
+			t.Errorf("Expected no %s IgnoredFields, got %v", path, result.IgnoredFields)
+		}
+		if result.AppliedFilters == nil || len(result.AppliedFilters.FieldFilters) != 1 {
+			t.Errorf("Expected %s AppliedFilters to echo the role filter, got %+v", path, result.AppliedFilters)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestPaginationTotals_AllPaths tests that DataQuery, DataGorm and both Hybrid strategies report the same
+// normalized page, page counts and flags for empty results, exact multiples and out-of-range pages
+func TestPaginationTotals_AllPaths(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	all := filter.Root{Logic: filter.LogicAnd}
+	none := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "role", Value: "nobody", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+	}}
+
+	type expectation struct {
+		pageIndex, pageSize, totalSize, totalPage, count int
+		hasNext, hasPrev, outOfRange                     bool
+	}
+	tests := []struct {
+		name      string
+		root      filter.Root
+		pageIndex int
+		pageSize  int
+		expected  expectation
+	}{
+		{"EmptyResult", none, 0, 10, expectation{0, 10, 0, 1, 0, false, false, false}},
+		{"EmptyResultLaterPage", none, 2, 10, expectation{2, 10, 0, 1, 0, false, true, true}},
+		{"ExactMultipleFirstPage", all, 0, 5, expectation{0, 5, 10, 2, 5, true, false, false}},
+		{"ExactMultipleLastPage", all, 1, 5, expectation{1, 5, 10, 2, 5, false, true, false}},
+		{"PartialLastPage", all, 3, 3, expectation{3, 3, 10, 4, 1, false, true, false}},
+		{"PastLastPage", all, 2, 5, expectation{2, 5, 10, 2, 0, false, true, true}},
+		{"PageSizeAboveTotal", all, 0, 50, expectation{0, 50, 10, 1, 10, false, false, false}},
+		{"DefaultsForInvalidPage", all, -3, 0, expectation{0, 30, 10, 1, 10, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := func(path string, result *filter.PaginationResult[TestUser]) {
+				got := expectation{
+					result.PageIndex, result.PageSize, result.TotalSize, result.TotalPage, len(result.Data),
+					result.HasNext, result.HasPrev, result.OutOfRange,
+				}
+				if got != tt.expected {
+					t.Errorf("%s: expected %+v, got %+v", path, tt.expected, got)
+				}
+			}
+
+			result, err := handler.DataQuery(users, tt.root, tt.pageIndex, tt.pageSize)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			check("DataQuery", result)
+
+			page, err := handler.DataGorm(db, tt.root, tt.pageIndex, tt.pageSize)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			check("DataGorm", page)
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, tt.root, tt.pageIndex, tt.pageSize)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				check("Hybrid "+string(hybrid.Strategy), hybrid)
+			}
+		})
+	}
+
+	// An empty slice returns early but reports the same totals
+	result, err := handler.DataQuery(nil, all, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if result.TotalPage != 1 || result.HasNext || result.OutOfRange {
+		t.Errorf("Expected page 1 of 1 for no data, got %+v", result)
+	}
+}
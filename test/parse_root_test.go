@@ -0,0 +1,194 @@
+package test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestParseRootFromJSON tests decoding and normalizing a JSON filter payload
+func TestParseRootFromJSON(t *testing.T) {
+	payload := `{
+		"logic": "AND",
+		"filters": [
+			{"field": "age", "value": {"from": 26, "to": 35}, "mode": "range", "dataType": "number"},
+			{"field": "name", "value": "john", "mode": "Contains", "dataType": "TEXT"}
+		],
+		"sortFields": [{"field": "age", "order": "DESC", "dataType": "number"}],
+		"groups": [
+			{"filters": [{"field": "role", "value": ["admin"], "mode": "in", "dataType": "text"}], "logic": "OR"}
+		]
+	}`
+
+	root, err := filter.ParseRootFromJSON([]byte(payload))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+
+	if root.Logic != filter.LogicAnd {
+		t.Errorf("Expected logic and, got %q", root.Logic)
+	}
+	if _, ok := root.FieldFilters[0].Value.(filter.Range); !ok {
+		t.Errorf("Expected range value to be converted to filter.Range, got %T", root.FieldFilters[0].Value)
+	}
+	if root.FieldFilters[1].Mode != filter.ModeContains || root.FieldFilters[1].DataType != filter.DataTypeText {
+		t.Errorf("Expected mode and data type to be canonicalized, got %q / %q", root.FieldFilters[1].Mode, root.FieldFilters[1].DataType)
+	}
+	if root.SortFields[0].Order != filter.SortOrderDesc {
+		t.Errorf("Expected sort order desc, got %q", root.SortFields[0].Order)
+	}
+	if root.SortFields[0].DataType != filter.DataTypeNumber {
+		t.Errorf("Expected sort data type number, got %q", root.SortFields[0].DataType)
+	}
+	if root.Groups[0].Logic != filter.LogicOr {
+		t.Errorf("Expected group logic or, got %q", root.Groups[0].Logic)
+	}
+
+	// The parsed root should be usable directly
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	result, err := handler.DataQuery(generateTestUsers(), root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	// John Doe (25) is out of range; John Smith (29) is a user, not admin
+	if result.TotalSize != 0 {
+		t.Errorf("Expected 0 results, got %d", result.TotalSize)
+	}
+}
+
+// TestParseRootFromJSON_Errors tests descriptive errors for invalid payloads
+func TestParseRootFromJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		errText string
+	}{
+		{"InvalidJSON", `{"filters":`, "invalid filter JSON"},
+		{"UnknownMode", `{"filters":[{"field":"age","value":1,"mode":"foo","dataType":"number"}]}`, "unknown mode 'foo' on field 'age'"},
+		{"UnknownDataType", `{"filters":[{"field":"age","value":1,"mode":"equal","dataType":"int"}]}`, "unknown data type 'int' on field 'age'"},
+		{"UnknownLogic", `{"logic":"xor"}`, "unknown logic 'xor'"},
+		{"UnknownSortOrder", `{"sortFields":[{"field":"age","order":"up"}]}`, "unknown sort order 'up' on field 'age'"},
+		{"BadRange", `{"filters":[{"field":"age","value":5,"mode":"range","dataType":"number"}]}`, "range value on field 'age'"},
+		{"NestedGroupError", `{"groups":[{"filters":[{"field":"x","value":1,"mode":"bad","dataType":"number"}]}]}`, "unknown mode 'bad' on field 'x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := filter.ParseRootFromJSON([]byte(tt.payload))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Expected error containing %q, got %q", tt.errText, err.Error())
+			}
+		})
+	}
+}
+
+// TestParseRootFromJSON_EmptyLogic tests that an empty logic means OR in parsed roots and nested groups,
+// as it does in a Root written in Go, in ExplainQuery and in MergeRoots
+func TestParseRootFromJSON_EmptyLogic(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{
+		"filters": [
+			{"field": "role", "value": "admin", "mode": "equal", "dataType": "text"},
+			{"field": "age", "value": 40, "mode": "gte", "dataType": "number"}
+		],
+		"groups": [{"filters": [
+			{"field": "name", "value": "john", "mode": "contains", "dataType": "text"},
+			{"field": "age", "value": 25, "mode": "lte", "dataType": "number"}
+		]}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.Logic != filter.LogicOr || root.Groups[0].Logic != filter.LogicOr {
+		t.Errorf("Expected empty logic to become or, got %q and %q", root.Logic, root.Groups[0].Logic)
+	}
+
+	goRoot := filter.Root{
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "age", Value: 40, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+		Groups: []filter.Root{{FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "john", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			{Field: "age", Value: 25, Mode: filter.ModeLTE, DataType: filter.DataTypeNumber},
+		}}},
+	}
+	merged, err := filter.MergeRoots(goRoot, filter.Root{}, filter.MergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeRoots failed: %v", err)
+	}
+
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	for name, r := range map[string]filter.Root{"parsed": root, "go": goRoot, "merged": merged} {
+		result, err := handler.DataQuery(generateTestUsers(), r, 0, 100)
+		if err != nil {
+			t.Fatalf("DataQuery (%s) failed: %v", name, err)
+		}
+		if expected, got := []uint{1, 3, 5, 7, 10}, userIDs(result.Data); !equalIDs(got, expected) {
+			t.Errorf("Expected the %s root to match %v, got %v", name, expected, got)
+		}
+	}
+
+	explained, err := handler.ExplainQuery(goRoot)
+	if err != nil {
+		t.Fatalf("ExplainQuery failed: %v", err)
+	}
+	if !strings.HasPrefix(explained, "or\n") || !strings.Contains(explained, "\n  or\n") {
+		t.Errorf("Expected ExplainQuery to show or for empty logic, got:\n%s", explained)
+	}
+}
+
+// TestParseRootFromBase64 tests decoding standard and URL-safe base64 payloads
+func TestParseRootFromBase64(t *testing.T) {
+	payload := []byte(`{"filters":[{"field":"role","value":"admin","mode":"equal","dataType":"text"}],"logic":"and"}`)
+
+	for _, encoded := range []string{
+		base64.StdEncoding.EncodeToString(payload),
+		base64.RawURLEncoding.EncodeToString(payload),
+	} {
+		root, err := filter.ParseRootFromBase64(encoded)
+		if err != nil {
+			t.Fatalf("ParseRootFromBase64 failed: %v", err)
+		}
+		if len(root.FieldFilters) != 1 || root.FieldFilters[0].Value != "admin" {
+			t.Errorf("Unexpected parsed root: %+v", root)
+		}
+	}
+
+	if _, err := filter.ParseRootFromBase64("not base64!!"); err == nil {
+		t.Error("Expected error for invalid base64, got nil")
+	}
+}
+
+// TestRootJSONRoundTrip tests that a Root marshals back to the same JSON shape it was parsed from
+func TestRootJSONRoundTrip(t *testing.T) {
+	original := filter.Root{
+		Logic: filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: filter.Range{From: 20.0, To: 30.0}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "name", Value: "john", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	parsed, err := filter.ParseRootFromJSON(data)
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	again, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != string(again) {
+		t.Errorf("Round trip mismatch:\n%s\n%s", data, again)
+	}
+}
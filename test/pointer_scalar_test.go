@@ -0,0 +1,179 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// PtrMember uses pointer scalars for every nullable column
+type PtrMember struct {
+	ID       uint       `gorm:"primaryKey" json:"id"`
+	Nickname *string    `json:"nickname"`
+	Age      *int       `json:"age"`
+	Score    *float64   `json:"score"`
+	Verified *bool      `json:"verified"`
+	JoinedAt *time.Time `json:"joined_at"`
+}
+
+func generatePtrMembers() []*PtrMember {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+	score := func(s float64) *float64 { return &s }
+	flag := func(b bool) *bool { return &b }
+	date := func(month time.Month) *time.Time {
+		t := time.Date(2024, month, 15, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	return []*PtrMember{
+		{ID: 1, Nickname: str("Ace"), Age: num(30), Score: score(8.5), Verified: flag(true), JoinedAt: date(time.January)},
+		{ID: 2, Nickname: str("bolt"), Age: num(22), Score: score(6), Verified: flag(false), JoinedAt: date(time.June)},
+		{ID: 3, Age: num(45), Verified: flag(true)},
+		{ID: 4, Nickname: str("Acer"), Score: score(9.25), JoinedAt: date(time.March)},
+		{ID: 5},
+	}
+}
+
+func setupPtrMembersDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&PtrMember{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generatePtrMembers()).Error; err != nil {
+		t.Fatalf("Failed to create members: %v", err)
+	}
+	return db
+}
+
+func ptrMemberIDs(members []*PtrMember) []uint {
+	ids := make([]uint, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	return ids
+}
+
+// TestPointerScalars_Filters tests that filters on pointer scalar fields match their values in memory
+// as they do in SQL, with nil pointers following the NULL semantics
+func TestPointerScalars_Filters(t *testing.T) {
+	db := setupPtrMembersDB(t)
+	handler := filter.NewFilter[PtrMember](filter.GolangFilteringConfig{StrictValidation: true})
+	members := generatePtrMembers()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"TextContains", filter.FieldFilter{Field: "nickname", Value: "ace", Mode: filter.ModeContains, DataType: filter.DataTypeText}, []uint{1, 4}},
+		{"TextNotEqual", filter.FieldFilter{Field: "nickname", Value: "bolt", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, []uint{1, 3, 4, 5}},
+		{"TextIn", filter.FieldFilter{Field: "nickname", Value: []string{"ACE", "bolt"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}, []uint{1, 2}},
+		{"NumberGTE", filter.FieldFilter{Field: "age", Value: 30, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber}, []uint{1, 3}},
+		{"NumberRange", filter.FieldFilter{Field: "score", Value: filter.Range{From: 6, To: 9}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, []uint{1, 2}},
+		{"NumberStartsWith", filter.FieldFilter{Field: "age", Value: "2", Mode: filter.ModeStartsWith, DataType: filter.DataTypeNumber}, []uint{2}},
+		{"Bool", filter.FieldFilter{Field: "verified", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool}, []uint{1, 3}},
+		{"DateBefore", filter.FieldFilter{Field: "joined_at", Value: "2024-04-01", Mode: filter.ModeBefore, DataType: filter.DataTypeDate}, []uint{1, 4}},
+		{"DateIsEmpty", filter.FieldFilter{Field: "joined_at", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeDate}, []uint{3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			result, err := handler.DataQuery(members, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := ptrMemberIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := ptrMemberIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestPointerScalars_Sort tests that pointer scalar fields sort by value, with nil pointers first
+// ascending and last descending like NULLs in SQL
+func TestPointerScalars_Sort(t *testing.T) {
+	db := setupPtrMembersDB(t)
+	handler := filter.NewFilter[PtrMember](filter.GolangFilteringConfig{})
+	members := generatePtrMembers()
+
+	tests := []struct {
+		field    string
+		order    filter.SortOrder
+		expected []uint
+	}{
+		{"age", filter.SortOrderAsc, []uint{4, 5, 2, 1, 3}},
+		{"age", filter.SortOrderDesc, []uint{3, 1, 2, 4, 5}},
+		{"nickname", filter.SortOrderAsc, []uint{3, 5, 1, 4, 2}},
+		{"score", filter.SortOrderDesc, []uint{4, 1, 2, 3, 5}},
+		{"joined_at", filter.SortOrderAsc, []uint{3, 5, 1, 4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"_"+string(tt.order), func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: tt.field, Order: tt.order}}}
+
+			result, err := handler.DataQuery(members, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := ptrMemberIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery order %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := ptrMemberIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm order %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestPointerScalars_CSV tests that CSV exports write the values of pointer scalar fields, not their addresses
+func TestPointerScalars_CSV(t *testing.T) {
+	db := setupPtrMembersDB(t)
+	handler := filter.NewFilter[PtrMember](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "id", Value: []int{1, 3}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber}},
+		SortFields:   []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+	opts := filter.DefaultCSVOptions()
+	opts.Columns = []string{"id", "nickname", "age", "score", "verified"}
+	expected := "id,nickname,age,score,verified\n1,Ace,30,8.5,true\n3,<nil>,45,<nil>,true\n"
+
+	csvData, err := handler.DataQueryNoPageCSVWithOptions(generatePtrMembers(), root, opts)
+	if err != nil {
+		t.Fatalf("DataQueryNoPageCSVWithOptions failed: %v", err)
+	}
+	if got := strings.ReplaceAll(string(csvData), "\r\n", "\n"); got != expected {
+		t.Errorf("Expected in-memory CSV:\n%s\ngot:\n%s", expected, got)
+	}
+
+	csvData, err = handler.GormNoPaginationCSVWithOptions(db, root, opts)
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVWithOptions failed: %v", err)
+	}
+	if got := strings.ReplaceAll(string(csvData), "\r\n", "\n"); got != expected {
+		t.Errorf("Expected GORM CSV:\n%s\ngot:\n%s", expected, got)
+	}
+}
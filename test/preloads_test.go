@@ -0,0 +1,194 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// PreloadItem is a line of a PreloadOrder
+type PreloadItem struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	OrderID  uint   `json:"order_id"`
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// PreloadOrder is an order of a PreloadCustomer
+type PreloadOrder struct {
+	ID         uint          `gorm:"primaryKey" json:"id"`
+	CustomerID uint          `json:"customer_id"`
+	Status     string        `json:"status"`
+	Items      []PreloadItem `gorm:"foreignKey:OrderID" json:"items"`
+}
+
+// PreloadCustomer has orders that have items, two levels of has-many relations to preload
+type PreloadCustomer struct {
+	ID     uint           `gorm:"primaryKey" json:"id"`
+	Name   string         `json:"name"`
+	Orders []PreloadOrder `gorm:"foreignKey:CustomerID" json:"orders"`
+}
+
+func setupPreloadDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&PreloadCustomer{}, &PreloadOrder{}, &PreloadItem{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	customers := []*PreloadCustomer{
+		{ID: 1, Name: "Alice", Orders: []PreloadOrder{
+			{ID: 1, Status: "open", Items: []PreloadItem{{ID: 1, SKU: "A", Quantity: 1}, {ID: 2, SKU: "B", Quantity: 5}}},
+			{ID: 2, Status: "closed", Items: []PreloadItem{{ID: 3, SKU: "C", Quantity: 2}}},
+		}},
+		{ID: 2, Name: "Bob", Orders: []PreloadOrder{
+			{ID: 3, Status: "open", Items: []PreloadItem{{ID: 4, SKU: "D", Quantity: 3}}},
+		}},
+	}
+	for _, customer := range customers {
+		if err := db.Create(customer).Error; err != nil {
+			t.Fatalf("Failed to create customer: %v", err)
+		}
+	}
+	return db
+}
+
+// preloaded describes the orders and items loaded with customers, e.g. "Alice: 1[A B] 2[C]; Bob: 3[D]"
+func preloaded(customers []*PreloadCustomer) string {
+	var parts []string
+	for _, customer := range customers {
+		var orders []string
+		for _, order := range customer.Orders {
+			var skus []string
+			for _, item := range order.Items {
+				skus = append(skus, item.SKU)
+			}
+			orders = append(orders, fmt.Sprintf("%d[%s]", order.ID, strings.Join(skus, " ")))
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", customer.Name, strings.Join(orders, " ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// TestPreloads tests that conditional and two-level nested preloads load the same relations in DataGorm,
+// DataGormNoPage and both strategies of Hybrid, whatever the names of their segments
+func TestPreloads(t *testing.T) {
+	db := setupPreloadDB(t)
+	handler := filter.NewFilter[PreloadCustomer](filter.GolangFilteringConfig{StrictValidation: true})
+	byID := []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected string
+	}{
+		{"Legacy", filter.Root{Preload: []string{"Orders"}}, "Alice: 1[] 2[]; Bob: 3[]"},
+		{"Conditional", filter.Root{Preloads: []filter.Preload{
+			{Relation: "orders", Conditions: []any{"status = ?", "open"}},
+		}}, "Alice: 1[]; Bob: 3[]"},
+		{"Nested", filter.Root{Preloads: []filter.Preload{{Relation: "orders.items"}}}, "Alice: 1[A B] 2[C]; Bob: 3[D]"},
+		{"NestedConditional", filter.Root{Preloads: []filter.Preload{
+			{Relation: "Orders.Items", Conditions: []any{"quantity > ?", 1}},
+		}}, "Alice: 1[B] 2[C]; Bob: 3[D]"},
+		{"BothLevelsConditional", filter.Root{Preloads: []filter.Preload{
+			{Relation: "Orders", Conditions: []any{"status = ?", "open"}},
+			{Relation: "Orders.Items", Conditions: []any{"quantity > ?", 1}},
+		}}, "Alice: 1[B]; Bob: 3[D]"},
+		{"WithFilter", filter.Root{FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "bob", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		}, Preload: []string{"Orders.Items"}}, "Bob: 3[D]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.root.Logic = filter.LogicAnd
+			tt.root.SortFields = byID
+
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := preloaded(page.Data); got != tt.expected {
+				t.Errorf("Expected DataGorm to preload %q, got %q", tt.expected, got)
+			}
+
+			all, err := handler.DataGormNoPage(db, tt.root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if got := preloaded(all); got != tt.expected {
+				t.Errorf("Expected DataGormNoPage to preload %q, got %q", tt.expected, got)
+			}
+
+			for _, strategy := range []filter.Strategy{filter.StrategyMemory, filter.StrategyDatabase} {
+				opts := filter.HybridOptions{StrategyFunc: func(int64, filter.Root) filter.Strategy { return strategy }}
+				hybrid, err := handler.HybridWithOptions(t.Context(), db, 1000, tt.root, 0, 10, opts)
+				if err != nil {
+					t.Fatalf("Hybrid (%s) failed: %v", strategy, err)
+				}
+				if got := preloaded(hybrid.Data); got != tt.expected {
+					t.Errorf("Expected Hybrid (%s) to preload %q, got %q", strategy, tt.expected, got)
+				}
+			}
+		})
+	}
+
+	root, err := filter.NewRoot().PreloadWhere("orders.items", "sku = ?", "A").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if got := preloaded(page.Data); got != "Alice: 1[A] 2[]; Bob: 3[]" {
+		t.Errorf("Expected the built Root to preload item A only, got %q", got)
+	}
+}
+
+// TestPreloads_UnknownRelation tests that unknown preload relations are skipped by default and rejected
+// with StrictValidation, RejectUnknownFields and ValidateRoot before any query runs
+func TestPreloads_UnknownRelation(t *testing.T) {
+	db := setupPreloadDB(t)
+	root := filter.Root{Logic: filter.LogicAnd, Preloads: []filter.Preload{
+		{Relation: "Orders.Items"},
+		{Relation: "Orders.Payments", Conditions: []any{"amount > ?", 0}},
+	}}
+
+	lenient := filter.NewFilter[PreloadCustomer](filter.GolangFilteringConfig{})
+	page, err := lenient.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("Expected the unknown relation to be skipped, got %v", err)
+	}
+	if got := preloaded(page.Data); got != "Alice: 1[A B] 2[C]; Bob: 3[D]" {
+		t.Errorf("Expected the known relations to be preloaded, got %q", got)
+	}
+	if err := lenient.ValidateRoot(root); !errors.Is(err, filter.ErrUnknownField) || !strings.Contains(err.Error(), "Orders.Payments") {
+		t.Errorf("Expected ValidateRoot to report the unknown relation, got %v", err)
+	}
+
+	strictValidation := true
+	strictRoot := root
+	strictRoot.StrictValidation = &strictValidation
+	for name, run := range map[string]func() error{
+		"DataGorm":       func() error { _, err := lenient.DataGorm(db, strictRoot, 0, 10); return err },
+		"DataGormNoPage": func() error { _, err := lenient.DataGormNoPage(db, strictRoot); return err },
+		"Hybrid":         func() error { _, err := lenient.Hybrid(db, 1000, strictRoot, 0, 10); return err },
+	} {
+		if err := run(); !errors.Is(err, filter.ErrUnknownField) {
+			t.Errorf("Expected %s to reject the unknown relation with StrictValidation, got %v", name, err)
+		}
+	}
+
+	rejecting := filter.NewFilter[PreloadCustomer](filter.GolangFilteringConfig{RejectUnknownFields: true})
+	var unknown *filter.UnknownFieldError
+	if _, err := rejecting.DataGorm(db, root, 0, 10); !errors.As(err, &unknown) || unknown.Field != "Orders.Payments" {
+		t.Errorf("Expected RejectUnknownFields to reject the unknown relation, got %v", err)
+	}
+}
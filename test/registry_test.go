@@ -0,0 +1,255 @@
+package test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RegisteredItem is only registered in the package-level Registry by TestRegistry_Default
+type RegisteredItem struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+// ListedItem is only registered in the package-level Registry by TestHandleList
+type ListedItem struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// TestRegistry_Default tests Register, For and Lookup on the package-level Registry
+func TestRegistry_Default(t *testing.T) {
+	if _, err := filter.Lookup[RegisteredItem](); err == nil {
+		t.Fatal("Expected Lookup to fail before Register")
+	}
+
+	handler := filter.Register[RegisteredItem](filter.GolangFilteringConfig{MaxPageSize: 5})
+	if got := filter.For[RegisteredItem](); got != handler {
+		t.Errorf("Expected For to return the registered handler")
+	}
+	if got, err := filter.Lookup[RegisteredItem](); err != nil || got != handler {
+		t.Errorf("Expected Lookup to return the registered handler, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a second Register to panic")
+			}
+		}()
+		filter.Register[RegisteredItem](filter.GolangFilteringConfig{})
+	}()
+}
+
+// TestRegistry_Explicit tests that registries are independent of each other and of the package-level one
+func TestRegistry_Explicit(t *testing.T) {
+	first, second := filter.NewRegistry(), filter.NewRegistry()
+	handler := filter.RegisterIn[LimitedItem](first, filter.GolangFilteringConfig{})
+
+	if got := filter.ForIn[LimitedItem](first); got != handler {
+		t.Errorf("Expected ForIn to return the registered handler")
+	}
+	if _, err := filter.LookupIn[LimitedItem](second); err == nil {
+		t.Error("Expected LookupIn on another registry to fail")
+	}
+	if _, err := filter.Lookup[LimitedItem](); err == nil {
+		t.Error("Expected Lookup on the package-level registry to fail")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected ForIn of an unregistered type to panic")
+			}
+		}()
+		filter.ForIn[LimitedItem](second)
+	}()
+}
+
+// TestRegistry_Concurrent tests that registering and looking up handlers from many goroutines is safe
+func TestRegistry_Concurrent(t *testing.T) {
+	registry := filter.NewRegistry()
+	filter.RegisterIn[LimitedItem](registry, filter.GolangFilteringConfig{})
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 0 {
+				filter.RegisterIn[ZonedShift](registry, filter.GolangFilteringConfig{})
+				return
+			}
+			if _, err := filter.LookupIn[LimitedItem](registry); err != nil {
+				t.Errorf("LookupIn failed: %v", err)
+			}
+			_, _ = filter.LookupIn[ZonedShift](registry)
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := filter.LookupIn[ZonedShift](registry); err != nil {
+		t.Errorf("Expected ZonedShift to be registered: %v", err)
+	}
+}
+
+// TestHandleList tests that HandleList answers with a filtered page as JSON, and 400 for invalid requests
+func TestHandleList(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ListedItem{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	items := []*ListedItem{{ID: 1, Name: "alpha", Score: 10}, {ID: 2, Name: "beta", Score: 20}, {ID: 3, Name: "gamma", Score: 30}}
+	if err := db.Create(items).Error; err != nil {
+		t.Fatalf("Failed to create items: %v", err)
+	}
+	filter.Register[ListedItem](filter.GolangFilteringConfig{})
+	list := filter.HandleList[ListedItem](db)
+
+	serve := func(req *http.Request) (int, map[string]any) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		list(rec, req)
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected application/json, got %q", ct)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+		}
+		return rec.Code, body
+	}
+	payload := `{"filters":[{"field":"score","value":15,"mode":"gte","dataType":"number"}],"sortFields":[{"field":"score","order":"desc"}]}`
+
+	t.Run("GetBase64", func(t *testing.T) {
+		encoded := base64.URLEncoding.EncodeToString([]byte(payload))
+		code, body := serve(httptest.NewRequest(http.MethodGet, "/items?filter="+encoded+"&pageSize=1", nil))
+		if code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", code, body)
+		}
+		data := body["data"].([]any)
+		if body["totalSize"] != float64(2) || len(data) != 1 || data[0].(map[string]any)["name"] != "gamma" {
+			t.Errorf("Expected gamma on a page of 1 out of 2, got %v", body)
+		}
+	})
+
+	t.Run("PostJSON", func(t *testing.T) {
+		code, body := serve(httptest.NewRequest(http.MethodPost, "/items?pageIndex=1&pageSize=1", strings.NewReader(payload)))
+		if code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", code, body)
+		}
+		data := body["data"].([]any)
+		if len(data) != 1 || data[0].(map[string]any)["name"] != "beta" {
+			t.Errorf("Expected beta on the second page, got %v", body)
+		}
+	})
+
+	t.Run("NoFilter", func(t *testing.T) {
+		code, body := serve(httptest.NewRequest(http.MethodGet, "/items", nil))
+		if code != http.StatusOK || body["totalSize"] != float64(3) {
+			t.Errorf("Expected every item, got %d: %v", code, body)
+		}
+	})
+
+	badRequests := map[string]*http.Request{
+		"InvalidBase64": httptest.NewRequest(http.MethodGet, "/items?filter=%25%25", nil),
+		"InvalidJSON":   httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"filters":`)),
+		"InvalidPage":   httptest.NewRequest(http.MethodGet, "/items?pageSize=ten", nil),
+		"InvalidValue": httptest.NewRequest(http.MethodPost, "/items",
+			strings.NewReader(`{"filters":[{"field":"score","value":"high","mode":"gte","dataType":"number"}]}`)),
+	}
+	for name, req := range badRequests {
+		t.Run(name, func(t *testing.T) {
+			code, body := serve(req)
+			if code != http.StatusBadRequest || body["error"] == nil {
+				t.Errorf("Expected 400 with an error, got %d: %v", code, body)
+			}
+		})
+	}
+
+	t.Run("BodyTooLarge", func(t *testing.T) {
+		large := `{"filters":[],"search":{"query":"` + strings.Repeat("a", filter.MaxListRequestBytes) + `"}}`
+		code, body := serve(httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(large)))
+		if code != http.StatusRequestEntityTooLarge || body["error"] == nil {
+			t.Errorf("Expected 413 with an error, got %d: %v", code, body)
+		}
+	})
+}
+
+// TestHandleList_QueryError tests that a failed query answers 500 without its error, which goes
+// to the Observer instead
+func TestHandleList_QueryError(t *testing.T) {
+	// No table, so the query fails
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	var observed error
+	handler := filter.NewFilter[ListedItem](filter.GolangFilteringConfig{
+		Observer: filter.ObserverFunc(func(filter.QueryInfo) func(filter.QueryResultInfo) {
+			return func(info filter.QueryResultInfo) { observed = info.Err }
+		}),
+	})
+
+	rec := httptest.NewRecorder()
+	handler.HandleList(db, 1000)(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if observed == nil {
+		t.Fatal("Expected the Observer to receive the query error")
+	}
+	if strings.Contains(rec.Body.String(), "listed_items") || strings.Contains(rec.Body.String(), observed.Error()) {
+		t.Errorf("Expected a generic error message, got %s", rec.Body.String())
+	}
+}
+
+// rejectingHook fails every GORM query with err, as a hook validating the request might
+type rejectingHook struct{ err error }
+
+func (h rejectingHook) BeforeGorm(db *gorm.DB, _ filter.Root) *gorm.DB {
+	db.AddError(h.err)
+	return db
+}
+
+// TestHandleList_RequestErrors tests that an unknown nested field is ignored rather than failing the
+// query, and that a query failing with an invalid filter error answers 400 with it rather than 500
+func TestHandleList_RequestErrors(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ListedItem{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	nested := `{"filters":[{"field":"owner.nmae","value":"x","mode":"equal","dataType":"text"}]}`
+	rec := httptest.NewRecorder()
+	filter.NewFilter[ListedItem](filter.GolangFilteringConfig{}).HandleList(db, 0)(rec,
+		httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(nested)))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ignoredFields":["owner.nmae"]`) {
+		t.Errorf("Expected 200 ignoring owner.nmae, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	handler := filter.NewFilter[ListedItem](filter.GolangFilteringConfig{})
+	handler.Use(rejectingHook{err: fmt.Errorf("%w: missing tenant", filter.ErrInvalidFilter)})
+	rec = httptest.NewRecorder()
+	handler.HandleList(db, 0)(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "missing tenant") {
+		t.Errorf("Expected 400 with the invalid filter error, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
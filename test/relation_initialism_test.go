@@ -0,0 +1,141 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// InitialismAPIKey is the belongs-to relation of InitialismAccount
+type InitialismAPIKey struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Token string `json:"token"`
+}
+
+// InitialismURLInfo is a has-one relation of InitialismAccount
+type InitialismURLInfo struct {
+	ID                  uint   `gorm:"primaryKey" json:"id"`
+	InitialismAccountID uint   `json:"account_id"`
+	Host                string `json:"host"`
+}
+
+// InitialismIOConfig is a has-one relation of InitialismAccount
+type InitialismIOConfig struct {
+	ID                  uint   `gorm:"primaryKey" json:"id"`
+	InitialismAccountID uint   `json:"account_id"`
+	Mode                string `json:"mode"`
+}
+
+// InitialismAccount has relations whose Go names are initialisms that snake_case does not round-trip
+type InitialismAccount struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `json:"name"`
+	APIKeyID uint   `json:"api_key_id"`
+	APIKey   *InitialismAPIKey
+	URLInfo  *InitialismURLInfo
+	IOConfig *InitialismIOConfig
+}
+
+func setupInitialismDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&InitialismAPIKey{}, &InitialismAccount{}, &InitialismURLInfo{}, &InitialismIOConfig{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	accounts := []*InitialismAccount{
+		{ID: 1, Name: "alpha", APIKey: &InitialismAPIKey{ID: 1, Token: "key-a"}, URLInfo: &InitialismURLInfo{Host: "a.example.com"}, IOConfig: &InitialismIOConfig{Mode: "sync"}},
+		{ID: 2, Name: "beta", APIKey: &InitialismAPIKey{ID: 2, Token: "key-b"}, URLInfo: &InitialismURLInfo{Host: "c.example.com"}, IOConfig: &InitialismIOConfig{Mode: "async"}},
+		{ID: 3, Name: "gamma", APIKey: &InitialismAPIKey{ID: 3, Token: "key-c"}, URLInfo: &InitialismURLInfo{Host: "b.example.com"}, IOConfig: &InitialismIOConfig{Mode: "async"}},
+	}
+	for _, account := range accounts {
+		if err := db.Create(account).Error; err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+	return db
+}
+
+// TestRelationInitialisms_FiltersSortsAndJoins tests that nested fields under relations such as APIKey,
+// URLInfo and IOConfig join, filter and sort by the relation's Go name instead of a PascalCase guess
+func TestRelationInitialisms_FiltersSortsAndJoins(t *testing.T) {
+	db := setupInitialismDB(t)
+	handler := filter.NewFilter[InitialismAccount](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []string
+	}{
+		{
+			name: "snake_case filter",
+			root: filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: "api_key.token", Value: "key-b", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+			},
+			expected: []string{"beta"},
+		},
+		{
+			name: "camelCase filter",
+			root: filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: "apiKey.token", Value: "key-c", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+			},
+			expected: []string{"gamma"},
+		},
+		{
+			name: "sort",
+			root: filter.Root{
+				Logic:      filter.LogicAnd,
+				SortFields: []filter.SortField{{Field: "url_info.host", Order: filter.SortOrderDesc}},
+			},
+			expected: []string{"beta", "gamma", "alpha"},
+		},
+		{
+			name: "filter and sort on different relations",
+			root: filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: "io_config.mode", Value: "async", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+				SortFields:   []filter.SortField{{Field: "url_info.host", Order: filter.SortOrderAsc}},
+			},
+			expected: []string{"gamma", "beta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if len(result.Data) != len(tt.expected) {
+				t.Fatalf("Expected %d accounts, got %d", len(tt.expected), len(result.Data))
+			}
+			for i, name := range tt.expected {
+				if result.Data[i].Name != name {
+					t.Errorf("Expected account %d to be %s, got %s", i, name, result.Data[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestRelationInitialisms_SelectFields tests that selecting a nested field joins the relation by its Go name
+func TestRelationInitialisms_SelectFields(t *testing.T) {
+	db := setupInitialismDB(t)
+	handler := filter.NewFilter[InitialismAccount](filter.GolangFilteringConfig{})
+	result, err := handler.DataGorm(db, filter.Root{
+		Logic:        filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "url_info.host", Value: "a.", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText}},
+		SelectFields: []string{"name", "io_config.mode"},
+	}, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].Name != "alpha" {
+		t.Fatalf("Expected only alpha, got %d accounts", len(result.Data))
+	}
+}
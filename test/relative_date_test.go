@@ -0,0 +1,199 @@
+package test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RelativeEvent has a timestamp stored in UTC
+type RelativeEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func setupRelativeEvents(t *testing.T, times []time.Time) (*gorm.DB, []*RelativeEvent) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&RelativeEvent{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	events := make([]*RelativeEvent, len(times))
+	for i, createdAt := range times {
+		events[i] = &RelativeEvent{ID: uint(i + 1), CreatedAt: createdAt.UTC()}
+		if err := db.Create(events[i]).Error; err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+	}
+	return db, events
+}
+
+func eventIDs(events []*RelativeEvent) []uint {
+	ids := make([]uint, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+type relativeDateCase struct {
+	name     string
+	mode     filter.Mode
+	value    any
+	expected []uint
+}
+
+// runRelativeDateCases checks each case against DataQuery and DataGorm
+func runRelativeDateCases(t *testing.T, handler *filter.Handler[RelativeEvent], db *gorm.DB, events []*RelativeEvent, tests []relativeDateCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "created_at", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeDate},
+				},
+			}
+
+			result, err := handler.DataQuery(events, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := eventIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery events %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := eventIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm events %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestRelativeDate_UTC tests the relative grammar, including month offsets clamped at a month end
+func TestRelativeDate_UTC(t *testing.T) {
+	now := time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC)
+	db, events := setupRelativeEvents(t, []time.Time{
+		time.Date(2025, 2, 27, 12, 0, 0, 0, time.UTC), // 1
+		time.Date(2025, 2, 28, 12, 0, 0, 0, time.UTC), // 2
+		time.Date(2025, 3, 1, 0, 30, 0, 0, time.UTC),  // 3
+		time.Date(2025, 3, 24, 9, 0, 0, 0, time.UTC),  // 4
+		time.Date(2025, 3, 30, 23, 0, 0, 0, time.UTC), // 5
+		time.Date(2025, 3, 31, 9, 59, 0, 0, time.UTC), // 6
+		time.Date(2025, 3, 31, 10, 1, 0, 0, time.UTC), // 7
+		time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC),   // 8
+	})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{
+		Now: func() time.Time { return now },
+	})
+
+	runRelativeDateCases(t, handler, db, events, []relativeDateCase{
+		{"AfterNow", filter.ModeAfter, "now", []uint{7, 8}},
+		{"LastSevenDays", filter.ModeGTE, "now-7d", []uint{5, 6, 7, 8}},
+		{"NextHour", filter.ModeLTE, "now+1h", []uint{1, 2, 3, 4, 5, 6, 7}},
+		{"Today", filter.ModeEqual, "today", []uint{6, 7}},
+		{"YesterdayUppercase", filter.ModeEqual, "YESTERDAY", []uint{5}},
+		{"ThisMonth", filter.ModeRange, filter.Range{From: "start_of_month", To: "end_of_month"}, []uint{3, 4, 5, 6, 7}},
+		{"ThisMonthFromJSONRange", filter.ModeRange, map[string]any{"from": "start_of_month", "to": "end_of_month"}, []uint{3, 4, 5, 6, 7}},
+		{"BeforeThisMonth", filter.ModeLT, "start_of_month", []uint{1, 2}},
+		// Mar 31 - 1 month clamps to Feb 28 (not Mar 3)
+		{"OneMonthClamped", filter.ModeGTE, "now-1M", []uint{2, 3, 4, 5, 6, 7, 8}},
+		{"InYesterdayOrToday", filter.ModeIn, []string{"yesterday", "today"}, []uint{5, 6, 7}},
+		{"MixedAbsoluteAndRelative", filter.ModeRange, filter.Range{From: "2025-03-24", To: "yesterday"}, []uint{4, 5}},
+	})
+}
+
+// TestRelativeDate_DST tests that day offsets keep the wall clock and "today" uses local midnight across a DST change
+func TestRelativeDate_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	// DST started at 2:00 on Mar 9, so the previous day at the same wall clock is 23 hours earlier
+	now := time.Date(2025, 3, 9, 12, 0, 0, 0, newYork)
+	db, events := setupRelativeEvents(t, []time.Time{
+		time.Date(2025, 3, 8, 16, 30, 0, 0, time.UTC), // 1: 11:30 EST Mar 8
+		time.Date(2025, 3, 8, 17, 30, 0, 0, time.UTC), // 2: 12:30 EST Mar 8
+		time.Date(2025, 3, 9, 4, 30, 0, 0, time.UTC),  // 3: 23:30 EST Mar 8
+		time.Date(2025, 3, 9, 5, 30, 0, 0, time.UTC),  // 4: 00:30 EST Mar 9
+	})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{
+		Location: newYork,
+		Now:      func() time.Time { return now },
+	})
+
+	runRelativeDateCases(t, handler, db, events, []relativeDateCase{
+		// 24 hours earlier would be 11:00 EST and include event 1
+		{"OneDayKeepsWallClock", filter.ModeGTE, "now-1d", []uint{2, 3, 4}},
+		{"TwentyFourHours", filter.ModeGTE, "now-24h", []uint{1, 2, 3, 4}},
+		// Event 3 is Mar 9 in UTC but Mar 8 in New York
+		{"TodayIsLocal", filter.ModeEqual, "today", []uint{4}},
+		{"YesterdayIsLocal", filter.ModeEqual, "yesterday", []uint{1, 2, 3}},
+	})
+}
+
+// TestRelativeDate_MonthBoundaryInZone tests that start_of_month is local midnight, which is the previous day in UTC
+func TestRelativeDate_MonthBoundaryInZone(t *testing.T) {
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	now := time.Date(2025, 11, 1, 2, 0, 0, 0, manila)
+	db, events := setupRelativeEvents(t, []time.Time{
+		time.Date(2025, 10, 31, 15, 0, 0, 0, time.UTC), // 1: 23:00 Oct 31 Manila
+		time.Date(2025, 10, 31, 17, 0, 0, 0, time.UTC), // 2: 01:00 Nov 1 Manila
+	})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{
+		Location: manila,
+		Now:      func() time.Time { return now },
+	})
+
+	runRelativeDateCases(t, handler, db, events, []relativeDateCase{
+		{"ThisMonth", filter.ModeGTE, "start_of_month", []uint{2}},
+		{"LastMonth", filter.ModeRange, filter.Range{From: "start_of_month-1M", To: "start_of_month-1d"}, []uint{1}},
+	})
+}
+
+// TestRelativeDate_Errors tests that malformed offsets are rejected and the caller's Root is left unchanged
+func TestRelativeDate_Errors(t *testing.T) {
+	db, events := setupRelativeEvents(t, []time.Time{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)})
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{})
+
+	for _, value := range []string{"now-7x", "today+", "now--1d", "start_of_month-1d7"} {
+		root := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "created_at", Value: value, Mode: filter.ModeGTE, DataType: filter.DataTypeDate},
+			},
+		}
+		if _, err := handler.DataQuery(events, root, 0, 10); err == nil {
+			t.Errorf("Expected DataQuery to reject %q", value)
+		}
+		if _, err := handler.DataGorm(db, root, 0, 10); err == nil {
+			t.Errorf("Expected DataGorm to reject %q", value)
+		}
+	}
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: "now-7d", Mode: filter.ModeGTE, DataType: filter.DataTypeDate},
+		},
+	}
+	if _, err := handler.DataQuery(events, root, 0, 10); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if root.FieldFilters[0].Value != "now-7d" {
+		t.Errorf("Expected the caller's filter value to stay \"now-7d\", got %v", root.FieldFilters[0].Value)
+	}
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RelevanceContact is searched by name and notes
+type RelevanceContact struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Name  string `json:"name"`
+	Notes string `json:"notes"`
+}
+
+func generateRelevanceContacts() []*RelevanceContact {
+	return []*RelevanceContact{
+		{ID: 1, Name: "Annabel"},
+		{ID: 2, Name: "Bob", Notes: "met ann at the expo"},
+		{ID: 3, Name: "Ann"},
+		{ID: 4, Name: "Joanne"},
+		{ID: 5, Name: "Carl", Notes: "Annual review due"},
+		{ID: 6, Name: "Dave"},
+	}
+}
+
+func relevanceContactIDs(contacts []*RelevanceContact) []uint {
+	ids := make([]uint, len(contacts))
+	for i, contact := range contacts {
+		ids[i] = contact.ID
+	}
+	return ids
+}
+
+// TestRelevanceSort tests that RelevanceField orders exact matches before prefix matches before
+// substring matches, the same way in DataQuery, DataGorm and their NoPage variants
+func TestRelevanceSort(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&RelevanceContact{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateRelevanceContacts()).Error; err != nil {
+		t.Fatalf("Failed to create contacts: %v", err)
+	}
+	handler := filter.NewFilter[RelevanceContact](filter.GolangFilteringConfig{StrictValidation: true})
+	search := &filter.SearchFilter{Fields: []string{"name", "notes"}, Value: "ANN"}
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		// Ann is exact, Annabel and Carl's notes start with it, Bob's notes and Joanne contain it
+		{"ThenName", filter.Root{
+			Search:     search,
+			SortFields: []filter.SortField{{Field: filter.RelevanceField}, {Field: "name", Order: filter.SortOrderAsc}},
+		}, []uint{3, 1, 5, 2, 4}},
+		{"ThenNameDesc", filter.Root{
+			Search:     search,
+			SortFields: []filter.SortField{{Field: filter.RelevanceField}, {Field: "name", Order: filter.SortOrderDesc}},
+		}, []uint{3, 5, 1, 4, 2}},
+		{"AfterName", filter.Root{
+			Search:     search,
+			SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderDesc}, {Field: filter.RelevanceField}},
+		}, []uint{4, 5, 2, 1, 3}},
+		{"NameOnly", filter.Root{
+			Search:     &filter.SearchFilter{Fields: []string{"name"}, Value: "ann"},
+			SortFields: []filter.SortField{{Field: filter.RelevanceField}},
+		}, []uint{3, 1, 4}},
+		{"WithoutSearch", filter.Root{
+			SortFields: []filter.SortField{{Field: filter.RelevanceField}, {Field: "name", Order: filter.SortOrderAsc}},
+		}, []uint{3, 1, 2, 5, 6, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handler.DataQuery(generateRelevanceContacts(), tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := relevanceContactIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := relevanceContactIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+
+			all, err := handler.DataQueryNoPage(generateRelevanceContacts(), tt.root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPage failed: %v", err)
+			}
+			if got := relevanceContactIDs(all); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQueryNoPage IDs %v, got %v", tt.expected, got)
+			}
+			rows, err := handler.DataGormNoPage(db, tt.root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if got := relevanceContactIDs(rows); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGormNoPage IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestRelevanceSort_CallerOrder tests that an ORDER BY already on the caller's query is kept ahead of a relevance sort
+func TestRelevanceSort_CallerOrder(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&RelevanceContact{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateRelevanceContacts()).Error; err != nil {
+		t.Fatalf("Failed to create contacts: %v", err)
+	}
+	handler := filter.NewFilter[RelevanceContact](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Search:     &filter.SearchFilter{Fields: []string{"name", "notes"}, Value: "ann"},
+		SortFields: []filter.SortField{{Field: filter.RelevanceField}},
+	}
+
+	rows, err := handler.DataGormNoPage(db.Order("notes = '' DESC"), root)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	if got, expected := relevanceContactIDs(rows), []uint{3, 1, 4, 5, 2}; !equalIDs(got, expected) {
+		t.Errorf("Expected IDs %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+)
+
+func newRoleRestrictedHandler() *filter.Handler[TestUser] {
+	return filter.NewFilter[TestUser](filter.GolangFilteringConfig{}).
+		RestrictValues("role", []string{"admin", "moderator", "user"})
+}
+
+// TestRestrictValues_AllowedValues tests that filters within the allowed set run normally in both paths
+func TestRestrictValues_AllowedValues(t *testing.T) {
+	db := setupTestDB(t)
+	handler := newRoleRestrictedHandler()
+	users := generateTestUsers()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected int
+	}{
+		{"Equal", filter.FieldFilter{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, 3},
+		{"NotEqual", filter.FieldFilter{Field: "role", Value: "user", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, 5},
+		{"In", filter.FieldFilter{Field: "role", Value: []string{"admin", "moderator"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}, 5},
+		// Modes other than equality and lists are not restricted
+		{"Contains", filter.FieldFilter{Field: "role", Value: "min", Mode: filter.ModeContains, DataType: filter.DataTypeText}, 3},
+		{"OtherField", filter.FieldFilter{Field: "name", Value: "John Doe", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			result, err := handler.DataQuery(users, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if result.TotalSize != tt.expected {
+				t.Errorf("Expected DataQuery TotalSize=%d, got %d", tt.expected, result.TotalSize)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if page.TotalSize != tt.expected {
+				t.Errorf("Expected DataGorm TotalSize=%d, got %d", tt.expected, page.TotalSize)
+			}
+		})
+	}
+}
+
+// TestRestrictValues_RejectsOtherValues tests that a value outside the set fails every path before any query runs
+func TestRestrictValues_RejectsOtherValues(t *testing.T) {
+	db := setupTestDB(t)
+	handler := newRoleRestrictedHandler()
+	users := generateTestUsers()
+
+	queries := 0
+	if err := db.Callback().Query().Before("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		root  filter.Root
+		value string
+	}{
+		{"Equal", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "superuser", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		}}, "superuser"},
+		// Values are compared exactly, so a differently cased value is rejected
+		{"NotEqualCase", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "Admin", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText},
+		}}, "Admin"},
+		{"InOneBadItem", filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: []any{"admin", "owner"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		}}, "owner"},
+		{"NestedGroup", filter.Root{Logic: filter.LogicAnd, Groups: []filter.Root{{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "age", Value: 30, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+				{Field: "role", Value: "guest", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}}}, "guest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr := func(path string, err error) {
+				t.Helper()
+				if err == nil {
+					t.Errorf("Expected %s to reject %q", path, tt.value)
+					return
+				}
+				if !strings.Contains(err.Error(), "role") || !strings.Contains(err.Error(), tt.value) {
+					t.Errorf("Expected %s error to name the field and %q, got %v", path, tt.value, err)
+				}
+			}
+
+			_, err := handler.DataQuery(users, tt.root, 0, 10)
+			checkErr("DataQuery", err)
+			_, err = handler.DataGorm(db, tt.root, 0, 10)
+			checkErr("DataGorm", err)
+			_, err = handler.CountGorm(db, tt.root)
+			checkErr("CountGorm", err)
+			_, err = handler.Hybrid(db, 1000, tt.root, 0, 10)
+			checkErr("Hybrid", err)
+		})
+	}
+	if queries != 0 {
+		t.Errorf("Expected no queries for rejected values, got %d", queries)
+	}
+}
+
+// TestRestrictValues_UnknownFieldPanics tests that restricting an unknown field is caught at setup
+func TestRestrictValues_UnknownFieldPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected RestrictValues to panic on an unknown field")
+		}
+	}()
+	filter.NewFilter[TestUser](filter.GolangFilteringConfig{}).RestrictValues("status", []string{"active"})
+}
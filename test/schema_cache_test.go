@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TestSchemaCache_PerNamingStrategy tests that one Handler serves databases with different naming
+// strategies, reusing the parsed schema only for the strategy it was parsed with
+func TestSchemaCache_PerNamingStrategy(t *testing.T) {
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+	plain := setupHasManyDB(t)
+	prefixed := setupHasManyDBWithConfig(t, &gorm.Config{NamingStrategy: schema.NamingStrategy{TablePrefix: "tenant_"}})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.sku", Value: "widget", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "items.quantity", Order: filter.SortOrderDesc}},
+	}
+
+	var expected []uint
+	for i, db := range []*gorm.DB{plain, prefixed, plain, prefixed} {
+		page, err := handler.DataGorm(db, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGorm call %d failed: %v", i, err)
+		}
+		ids := orderIDs(page.Data)
+		if i == 0 {
+			expected = ids
+			if len(expected) == 0 {
+				t.Fatal("Expected matching orders")
+			}
+		} else if !equalIDs(ids, expected) {
+			t.Errorf("Expected call %d to return %v, got %v", i, expected, ids)
+		}
+	}
+
+	if _, err := handler.Hybrid(prefixed, 1000, root, 0, 10); err != nil {
+		t.Errorf("Hybrid failed on the prefixed database: %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+func userIDs(users []*TestUser) []uint {
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// TestSearch_MatchesAnyField tests that Root.Search ORs the term across its fields and ANDs it
+// with the regular filters, in DataGorm and DataQuery alike
+func TestSearch_MatchesAnyField(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		// Bob Johnson only matches through his name, John Smith through both
+		{"NameOrEmail", filter.Root{
+			Logic:  filter.LogicAnd,
+			Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "john"},
+		}, []uint{1, 3, 7}},
+		{"AndWithFilters", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "is_active", Value: true, Mode: filter.ModeEqual, DataType: filter.DataTypeBool},
+			},
+			Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "john"},
+		}, []uint{1, 7}},
+		// The filters keep their OR logic: (moderator OR age >= 38) AND name contains "i"
+		{"AndWithOrFilters", filter.Root{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "moderator", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "age", Value: 38, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			},
+			Search: &filter.SearchFilter{Fields: []string{"name"}, Value: "i"},
+		}, []uint{4, 5, 9}},
+		{"DefaultsToTextFields", filter.Root{
+			Logic:  filter.LogicAnd,
+			Search: &filter.SearchFilter{Value: "ADMIN"},
+		}, []uint{1, 5, 10}},
+		{"StartsWithMode", filter.Root{
+			Logic:  filter.LogicAnd,
+			Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "j", Mode: filter.ModeStartsWith},
+		}, []uint{1, 2, 7}},
+		// LIKE wildcards in the term match themselves, as the substring match of DataQuery does
+		{"WildcardsMatchLiterally", filter.Root{
+			Logic:  filter.LogicAnd,
+			Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "%"},
+		}, []uint{}},
+		{"UnderscoreMatchesLiterally", filter.Root{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "email", Value: "j_hn", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+				{Field: "email", Value: `\`, Mode: filter.ModeEndsWith, DataType: filter.DataTypeText},
+			},
+			Search: &filter.SearchFilter{Fields: []string{"name"}, Value: "_", Mode: filter.ModeStartsWith},
+		}, []uint{}},
+		{"EmptyValueIsIgnored", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			Search: &filter.SearchFilter{Fields: []string{"name"}},
+		}, []uint{1, 5, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := userIDs(page.Data); !equalIDs(ids, tt.expected) || page.TotalSize != len(tt.expected) {
+				t.Errorf("Expected DataGorm users %v, got %v (TotalSize=%d)", tt.expected, ids, page.TotalSize)
+			}
+
+			result, err := handler.DataQuery(users, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := userIDs(result.Data); !equalIDs(ids, tt.expected) || result.TotalSize != len(tt.expected) {
+				t.Errorf("Expected DataQuery users %v, got %v (TotalSize=%d)", tt.expected, ids, result.TotalSize)
+			}
+
+			count, err := handler.CountGorm(db, tt.root)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) {
+				t.Errorf("Expected CountGorm=%d, got %d", len(tt.expected), count)
+			}
+		})
+	}
+}
+
+// TestSearch_PaginationAndSorting tests that searched results are sorted and paginated as a whole
+func TestSearch_PaginationAndSorting(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		Search:     &filter.SearchFilter{Fields: []string{"name"}, Value: "o"},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	// Names containing "o", oldest first: Charlie 42, Bob 35, John Smith 29, Alice 28, John Doe 25
+	expectedPages := [][]uint{{5, 3}, {7, 4}, {1}}
+	for pageIndex, expected := range expectedPages {
+		page, err := handler.DataGorm(db, root, pageIndex, 2)
+		if err != nil {
+			t.Fatalf("DataGorm failed: %v", err)
+		}
+		if page.TotalSize != 5 || page.TotalPage != 3 {
+			t.Errorf("Expected DataGorm TotalSize=5 TotalPage=3, got %d and %d", page.TotalSize, page.TotalPage)
+		}
+		if ids := userIDs(page.Data); !equalIDs(ids, expected) {
+			t.Errorf("Expected DataGorm page %d to be %v, got %v", pageIndex, expected, ids)
+		}
+
+		result, err := handler.DataQuery(users, root, pageIndex, 2)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if result.TotalSize != 5 || result.TotalPage != 3 {
+			t.Errorf("Expected DataQuery TotalSize=5 TotalPage=3, got %d and %d", result.TotalSize, result.TotalPage)
+		}
+		if ids := userIDs(result.Data); !equalIDs(ids, expected) {
+			t.Errorf("Expected DataQuery page %d to be %v, got %v", pageIndex, expected, ids)
+		}
+	}
+}
+
+// TestSearch_DeniedFieldsAreNotSearched tests that search fields go through AllowedFields and DeniedFields
+func TestSearch_DeniedFieldsAreNotSearched(t *testing.T) {
+	db := setupTestDB(t)
+	root := filter.Root{
+		Logic:  filter.LogicAnd,
+		Search: &filter.SearchFilter{Fields: []string{"name", "email"}, Value: "johnsmith"},
+	}
+
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email"}})
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if page.TotalSize != 0 {
+		t.Errorf("Expected no users when the matching field is denied, got %v", userIDs(page.Data))
+	}
+
+	rejecting := filter.NewFilter[TestUser](filter.GolangFilteringConfig{
+		DeniedFields:           []string{"email"},
+		RejectDisallowedFields: true,
+	})
+	if _, err := rejecting.DataQuery(generateTestUsers(), root, 0, 10); err == nil {
+		t.Error("Expected an error for a denied search field with RejectDisallowedFields")
+	}
+}
+
+// TestSearch_DefaultFieldsLeaveOutDeniedFields tests that a search without Fields only searches the
+// allowed text fields, so RejectDisallowedFields does not reject it
+func TestSearch_DefaultFieldsLeaveOutDeniedFields(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{
+		DeniedFields:           []string{"email"},
+		RejectDisallowedFields: true,
+	})
+	root := filter.Root{Logic: filter.LogicAnd, Search: &filter.SearchFilter{Value: "example.com"}}
+
+	result, err := handler.DataQuery(generateTestUsers(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if result.TotalSize != 0 {
+		t.Errorf("Expected the denied email field not to be searched, got %v", userIDs(result.Data))
+	}
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if page.TotalSize != 0 {
+		t.Errorf("Expected the denied email field not to be searched, got %v", userIDs(page.Data))
+	}
+
+	root.Search.Value = "smith"
+	if result, err = handler.DataQuery(generateTestUsers(), root, 0, 10); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if ids := userIDs(result.Data); !equalIDs(ids, []uint{2, 7}) {
+		t.Errorf("Expected users [2 7] by name, got %v", ids)
+	}
+}
+
+// TestSearch_ParseFromJSON tests that the search object is decoded and its mode normalized
+func TestSearch_ParseFromJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"search":{"fields":["name","email"],"value":"john","mode":"STARTSWITH"},"filters":[]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.Search == nil || root.Search.Value != "john" || len(root.Search.Fields) != 2 || root.Search.Mode != filter.ModeStartsWith {
+		t.Errorf("Unexpected search: %+v", root.Search)
+	}
+
+	if _, err := filter.ParseRootFromJSON([]byte(`{"search":{"value":"john","mode":"fuzzy"}}`)); err == nil {
+		t.Error("Expected an error for an unknown search mode")
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestSelectFields_LimitsColumns tests that only selected columns (plus id) are fetched
+func TestSelectFields_LimitsColumns(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	filterRoot := filter.Root{
+		Logic:        filter.LogicAnd,
+		SelectFields: []string{"name", "unknown_field"},
+		// Filters and sorts on unselected fields still work
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}
+
+	result, err := handler.DataGorm(db, filterRoot, 0, 2)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 3 || result.TotalPage != 2 || len(result.Data) != 2 {
+		t.Fatalf("Expected 3 admins over 2 pages, got total %d pages %d", result.TotalSize, result.TotalPage)
+	}
+	first := result.Data[0]
+	if first.ID != 5 || first.Name != "Charlie Wilson" {
+		t.Errorf("Expected oldest admin Charlie Wilson (ID 5), got %d %q", first.ID, first.Name)
+	}
+	if first.Email != "" || first.Age != 0 || first.Role != "" {
+		t.Errorf("Expected unselected columns to be zero, got email=%q age=%d role=%q", first.Email, first.Age, first.Role)
+	}
+
+	all, err := handler.DataGormNoPage(db, filterRoot)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	if len(all) != 3 || all[0].Email != "" {
+		t.Errorf("DataGormNoPage: expected 3 projected records, got %d", len(all))
+	}
+}
+
+// TestSelectFields_NestedFields tests projection together with nested filters, sorts, and selected relations
+func TestSelectFields_NestedFields(t *testing.T) {
+	db := setupNestedRelationsDB(t)
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{})
+
+	filterRoot := filter.Root{
+		Logic:        filter.LogicAnd,
+		SelectFields: []string{"name", "currency.currency_code"},
+		FieldFilters: []filter.FieldFilter{
+			{Field: "value", Value: 1, Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "currency.name", Order: filter.SortOrderAsc}},
+	}
+
+	result, err := handler.DataGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize != 3 {
+		t.Fatalf("Expected 3 records with value 1, got %d", result.TotalSize)
+	}
+	// Euro < Philippine Peso < US Dollar
+	expected := []string{"Euro Coin", "Peso Coin", "One Dollar Bill"}
+	for i, item := range result.Data {
+		if item.Name != expected[i] {
+			t.Errorf("Position %d: expected %q, got %q", i, expected[i], item.Name)
+		}
+		if item.Currency == nil || item.Currency.CurrencyCode == "" {
+			t.Errorf("Position %d: expected joined currency to be loaded", i)
+		}
+		if item.Value != 0 {
+			t.Errorf("Position %d: expected unselected value to be zero, got %v", i, item.Value)
+		}
+	}
+}
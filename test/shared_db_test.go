@@ -0,0 +1,148 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm"
+)
+
+// TestSharedPreScopedDBConcurrency runs many goroutines with different filters against one shared,
+// pre-scoped *gorm.DB and checks that no filter, join, or order leaks into another request or into the handle
+func TestSharedPreScopedDBConcurrency(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	// Every connection to ":memory:" is a separate database, so keep a single one
+	sqlDB.SetMaxOpenConns(1)
+
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+
+	// Users 2, 3, 6, 7, 9 with ages 30, 35, 33, 29, 38
+	scoped := db.Where("role = ?", "user")
+
+	ageFilter := func(age int) filter.Root {
+		return filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "age", Value: age, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+			},
+			SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}},
+		}
+	}
+	// expected counts the scoped users with age >= minAge
+	expected := func(minAge int) int {
+		count := 0
+		for _, age := range []int{30, 35, 33, 29, 38} {
+			if age >= minAge {
+				count++
+			}
+		}
+		return count
+	}
+
+	calls := map[string]func(minAge int) (int, error){
+		"DataGorm": func(minAge int) (int, error) {
+			result, err := handler.DataGorm(scoped, ageFilter(minAge), 0, 100)
+			if err != nil {
+				return 0, err
+			}
+			if len(result.Data) != result.TotalSize {
+				return 0, fmt.Errorf("page has %d rows, total %d", len(result.Data), result.TotalSize)
+			}
+			return result.TotalSize, nil
+		},
+		"DataGormNoPage": func(minAge int) (int, error) {
+			data, err := handler.DataGormNoPage(scoped, ageFilter(minAge))
+			return len(data), err
+		},
+		"CountGorm": func(minAge int) (int, error) {
+			count, err := handler.CountGorm(scoped, ageFilter(minAge))
+			return int(count), err
+		},
+		"DataGormCursor": func(minAge int) (int, error) {
+			page, err := handler.DataGormCursor(scoped, ageFilter(minAge), "", 100)
+			if err != nil {
+				return 0, err
+			}
+			return len(page.Data), nil
+		},
+		"DataGormWithPreset": func(minAge int) (int, error) {
+			result, err := handler.DataGormWithPreset(scoped, map[string]any{"is_active": true}, ageFilter(minAge), 0, 100)
+			if err != nil {
+				return 0, err
+			}
+			// The preset narrows to active users (2 and 7 are the only active ones), so count those separately
+			for _, user := range result.Data {
+				if !user.IsActive || user.Role != "user" {
+					return 0, fmt.Errorf("unexpected user %d", user.ID)
+				}
+			}
+			return -1, nil
+		},
+		"GormNoPaginationCSVCustom": func(minAge int) (int, error) {
+			csvData, err := handler.GormNoPaginationCSVCustom(scoped, ageFilter(minAge), func(user *TestUser) map[string]any {
+				return map[string]any{"id": user.ID}
+			})
+			if err != nil || len(csvData) == 0 {
+				return 0, err
+			}
+			return bytes.Count(csvData, []byte("\n")) - 1, nil
+		},
+		"GormCSVStream": func(minAge int) (int, error) {
+			var buf bytes.Buffer
+			err := handler.GormCSVStream(scoped, ageFilter(minAge), &buf, filter.CSVOptions{OmitHeaders: true, BatchSize: 2})
+			return bytes.Count(buf.Bytes(), []byte("\n")), err
+		},
+		"GormNDJSONStream": func(minAge int) (int, error) {
+			var buf bytes.Buffer
+			err := handler.GormNDJSONStream(scoped, ageFilter(minAge), &buf)
+			return bytes.Count(buf.Bytes(), []byte("\n")), err
+		},
+		"Hybrid": func(minAge int) (int, error) {
+			result, err := handler.Hybrid(scoped, 0, ageFilter(minAge), 0, 100)
+			if err != nil {
+				return 0, err
+			}
+			return result.TotalSize, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50*len(calls))
+	for name, call := range calls {
+		for i := range 50 {
+			wg.Add(1)
+			go func(minAge int) {
+				defer wg.Done()
+				got, err := call(minAge)
+				if err != nil {
+					errs <- fmt.Errorf("%s(age >= %d): %w", name, minAge, err)
+					return
+				}
+				if got != -1 && got != expected(minAge) {
+					errs <- fmt.Errorf("%s(age >= %d): expected %d rows, got %d", name, minAge, expected(minAge), got)
+				}
+			}(25 + i%15)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// The shared handle itself must still only carry its own condition
+	var users []TestUser
+	if err := scoped.Session(&gorm.Session{}).Find(&users).Error; err != nil {
+		t.Fatalf("Failed to query scoped handle: %v", err)
+	}
+	if len(users) != 5 {
+		t.Errorf("Expected the scoped handle to return 5 users, got %d", len(users))
+	}
+}
@@ -0,0 +1,187 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SoftDeleteTicket embeds gorm.Model, so its DeletedAt is a promoted gorm.DeletedAt
+type SoftDeleteTicket struct {
+	gorm.Model
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// SoftDeleteNote marks deletion with a plain *time.Time
+type SoftDeleteNote struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Body      string     `json:"body"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// setupSoftDeleteDB seeds tickets 1-4 and soft-deletes ticket 2 (open) through GORM
+func setupSoftDeleteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&SoftDeleteTicket{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for i, status := range []string{"open", "open", "closed", "open"} {
+		ticket := &SoftDeleteTicket{Model: gorm.Model{ID: uint(i + 1)}, Title: "ticket", Status: status}
+		if err := db.Create(ticket).Error; err != nil {
+			t.Fatalf("Failed to create ticket: %v", err)
+		}
+	}
+	if err := db.Delete(&SoftDeleteTicket{}, 2).Error; err != nil {
+		t.Fatalf("Failed to delete ticket: %v", err)
+	}
+	return db
+}
+
+func ticketIDs(tickets []*SoftDeleteTicket) []uint {
+	ids := make([]uint, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+	}
+	return ids
+}
+
+// TestIncludeDeleted_AllPaths tests that soft-deleted rows are excluded by default and included with
+// IncludeDeleted, identically in DataQuery, DataGorm, DataGormNoPage, both Hybrid strategies and counts
+func TestIncludeDeleted_AllPaths(t *testing.T) {
+	db := setupSoftDeleteDB(t)
+	handler := filter.NewFilter[SoftDeleteTicket](filter.GolangFilteringConfig{})
+
+	// The slice a caller loaded without the soft-delete scope
+	var allTickets []*SoftDeleteTicket
+	if err := db.Unscoped().Order("id").Find(&allTickets).Error; err != nil {
+		t.Fatalf("Failed to load tickets: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		includeDeleted bool
+		expected       []uint
+	}{
+		{"Excluded", false, []uint{1, 4}},
+		{"Included", true, []uint{1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "status", Value: "open", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				},
+				IncludeDeleted: tt.includeDeleted,
+			}
+
+			result, err := handler.DataQuery(allTickets, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := ticketIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery tickets %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := ticketIDs(page.Data); !equalIDs(ids, tt.expected) || page.TotalSize != len(tt.expected) {
+				t.Errorf("Expected DataGorm tickets %v, got %v (total %d)", tt.expected, ids, page.TotalSize)
+			}
+
+			tickets, err := handler.DataGormNoPage(db, root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if ids := ticketIDs(tickets); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGormNoPage tickets %v, got %v", tt.expected, ids)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, root, 0, 10)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if ids := ticketIDs(hybrid.Data); !equalIDs(ids, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) tickets %v, got %v", hybrid.Strategy, tt.expected, ids)
+				}
+			}
+
+			count, err := handler.CountGorm(db, root)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			memoryCount, err := handler.CountQuery(allTickets, root)
+			if err != nil {
+				t.Fatalf("CountQuery failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) || memoryCount != len(tt.expected) {
+				t.Errorf("Expected counts of %d, got %d and %d", len(tt.expected), count, memoryCount)
+			}
+		})
+	}
+}
+
+// TestIncludeDeleted_CSV tests that both CSV exporters honor IncludeDeleted
+func TestIncludeDeleted_CSV(t *testing.T) {
+	db := setupSoftDeleteDB(t)
+	handler := filter.NewFilter[SoftDeleteTicket](filter.GolangFilteringConfig{})
+
+	for _, includeDeleted := range []bool{false, true} {
+		root := filter.Root{Logic: filter.LogicAnd, IncludeDeleted: includeDeleted}
+		expected := 3
+		if includeDeleted {
+			expected = 4
+		}
+
+		gormCSV, err := handler.GormNoPaginationCSV(db, root)
+		if err != nil {
+			t.Fatalf("GormNoPaginationCSV failed: %v", err)
+		}
+		hybridCSV, err := handler.HybridCSV(db, 1000, root)
+		if err != nil {
+			t.Fatalf("HybridCSV failed: %v", err)
+		}
+		for name, csvData := range map[string][]byte{"GormNoPaginationCSV": gormCSV, "HybridCSV": hybridCSV} {
+			// One header line plus one line per ticket
+			if lines := strings.Count(strings.TrimSpace(string(csvData)), "\n"); lines != expected {
+				t.Errorf("Expected %d %s rows with IncludeDeleted=%v, got %d", expected, name, includeDeleted, lines)
+			}
+		}
+	}
+}
+
+// TestIncludeDeleted_TimePointer tests that a *time.Time DeletedAt is detected in memory
+func TestIncludeDeleted_TimePointer(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notes := []*SoftDeleteNote{
+		{ID: 1, Body: "kept"},
+		{ID: 2, Body: "removed", DeletedAt: &deletedAt},
+		{ID: 3, Body: "kept"},
+	}
+	handler := filter.NewFilter[SoftDeleteNote](filter.GolangFilteringConfig{})
+
+	for _, tt := range []struct {
+		includeDeleted bool
+		expected       int
+	}{{false, 2}, {true, 3}} {
+		result, err := handler.DataQueryNoPage(notes, filter.Root{Logic: filter.LogicAnd, IncludeDeleted: tt.includeDeleted})
+		if err != nil {
+			t.Fatalf("DataQueryNoPage failed: %v", err)
+		}
+		if len(result) != tt.expected {
+			t.Errorf("Expected %d notes with IncludeDeleted=%v, got %d", tt.expected, tt.includeDeleted, len(result))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestSortDedupe_FirstOccurrenceWins tests that a repeated sort field (aliases included) and an unknown
+// sort field are dropped identically by every path and reported in IgnoredSorts
+func TestSortDedupe_FirstOccurrenceWins(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		SortFields: []filter.SortField{
+			{Field: "age", Order: filter.SortOrderDesc},
+			{Field: "nmae", Order: filter.SortOrderAsc},
+			{Field: "age", Order: filter.SortOrderAsc},
+			{Field: "Age", Order: filter.SortOrderAsc},
+		},
+	}
+
+	expected, err := handler.DataQuery(generateTestUsers(), filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc}},
+	}, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	expectedIDs := userIDs(expected.Data)
+
+	results := map[string]*filter.PaginationResult[TestUser]{}
+	if results["DataQuery"], err = handler.DataQuery(generateTestUsers(), filterRoot, 0, 100); err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if results["DataGorm"], err = handler.DataGorm(db, filterRoot, 0, 100); err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	for _, threshold := range []int{0, 1000} {
+		result, err := handler.Hybrid(db, threshold, filterRoot, 0, 100)
+		if err != nil {
+			t.Fatalf("Hybrid failed: %v", err)
+		}
+		results["Hybrid "+string(result.Strategy)] = result
+	}
+
+	for name, result := range results {
+		if got := userIDs(result.Data); !equalIDs(got, expectedIDs) {
+			t.Errorf("Expected %s IDs %v, got %v", name, expectedIDs, got)
+		}
+		if strings.Join(result.IgnoredSorts, ",") != "nmae,age,Age" {
+			t.Errorf("Expected %s IgnoredSorts [nmae age Age], got %v", name, result.IgnoredSorts)
+		}
+		if len(result.AppliedFilters.SortFields) != 1 || result.AppliedFilters.SortFields[0].Order != filter.SortOrderDesc {
+			t.Errorf("Expected %s AppliedFilters to keep only age desc, got %v", name, result.AppliedFilters.SortFields)
+		}
+	}
+}
+
+// TestSortDedupe_SingleOrderByTerm tests that a repeated sort field generates a single ORDER BY term
+func TestSortDedupe_SingleOrderByTerm(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		SortFields: []filter.SortField{
+			{Field: "name", Order: filter.SortOrderAsc},
+			{Field: "name", Order: filter.SortOrderDesc},
+		},
+	}
+
+	sql, _, err := handler.ExplainGorm(db, filterRoot, 0, 10)
+	if err != nil {
+		t.Fatalf("ExplainGorm failed: %v", err)
+	}
+	_, orderBy, found := strings.Cut(sql, "ORDER BY")
+	if !found {
+		t.Fatalf("Expected an ORDER BY clause, got %q", sql)
+	}
+	// Text sorts by its lowercase value, then the value itself
+	if strings.Count(orderBy, "LOWER(name)") != 1 || strings.Contains(orderBy, "DESC") {
+		t.Errorf("Expected a single name ASC term, got %q", orderBy)
+	}
+}
+
+// TestSortDedupe_Strict tests that strict mode rejects a repeated sort field
+func TestSortDedupe_Strict(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		SortFields: []filter.SortField{
+			{Field: "name", Order: filter.SortOrderAsc},
+			{Field: "name", Order: filter.SortOrderDesc},
+		},
+	}
+	if _, err := handler.DataGorm(db, filterRoot, 0, 10); err == nil || !strings.Contains(err.Error(), "duplicate sort field") {
+		t.Errorf("Expected a duplicate sort field error, got %v", err)
+	}
+	if _, err := handler.DataQuery(generateTestUsers(), filterRoot, 0, 10); err == nil {
+		t.Error("Expected DataQuery to reject a duplicate sort field in strict mode, got nil")
+	}
+}
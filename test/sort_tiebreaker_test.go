@@ -0,0 +1,302 @@
+package test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TieUser has few distinct scores, so most records tie on a score sort
+type TieUser struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// TieProduct has a primary key that is not named ID
+type TieProduct struct {
+	Code  string `gorm:"primaryKey" json:"code"`
+	Price int    `json:"price"`
+}
+
+// TieEntry is a plain struct that GORM cannot parse, with a primary key tag
+type TieEntry struct {
+	Key    string            `gorm:"primaryKey" json:"key"`
+	Score  int               `json:"score"`
+	Attrs  map[string]string `json:"attrs"`
+	Format func() string     `json:"-"`
+}
+
+// generateTieUsers returns 20 users in descending id order with scores 1 to 3
+func generateTieUsers() []*TieUser {
+	users := make([]*TieUser, 0, 20)
+	for id := 20; id >= 1; id-- {
+		users = append(users, &TieUser{ID: uint(id), Name: "user", Score: id % 3})
+	}
+	return users
+}
+
+func setupTieDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&TieUser{}, &TieProduct{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, user := range generateTieUsers() {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	for _, product := range []*TieProduct{{Code: "c", Price: 5}, {Code: "a", Price: 5}, {Code: "b", Price: 5}} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatalf("Failed to create product: %v", err)
+		}
+	}
+
+	var queries []string
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return db, &queries
+}
+
+// TestSortTiebreaker_PagesAreDisjoint tests that walking every page of a sort full of ties returns each
+// record exactly once, in the same order on every path
+func TestSortTiebreaker_PagesAreDisjoint(t *testing.T) {
+	db, _ := setupTieDB(t)
+	handler := filter.NewFilter[TieUser](filter.GolangFilteringConfig{})
+	users := generateTieUsers()
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "score", Order: filter.SortOrderDesc}},
+	}
+
+	// Score descending, then id ascending
+	var expected []uint
+	for _, score := range []int{2, 1, 0} {
+		for id := 1; id <= 20; id++ {
+			if id%3 == score {
+				expected = append(expected, uint(id))
+			}
+		}
+	}
+
+	paths := map[string]func(pageIndex int) (*filter.PaginationResult[TieUser], error){
+		"DataQuery": func(pageIndex int) (*filter.PaginationResult[TieUser], error) {
+			return handler.DataQuery(users, root, pageIndex, 3)
+		},
+		"DataGorm": func(pageIndex int) (*filter.PaginationResult[TieUser], error) {
+			return handler.DataGorm(db, root, pageIndex, 3)
+		},
+		"HybridMemory": func(pageIndex int) (*filter.PaginationResult[TieUser], error) {
+			return handler.Hybrid(db, 1000, root, pageIndex, 3)
+		},
+		"HybridDatabase": func(pageIndex int) (*filter.PaginationResult[TieUser], error) {
+			return handler.Hybrid(db, 0, root, pageIndex, 3)
+		},
+	}
+
+	for path, run := range paths {
+		t.Run(path, func(t *testing.T) {
+			var ids []uint
+			for pageIndex := 0; ; pageIndex++ {
+				page, err := run(pageIndex)
+				if err != nil {
+					t.Fatalf("%s failed: %v", path, err)
+				}
+				for _, user := range page.Data {
+					ids = append(ids, user.ID)
+				}
+				if !page.HasNext {
+					break
+				}
+			}
+			if !equalIDs(ids, expected) {
+				t.Errorf("Expected pages %v, got %v", expected, ids)
+			}
+		})
+	}
+}
+
+// TestSortTiebreaker_CustomPrimaryKey tests that the primary key is detected from the GORM schema, and that
+// the tiebreaker sorts by the raw string key, without LOWER(), on every path
+func TestSortTiebreaker_CustomPrimaryKey(t *testing.T) {
+	db, queries := setupTieDB(t)
+	handler := filter.NewFilter[TieProduct](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "price", Order: filter.SortOrderAsc}},
+	}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	var codes []string
+	for _, product := range page.Data {
+		codes = append(codes, product.Code)
+	}
+	if strings.Join(codes, ",") != "a,b,c" {
+		t.Errorf("Expected products ordered by code, got %v", codes)
+	}
+	if query := (*queries)[len(*queries)-1]; !strings.Contains(query, "ORDER BY price ASC,code ASC") {
+		t.Errorf("Expected the code tiebreaker in %s", query)
+	}
+
+	products := []*TieProduct{{Code: "c", Price: 5}, {Code: "a", Price: 5}, {Code: "b", Price: 5}}
+	result, err := handler.DataQuery(products, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	codes = codes[:0]
+	for _, product := range result.Data {
+		codes = append(codes, product.Code)
+	}
+	if strings.Join(codes, ",") != "a,b,c" {
+		t.Errorf("Expected DataQuery products ordered by code, got %v", codes)
+	}
+
+	// DataQueryNoPage breaks the ties the same way
+	all, err := handler.DataQueryNoPage(products, root)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	codes = codes[:0]
+	for _, product := range all {
+		codes = append(codes, product.Code)
+	}
+	if strings.Join(codes, ",") != "a,b,c" {
+		t.Errorf("Expected DataQueryNoPage products ordered by code, got %v", codes)
+	}
+}
+
+// TestSortTiebreaker_NotDuplicated tests that an explicit sort on the primary key is not repeated
+func TestSortTiebreaker_NotDuplicated(t *testing.T) {
+	db, queries := setupTieDB(t)
+	handler := filter.NewFilter[TieUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "ID", Order: filter.SortOrderDesc}},
+	}
+
+	if _, err := handler.DataGorm(db, root, 0, 10); err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if query := (*queries)[len(*queries)-1]; strings.Contains(query, "ASC") {
+		t.Errorf("Expected no tiebreaker after an explicit id sort, got %s", query)
+	}
+}
+
+// TestSortTiebreaker_Disabled tests that DisableSortTiebreaker leaves ties in input order in memory
+// and adds nothing to the ORDER BY
+func TestSortTiebreaker_Disabled(t *testing.T) {
+	db, queries := setupTieDB(t)
+	handler := filter.NewFilter[TieUser](filter.GolangFilteringConfig{DisableSortTiebreaker: true})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "score", Order: filter.SortOrderDesc}},
+	}
+
+	result, err := handler.DataQuery(generateTieUsers(), root, 0, 3)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	// The sort is stable, so tied users keep their descending id input order
+	if ids := []uint{result.Data[0].ID, result.Data[1].ID, result.Data[2].ID}; !equalIDs(ids, []uint{20, 17, 14}) {
+		t.Errorf("Expected ties in input order, got %v", ids)
+	}
+
+	if _, err := handler.DataGorm(db, root, 0, 3); err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if query := (*queries)[len(*queries)-1]; strings.Contains(query, "id ASC") {
+		t.Errorf("Expected no tiebreaker, got %s", query)
+	}
+}
+
+// TestSortTiebreaker_PlainStruct tests that the primary key of a type GORM cannot parse is read from its
+// tags, without NewFilter logging a GORM error
+func TestSortTiebreaker_PlainStruct(t *testing.T) {
+	var logged bytes.Buffer
+	defaultLogger := logger.Default
+	logger.Default = logger.New(log.New(&logged, "", 0), logger.Config{LogLevel: logger.Warn})
+	defer func() { logger.Default = defaultLogger }()
+
+	handler := filter.NewFilter[TieEntry](filter.GolangFilteringConfig{})
+	if logged.Len() > 0 {
+		t.Errorf("Expected NewFilter to log nothing, got %s", logged.String())
+	}
+
+	entries := []*TieEntry{{Key: "c", Score: 1}, {Key: "a", Score: 1}, {Key: "b", Score: 1}}
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "score", Order: filter.SortOrderAsc}},
+	}
+	result, err := handler.DataQuery(entries, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	var keys []string
+	for _, entry := range result.Data {
+		keys = append(keys, entry.Key)
+	}
+	if strings.Join(keys, ",") != "a,b,c" {
+		t.Errorf("Expected entries ordered by key, got %v", keys)
+	}
+}
+
+// TestSortTiebreaker_NoPage tests that DataGormNoPage and the custom CSV export order unsorted rows by
+// the primary key like DataQueryNoPage, rather than in whatever order the database returns them
+func TestSortTiebreaker_NoPage(t *testing.T) {
+	db, _ := setupTieDB(t)
+	handler := filter.NewFilter[TieProduct](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "price", Value: []any{5, 7}, Mode: filter.ModeIn, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	products, err := handler.DataGormNoPage(db, root)
+	if err != nil {
+		t.Fatalf("DataGormNoPage failed: %v", err)
+	}
+	var codes []string
+	for _, product := range products {
+		codes = append(codes, product.Code)
+	}
+	if strings.Join(codes, ",") != "a,b,c" {
+		t.Errorf("Expected DataGormNoPage to order products by code, got %v", codes)
+	}
+	inMemory, err := handler.DataQueryNoPage([]*TieProduct{{Code: "c", Price: 5}, {Code: "a", Price: 5}, {Code: "b", Price: 5}}, root)
+	if err != nil {
+		t.Fatalf("DataQueryNoPage failed: %v", err)
+	}
+	codes = nil
+	for _, product := range inMemory {
+		codes = append(codes, product.Code)
+	}
+	if strings.Join(codes, ",") != "a,b,c" {
+		t.Errorf("Expected DataQueryNoPage to order products by code, got %v", codes)
+	}
+
+	csvData, err := handler.GormNoPaginationCSVCustom(db, root, func(product *TieProduct) map[string]any {
+		return map[string]any{"Code": product.Code}
+	})
+	if err != nil {
+		t.Fatalf("GormNoPaginationCSVCustom failed: %v", err)
+	}
+	if got := strings.Fields(string(csvData)); strings.Join(got, ",") != "Code,a,b,c" {
+		t.Errorf("Expected the CSV rows ordered by code, got %v", got)
+	}
+}
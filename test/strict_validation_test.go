@@ -0,0 +1,126 @@
+package test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/gorm/logger"
+)
+
+// TestStrictValidation_DataGorm tests that strict mode returns errors for invalid filter values
+func TestStrictValidation_DataGorm(t *testing.T) {
+	db := setupTestDB(t)
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	// Default (non-strict) keeps the legacy behavior of skipping the invalid filter
+	lenient := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	result, err := lenient.DataGorm(db, filterRoot, 0, 100)
+	if err != nil {
+		t.Fatalf("Expected no error in non-strict mode, got %v", err)
+	}
+	if result.TotalSize != 10 {
+		t.Errorf("Expected invalid filter to be skipped (10 records), got %d", result.TotalSize)
+	}
+
+	strict := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	_, err = strict.DataGorm(db, filterRoot, 0, 100)
+	if err == nil {
+		t.Fatal("Expected error in strict mode, got nil")
+	}
+	if !strings.Contains(err.Error(), "age") || !strings.Contains(err.Error(), "twentyfive") {
+		t.Errorf("Expected error to name the field and value, got %q", err.Error())
+	}
+
+	if _, err := strict.DataGormNoPage(db, filterRoot); err == nil {
+		t.Error("Expected DataGormNoPage error in strict mode, got nil")
+	}
+	if _, err := strict.Hybrid(db, 0, filterRoot, 0, 100); err == nil {
+		t.Error("Expected Hybrid error in strict mode, got nil")
+	}
+}
+
+// TestStrictValidation_RootOverride tests that Root.StrictValidation overrides the handler config
+func TestStrictValidation_RootOverride(t *testing.T) {
+	db := setupTestDB(t)
+	enabled, disabled := true, false
+
+	filterRoot := filter.Root{
+		Logic: filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: "not a date", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+		},
+		StrictValidation: &enabled,
+	}
+	lenient := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	if _, err := lenient.DataGorm(db, filterRoot, 0, 100); err == nil {
+		t.Error("Expected error when Root enables strict validation, got nil")
+	}
+
+	filterRoot.StrictValidation = &disabled
+	strict := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	if _, err := strict.DataGorm(db, filterRoot, 0, 100); err != nil {
+		t.Errorf("Expected no error when Root disables strict validation, got %v", err)
+	}
+}
+
+// TestStrictValidation_UnsupportedMode tests that unsupported modes error in strict mode
+func TestStrictValidation_UnsupportedMode(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		Groups: []filter.Root{
+			{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "age", Value: 5, Mode: filter.ModeBefore, DataType: filter.DataTypeNumber},
+				},
+			},
+		},
+	}
+
+	if _, err := handler.DataGorm(db, filterRoot, 0, 100); err == nil {
+		t.Error("Expected error for unsupported mode in nested group, got nil")
+	}
+}
+
+// TestStrictValidation_PlainStructLogsOnce tests that strict queries on a type GORM cannot parse log nothing
+// without preloads or dotted fields, and parse it at most once for unknown dotted fields
+func TestStrictValidation_PlainStructLogsOnce(t *testing.T) {
+	var logged bytes.Buffer
+	defaultLogger := logger.Default
+	logger.Default = logger.New(log.New(&logged, "", 0), logger.Config{LogLevel: logger.Warn})
+	defer func() { logger.Default = defaultLogger }()
+
+	handler := filter.NewFilter[TieEntry](filter.GolangFilteringConfig{StrictValidation: true, RejectUnknownFields: true})
+	entries := []*TieEntry{{Key: "a", Score: 1}, {Key: "b", Score: 2}}
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "score", Value: 1, Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+	}}
+	for range 3 {
+		if _, err := handler.DataQuery(entries, root, 0, 10); err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+	}
+	if logged.Len() > 0 {
+		t.Errorf("Expected DataQuery to log nothing, got %s", logged.String())
+	}
+
+	root.FieldFilters[0].Field = "owner.name"
+	for range 3 {
+		if _, err := handler.DataQuery(entries, root, 0, 10); err == nil {
+			t.Fatal("Expected an unknown field error")
+		}
+	}
+	if count := strings.Count(logged.String(), "failed to parse"); count > 1 {
+		t.Errorf("Expected the schema to be parsed at most once, got %d errors logged:\n%s", count, logged.String())
+	}
+}
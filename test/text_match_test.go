@@ -0,0 +1,127 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// MatchCustomer has a name searched with pattern filters
+type MatchCustomer struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+// TestTextMatchStrategy_DialectSQL tests that pattern filters use ILIKE on PostgreSQL and LOWER elsewhere
+func TestTextMatchStrategy_DialectSQL(t *testing.T) {
+	postgres := mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}
+	mysql := mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}
+	dialects := []struct {
+		name      string
+		dialector gorm.Dialector
+		strategy  filter.TextMatchStrategy
+		ilike     bool
+	}{
+		{"Postgres", postgres, filter.TextMatchAuto, true},
+		{"PostgresLower", postgres, filter.TextMatchLower, false},
+		{"SQLite", sqlite.Open(":memory:"), filter.TextMatchAuto, false},
+		{"MySQL", mysql, filter.TextMatchAuto, false},
+		{"MySQLILike", mysql, filter.TextMatchILike, true},
+	}
+	filters := []struct {
+		name  string
+		mode  filter.Mode
+		ilike string
+		lower string
+	}{
+		{"Contains", filter.ModeContains, "name ILIKE ", "LOWER(name) LIKE LOWER("},
+		{"NotContains", filter.ModeNotContains, "name NOT ILIKE ", "LOWER(name) NOT LIKE LOWER("},
+		{"StartsWith", filter.ModeStartsWith, "name ILIKE ", "LOWER(name) LIKE LOWER("},
+		{"EndsWith", filter.ModeEndsWith, "name ILIKE ", "LOWER(name) LIKE LOWER("},
+	}
+
+	for _, dialect := range dialects {
+		handler := filter.NewFilter[MatchCustomer](filter.GolangFilteringConfig{TextMatchStrategy: dialect.strategy})
+		for _, tt := range filters {
+			t.Run(dialect.name+"/"+tt.name, func(t *testing.T) {
+				root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+					{Field: "name", Value: "Ann", Mode: tt.mode, DataType: filter.DataTypeText},
+				}}
+				sql := captureDryRunSQL(t, dialect.dialector, func(db *gorm.DB) error {
+					_, err := handler.DataGorm(db, root, 0, 10)
+					return err
+				})
+				expected, unexpected := tt.lower, "ILIKE"
+				if dialect.ilike {
+					expected, unexpected = tt.ilike, "LOWER("
+				}
+				if !strings.Contains(sql, expected) {
+					t.Errorf("Expected SQL to contain %q, got:\n%s", expected, sql)
+				}
+				if strings.Contains(sql, unexpected) {
+					t.Errorf("Expected SQL not to contain %q, got:\n%s", unexpected, sql)
+				}
+			})
+		}
+	}
+}
+
+// TestTextMatchStrategy_CaseSensitive tests that case-sensitive filters keep LIKE on the raw column on PostgreSQL
+func TestTextMatchStrategy_CaseSensitive(t *testing.T) {
+	postgres := mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}
+	handler := filter.NewFilter[MatchCustomer](filter.GolangFilteringConfig{CaseSensitive: true})
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "name", Value: "Ann", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+	}}
+	sql := captureDryRunSQL(t, postgres, func(db *gorm.DB) error {
+		_, err := handler.DataGorm(db, root, 0, 10)
+		return err
+	})
+	if !strings.Contains(sql, "name LIKE ") || strings.Contains(sql, "ILIKE") {
+		t.Errorf("Expected a case-sensitive LIKE, got:\n%s", sql)
+	}
+}
+
+// TestTextMatchStrategy_CaseSensitiveMySQL tests that case-sensitive filters compare the column as binary
+// on MySQL, whose default collations ignore case even with LIKE
+func TestTextMatchStrategy_CaseSensitiveMySQL(t *testing.T) {
+	mysql := mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}
+	handler := filter.NewFilter[MatchCustomer](filter.GolangFilteringConfig{CaseSensitive: true})
+	for _, mode := range []filter.Mode{filter.ModeEqual, filter.ModeContains} {
+		root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "Ann", Mode: mode, DataType: filter.DataTypeText},
+		}}
+		sql := captureDryRunSQL(t, mysql, func(db *gorm.DB) error {
+			_, err := handler.DataGorm(db, root, 0, 10)
+			return err
+		})
+		if !strings.Contains(sql, "CAST(name AS BINARY)") || strings.Contains(sql, "LOWER(") {
+			t.Errorf("Expected %s to compare the column as binary, got:\n%s", mode, sql)
+		}
+	}
+}
+
+// TestTextMatchStrategy_DataQuery tests that in-memory pattern filters stay case-insensitive whatever the strategy
+func TestTextMatchStrategy_DataQuery(t *testing.T) {
+	customers := []*MatchCustomer{{ID: 1, Name: "ANNA"}, {ID: 2, Name: "Joanne"}, {ID: 3, Name: "Bob"}}
+	for _, strategy := range []filter.TextMatchStrategy{filter.TextMatchAuto, filter.TextMatchLower, filter.TextMatchILike} {
+		handler := filter.NewFilter[MatchCustomer](filter.GolangFilteringConfig{TextMatchStrategy: strategy})
+		root := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "name", Value: "anN", Mode: filter.ModeContains, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+		}
+		result, err := handler.DataQuery(customers, root, 0, 10)
+		if err != nil {
+			t.Fatalf("DataQuery failed: %v", err)
+		}
+		if len(result.Data) != 2 || result.Data[0].ID != 1 || result.Data[1].ID != 2 {
+			t.Errorf("Strategy %q: expected IDs [1 2], got %d records", strategy, len(result.Data))
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RangeProduct has a code that users filter by prefix ranges such as "A000" to "A999"
+type RangeProduct struct {
+	ID   uint    `gorm:"primaryKey" json:"id"`
+	Code string  `json:"code"`
+	Alt  *string `json:"alt"`
+}
+
+func generateRangeProducts() []*RangeProduct {
+	alt := "b100"
+	return []*RangeProduct{
+		{ID: 1, Code: "A000", Alt: &alt},
+		{ID: 2, Code: "a500"},
+		{ID: 3, Code: "A999"},
+		{ID: 4, Code: "B000"},
+		{ID: 5, Code: "a9999"},
+		{ID: 6, Code: "9ZZ"},
+	}
+}
+
+func rangeProductIDs(products []*RangeProduct) []uint {
+	ids := make([]uint, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+// TestTextRange_AllPaths tests that a text ModeRange matches the same records, case-insensitively and
+// with inclusive or exclusive bounds, in DataQuery, DataGorm and both Hybrid strategies
+func TestTextRange_AllPaths(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&RangeProduct{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(generateRangeProducts()).Error; err != nil {
+		t.Fatalf("Failed to create products: %v", err)
+	}
+	handler := filter.NewFilter[RangeProduct](filter.GolangFilteringConfig{StrictValidation: true})
+	products := generateRangeProducts()
+
+	tests := []struct {
+		name          string
+		field         string
+		value         any
+		caseSensitive bool
+		expected      []uint
+	}{
+		{"Inclusive", "code", filter.Range{From: "A000", To: "A999"}, false, []uint{1, 2, 3}},
+		{"LowercaseBounds", "code", filter.Range{From: "a000", To: "a999"}, false, []uint{1, 2, 3}},
+		{"MixedCaseBounds", "code", filter.Range{From: "a000", To: "A9999"}, false, []uint{1, 2, 3, 5}},
+		{"FromExclusive", "code", filter.Range{From: "A000", To: "A999", FromExclusive: true}, false, []uint{2, 3}},
+		{"ToExclusive", "code", filter.Range{From: "A000", To: "A999", ToExclusive: true}, false, []uint{1, 2}},
+		{"FromJSON", "code", map[string]any{"from": "a999", "to": "b000"}, false, []uint{3, 4, 5}},
+		{"CaseSensitive", "code", filter.Range{From: "A000", To: "A999"}, true, []uint{1, 3}},
+		{"Nullable", "alt", filter.Range{From: "B000", To: "B999"}, false, []uint{1}},
+		{"Empty", "code", filter.Range{From: "C", To: "D"}, false, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic: filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{
+					{Field: tt.field, Value: tt.value, Mode: filter.ModeRange, DataType: filter.DataTypeText, CaseSensitive: &tt.caseSensitive},
+				},
+			}
+
+			result, err := handler.DataQuery(products, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := rangeProductIDs(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 100)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := rangeProductIDs(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+
+			for _, threshold := range []int{0, 1000} {
+				hybrid, err := handler.Hybrid(db, threshold, root, 0, 100)
+				if err != nil {
+					t.Fatalf("Hybrid failed: %v", err)
+				}
+				if got := rangeProductIDs(hybrid.Data); !equalIDs(got, tt.expected) {
+					t.Errorf("Expected Hybrid (%s) IDs %v, got %v", hybrid.Strategy, tt.expected, got)
+				}
+			}
+		})
+	}
+}
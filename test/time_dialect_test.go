@@ -0,0 +1,141 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// DialectShift stores its start as a datetime and its end as "HH:MM:SS" text
+type DialectShift struct {
+	ID       uint      `gorm:"primaryKey" json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   string    `json:"ends_at"`
+}
+
+// captureDryRunSQL builds the queries of run on a DryRun session of dialector and returns their SQL
+func captureDryRunSQL(t *testing.T, dialector gorm.Dialector, run func(db *gorm.DB) error) string {
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	if err := run(db); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	return strings.Join(queries, "\n")
+}
+
+// TestTimeFilter_DialectSQL tests that time filters extract the time of day with each dialect's function
+func TestTimeFilter_DialectSQL(t *testing.T) {
+	handler := filter.NewFilter[DialectShift](filter.GolangFilteringConfig{})
+	dialects := []struct {
+		name      string
+		dialector gorm.Dialector
+		expr      string
+	}{
+		{"SQLite", sqlite.Open(":memory:"), "time(substr(starts_at, 1, 19))"},
+		{"MySQL", mockDialector{Dialector: sqlite.Open(":memory:"), name: "mysql", quote: '`'}, "TIME(starts_at)"},
+		{"Postgres", mockDialector{Dialector: sqlite.Open(":memory:"), name: "postgres", quote: '"'}, "CAST(starts_at AS TIME)"},
+	}
+	filters := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected string
+	}{
+		{"Equal", filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeEqual, DataType: filter.DataTypeTime}, "%s = "},
+		{"After", filter.FieldFilter{Field: "starts_at", Value: "08:00", Mode: filter.ModeAfter, DataType: filter.DataTypeTime}, "%s >= "},
+		{"Range", filter.FieldFilter{Field: "starts_at", Value: filter.Range{From: "08:00", To: "17:00"}, Mode: filter.ModeRange, DataType: filter.DataTypeTime}, "%s BETWEEN "},
+		{"ExclusiveRange", filter.FieldFilter{Field: "starts_at", Value: filter.Range{From: "08:00", To: "17:00", ToExclusive: true}, Mode: filter.ModeRange, DataType: filter.DataTypeTime}, "%s < "},
+		{"CompareField", filter.FieldFilter{Field: "starts_at", CompareField: "ends_at", Mode: filter.ModeLT, DataType: filter.DataTypeTime}, "%s < "},
+	}
+
+	for _, dialect := range dialects {
+		for _, tt := range filters {
+			t.Run(dialect.name+"/"+tt.name, func(t *testing.T) {
+				root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+				sql := captureDryRunSQL(t, dialect.dialector, func(db *gorm.DB) error {
+					_, err := handler.DataGorm(db, root, 0, 10)
+					return err
+				})
+				if expected := strings.Replace(tt.expected, "%s", dialect.expr, 1); !strings.Contains(sql, expected) {
+					t.Errorf("Expected SQL to contain %q, got:\n%s", expected, sql)
+				}
+				if dialect.name != "SQLite" && strings.Contains(sql, "time(") {
+					t.Errorf("Expected no SQLite time() on %s, got:\n%s", dialect.name, sql)
+				}
+			})
+		}
+	}
+}
+
+// TestTimeFilter_TextColumn tests that time filters on a column holding "HH:MM:SS" text match like
+// on a datetime column, in DataQuery and DataGorm
+func TestTimeFilter_TextColumn(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&DialectShift{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	shifts := []*DialectShift{
+		{ID: 1, StartsAt: day.Add(6 * time.Hour), EndsAt: "14:00:00"},
+		{ID: 2, StartsAt: day.Add(9 * time.Hour), EndsAt: "17:30:00"},
+		{ID: 3, StartsAt: day.Add(22 * time.Hour), EndsAt: "06:00:00"},
+	}
+	if err := db.Create(shifts).Error; err != nil {
+		t.Fatalf("Failed to create shifts: %v", err)
+	}
+	handler := filter.NewFilter[DialectShift](filter.GolangFilteringConfig{StrictValidation: true})
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"After", filter.FieldFilter{Field: "ends_at", Value: "14:00", Mode: filter.ModeAfter, DataType: filter.DataTypeTime}, []uint{1, 2}},
+		{"Range", filter.FieldFilter{Field: "ends_at", Value: filter.Range{From: "05:00", To: "15:00"}, Mode: filter.ModeRange, DataType: filter.DataTypeTime}, []uint{1, 3}},
+		{"DatetimeBefore", filter.FieldFilter{Field: "starts_at", Value: "09:00", Mode: filter.ModeBefore, DataType: filter.DataTypeTime}, []uint{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			ids := func(data []*DialectShift) []uint {
+				result := make([]uint, len(data))
+				for i, shift := range data {
+					result[i] = shift.ID
+				}
+				return result
+			}
+
+			result, err := handler.DataQuery(shifts, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if got := ids(result.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataQuery IDs %v, got %v", tt.expected, got)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if got := ids(page.Data); !equalIDs(got, tt.expected) {
+				t.Errorf("Expected DataGorm IDs %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ZonedShift is a shift whose start times are stored in different zones
+type ZonedShift struct {
+	ID      uint      `gorm:"primaryKey" json:"id"`
+	StartAt time.Time `json:"start_at"`
+}
+
+// zonedShifts returns shifts starting at 09:30 wall clock in Local, UTC, +08:00 and -05:00, one more
+// in Local carrying a monotonic clock reading, and one starting at 10:15 UTC
+func zonedShifts() []*ZonedShift {
+	wallClock := func(loc *time.Location) time.Time {
+		return time.Date(2025, time.March, 4, 9, 30, 0, 0, loc)
+	}
+	now := time.Now()
+	monotonic := now.Add(wallClock(time.Local).Sub(now))
+	return []*ZonedShift{
+		{ID: 1, StartAt: wallClock(time.Local)},
+		{ID: 2, StartAt: wallClock(time.UTC)},
+		{ID: 3, StartAt: wallClock(time.FixedZone("", 8*3600))},
+		{ID: 4, StartAt: wallClock(time.FixedZone("", -5*3600))},
+		{ID: 5, StartAt: monotonic},
+		{ID: 6, StartAt: time.Date(2025, time.March, 4, 10, 15, 0, 0, time.UTC)},
+	}
+}
+
+func zonedShiftIDs(shifts []*ZonedShift) []uint {
+	ids := make([]uint, len(shifts))
+	for i, shift := range shifts {
+		ids[i] = shift.ID
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// TestTimeFilter_WallClockAcrossZones tests that DataTypeTime compares the wall-clock time of day of
+// values in any zone, with or without a monotonic reading, in DataQuery and DataGorm on SQLite
+func TestTimeFilter_WallClockAcrossZones(t *testing.T) {
+	shifts := zonedShifts()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ZonedShift{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(shifts).Error; err != nil {
+		t.Fatalf("Failed to create shifts: %v", err)
+	}
+	var fetched []*ZonedShift
+	if err := db.Find(&fetched).Error; err != nil {
+		t.Fatalf("Failed to fetch shifts: %v", err)
+	}
+
+	handler := filter.NewFilter[ZonedShift](filter.GolangFilteringConfig{})
+	tests := []struct {
+		name     string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"EqualText", filter.ModeEqual, "09:30:00", []uint{1, 2, 3, 4, 5}},
+		{"EqualOffsetText", filter.ModeEqual, "09:30:00+08:00", []uint{1, 2, 3, 4, 5}},
+		{"EqualTimeInZone", filter.ModeEqual, time.Date(1, 1, 1, 9, 30, 0, 0, time.FixedZone("", 3*3600)), []uint{1, 2, 3, 4, 5}},
+		{"NotEqual", filter.ModeNotEqual, "09:30", []uint{6}},
+		{"After", filter.ModeAfter, "10:00", []uint{6}},
+		{"Range", filter.ModeRange, filter.Range{From: "09:00", To: "09:45"}, []uint{1, 2, 3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: "start_at", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeTime}},
+			}
+
+			memory, err := handler.DataQueryNoPage(shifts, root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPage failed: %v", err)
+			}
+			if ids := zonedShiftIDs(memory); !slices.Equal(ids, tt.expected) {
+				t.Errorf("DataQueryNoPage: expected IDs %v, got %v", tt.expected, ids)
+			}
+
+			// Values read back from SQLite carry the zone the driver parsed
+			reread, err := handler.DataQueryNoPage(fetched, root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPage on fetched shifts failed: %v", err)
+			}
+			if ids := zonedShiftIDs(reread); !slices.Equal(ids, tt.expected) {
+				t.Errorf("DataQueryNoPage on fetched shifts: expected IDs %v, got %v", tt.expected, ids)
+			}
+
+			database, err := handler.DataGormNoPage(db, root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if ids := zonedShiftIDs(database); !slices.Equal(ids, tt.expected) {
+				t.Errorf("DataGormNoPage: expected IDs %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// ClockedShift records when a shift was clocked in and, once it is, out
+type ClockedShift struct {
+	ID       uint       `gorm:"primaryKey" json:"id"`
+	ClockIn  time.Time  `json:"clock_in"`
+	ClockOut *time.Time `json:"clock_out"`
+}
+
+// clockedShifts returns shifts clocked in at midnight, never (the zero time), at 09:30 and at 17:00,
+// clocked out at 08:00, never (nil), at the zero time and at midnight
+func clockedShifts() []*ClockedShift {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.March, 4, hour, minute, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+	return []*ClockedShift{
+		{ID: 1, ClockIn: at(0, 0), ClockOut: ptr(at(8, 0))},
+		{ID: 2, ClockIn: time.Time{}},
+		{ID: 3, ClockIn: at(9, 30), ClockOut: ptr(time.Time{})},
+		{ID: 4, ClockIn: at(17, 0), ClockOut: ptr(time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC))},
+	}
+}
+
+// TestTimeFilter_ZeroTime tests that a zero time.Time, like a nil *time.Time, is no value for
+// DataTypeTime filters rather than midnight: only ModeNotEqual matches it, in DataQuery and DataGorm
+func TestTimeFilter_ZeroTime(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ClockedShift{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := db.Create(clockedShifts()).Error; err != nil {
+		t.Fatalf("Failed to create shifts: %v", err)
+	}
+
+	handler := filter.NewFilter[ClockedShift](filter.GolangFilteringConfig{})
+	tests := []struct {
+		name     string
+		field    string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"Midnight", "clock_in", filter.ModeEqual, "00:00:00", []uint{1}},
+		{"NotMidnight", "clock_in", filter.ModeNotEqual, "00:00:00", []uint{2, 3, 4}},
+		{"Before", "clock_in", filter.ModeLTE, "09:30", []uint{1, 3}},
+		{"Range", "clock_in", filter.ModeRange, filter.Range{From: "00:00", To: "01:00"}, []uint{1}},
+		{"PointerMidnight", "clock_out", filter.ModeEqual, "00:00:00", []uint{4}},
+		{"PointerNotMidnight", "clock_out", filter.ModeNotEqual, "00:00:00", []uint{1, 2, 3}},
+		{"PointerBefore", "clock_out", filter.ModeBefore, "09:00", []uint{1, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:        filter.LogicAnd,
+				FieldFilters: []filter.FieldFilter{{Field: tt.field, Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeTime}},
+			}
+
+			memory, err := handler.DataQueryNoPage(clockedShifts(), root)
+			if err != nil {
+				t.Fatalf("DataQueryNoPage failed: %v", err)
+			}
+			if ids := clockedShiftIDs(memory); !slices.Equal(ids, tt.expected) {
+				t.Errorf("DataQueryNoPage: expected IDs %v, got %v", tt.expected, ids)
+			}
+
+			database, err := handler.DataGormNoPage(db, root)
+			if err != nil {
+				t.Fatalf("DataGormNoPage failed: %v", err)
+			}
+			if ids := clockedShiftIDs(database); !slices.Equal(ids, tt.expected) {
+				t.Errorf("DataGormNoPage: expected IDs %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func clockedShiftIDs(shifts []*ClockedShift) []uint {
+	ids := make([]uint, len(shifts))
+	for i, shift := range shifts {
+		ids[i] = shift.ID
+	}
+	slices.Sort(ids)
+	return ids
+}
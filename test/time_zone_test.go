@@ -0,0 +1,164 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// zoneEventTimes straddle day boundaries in Manila (UTC+8) and Los Angeles (UTC-8 after Nov 2)
+var zoneEventTimes = []time.Time{
+	time.Date(2025, 11, 2, 15, 30, 0, 0, time.UTC), // 1: Nov 2 23:30 Manila, Nov 2 07:30 LA
+	time.Date(2025, 11, 2, 16, 30, 0, 0, time.UTC), // 2: Nov 3 00:30 Manila, Nov 2 08:30 LA
+	time.Date(2025, 11, 3, 14, 0, 0, 0, time.UTC),  // 3: Nov 3 22:00 Manila, Nov 3 06:00 LA
+	time.Date(2025, 11, 3, 16, 30, 0, 0, time.UTC), // 4: Nov 4 00:30 Manila, Nov 3 08:30 LA
+	time.Date(2025, 11, 4, 5, 0, 0, 0, time.UTC),   // 5: Nov 4 13:00 Manila, Nov 3 21:00 LA
+}
+
+// TestTimeZone_DateOnlyValues tests that Root.TimeZone moves the day boundaries of date-only values,
+// for positive and negative offsets, in DataQuery and DataGorm alike
+func TestTimeZone_DateOnlyValues(t *testing.T) {
+	db, events := setupRelativeEvents(t, zoneEventTimes)
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		timeZone string
+		mode     filter.Mode
+		value    any
+		expected []uint
+	}{
+		{"EqualUTC", "", filter.ModeEqual, "2025-11-03", []uint{3, 4}},
+		{"EqualManila", "Asia/Manila", filter.ModeEqual, "2025-11-03", []uint{2, 3}},
+		{"EqualLosAngeles", "America/Los_Angeles", filter.ModeEqual, "2025-11-03", []uint{3, 4, 5}},
+		{"NotEqualManila", "Asia/Manila", filter.ModeNotEqual, "2025-11-03", []uint{1, 4, 5}},
+		{"BeforeManila", "Asia/Manila", filter.ModeBefore, "2025-11-03", []uint{1}},
+		{"AfterLosAngeles", "America/Los_Angeles", filter.ModeAfter, "2025-11-02", []uint{3, 4, 5}},
+		{"LTEManila", "Asia/Manila", filter.ModeLTE, "2025/11/03", []uint{1, 2, 3}},
+		{"InManila", "Asia/Manila", filter.ModeIn, []string{"2025-11-02", "2025-11-04"}, []uint{1, 4, 5}},
+		{"RangeUTC", "", filter.ModeRange, filter.Range{From: "2025-11-02", To: "2025-11-03"}, []uint{1, 2, 3, 4}},
+		{"RangeManila", "Asia/Manila", filter.ModeRange, filter.Range{From: "2025-11-02", To: "2025-11-03"}, []uint{1, 2, 3}},
+		// Nov 2 starts in daylight time (UTC-7) in Los Angeles and Nov 3 ends in standard time (UTC-8)
+		{"RangeLosAngelesAcrossDST", "America/Los_Angeles", filter.ModeRange, filter.Range{From: "2025-11-02", To: "2025-11-03"}, []uint{1, 2, 3, 4, 5}},
+		// Date-times without an offset are local to the zone too
+		{"DateTimeManila", "Asia/Manila", filter.ModeGTE, "2025-11-03 22:00:00", []uint{3, 4, 5}},
+		// An explicit offset wins over the zone
+		{"ExplicitOffsetManila", "Asia/Manila", filter.ModeEqual, "2025-11-03T00:00:00Z", []uint{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:    filter.LogicAnd,
+				TimeZone: tt.timeZone,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "created_at", Value: tt.value, Mode: tt.mode, DataType: filter.DataTypeDate},
+				},
+			}
+
+			result, err := handler.DataQuery(events, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := eventIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery events %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := eventIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm events %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestTimeZone_OverridesLocation tests that Root.TimeZone takes precedence over GolangFilteringConfig.Location,
+// which is otherwise used for absolute and relative values alike
+func TestTimeZone_OverridesLocation(t *testing.T) {
+	db, events := setupRelativeEvents(t, zoneEventTimes)
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+	now := time.Date(2025, 11, 3, 12, 0, 0, 0, time.UTC) // Nov 3 20:00 Manila, Nov 3 04:00 LA
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{
+		Location: manila,
+		Now:      func() time.Time { return now },
+	})
+
+	tests := []struct {
+		name     string
+		timeZone string
+		value    string
+		expected []uint
+	}{
+		{"LocationAbsolute", "", "2025-11-03", []uint{2, 3}},
+		{"LocationRelative", "", "today", []uint{2, 3}},
+		{"TimeZoneAbsolute", "America/Los_Angeles", "2025-11-03", []uint{3, 4, 5}},
+		{"TimeZoneRelative", "America/Los_Angeles", "today", []uint{3, 4, 5}},
+		{"TimeZoneUTC", "UTC", "2025-11-03", []uint{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{
+				Logic:    filter.LogicAnd,
+				TimeZone: tt.timeZone,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "created_at", Value: tt.value, Mode: filter.ModeEqual, DataType: filter.DataTypeDate},
+				},
+			}
+
+			result, err := handler.DataQuery(events, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := eventIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery events %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 20)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := eventIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm events %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestTimeZone_Invalid tests that an unknown zone name is an error
+func TestTimeZone_Invalid(t *testing.T) {
+	db, events := setupRelativeEvents(t, zoneEventTimes)
+	handler := filter.NewFilter[RelativeEvent](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic:    filter.LogicAnd,
+		TimeZone: "Mars/Olympus_Mons",
+		FieldFilters: []filter.FieldFilter{
+			{Field: "created_at", Value: "2025-11-03", Mode: filter.ModeEqual, DataType: filter.DataTypeDate},
+		},
+	}
+
+	if _, err := handler.DataQuery(events, root, 0, 20); err == nil {
+		t.Error("Expected DataQuery to reject an unknown time zone")
+	}
+	if _, err := handler.DataGorm(db, root, 0, 20); err == nil {
+		t.Error("Expected DataGorm to reject an unknown time zone")
+	}
+}
+
+// TestTimeZone_ParseFromJSON tests that timeZone is decoded from the filter payload
+func TestTimeZone_ParseFromJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"timeZone":"Asia/Manila","filters":[{"field":"created_at","value":"2025-11-03","mode":"equal","dataType":"date"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.TimeZone != "Asia/Manila" {
+		t.Errorf("Expected TimeZone Asia/Manila, got %q", root.TimeZone)
+	}
+}
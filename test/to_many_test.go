@@ -0,0 +1,263 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ManyRole is assigned to any number of ManyMembers through a join table
+type ManyRole struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `json:"name"`
+}
+
+// ManyMember has many2many roles
+type ManyMember struct {
+	ID    uint       `gorm:"primaryKey" json:"id"`
+	Name  string     `json:"name"`
+	Roles []ManyRole `gorm:"many2many:many_member_roles" json:"roles"`
+}
+
+func generateManyMembers() []*ManyMember {
+	admin := ManyRole{ID: 1, Name: "admin"}
+	editor := ManyRole{ID: 2, Name: "editor"}
+	viewer := ManyRole{ID: 3, Name: "viewer"}
+	return []*ManyMember{
+		{ID: 1, Name: "Alice", Roles: []ManyRole{admin, editor}},
+		{ID: 2, Name: "Bob", Roles: []ManyRole{viewer}},
+		{ID: 3, Name: "Carol", Roles: []ManyRole{editor, viewer}},
+		{ID: 4, Name: "Dave"}, // No roles
+	}
+}
+
+func setupManyMemberDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&ManyRole{}, &ManyMember{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, member := range generateManyMembers() {
+		if err := db.Create(member).Error; err != nil {
+			t.Fatalf("Failed to create member: %v", err)
+		}
+	}
+	return db
+}
+
+func memberIDs(members []*ManyMember) []uint {
+	ids := make([]uint, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	return ids
+}
+
+// TestToMany_Many2ManyMatchesInMemory tests that filters on a many2many relation return each member once
+// in DataGorm and match the same members in memory, where any element of the slice may match
+func TestToMany_Many2ManyMatchesInMemory(t *testing.T) {
+	db := setupManyMemberDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[ManyMember](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	members := generateManyMembers()
+
+	tests := []struct {
+		name     string
+		root     filter.Root
+		expected []uint
+	}{
+		{"RoleEqual", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "roles.name", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, []uint{1}},
+		// Alice and Carol both hold editor alongside another role
+		{"RoleIn", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "roles.name", Value: []any{"editor", "viewer"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			},
+		}, []uint{1, 2, 3}},
+		{"NoRoles", filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "roles.name", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText},
+			},
+		}, []uint{4}},
+		{"OrWithParentField", filter.Root{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "roles.name", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "name", Value: "Dave", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+		}, []uint{1, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if page.TotalSize != len(tt.expected) {
+				t.Errorf("Expected TotalSize=%d, got %d", len(tt.expected), page.TotalSize)
+			}
+			if ids := memberIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm members %v, got %v", tt.expected, ids)
+			}
+
+			result, err := handler.DataQuery(members, tt.root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := memberIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery members %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestToMany_HasManyInMemory tests that in-memory filters and sorts on a has-many slice agree with DataGorm
+func TestToMany_HasManyInMemory(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	orders := generateHasManyOrders()
+
+	roots := map[string]filter.Root{
+		"FilterAnyItem": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.sku", Value: "widget", Mode: filter.ModeStartsWith, DataType: filter.DataTypeText},
+			},
+		},
+		"NotEqualMatchesOtherItems": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText},
+			},
+		},
+		"IsEmptyMatchesNoItems": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "items.sku", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText},
+			},
+		},
+		"SortAsc": {
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "items.quantity", Order: filter.SortOrderAsc}, {Field: "id", Order: filter.SortOrderAsc}},
+		},
+		"SortDesc": {
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "items.quantity", Order: filter.SortOrderDesc}, {Field: "id", Order: filter.SortOrderAsc}},
+		},
+	}
+
+	for name, root := range roots {
+		t.Run(name, func(t *testing.T) {
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			result, err := handler.DataQuery(orders, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if gormIDs, memoryIDs := orderIDs(page.Data), orderIDs(result.Data); !equalIDs(gormIDs, memoryIDs) {
+				t.Errorf("Expected DataQuery %v to match DataGorm %v", memoryIDs, gormIDs)
+			}
+			if result.TotalSize != page.TotalSize {
+				t.Errorf("Expected DataQuery TotalSize=%d to match DataGorm, got %d", page.TotalSize, result.TotalSize)
+			}
+		})
+	}
+}
+
+// TestToMany_AndOfTwoValues tests that each filter on a has-many field may match a different item, so
+// an order with both SKUs matches "items.sku = WIDGET AND items.sku = GADGET" in DataGorm as in DataQuery
+func TestToMany_AndOfTwoValues(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.sku", Value: "WIDGET", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "items.sku", Value: "GADGET", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if ids := orderIDs(page.Data); !equalIDs(ids, []uint{1}) || page.TotalSize != 1 {
+		t.Errorf("Expected DataGorm order [1] of 1, got %v of %d", ids, page.TotalSize)
+	}
+	count, err := handler.CountGorm(db, root)
+	if err != nil {
+		t.Fatalf("CountGorm failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected CountGorm=1, got %d", count)
+	}
+	result, err := handler.DataQuery(generateHasManyOrders(), root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if ids := orderIDs(result.Data); !equalIDs(ids, []uint{1}) || result.TotalSize != 1 {
+		t.Errorf("Expected DataQuery order [1] of 1, got %v of %d", ids, result.TotalSize)
+	}
+}
+
+// TestToMany_CursorRejectsToManySort tests that cursor pagination refuses a sort field with many values per record
+func TestToMany_CursorRejectsToManySort(t *testing.T) {
+	db := setupHasManyDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		SortFields: []filter.SortField{{Field: "items.quantity", Order: filter.SortOrderAsc}},
+	}
+
+	if _, err := handler.DataGormCursor(db, root, "", 10); err == nil {
+		t.Error("Expected DataGormCursor to reject a has-many sort field")
+	}
+	if _, err := handler.DataQueryCursor(generateHasManyOrders(), root, "", 10); err == nil {
+		t.Error("Expected DataQueryCursor to reject a has-many sort field")
+	}
+}
+
+// TestToMany_CursorFiltersHasMany tests that cursor pagination returns each record once when filtering on a has-many field
+func TestToMany_CursorFiltersHasMany(t *testing.T) {
+	db := setupHasManyDB(t)
+	handler := filter.NewFilter[HasManyOrder](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "items.quantity", Value: 1, Mode: filter.ModeGTE, DataType: filter.DataTypeNumber},
+		},
+	}
+
+	var seen []uint
+	cursor := ""
+	for {
+		page, err := handler.DataGormCursor(db, root, cursor, 2)
+		if err != nil {
+			t.Fatalf("DataGormCursor failed: %v", err)
+		}
+		seen = append(seen, orderIDs(page.Data)...)
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if !equalIDs(seen, []uint{1, 2, 3}) {
+		t.Errorf("Expected orders [1 2 3], got %v", seen)
+	}
+}
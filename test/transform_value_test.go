@@ -0,0 +1,191 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TransformContact stores phone numbers normalized as "+1" followed by seven digits
+type TransformContact struct {
+	ID    uint   `gorm:"primaryKey" json:"id"`
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+	Age   int    `json:"age"`
+}
+
+func generateTransformContacts() []*TransformContact {
+	return []*TransformContact{
+		{ID: 1, Name: "Ann", Phone: "+15550101", Age: 25},
+		{ID: 2, Name: "Ben", Phone: "+15550102", Age: 32},
+		{ID: 3, Name: "Cid", Phone: "+15550103", Age: 41},
+		{ID: 4, Name: "Dee", Phone: "", Age: 19},
+	}
+}
+
+func setupTransformDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&TransformContact{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, contact := range generateTransformContacts() {
+		if err := db.Create(contact).Error; err != nil {
+			t.Fatalf("Failed to create contact: %v", err)
+		}
+	}
+	return db
+}
+
+// normalizePhone keeps the digits of a typed phone number and adds the country code
+func normalizePhone(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.New("phone must be a string")
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+	if len(digits) != 7 {
+		return nil, errors.New("phone must have 7 digits")
+	}
+	return "+1" + digits, nil
+}
+
+// parseYears reads ages typed as "30y"
+func parseYears(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	return strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "y"))
+}
+
+func newTransformHandler() *filter.Handler[TransformContact] {
+	return filter.NewFilter[TransformContact](filter.GolangFilteringConfig{}).
+		TransformValue("phone", normalizePhone).
+		TransformValue("age", parseYears)
+}
+
+// TestTransformValue_BothPaths tests that transformed values match the stored values in DataQuery,
+// DataGorm and CountGorm alike
+func TestTransformValue_BothPaths(t *testing.T) {
+	db := setupTransformDB(t)
+	handler := newTransformHandler()
+	contacts := generateTransformContacts()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"Equal", filter.FieldFilter{Field: "phone", Value: "(555) 010-1", Mode: filter.ModeEqual, DataType: filter.DataTypeText}, []uint{1}},
+		{"NotEqual", filter.FieldFilter{Field: "phone", Value: "555 0101", Mode: filter.ModeNotEqual, DataType: filter.DataTypeText}, []uint{2, 3, 4}},
+		{"InItems", filter.FieldFilter{Field: "phone", Value: []string{"555-0102", "(555) 010-3"}, Mode: filter.ModeIn, DataType: filter.DataTypeText}, []uint{2, 3}},
+		{"RangeBounds", filter.FieldFilter{Field: "age", Value: filter.Range{From: "20y", To: "32y"}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, []uint{1, 2}},
+		{"RangeFromJSON", filter.FieldFilter{Field: "age", Value: map[string]any{"from": "30y", "to": "50y", "fromExclusive": true}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber}, []uint{2, 3}},
+		{"Scalar", filter.FieldFilter{Field: "age", Value: "40y", Mode: filter.ModeGT, DataType: filter.DataTypeNumber}, []uint{3}},
+		// Values that are not strings pass through parseYears unchanged
+		{"PassThrough", filter.FieldFilter{Field: "age", Value: 30, Mode: filter.ModeLT, DataType: filter.DataTypeNumber}, []uint{1, 4}},
+		// IsEmpty has no value to transform
+		{"IsEmpty", filter.FieldFilter{Field: "phone", Mode: filter.ModeIsEmpty, DataType: filter.DataTypeText}, []uint{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			original := fmt.Sprint(root.FieldFilters[0].Value)
+
+			result, err := handler.DataQuery(contacts, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			var ids []uint
+			for _, contact := range result.Data {
+				ids = append(ids, contact.ID)
+			}
+			if !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery contacts %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			ids = ids[:0]
+			for _, contact := range page.Data {
+				ids = append(ids, contact.ID)
+			}
+			if !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm contacts %v, got %v", tt.expected, ids)
+			}
+
+			count, err := handler.CountGorm(db, root)
+			if err != nil {
+				t.Fatalf("CountGorm failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) {
+				t.Errorf("Expected CountGorm=%d, got %d", len(tt.expected), count)
+			}
+
+			// The caller's filter keeps the typed value
+			if value := fmt.Sprint(root.FieldFilters[0].Value); value != original {
+				t.Errorf("Expected the caller's value %s to be unchanged, got %s", original, value)
+			}
+		})
+	}
+}
+
+// TestTransformValue_Errors tests that transform errors fail every path as invalid filter values
+func TestTransformValue_Errors(t *testing.T) {
+	db := setupTransformDB(t)
+	handler := newTransformHandler()
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		Groups: []filter.Root{{
+			Logic: filter.LogicOr,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "name", Value: "Ann", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+				{Field: "phone", Value: []string{"555-0101", "12"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			},
+		}},
+	}
+
+	checkErr := func(path string, err error) {
+		t.Helper()
+		if err == nil {
+			t.Errorf("Expected %s to fail", path)
+			return
+		}
+		if !strings.Contains(err.Error(), "phone") || !strings.Contains(err.Error(), "7 digits") {
+			t.Errorf("Expected %s error to name the field and the cause, got %v", path, err)
+		}
+	}
+	_, err := handler.DataQuery(generateTransformContacts(), root, 0, 10)
+	checkErr("DataQuery", err)
+	_, err = handler.DataGorm(db, root, 0, 10)
+	checkErr("DataGorm", err)
+	_, err = handler.Hybrid(db, 1000, root, 0, 10)
+	checkErr("Hybrid", err)
+}
+
+// TestTransformValue_UnknownFieldPanics tests that a transform on an unknown field is caught at setup
+func TestTransformValue_UnknownFieldPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected TransformValue to panic on an unknown field")
+		}
+	}()
+	filter.NewFilter[TransformContact](filter.GolangFilteringConfig{}).TransformValue("mobile", normalizePhone)
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestDataGormThenQuery tests that gormRoot filters in SQL, memRoot then filters the rows in memory over
+// preloaded relations, and the final set is paginated with its own TotalSize
+func TestDataGormThenQuery(t *testing.T) {
+	db := setupNestedRelationsDB(t)
+	depth := 2 // Nested getters for currency.*
+	handler := filter.NewFilter[TestBillAndCoin](filter.GolangFilteringConfig{MaxDepth: &depth}).
+		RegisterField("symbol", func(item *TestBillAndCoin) any {
+			if item.Currency == nil {
+				return nil
+			}
+			return item.Currency.Symbol
+		}, filter.DataTypeText)
+	gormRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "value", Value: 20, Mode: filter.ModeLTE, DataType: filter.DataTypeNumber},
+		},
+		SortFields: []filter.SortField{{Field: "value", Order: filter.SortOrderDesc}},
+	}
+
+	t.Run("NestedGetter", func(t *testing.T) {
+		// currency is preloaded for the nested getter although gormRoot does not preload it
+		memRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "currency.currency_code", Value: []string{"USD", "PHP"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+			},
+		}
+		expected := [][]uint{{5, 2, 1}, {4}} // Sorted by gormRoot, the primary key breaking ties
+		for pageIndex, ids := range expected {
+			result, err := handler.DataGormThenQuery(db, gormRoot, memRoot, pageIndex, 3)
+			if err != nil {
+				t.Fatalf("DataGormThenQuery failed: %v", err)
+			}
+			if result.TotalSize != 4 || result.TotalPage != 2 || result.HasNext != (pageIndex == 0) {
+				t.Errorf("Expected page %d of 2 out of 4 items, got %+v", pageIndex+1, result)
+			}
+			if len(result.Data) != len(ids) {
+				t.Fatalf("Expected items %v on page %d, got %d items", ids, pageIndex, len(result.Data))
+			}
+			for i, item := range result.Data {
+				if item.ID != ids[i] {
+					t.Errorf("Expected items %v on page %d, got item %d at %d", ids, pageIndex, item.ID, i)
+				}
+			}
+		}
+	})
+
+	t.Run("RegisteredField", func(t *testing.T) {
+		gormRoot := gormRoot
+		gormRoot.Preload = []string{"Currency"} // Read by the symbol getter
+		memRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "symbol", Value: "₱", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			},
+			SortFields: []filter.SortField{{Field: "name", Order: filter.SortOrderAsc}},
+		}
+		result, err := handler.DataGormThenQuery(db, gormRoot, memRoot, 0, 10)
+		if err != nil {
+			t.Fatalf("DataGormThenQuery failed: %v", err)
+		}
+		if result.TotalSize != 2 || result.Data[0].Name != "Peso Coin" || result.Data[1].Name != "Twenty Peso Bill" {
+			t.Errorf("Expected the peso items sorted by name, got %d items", result.TotalSize)
+		}
+		if !strings.HasPrefix(result.Data[0].Currency.Name, "Philippine") {
+			t.Errorf("Expected the preloaded currency, got %+v", result.Data[0].Currency)
+		}
+	})
+
+	t.Run("InvalidMemRoot", func(t *testing.T) {
+		memRoot := filter.Root{
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "value", Value: "lots", Mode: filter.ModeGT, DataType: filter.DataTypeNumber},
+			},
+		}
+		if _, err := handler.DataGormThenQuery(db, gormRoot, memRoot, 0, 10); !errors.Is(err, filter.ErrInvalidValue) {
+			t.Errorf("Expected an invalid value error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestTypedErrors tests that each category of invalid filter is an error of its type, matching its
+// sentinel, from DataQuery, from DataGorm with StrictValidation and from ValidateRoot alike
+func TestTypedErrors(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true, RejectUnknownFields: true}).
+		RestrictValues("role", []string{"admin", "user"})
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		sentinel error
+		field    func(err error) (string, bool)
+	}{
+		{"UnknownField", filter.FieldFilter{Field: "nmae", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			filter.ErrUnknownField, asField[*filter.UnknownFieldError](func(e *filter.UnknownFieldError) string { return e.Field })},
+		{"InvalidValue", filter.FieldFilter{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+			filter.ErrInvalidValue, asField[*filter.InvalidValueError](func(e *filter.InvalidValueError) string { return e.Field })},
+		{"RestrictedValue", filter.FieldFilter{Field: "role", Value: "root", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			filter.ErrInvalidValue, asField[*filter.InvalidValueError](func(e *filter.InvalidValueError) string { return e.Field })},
+		{"UnsupportedMode", filter.FieldFilter{Field: "is_active", Value: true, Mode: filter.ModeGT, DataType: filter.DataTypeBool},
+			filter.ErrUnsupportedMode, asField[*filter.UnsupportedModeError](func(e *filter.UnsupportedModeError) string { return e.Field })},
+		{"UnsupportedDataType", filter.FieldFilter{Field: "age", Value: 1, Mode: filter.ModeEqual, DataType: "int"},
+			filter.ErrUnsupportedMode, asField[*filter.UnsupportedModeError](func(e *filter.UnsupportedModeError) string { return e.Field })},
+		{"MalformedRange", filter.FieldFilter{Field: "age", Value: map[string]any{"from": 18}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			filter.ErrInvalidRange, asField[*filter.InvalidRangeError](func(e *filter.InvalidRangeError) string { return e.Field })},
+		{"InvertedRange", filter.FieldFilter{Field: "created_at", Value: filter.Range{From: "2024-06-01", To: "2024-01-01"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+			filter.ErrInvalidRange, asField[*filter.InvalidRangeError](func(e *filter.InvalidRangeError) string { return e.Field })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			check := func(path string, err error) {
+				t.Helper()
+				if err == nil {
+					t.Fatalf("Expected %s to fail", path)
+				}
+				if !errors.Is(err, tt.sentinel) {
+					t.Errorf("Expected %s error to match %v, got %T: %v", path, tt.sentinel, err, err)
+				}
+				if field, ok := tt.field(err); !ok || field != tt.filter.Field {
+					t.Errorf("Expected %s error of the type of %v on field %s, got %q (%v)", path, tt.sentinel, tt.filter.Field, field, err)
+				}
+				for _, other := range []error{filter.ErrUnknownField, filter.ErrInvalidValue, filter.ErrUnsupportedMode, filter.ErrInvalidRange} {
+					if other != tt.sentinel && errors.Is(err, other) {
+						t.Errorf("Expected %s error not to match %v: %v", path, other, err)
+					}
+				}
+			}
+
+			_, err := handler.DataQuery(generateTestUsers(), root, 0, 10)
+			check("DataQuery", err)
+			_, err = handler.DataGorm(db, root, 0, 10)
+			check("DataGorm", err)
+			check("ValidateRoot", handler.ValidateRoot(root))
+		})
+	}
+}
+
+// TestTypedErrors_NonStrictGorm tests that DataGorm without StrictValidation returns an *InvalidRangeError
+// for an inverted range, and a RejectUnknownFields error listing every unknown field
+func TestTypedErrors_NonStrictGorm(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{
+		{Field: "created_at", Value: filter.Range{From: "2024-06-01", To: "2024-01-01"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+	}}
+	var rangeErr *filter.InvalidRangeError
+	if _, err := handler.DataGorm(db, root, 0, 10); !errors.As(err, &rangeErr) || rangeErr.Field != "created_at" {
+		t.Errorf("Expected an *InvalidRangeError on created_at, got %T: %v", err, err)
+	}
+
+	rejecting := filter.NewFilter[TestUser](filter.GolangFilteringConfig{RejectUnknownFields: true})
+	root = filter.Root{Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "nmae", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText}},
+		SortFields:   []filter.SortField{{Field: "agee", Order: filter.SortOrderAsc}},
+	}
+	var unknownErr *filter.UnknownFieldError
+	if _, err := rejecting.DataGorm(db, root, 0, 10); !errors.As(err, &unknownErr) || len(unknownErr.Fields) != 2 {
+		t.Errorf("Expected an *UnknownFieldError listing 2 fields, got %T: %v", err, err)
+	}
+}
+
+// TestTypedErrors_FieldNotAllowed tests that RejectDisallowedFields returns a *FieldNotAllowedError listing
+// every disallowed field, from the queries and from ValidateRoot
+func TestTypedErrors_FieldNotAllowed(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email", "age"}, RejectDisallowedFields: true})
+	root := filter.Root{Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{{Field: "email", Value: "x", Mode: filter.ModeContains, DataType: filter.DataTypeText}},
+		SortFields:   []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}},
+	}
+
+	var notAllowed *filter.FieldNotAllowedError
+	if _, err := handler.DataGorm(db, root, 0, 10); !errors.As(err, &notAllowed) || len(notAllowed.Fields) != 2 {
+		t.Errorf("Expected DataGorm to return a *FieldNotAllowedError listing 2 fields, got %T: %v", err, err)
+	}
+	if _, err := handler.DataQuery(generateTestUsers(), root, 0, 10); !errors.As(err, &notAllowed) || notAllowed.Field != "email" {
+		t.Errorf("Expected DataQuery to return a *FieldNotAllowedError on email, got %T: %v", err, err)
+	}
+	if err := handler.ValidateRoot(root); !errors.As(err, &notAllowed) || !errors.Is(err, filter.ErrFieldNotAllowed) {
+		t.Errorf("Expected ValidateRoot to report a *FieldNotAllowedError, got %T: %v", err, err)
+	}
+}
+
+// TestTypedErrors_InvalidFilter tests that payloads that cannot be parsed, and unknown logic, sort order,
+// nulls order and language values, are an *InvalidFilterError naming the sort field they are on
+func TestTypedErrors_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		field   string
+	}{
+		{"InvalidJSON", `{"filters":`, ""},
+		{"UnknownLogic", `{"logic":"xor"}`, ""},
+		{"UnknownSortOrder", `{"sortFields":[{"field":"age","order":"up"}]}`, "age"},
+		{"UnknownNulls", `{"sortFields":[{"field":"age","nulls":"middle"}]}`, "age"},
+		{"UnknownLanguage", `{"sortFields":[{"field":"name","language":"not a tag"}]}`, "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := filter.ParseRootFromJSON([]byte(tt.payload))
+			var invalid *filter.InvalidFilterError
+			if !errors.As(err, &invalid) || !errors.Is(err, filter.ErrInvalidFilter) {
+				t.Fatalf("Expected an *InvalidFilterError, got %T: %v", err, err)
+			}
+			if invalid.Field != tt.field {
+				t.Errorf("Expected the error on field %q, got %q", tt.field, invalid.Field)
+			}
+		})
+	}
+
+	var invalid *filter.InvalidFilterError
+	if _, err := filter.ParseRootFromBase64("%%"); !errors.As(err, &invalid) {
+		t.Errorf("Expected an *InvalidFilterError for invalid base64, got %T: %v", err, err)
+	}
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	if err := handler.ValidateRoot(filter.Root{Logic: "xor"}); !errors.As(err, &invalid) {
+		t.Errorf("Expected ValidateRoot to report an *InvalidFilterError, got %T: %v", err, err)
+	}
+}
+
+// TestTypedErrors_InvalidCursor tests that a cursor the cursor methods did not return is an *InvalidCursorError
+func TestTypedErrors_InvalidCursor(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{Logic: filter.LogicAnd, SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderAsc}}}
+
+	for _, cursor := range []string{"not a cursor", "W10"} {
+		var invalid *filter.InvalidCursorError
+		if _, err := handler.DataGormCursor(db, root, cursor, 10); !errors.As(err, &invalid) || !errors.Is(err, filter.ErrInvalidCursor) {
+			t.Errorf("Expected DataGormCursor to return an *InvalidCursorError for %q, got %T: %v", cursor, err, err)
+		}
+		if _, err := handler.DataQueryCursor(generateTestUsers(), root, cursor, 10); !errors.As(err, &invalid) {
+			t.Errorf("Expected DataQueryCursor to return an *InvalidCursorError for %q, got %T: %v", cursor, err, err)
+		}
+	}
+}
+
+// TestTypedErrors_DatabaseWrapped tests that database errors are wrapped, not typed as invalid filters
+func TestTypedErrors_DatabaseWrapped(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := handler.DataGormCtx(ctx, db, filter.Root{Logic: filter.LogicAnd}, 0, 10)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the error to wrap context.Canceled, got %v", err)
+	}
+	for _, sentinel := range []error{filter.ErrUnknownField, filter.ErrInvalidValue, filter.ErrUnsupportedMode, filter.ErrInvalidRange,
+		filter.ErrFieldNotAllowed, filter.ErrInvalidFilter, filter.ErrInvalidCursor} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("Expected a database error not to match %v", sentinel)
+		}
+	}
+}
+
+// asField returns a function reading the field of the first E in an error chain with field
+func asField[E error](field func(E) string) func(err error) (string, bool) {
+	return func(err error) (string, bool) {
+		var target E
+		if !errors.As(err, &target) {
+			return "", false
+		}
+		return field(target), true
+	}
+}
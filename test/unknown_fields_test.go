@@ -0,0 +1,264 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestRejectUnknownFields_AllPaths tests that a misspelled field fails every path instead of returning
+// unfiltered rows, whether it is filtered on, searched or sorted on
+func TestRejectUnknownFields_AllPaths(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{RejectUnknownFields: true})
+	users := generateTestUsers()
+
+	roots := map[string]filter.Root{
+		"Filter": {
+			Logic: filter.LogicAnd,
+			FieldFilters: []filter.FieldFilter{
+				{Field: "crated_at", Value: "2024-01-01", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+			},
+		},
+		"NestedGroup": {
+			Logic: filter.LogicAnd,
+			Groups: []filter.Root{{
+				Logic: filter.LogicOr,
+				FieldFilters: []filter.FieldFilter{
+					{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+					{Field: "crated_at", Value: "2024-01-01", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+				},
+			}},
+		},
+		"Search": {
+			Logic:  filter.LogicAnd,
+			Search: &filter.SearchFilter{Value: "john", Fields: []string{"name", "crated_at"}},
+		},
+		"Sort": {
+			Logic:      filter.LogicAnd,
+			SortFields: []filter.SortField{{Field: "crated_at", Order: filter.SortOrderDesc}},
+		},
+	}
+
+	paths := map[string]func(root filter.Root) error{
+		"DataQuery": func(root filter.Root) error {
+			_, err := handler.DataQuery(users, root, 0, 10)
+			return err
+		},
+		"DataGorm": func(root filter.Root) error {
+			_, err := handler.DataGorm(db, root, 0, 10)
+			return err
+		},
+		"DataGormNoPage": func(root filter.Root) error {
+			_, err := handler.DataGormNoPage(db, root)
+			return err
+		},
+		"DataQueryNoPageCSV": func(root filter.Root) error {
+			_, err := handler.DataQueryNoPageCSV(users, root)
+			return err
+		},
+		"GormNoPaginationCSV": func(root filter.Root) error {
+			_, err := handler.GormNoPaginationCSV(db, root)
+			return err
+		},
+		"Hybrid": func(root filter.Root) error {
+			_, err := handler.Hybrid(db, 1000, root, 0, 10)
+			return err
+		},
+	}
+
+	for rootName, root := range roots {
+		for pathName, run := range paths {
+			t.Run(rootName+"/"+pathName, func(t *testing.T) {
+				err := run(root)
+				if err == nil || !strings.Contains(err.Error(), "unknown fields: crated_at") {
+					t.Errorf("Expected an unknown field error, got %v", err)
+				}
+			})
+		}
+	}
+}
+
+// TestRejectUnknownFields_KnownFields tests that aliases, nested paths and valid roots still work
+func TestRejectUnknownFields_KnownFields(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{RejectUnknownFields: true})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "Role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "created_at", Order: filter.SortOrderAsc}},
+	}
+
+	result, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if result.TotalSize == 0 || len(result.IgnoredFields) != 0 {
+		t.Errorf("Expected admins and no ignored fields, got %d and %v", result.TotalSize, result.IgnoredFields)
+	}
+	if _, err := handler.DataQuery(generateTestUsers(), root, 0, 10); err != nil {
+		t.Errorf("DataQuery failed: %v", err)
+	}
+}
+
+// TestRejectUnknownFields_Default tests that by default unknown fields are skipped and reported
+func TestRejectUnknownFields_Default(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "crated_at", Value: "2024-01-01", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+		},
+		SortFields: []filter.SortField{{Field: "agee", Order: filter.SortOrderAsc}},
+	}
+
+	result, err := handler.DataQuery(generateTestUsers(), root, 0, 100)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if result.TotalSize != 10 {
+		t.Errorf("Expected every user, got %d", result.TotalSize)
+	}
+	if strings.Join(result.IgnoredFields, ",") != "crated_at,agee" {
+		t.Errorf("Expected ignored fields [crated_at agee], got %v", result.IgnoredFields)
+	}
+}
+
+// TestRejectUnknownFields_MisspelledNestedField tests that a typo in a nested field fails instead of being
+// dropped, whether or not MaxDepth gives the relation getters, while nested fields beyond MaxDepth that
+// resolve through the GORM relations still work
+func TestRejectUnknownFields_MisspelledNestedField(t *testing.T) {
+	db := setupNilParentDB(t)
+	staff := generateNilParentStaff()
+	depthTwo := 2
+	handlers := map[string]*filter.Handler[NilParentStaff]{
+		"Getters":   filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &depthTwo, RejectUnknownFields: true}),
+		"Relations": filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{RejectUnknownFields: true}),
+	}
+	misspelled := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "department.nmae", Value: "Engineering", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.DataQuery(staff, misspelled, 0, 10); err == nil || !strings.Contains(err.Error(), "department.nmae") {
+				t.Errorf("Expected DataQuery to reject department.nmae, got %v", err)
+			}
+			if _, err := handler.DataGorm(db, misspelled, 0, 10); err == nil || !strings.Contains(err.Error(), "department.nmae") {
+				t.Errorf("Expected DataGorm to reject department.nmae, got %v", err)
+			}
+			if err := handler.ValidateRoot(misspelled); err == nil || !strings.Contains(err.Error(), "department.nmae") {
+				t.Errorf("Expected ValidateRoot to report department.nmae, got %v", err)
+			}
+
+			valid := misspelled
+			valid.FieldFilters = []filter.FieldFilter{
+				{Field: "department.name", Value: "Engineering", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			}
+			page, err := handler.DataGorm(db, valid, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if page.TotalSize != 1 {
+				t.Errorf("Expected one staff member in Engineering, got %d", page.TotalSize)
+			}
+			if err := handler.ValidateRoot(valid); err != nil {
+				t.Errorf("Expected department.name to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+// TestRejectUnknownFields_DefaultNestedField tests that by default a misspelled nested filter or sort field
+// is skipped and reported by DataQuery and DataGorm alike, rather than reaching the SQL
+func TestRejectUnknownFields_DefaultNestedField(t *testing.T) {
+	db := setupNilParentDB(t)
+	staff := generateNilParentStaff()
+	depthTwo := 2
+	handlers := map[string]*filter.Handler[NilParentStaff]{
+		"Getters":   filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &depthTwo}),
+		"Relations": filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{}),
+	}
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "department.nmae", Value: "Engineering", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "department.nmae", Order: filter.SortOrderAsc}},
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			for path, fetch := range map[string]func() (*filter.PaginationResult[NilParentStaff], error){
+				"DataQuery": func() (*filter.PaginationResult[NilParentStaff], error) { return handler.DataQuery(staff, root, 0, 10) },
+				"DataGorm":  func() (*filter.PaginationResult[NilParentStaff], error) { return handler.DataGorm(db, root, 0, 10) },
+			} {
+				result, err := fetch()
+				if err != nil {
+					t.Fatalf("%s failed: %v", path, err)
+				}
+				if result.TotalSize != len(staff) {
+					t.Errorf("Expected %s to return every staff member, got %d", path, result.TotalSize)
+				}
+				if strings.Join(result.IgnoredFields, ",") != "department.nmae" || strings.Join(result.IgnoredSorts, ",") != "department.nmae" {
+					t.Errorf("Expected %s to ignore department.nmae, got fields %v and sorts %v", path, result.IgnoredFields, result.IgnoredSorts)
+				}
+			}
+		})
+	}
+}
+
+// TestRejectUnknownFields_NestedFieldWithoutGetter tests that a nested field DataGorm reads through the GORM
+// relations but that has no getter (past MaxDepth) is unknown to the in-memory paths: ignored and reported
+// by default, rejected with RejectUnknownFields, and sent to the database by the hybrid exports
+func TestRejectUnknownFields_NestedFieldWithoutGetter(t *testing.T) {
+	db := setupNilParentDB(t)
+	staff := generateNilParentStaff()
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "department.name", Value: "Engineering", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{}) // MaxDepth 1: no department getters
+	page, err := handler.DataGorm(db, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataGorm failed: %v", err)
+	}
+	if page.TotalSize != 1 || len(page.IgnoredFields) != 0 {
+		t.Errorf("Expected DataGorm to return Alice alone, got %d (ignored %v)", page.TotalSize, page.IgnoredFields)
+	}
+	page, err = handler.DataQuery(staff, root, 0, 10)
+	if err != nil {
+		t.Fatalf("DataQuery failed: %v", err)
+	}
+	if page.TotalSize != len(staff) || strings.Join(page.IgnoredFields, ",") != "department.name" {
+		t.Errorf("Expected DataQuery to ignore department.name, got %d (ignored %v)", page.TotalSize, page.IgnoredFields)
+	}
+	csvData, err := handler.HybridCSV(db, 1000, root)
+	if err != nil {
+		t.Fatalf("HybridCSV failed: %v", err)
+	}
+	if csv := string(csvData); !strings.Contains(csv, "Alice") || strings.Contains(csv, "Bob") {
+		t.Errorf("Expected HybridCSV to export Alice alone through the database, got %q", csv)
+	}
+
+	strict := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{RejectUnknownFields: true})
+	if _, err := strict.DataQuery(staff, root, 0, 10); !errors.Is(err, filter.ErrUnknownField) {
+		t.Errorf("Expected DataQuery to reject department.name, got %v", err)
+	}
+	if _, err := strict.CountQuery(staff, root); !errors.Is(err, filter.ErrUnknownField) {
+		t.Errorf("Expected CountQuery to reject department.name, got %v", err)
+	}
+	if _, err := strict.DataGorm(db, root, 0, 10); err != nil {
+		t.Errorf("Expected DataGorm to accept department.name, got %v", err)
+	}
+}
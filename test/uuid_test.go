@@ -0,0 +1,270 @@
+package test
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testUUID mirrors uuid.UUID: a [16]byte stored as its canonical string
+type testUUID [16]byte
+
+func mustUUID(s string) testUUID {
+	var id testUUID
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != 16 {
+		panic(fmt.Sprintf("bad uuid %q", s))
+	}
+	copy(id[:], b)
+	return id
+}
+
+func (id testUUID) String() string {
+	h := hex.EncodeToString(id[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func (id testUUID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+func (id *testUUID) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*id = mustUUID(v)
+	case []byte:
+		*id = mustUUID(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into testUUID", src)
+	}
+	return nil
+}
+
+const (
+	uuidAcme    = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	uuidGlobex  = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+	uuidInitech = "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+)
+
+// UUIDAccount has UUID fields stored as a [16]byte type, a nullable pointer and a plain string
+type UUIDAccount struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	OrganizationID testUUID  `gorm:"type:text" json:"organization_id"`
+	ParentID       *testUUID `gorm:"type:text" json:"parent_id"`
+	ExternalRef    string    `json:"external_ref"`
+}
+
+func generateUUIDAccounts() []*UUIDAccount {
+	acme, globex := mustUUID(uuidAcme), mustUUID(uuidGlobex)
+	return []*UUIDAccount{
+		{ID: 1, OrganizationID: acme, ExternalRef: uuidInitech},
+		{ID: 2, OrganizationID: acme, ParentID: &globex, ExternalRef: ""},
+		{ID: 3, OrganizationID: globex, ParentID: &acme, ExternalRef: uuidAcme},
+		{ID: 4, OrganizationID: mustUUID(uuidInitech), ExternalRef: uuidGlobex},
+	}
+}
+
+func setupUUIDDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&UUIDAccount{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, account := range generateUUIDAccounts() {
+		if err := db.Create(account).Error; err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+	return db
+}
+
+func accountIDs(accounts []*UUIDAccount) []uint {
+	ids := make([]uint, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.ID
+	}
+	return ids
+}
+
+// TestUUID_Modes tests DataTypeUUID against [16]byte, pointer and string fields in DataQuery and DataGorm
+func TestUUID_Modes(t *testing.T) {
+	db := setupUUIDDB(t)
+	handler := filter.NewFilter[UUIDAccount](filter.GolangFilteringConfig{})
+	accounts := generateUUIDAccounts()
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"Equal", filter.FieldFilter{Field: "organization_id", Value: uuidAcme, Mode: filter.ModeEqual}, []uint{1, 2}},
+		{"EqualUppercase", filter.FieldFilter{Field: "organization_id", Value: strings.ToUpper(uuidAcme), Mode: filter.ModeEqual}, []uint{1, 2}},
+		{"EqualBraced", filter.FieldFilter{Field: "organization_id", Value: "{" + uuidGlobex + "}", Mode: filter.ModeEqual}, []uint{3}},
+		{"EqualUnhyphenated", filter.FieldFilter{Field: "organization_id", Value: strings.ReplaceAll(uuidInitech, "-", ""), Mode: filter.ModeEqual}, []uint{4}},
+		{"EqualUUIDValue", filter.FieldFilter{Field: "organization_id", Value: mustUUID(uuidGlobex), Mode: filter.ModeEqual}, []uint{3}},
+		{"NotEqual", filter.FieldFilter{Field: "organization_id", Value: uuidAcme, Mode: filter.ModeNotEqual}, []uint{3, 4}},
+		{"In", filter.FieldFilter{Field: "organization_id", Value: []string{uuidGlobex, uuidInitech}, Mode: filter.ModeIn}, []uint{3, 4}},
+		{"NotIn", filter.FieldFilter{Field: "organization_id", Value: []any{uuidGlobex, mustUUID(uuidInitech)}, Mode: filter.ModeNotIn}, []uint{1, 2}},
+		{"PointerEqual", filter.FieldFilter{Field: "parent_id", Value: uuidAcme, Mode: filter.ModeEqual}, []uint{3}},
+		// NULL parents match ModeNotEqual, the complement of ModeEqual
+		{"PointerNotEqual", filter.FieldFilter{Field: "parent_id", Value: uuidAcme, Mode: filter.ModeNotEqual}, []uint{1, 2, 4}},
+		{"PointerIsEmpty", filter.FieldFilter{Field: "parent_id", Mode: filter.ModeIsEmpty}, []uint{1, 4}},
+		{"PointerIsNotEmpty", filter.FieldFilter{Field: "parent_id", Mode: filter.ModeIsNotEmpty}, []uint{2, 3}},
+		{"StringEqual", filter.FieldFilter{Field: "external_ref", Value: uuidGlobex, Mode: filter.ModeEqual}, []uint{4}},
+		// The empty string is not a UUID, so it is never equal to one
+		{"StringNotEqualIncludesEmpty", filter.FieldFilter{Field: "external_ref", Value: uuidGlobex, Mode: filter.ModeNotEqual}, []uint{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.DataType = filter.DataTypeUUID
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			result, err := handler.DataQuery(accounts, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if ids := accountIDs(result.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataQuery accounts %v, got %v", tt.expected, ids)
+			}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			if ids := accountIDs(page.Data); !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm accounts %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestUUID_RejectsInvalidValues tests that malformed UUIDs and unsupported modes are errors
+func TestUUID_RejectsInvalidValues(t *testing.T) {
+	db := setupUUIDDB(t)
+	handler := filter.NewFilter[UUIDAccount](filter.GolangFilteringConfig{StrictValidation: true})
+	accounts := generateUUIDAccounts()
+
+	tests := []struct {
+		name   string
+		filter filter.FieldFilter
+	}{
+		{"TooShort", filter.FieldFilter{Field: "organization_id", Value: "6ba7b810-9dad-11d1-80b4", Mode: filter.ModeEqual}},
+		{"BadHex", filter.FieldFilter{Field: "organization_id", Value: "zba7b810-9dad-11d1-80b4-00c04fd430c8", Mode: filter.ModeEqual}},
+		{"MisplacedHyphen", filter.FieldFilter{Field: "organization_id", Value: "6ba7b8109-dad-11d1-80b4-00c04fd430c8", Mode: filter.ModeEqual}},
+		{"BadListItem", filter.FieldFilter{Field: "organization_id", Value: []string{uuidAcme, "nope"}, Mode: filter.ModeIn}},
+		{"Number", filter.FieldFilter{Field: "organization_id", Value: 42, Mode: filter.ModeEqual}},
+		{"UnsupportedMode", filter.FieldFilter{Field: "organization_id", Value: uuidAcme, Mode: filter.ModeContains}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.DataType = filter.DataTypeUUID
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+			if _, err := handler.DataQuery(accounts, root, 0, 10); err == nil {
+				t.Error("Expected DataQuery to fail")
+			}
+			if _, err := handler.DataGorm(db, root, 0, 10); err == nil {
+				t.Error("Expected DataGorm to fail")
+			}
+		})
+	}
+}
+
+// TestUUID_ParseFromJSON tests that "uuid" is a known data type
+func TestUUID_ParseFromJSON(t *testing.T) {
+	root, err := filter.ParseRootFromJSON([]byte(`{"filters":[{"field":"organization_id","value":"` + uuidAcme + `","mode":"equal","dataType":"UUID"}]}`))
+	if err != nil {
+		t.Fatalf("ParseRootFromJSON failed: %v", err)
+	}
+	if root.FieldFilters[0].DataType != filter.DataTypeUUID {
+		t.Errorf("Expected DataTypeUUID, got %q", root.FieldFilters[0].DataType)
+	}
+}
+
+// binaryUUID is a [16]byte stored as its 16 bytes, as in a BINARY(16) column
+type binaryUUID [16]byte
+
+func (id binaryUUID) Value() (driver.Value, error) {
+	return id[:], nil
+}
+
+func (id *binaryUUID) Scan(src any) error {
+	b, ok := src.([]byte)
+	if !ok || len(b) != 16 {
+		return fmt.Errorf("cannot scan %T into binaryUUID", src)
+	}
+	copy(id[:], b)
+	return nil
+}
+
+// UUIDDevice has a UUID stored as binary
+type UUIDDevice struct {
+	ID  uint       `gorm:"primaryKey" json:"id"`
+	Key binaryUUID `gorm:"type:blob" json:"key"`
+}
+
+// TestUUID_BinaryColumn tests that DataGorm binds values of a [16]byte field as the field's type
+// stores them, here 16 bytes rather than the canonical string
+func TestUUID_BinaryColumn(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&UUIDDevice{}); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	devices := []*UUIDDevice{
+		{ID: 1, Key: binaryUUID(mustUUID(uuidAcme))},
+		{ID: 2, Key: binaryUUID(mustUUID(uuidGlobex))},
+		{ID: 3, Key: binaryUUID(mustUUID(uuidInitech))},
+	}
+	if err := db.Create(devices).Error; err != nil {
+		t.Fatalf("Failed to create devices: %v", err)
+	}
+	handler := filter.NewFilter[UUIDDevice](filter.GolangFilteringConfig{})
+
+	tests := []struct {
+		name     string
+		filter   filter.FieldFilter
+		expected []uint
+	}{
+		{"Equal", filter.FieldFilter{Field: "key", Value: strings.ToUpper(uuidGlobex), Mode: filter.ModeEqual}, []uint{2}},
+		{"NotEqual", filter.FieldFilter{Field: "key", Value: uuidGlobex, Mode: filter.ModeNotEqual}, []uint{1, 3}},
+		{"In", filter.FieldFilter{Field: "key", Value: []string{uuidAcme, uuidInitech}, Mode: filter.ModeIn}, []uint{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.DataType = filter.DataTypeUUID
+			root := filter.Root{Logic: filter.LogicAnd, FieldFilters: []filter.FieldFilter{tt.filter}}
+
+			page, err := handler.DataGorm(db, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataGorm failed: %v", err)
+			}
+			ids := make([]uint, len(page.Data))
+			for i, device := range page.Data {
+				ids[i] = device.ID
+			}
+			if !equalIDs(ids, tt.expected) {
+				t.Errorf("Expected DataGorm devices %v, got %v", tt.expected, ids)
+			}
+
+			result, err := handler.DataQuery(devices, root, 0, 10)
+			if err != nil {
+				t.Fatalf("DataQuery failed: %v", err)
+			}
+			if result.TotalSize != len(tt.expected) {
+				t.Errorf("Expected DataQuery to match %d devices, got %d", len(tt.expected), result.TotalSize)
+			}
+		})
+	}
+}
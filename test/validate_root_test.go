@@ -0,0 +1,141 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// TestValidateRoot_ReportsEveryProblem tests that ValidateRoot lists every problem of a Root at once,
+// each as a *ValidationError naming its field
+func TestValidateRoot_ReportsEveryProblem(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "nmae", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: filter.Range{From: true, To: false}, Mode: filter.ModeRange, DataType: filter.DataTypeBool},
+		},
+		Groups: []filter.Root{
+			{
+				Logic: "xor",
+				FieldFilters: []filter.FieldFilter{
+					{Field: "created_at", Value: filter.Range{From: "2024-06-01", To: "2024-01-01"}, Mode: filter.ModeRange, DataType: filter.DataTypeDate},
+				},
+			},
+		},
+		SortFields: []filter.SortField{{Field: "name", Order: "sideways"}},
+	}
+
+	err := handler.ValidateRoot(filterRoot)
+	if err == nil {
+		t.Fatal("Expected validation errors, got nil")
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected a joined error, got %T", err)
+	}
+	fields := make(map[string]bool)
+	for _, e := range joined.Unwrap() {
+		var validationErr *filter.ValidationError
+		if !errors.As(e, &validationErr) {
+			t.Fatalf("Expected *filter.ValidationError, got %T", e)
+		}
+		fields[validationErr.Field] = true
+	}
+	if len(joined.Unwrap()) != 6 {
+		t.Errorf("Expected 6 problems, got %d: %v", len(joined.Unwrap()), err)
+	}
+	for _, field := range []string{"nmae", "age", "is_active", "created_at", "name", ""} {
+		if !fields[field] {
+			t.Errorf("Expected a problem on field %q, got %v", field, err)
+		}
+	}
+	for _, want := range []string{"unknown field", "twentyfive", "unknown logic 'xor'", "unknown sort order 'sideways'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+// TestValidateRoot_Valid tests that a valid Root, including relative dates and nested fields, passes
+func TestValidateRoot_Valid(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	filterRoot := filter.Root{
+		Logic: filter.LogicOr,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "age", Value: filter.Range{From: 18, To: 65}, Mode: filter.ModeRange, DataType: filter.DataTypeNumber},
+			{Field: "created_at", Value: "now-7d", Mode: filter.ModeAfter, DataType: filter.DataTypeDate},
+			{Field: "role", Value: []string{"admin", "user"}, Mode: filter.ModeIn, DataType: filter.DataTypeText},
+		},
+		Search:     &filter.SearchFilter{Value: "jo"},
+		SortFields: []filter.SortField{{Field: "age", Order: filter.SortOrderDesc, Nulls: filter.NullsLast}},
+	}
+	if err := handler.ValidateRoot(filterRoot); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestValidateRoot_RestrictionsAndTransforms tests that disallowed fields, RestrictValues and
+// TransformValue errors are reported
+func TestValidateRoot_RestrictionsAndTransforms(t *testing.T) {
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{DeniedFields: []string{"email"}}).
+		RestrictValues("role", []string{"admin", "user"}).
+		TransformValue("name", func(v any) (any, error) {
+			return nil, errors.New("names are not searchable")
+		})
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "email", Value: "john@example.com", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "role", Value: "root", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "name", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	err := handler.ValidateRoot(filterRoot)
+	if err == nil {
+		t.Fatal("Expected validation errors, got nil")
+	}
+	for _, want := range []string{"email: field is not allowed", `value "root" is not allowed`, "names are not searchable"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
+
+// TestValidateRoot_StrictQueries tests that strict queries report every invalid filter at once,
+// while unknown fields stay skipped unless RejectUnknownFields is set
+func TestValidateRoot_StrictQueries(t *testing.T) {
+	db := setupTestDB(t)
+	filterRoot := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "nmae", Value: "John", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+			{Field: "age", Value: "twentyfive", Mode: filter.ModeEqual, DataType: filter.DataTypeNumber},
+			{Field: "is_active", Value: 1, Mode: filter.ModeGT, DataType: filter.DataTypeBool},
+		},
+	}
+
+	strict := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true})
+	_, err := strict.DataGorm(db, filterRoot, 0, 10)
+	if err == nil {
+		t.Fatal("Expected error in strict mode, got nil")
+	}
+	if !strings.Contains(err.Error(), "twentyfive") || !strings.Contains(err.Error(), "is_active") {
+		t.Errorf("Expected both invalid filters to be reported, got %q", err.Error())
+	}
+	if strings.Contains(err.Error(), "nmae") {
+		t.Errorf("Expected unknown field to be skipped without RejectUnknownFields, got %q", err.Error())
+	}
+	if _, err := strict.DataQuery(generateTestUsers(), filterRoot, 0, 10); err == nil || !strings.Contains(err.Error(), "is_active") {
+		t.Errorf("Expected DataQuery to report every invalid filter, got %v", err)
+	}
+
+	rejecting := filter.NewFilter[TestUser](filter.GolangFilteringConfig{StrictValidation: true, RejectUnknownFields: true})
+	if _, err := rejecting.DataGorm(db, filterRoot, 0, 10); err == nil || !strings.Contains(err.Error(), "nmae") {
+		t.Errorf("Expected unknown field to be reported with RejectUnknownFields, got %v", err)
+	}
+}
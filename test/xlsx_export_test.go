@@ -0,0 +1,262 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Lands-Horizon-Corp/golang-filtering/filter"
+)
+
+// xlsxCell is a parsed worksheet cell
+type xlsxCell struct {
+	Ref    string `xml:"r,attr"`
+	Type   string `xml:"t,attr"`
+	Style  string `xml:"s,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+// text returns the cell's displayed raw value
+func (c xlsxCell) text() string {
+	if c.Type == "inlineStr" {
+		return c.Inline
+	}
+	return c.Value
+}
+
+// readXLSXSheet unzips data and returns the rows of the first worksheet
+func readXLSXSheet(t *testing.T, data []byte) [][]xlsxCell {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Output is not a zip archive: %v", err)
+	}
+	parts := map[string]bool{}
+	var sheet []byte
+	for _, file := range reader.File {
+		parts[file.Name] = true
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := file.Open()
+			if err != nil {
+				t.Fatalf("Failed to open sheet: %v", err)
+			}
+			sheet, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if !parts[name] {
+			t.Errorf("Missing workbook part %s", name)
+		}
+	}
+
+	var worksheet struct {
+		Rows []struct {
+			Cells []xlsxCell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(sheet, &worksheet); err != nil {
+		t.Fatalf("Failed to parse sheet XML: %v", err)
+	}
+	rows := make([][]xlsxCell, len(worksheet.Rows))
+	for i, row := range worksheet.Rows {
+		rows[i] = row.Cells
+	}
+	return rows
+}
+
+// cellByHeader returns the cell of row under header, or a zero cell if it is empty
+func cellByHeader(rows [][]xlsxCell, row int, header string) xlsxCell {
+	for _, headerCell := range rows[0] {
+		if headerCell.text() != header {
+			continue
+		}
+		column := strings.TrimRight(headerCell.Ref, "0123456789")
+		for _, cell := range rows[row] {
+			if cell.Ref == column+strconv.Itoa(row+1) {
+				return cell
+			}
+		}
+	}
+	return xlsxCell{}
+}
+
+func TestXLSXExport(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "admin", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+
+	exports := map[string]func() ([]byte, error){
+		"gorm":   func() ([]byte, error) { return handler.GormNoPaginationXLSX(db, root) },
+		"memory": func() ([]byte, error) { return handler.DataQueryNoPageXLSX(users, root) },
+	}
+
+	for name, export := range exports {
+		t.Run(name, func(t *testing.T) {
+			data, err := export()
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			rows := readXLSXSheet(t, data)
+			if len(rows) != 4 {
+				t.Fatalf("Expected header + 3 rows, got %d rows", len(rows))
+			}
+
+			// Header order matches the CSV export
+			csvData, err := handler.DataQueryNoPageCSVWithOptions(users, root, filter.DefaultCSVOptions())
+			if err != nil {
+				t.Fatalf("CSV export failed: %v", err)
+			}
+			var headers []string
+			for _, cell := range rows[0] {
+				headers = append(headers, cell.text())
+			}
+			csvHeader := string(bytes.SplitN(csvData, []byte("\n"), 2)[0])
+			if got := strings.Join(headers, ","); got != csvHeader {
+				t.Errorf("Expected headers %q, got %q", csvHeader, got)
+			}
+
+			if cell := cellByHeader(rows, 1, "name"); cell.Type != "inlineStr" || cell.text() != "John Doe" {
+				t.Errorf("Expected text cell John Doe, got %+v", cell)
+			}
+			if cell := cellByHeader(rows, 2, "age"); cell.Type != "" || cell.text() != "42" {
+				t.Errorf("Expected number cell 42, got %+v", cell)
+			}
+			if cell := cellByHeader(rows, 1, "is_active"); cell.Type != "b" || cell.text() != "1" {
+				t.Errorf("Expected boolean cell 1, got %+v", cell)
+			}
+			// 2024-01-01 is serial 45292
+			if cell := cellByHeader(rows, 1, "created_at"); cell.Style != "1" || cell.text() != "45292" {
+				t.Errorf("Expected date cell 45292, got %+v", cell)
+			}
+		})
+	}
+}
+
+func TestXLSXExportKeepsLeadingZerosAndEscapes(t *testing.T) {
+	type Contact struct {
+		ID    uint   `json:"id"`
+		Phone string `json:"phone"`
+		Note  string `json:"note"`
+	}
+	handler := filter.NewFilter[Contact](filter.GolangFilteringConfig{})
+	contacts := []*Contact{{ID: 1, Phone: "00123", Note: `<b>"Tom" & Jerry</b>`}}
+
+	data, err := handler.DataQueryNoPageXLSX(contacts, filter.Root{Logic: filter.LogicAnd})
+	if err != nil {
+		t.Fatalf("DataQueryNoPageXLSX failed: %v", err)
+	}
+	rows := readXLSXSheet(t, data)
+	if cell := cellByHeader(rows, 1, "phone"); cell.Type != "inlineStr" || cell.text() != "00123" {
+		t.Errorf("Expected text cell 00123, got %+v", cell)
+	}
+	if cell := cellByHeader(rows, 1, "note"); cell.text() != `<b>"Tom" & Jerry</b>` {
+		t.Errorf("Expected escaped note to round-trip, got %+v", cell)
+	}
+}
+
+func TestXLSXExportNilValues(t *testing.T) {
+	db := setupNilParentDB(t)
+	maxDepth := 2
+	handler := filter.NewFilter[NilParentStaff](filter.GolangFilteringConfig{MaxDepth: &maxDepth})
+	root := filter.Root{
+		Logic:      filter.LogicAnd,
+		Preload:    []string{"Department"},
+		SortFields: []filter.SortField{{Field: "id", Order: filter.SortOrderAsc}},
+	}
+
+	data, err := handler.GormNoPaginationXLSX(db, root)
+	if err != nil {
+		t.Fatalf("GormNoPaginationXLSX failed: %v", err)
+	}
+	rows := readXLSXSheet(t, data)
+	if cell := cellByHeader(rows, 1, "department_id"); cell.text() != "1" {
+		t.Errorf("Expected dereferenced department_id 1, got %+v", cell)
+	}
+	if cell := cellByHeader(rows, 2, "department.name"); cell.Ref != "" {
+		t.Errorf("Expected empty cell for nil parent, got %+v", cell)
+	}
+	if cell := cellByHeader(rows, 2, "department_id"); cell.Ref != "" {
+		t.Errorf("Expected empty cell for nil pointer, got %+v", cell)
+	}
+}
+
+func TestXLSXExportCustom(t *testing.T) {
+	db := setupTestDB(t)
+	handler := filter.NewFilter[TestUser](filter.GolangFilteringConfig{})
+	users := generateTestUsers()
+
+	customGetter := func(user *TestUser) map[string]any {
+		return map[string]any{
+			"User ID":   user.ID,
+			"Full Name": user.Name,
+			"Joined":    user.CreatedAt,
+		}
+	}
+	root := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "role", Value: "moderator", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+	noMatch := filter.Root{
+		Logic: filter.LogicAnd,
+		FieldFilters: []filter.FieldFilter{
+			{Field: "name", Value: "nobody", Mode: filter.ModeEqual, DataType: filter.DataTypeText},
+		},
+	}
+
+	exports := map[string]func(root filter.Root) ([]byte, error){
+		"gorm": func(root filter.Root) ([]byte, error) {
+			return handler.GormNoPaginationXLSXCustom(db, root, customGetter)
+		},
+		"memory": func(root filter.Root) ([]byte, error) {
+			return handler.DataQueryNoPageXLSXCustom(users, root, customGetter)
+		},
+	}
+
+	for name, export := range exports {
+		t.Run(name, func(t *testing.T) {
+			data, err := export(root)
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			rows := readXLSXSheet(t, data)
+			var headers []string
+			for _, cell := range rows[0] {
+				headers = append(headers, cell.text())
+			}
+			if !slices.Equal(headers, []string{"Full Name", "Joined", "User ID"}) {
+				t.Errorf("Unexpected headers %v", headers)
+			}
+			if len(rows) != 3 {
+				t.Fatalf("Expected header + 2 rows, got %d", len(rows))
+			}
+			if cell := cellByHeader(rows, 1, "User ID"); cell.text() != "4" || cell.Type != "" {
+				t.Errorf("Expected number cell 4, got %+v", cell)
+			}
+
+			data, err = export(noMatch)
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if rows := readXLSXSheet(t, data); len(rows) != 0 {
+				t.Errorf("Expected empty sheet, got %d rows", len(rows))
+			}
+		})
+	}
+}